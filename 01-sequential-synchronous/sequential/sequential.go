@@ -0,0 +1,65 @@
+// Package sequential is lesson 01: Sequential Synchronous Order Processing System.
+package sequential
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+}
+
+func processOrder(order Order) {
+	// Print order start message
+	out.Printf("📝 Order %d: Started processing\n", order.ID)
+
+	// Simulate order processing time (blocking operation)
+	clk.Sleep(context.Background(), order.PrepTime)
+
+	// Print order completion message with time taken
+	out.Printf("✅ Order %d : Ready for pickup! Time taken: %v\n\n", order.ID, order.PrepTime)
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("🏪 Sequential Synchronous Order Processing System")
+	out.Print("⏰ Processing started\n\n")
+
+	// Record start time for total processing calculation
+	startTime := clk.Now()
+
+	// Create orders
+	orders := []Order{
+		{ID: 1, PrepTime: 2 * time.Second},
+		{ID: 2, PrepTime: 3 * time.Second},
+		{ID: 3, PrepTime: 1 * time.Second},
+		{ID: 4, PrepTime: 4 * time.Second},
+		{ID: 5, PrepTime: 2 * time.Second},
+	}
+
+	// Process orders sequentially (one after another)
+	for _, order := range orders {
+		processOrder(order)
+	}
+
+	// Calculate and display total processing time
+	out.Printf("⏱️  Total processing time: %v\n", clk.Since(startTime)) // 2 + 3 + 1 + 4 + 2 = 12 seconds
+	out.Println("🔄 Note: Orders processed sequentially - one after another")
+	return nil
+}
@@ -0,0 +1,12 @@
+package sequential
+
+import (
+	"testing"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// The whole lesson on a fake clock: five orders one after another, 12s in total
+func TestGolden(t *testing.T) {
+	testutil.Golden(t, "testdata/golden.txt", testutil.RunLesson(t, Run))
+}
@@ -0,0 +1,518 @@
+// Package waitgroups is lesson 02: Goroutines and WaitGroup.
+package waitgroups
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+	"github.com/Ajay2521/go-concurrency/pkg/order"
+	"github.com/Ajay2521/go-concurrency/pkg/report"
+)
+
+// Order is the shared order type: order.Generate makes the load generator's
+// batches, and Validate rejects orders that can't be cooked
+type Order = order.Order
+
+// out is where the lesson prints. Run starts it before anything else runs;
+// it is a display.Printer, so lines from the goroutines never interleave.
+var out *display.Printer
+
+// clk is where the lesson gets the time and waits for prep times, so a run
+// can be replayed on a fake clock without real sleeps. Run sets it from its
+// options, which scale it by -speed.
+var clk clock.Clock = clock.Real()
+
+// speed is how many times faster than real time clk runs
+func speed() float64 {
+	if c, ok := clk.(*clock.ScaledClock); ok {
+		return c.Speed()
+	}
+	return 1
+}
+
+// onFakeClock runs fn with clk on a fake clock that moves to the next
+// deadline whenever every goroutine fn started is waiting on it, so fn's
+// prep times take no real time but still overlap as they would for real
+func onFakeClock(fn func()) {
+	saved := clk
+	defer func() { clk = saved }()
+
+	fake := clock.NewFake(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go fake.AdvanceWhenIdle(ctx, time.Millisecond)
+	clk = fake
+	fn()
+}
+
+// Result is the shared result type, so PrintSummary can print the lesson's orders
+type Result = order.Result
+
+// chatty is set by -chatty: orders also print from inside their goroutines
+// as they start and finish, in whatever order the scheduler runs them
+var chatty bool
+
+// processOrder cooks one order and returns its Result. An order that fails
+// Validate isn't cooked; its Result carries the error.
+func processOrder(order Order, worker int) Result {
+	r := Result{Order: order, Worker: worker, StartedAt: clk.Now()}
+	if r.Err = order.Validate(); r.Err != nil {
+		r.FinishedAt = r.StartedAt
+		return r
+	}
+	if chatty {
+		out.Printf("📝 Order %d: Started processing\n", order.ID)
+	}
+	clk.Sleep(context.Background(), order.PrepTime)
+	r.FinishedAt = clk.Now()
+	if chatty {
+		out.Printf("✅ Order %d: Ready for pickup! Time taken: %v\n", order.ID, order.PrepTime)
+	}
+	return r
+}
+
+// PrintSummary prints results with order.PrintSummary, set apart from the
+// inline prints when -chatty is on
+func PrintSummary(results []Result) {
+	if chatty {
+		out.Printf("\n")
+	}
+	order.PrintSummary(out, results)
+}
+
+// Simple goroutine
+func simpleGoroutine() {
+	out.Printf("\n=== 1. SIMPLE GOROUTINE ===\n\n")
+
+	order := Order{
+		ID: 1, PrepTime: 2 * time.Second,
+	}
+
+	var wg sync.WaitGroup
+	var result Result
+
+	out.Printf("Before starting goroutine\n")
+
+	// Start processing order in a goroutine
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		result = processOrder(order, 0)
+	}()
+
+	out.Printf("After starting goroutine - main continues immediately!\n\n")
+
+	// Wait for goroutine to complete (a fixed time.Sleep may be too short on a slow machine)
+	wg.Wait()
+	PrintSummary([]Result{result}) // Safe to read: wg.Wait happens after the write
+}
+
+// Multiple goroutines processing orders concurrently
+func multipleGoroutines() {
+	out.Printf("\n=== 2. MULTIPLE GOROUTINES (Concurrent Processing) ===\n\n")
+	var wg sync.WaitGroup
+	startTime := clk.Now()
+
+	orders := []Order{
+		{ID: 1, PrepTime: 2 * time.Second},
+		{ID: 2, PrepTime: 3 * time.Second},
+		{ID: 3, PrepTime: 1 * time.Second},
+		{ID: 4, PrepTime: 4 * time.Second},
+		{ID: 5, PrepTime: 2 * time.Second},
+	}
+
+	// Process all orders concurrently; each goroutine owns one slot of results
+	results := make([]Result, len(orders))
+	for i, order := range orders {
+		wg.Add(1)
+		go func(i int, o Order) {
+			defer wg.Done()
+			results[i] = processOrder(o, 0)
+		}(i, order)
+	}
+
+	// Wait for all to complete - no guessing how long the longest order takes
+	wg.Wait()
+
+	PrintSummary(results)
+	out.Printf("🚀 Concurrent processing time: %s\n", out.Duration(clk.Since(startTime)))
+}
+
+// Goroutines with parameters and proper synchronization
+// WaitGroup is like a counter that tracks how many goroutines are still running.
+// We need it to wait for all goroutines to finish before the main program exits.
+//
+// WaitGroup Methods:
+// - Add(1): Tell WaitGroup "one more goroutine is starting" (increment counter)
+// - Done(): Tell WaitGroup "this goroutine is finished" (decrement counter)
+// - Wait(): Make main goroutine wait until counter reaches zero (all done)
+func goroutinesWithWaitGroup() {
+	out.Printf("\n=== 3. GOROUTINES WITH WAITGROUP (Proper Sync) ===\n\n")
+
+	startTime := clk.Now()
+
+	orders := []Order{
+		{ID: 1, PrepTime: 2 * time.Second},
+		{ID: 2, PrepTime: 3 * time.Second},
+		{ID: 3, PrepTime: 1 * time.Second},
+		{ID: 4, PrepTime: 4 * time.Second},
+		{ID: 5, PrepTime: 2 * time.Second},
+	}
+
+	PrintSummary(processConcurrently(orders))
+	out.Printf("⏱️  Sequential Processing time: 12s\n")
+	out.Printf("🎯 Concurrent processing time: %s\n", out.Duration(clk.Since(startTime))) // time of the longest task
+}
+
+// processConcurrently cooks every order in its own goroutine and returns the
+// Results in the same order. With no orders it says so and returns at once.
+func processConcurrently(orders []Order) []Result {
+	if len(orders) == 0 {
+		out.Printf("📭 No orders to process\n")
+		return nil
+	}
+
+	var wg sync.WaitGroup // WaitGroup to synchronize goroutines
+	results := make([]Result, len(orders))
+	for i, order := range orders {
+		wg.Add(1) // Increment WaitGroup counter
+		go func(i int, o Order) {
+			defer wg.Done() // Decrement counter when done
+			results[i] = processOrder(o, 0)
+		}(i, order) // Pass order as parameter to avoid closure capture issues
+	}
+
+	wg.Wait() // Wait for all goroutines to complete
+	return results
+}
+
+// Anonymous goroutines for order processing
+func anonymousGoroutines() {
+	out.Printf("\n=== 4. ANONYMOUS GOROUTINES ===\n\n")
+
+	var wg sync.WaitGroup
+	results := make([]Result, 2)
+
+	// Anonymous goroutine for rush order
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		rushOrder := Order{ID: 1, PrepTime: 2 * time.Second}
+		if chatty {
+			out.Printf("🔥 Rush Order: Processing immediately!\n")
+		}
+		results[0] = processOrder(rushOrder, 0)
+	}()
+
+	// Anonymous goroutine with parameters
+	customerName := "Alice"
+	vipOrder := Order{ID: 2, PrepTime: 1 * time.Second}
+	wg.Add(1)
+	go func(name string, order Order) {
+		defer wg.Done()
+		r := Result{Order: order, StartedAt: clk.Now()}
+		if chatty {
+			out.Printf("👤 VIP Order %d for %s: Started processing\n", order.ID, name)
+		}
+		clk.Sleep(context.Background(), order.PrepTime)
+		r.FinishedAt = clk.Now()
+		if chatty {
+			out.Printf("✅ VIP Order %d for %s: Ready for pickup! Time taken: %v (Priority Service)\n",
+				order.ID, name, order.PrepTime)
+		}
+		results[1] = r
+	}(customerName, vipOrder)
+
+	wg.Wait()
+	out.Printf("🔥 Order 1 was the rush order; 👤 order 2 was %s's VIP order\n\n", customerName)
+	PrintSummary(results)
+}
+
+// Goroutine runtime information during order processing
+func goroutineRuntimeInfo() {
+	out.Printf("\n=== 5. GOROUTINE RUNTIME INFO ===\n")
+
+	// The count includes main and the printer goroutine
+	out.Printf("📊 Initial goroutines count: %d\n", runtime.NumGoroutine())
+
+	var wg sync.WaitGroup // WaitGroup to synchronize goroutines
+
+	orders := []Order{
+		{ID: 1, PrepTime: 2 * time.Second},
+		{ID: 2, PrepTime: 3 * time.Second},
+		{ID: 3, PrepTime: 1 * time.Second},
+		{ID: 4, PrepTime: 4 * time.Second},
+		{ID: 5, PrepTime: 2 * time.Second},
+	}
+
+	// Process orders with proper synchronization
+	results := make([]Result, len(orders))
+	for i, order := range orders {
+		wg.Add(1) // Increment WaitGroup counter
+		go func(i int, o Order) {
+			defer wg.Done() // Decrement counter when done
+			results[i] = processOrder(o, 0)
+		}(i, order) // Pass order explicitly - before Go 1.22 all goroutines could share the last loop value
+	}
+
+	out.Printf("📈 After starting order processing, goroutines count: %d\n", runtime.NumGoroutine())
+
+	wg.Wait() // Wait for all goroutines to complete
+
+	out.Printf("📉 Final goroutines count: %d\n\n", runtime.NumGoroutine())
+	PrintSummary(results)
+}
+
+// Config tunes the load generator
+type Config struct {
+	order.Options      // Batch and pool size; Seed 0 picks a fresh seed each run
+	Chatty        bool // Print from inside goroutines too
+	LoadMode      bool // A load flag was given: run the load generator instead of the walkthrough
+}
+
+// parseConfig reads -workers, -orders, -maxprep, -seed and -chatty from
+// args. -speed, -timestamps, -deterministic and -output are common to every
+// lesson, so lesson.Parse has already taken them out.
+// Usage and parse errors are written to errOut.
+func parseConfig(args []string, errOut io.Writer) (Config, error) {
+	cfg := Config{Options: order.Options{Orders: 12, Workers: 4, MaxPrep: time.Second}}
+	fs := flag.NewFlagSet("orders", flag.ContinueOnError)
+	fs.SetOutput(errOut)
+	cfg.AddFlags(fs)
+	fs.BoolVar(&cfg.Chatty, "chatty", false, "also print from inside goroutines as orders start and finish")
+
+	if err := fs.Parse(args); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	fs.Visit(func(f *flag.Flag) {
+		cfg.LoadMode = cfg.LoadMode || f.Name != "chatty" // -chatty changes how orders print, not what runs
+	})
+	return cfg, nil
+}
+
+// generateOrdersCtx makes the same orders as order.Generate, one at a time on
+// the returned channel. It stops and closes the channel once n orders are sent
+// or ctx is done, even while it waits for the reader, so a consumer that
+// stops early only has to cancel ctx for the generator goroutine to exit.
+func generateOrdersCtx(ctx context.Context, opts order.Options) <-chan Order {
+	orders := make(chan Order)
+	go func() {
+		defer close(orders)
+		r := rand.New(rand.NewSource(opts.Seed))
+		for i := 0; i < opts.Orders; i++ {
+			o := Order{ID: i + 1, PrepTime: time.Duration(r.Int63n(int64(opts.MaxPrep))) + 1}
+			if ctx.Err() != nil {
+				return // select picks at random when the reader is ready too
+			}
+			select {
+			case orders <- o:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return orders
+}
+
+// cookAll cooks orders on the given number of worker goroutines and waits for
+// every one to finish. Results come back in completion order.
+func cookAll(orders []Order, workers int) ([]Result, time.Duration) {
+	queue := make(chan Order, len(orders))
+	for _, o := range orders {
+		queue <- o
+	}
+	close(queue)
+
+	startTime := clk.Now()
+	results := make(chan Result, len(orders))
+	var wg sync.WaitGroup
+	for w := 1; w <= workers; w++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for o := range queue {
+				results <- processOrder(o, worker)
+			}
+		}(w)
+	}
+
+	// Drain: every order finishes before anything is reported
+	wg.Wait()
+	close(results)
+	total := clk.Since(startTime)
+
+	var done []Result
+	for r := range results {
+		done = append(done, r)
+	}
+	return done, total
+}
+
+// goroutineWarnAt is the worker count above which the load generator warns
+const goroutineWarnAt = 1000
+
+// planWorkers caps workers at the number of orders, since a worker with no
+// order to take exits straight away; an empty batch keeps them as asked, as
+// lesson 04 does. It also warns when the pool is still above
+// goroutineWarnAt. notes holds a line for each, ready to print.
+func planWorkers(workers, orders int) (capped int, notes []string) {
+	capped = workers
+	if workers > orders && orders > 0 {
+		capped = orders
+		notes = append(notes, fmt.Sprintf("🔧 -workers=%d capped to %d: a worker per order is already one goroutine each", workers, capped))
+	}
+	if capped > goroutineWarnAt {
+		notes = append(notes, fmt.Sprintf("⚠️  %d worker goroutines: fine for orders that mostly wait, but each has its own stack, and CPU-bound work gains nothing past GOMAXPROCS", capped))
+	}
+	return capped, notes
+}
+
+// loadGenerator cooks cfg.Orders random orders, drawn from cfg.Seed, on
+// cfg.Workers goroutines, waits for every one to finish, then prints the
+// per-order breakdown and totals
+func loadGenerator(cfg Config) {
+	title := "6. LOAD GENERATOR"
+	if cfg.LoadMode {
+		title = "LOAD GENERATOR" // Run on its own from the command line
+	}
+	workers, notes := planWorkers(cfg.Workers, cfg.Orders)
+	out.Printf("\n=== %s (%d orders, %d workers, prep up to %v) ===\n\n", title, cfg.Orders, workers, cfg.MaxPrep)
+	for _, note := range notes {
+		out.Printf("%s\n", note)
+	}
+	if len(notes) > 0 {
+		out.Printf("\n")
+	}
+
+	out.Printf("🎲 Seed %d (pass -seed=%d to get the same prep times again)\n\n", cfg.Seed, cfg.Seed)
+	if cfg.Orders == 0 {
+		out.Printf("📭 No orders to cook\n")
+		return
+	}
+
+	orders, _ := order.Generate(cfg.Options) // parseConfig has checked the options
+	var sequential time.Duration
+	for _, o := range orders {
+		sequential += o.PrepTime
+	}
+
+	all, total := cookAll(orders, workers)
+	PrintSummary(all)
+
+	out.Printf("⏱️  Sequential time:      %s\n", out.Duration(sequential))
+	out.Printf("🎯 Concurrent time:      %s\n", out.Duration(total))
+	if out.Deterministic() {
+		out.Printf("🚀 Speedup:              ~%.0fx\n", sequential.Seconds()/total.Seconds())
+		return
+	}
+	out.Printf("🚀 Speedup:              %.1fx\n", sequential.Seconds()/total.Seconds())
+}
+
+// loadReport is the load generator for -output=json: the same run, as a
+// report. Worker cap notes go to errOut, so stdout holds nothing a JSON
+// reader would trip over.
+func loadReport(cfg Config, errOut io.Writer) report.Run {
+	workers, notes := planWorkers(cfg.Workers, cfg.Orders)
+	for _, note := range notes {
+		fmt.Fprintln(errOut, note)
+	}
+	orders, _ := order.Generate(cfg.Options)
+	all, _ := cookAll(orders, workers)
+	opts := report.Options{Orders: cfg.Orders, Workers: workers, Seed: cfg.Seed, MaxPrep: cfg.MaxPrep, Speed: speed()}
+	return report.FromResults("02-goroutines-and-waitgroups", opts, all)
+}
+
+// Original sequential processing for comparison
+func sequentialProcessing() {
+	out.Printf("\n=== 0. SEQUENTIAL PROCESSING (Original) ===\n\n")
+
+	startTime := clk.Now()
+
+	orders := []Order{
+		{ID: 1, PrepTime: 2 * time.Second},
+		{ID: 2, PrepTime: 3 * time.Second},
+		{ID: 3, PrepTime: 1 * time.Second},
+		{ID: 4, PrepTime: 4 * time.Second},
+		{ID: 5, PrepTime: 2 * time.Second},
+	}
+
+	var results []Result
+	for _, order := range orders {
+		results = append(results, processOrder(order, 0))
+	}
+
+	PrintSummary(results)
+	out.Printf("⏱️  Sequential processing time: %s\n", out.Duration(clk.Since(startTime)))
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	cfg, err := parseConfig(opts.Args, os.Stderr)
+	if err != nil {
+		return lesson.Usage(err)
+	}
+
+	cfg.Seed = opts.Seed(cfg.Seed)
+	// Chatty lines come in whatever order the scheduler runs the goroutines
+	chatty = cfg.Chatty && !opts.Deterministic && !opts.JSON()
+	out = opts.NewPrinter()
+	defer out.Close() // Every queued line is written before the program exits
+
+	// The walkthrough is narrative through and through; a JSON report is of a load run
+	if opts.JSON() {
+		return report.Encode(opts.Out(), loadReport(cfg, os.Stderr))
+	}
+
+	out.Printf("==========================================\n")
+	out.Printf("🏪 Go Concurrency: Order Processing System\n")
+	out.Printf("==========================================\n")
+
+	// With any flag, skip the walkthrough and act as a load generator
+	if cfg.LoadMode {
+		loadGenerator(cfg)
+		return nil
+	}
+
+	// Show original sequential approach first
+	sequentialProcessing()
+
+	// Demonstrate all goroutine concepts
+	simpleGoroutine()
+	multipleGoroutines()
+	goroutinesWithWaitGroup()
+	anonymousGoroutines()
+	goroutineRuntimeInfo()
+	loadGenerator(cfg)
+
+	out.Printf("\n📝 Key Learnings:\n")
+	out.Printf("✅ Goroutines enable concurrent order processing\n")
+	out.Printf("✅ Use 'go' keyword to start concurrent processing\n")
+	out.Printf("✅ WaitGroups provide proper synchronization\n")
+	out.Printf("✅ Anonymous functions can be used as goroutines\n")
+	out.Printf("✅ Pass parameters to avoid variable capture issues\n")
+	out.Printf("✅ Concurrent processing dramatically reduces total time!\n")
+	out.Printf("✅ flag.NewFlagSet parses any argument list, so config parsing can be checked\n")
+	out.Printf("✅ Injecting a seeded rand.Source makes a random run reproducible\n")
+	out.Printf("✅ Goroutines return Results; the caller prints once they're all done\n")
+	out.Printf("✅ One printer goroutine owning stdout means lines never interleave\n")
+	out.Printf("✅ A seed, sorted results and a fake clock make output stable enough for golden files\n")
+	out.Printf("✅ Validate each order before cooking it: a zero-value order is an error, not an instant success\n")
+	out.Printf("✅ Scaling the clock, not the prep times, speeds up a demo while its numbers stay true\n")
+	out.Printf("✅ A generator that selects on ctx.Done() exits when its reader cancels, instead of leaking\n")
+	out.Printf("✅ Cap the pool at the number of orders, and warn before a flag turns into thousands of goroutines\n")
+	out.Printf("✅ Keep results as data, and text or JSON is only a choice of how to print them\n")
+	return nil
+}
@@ -0,0 +1,440 @@
+package waitgroups
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+	"github.com/Ajay2521/go-concurrency/pkg/order"
+	"github.com/Ajay2521/go-concurrency/pkg/report"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// renderGolden runs the sequential walkthrough and a one-worker load
+// generator on a fake clock in deterministic mode, and returns what they
+// print. No real time passes.
+func renderGolden() string {
+	savedOut, savedChatty := out, chatty
+	defer func() {
+		out, chatty = savedOut, savedChatty
+	}()
+
+	var buf bytes.Buffer
+	out, chatty = display.NewPrinter(&buf, display.WithDeterministic()), false
+	onFakeClock(func() {
+		sequentialProcessing()
+		loadGenerator(Config{Options: order.Options{Orders: 8, Seed: lesson.DeterministicSeed, Workers: 1, MaxPrep: 2 * time.Second}, LoadMode: true})
+	})
+	out.Close()
+	return buf.String()
+}
+
+// The deterministic output, compared byte for byte with the golden file.
+// go test -update rewrites the file instead.
+func TestGolden(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	got := renderGolden()
+	if again := renderGolden(); again != got {
+		t.Fatalf("two renders differ: %s", testutil.FirstDiff(again, got))
+	}
+	if !strings.Contains(got, "1       -") || strings.Contains(got, "\n8       1") {
+		t.Error("want rows sorted by order ID with the worker column hidden")
+	}
+	testutil.Golden(t, "testdata/golden.txt", got)
+}
+
+// parseConfig reads a custom argument list and never touches os.Args. A
+// flag that only changes how the walkthrough prints keeps the walkthrough;
+// any other flag turns the lesson into a load generator.
+func TestParseConfig(t *testing.T) {
+	defaults := order.Options{Orders: 12, Workers: 4, MaxPrep: time.Second}
+	const rejected = "rejected"
+	testutil.RunParallel(t, []testutil.TestCase{
+		{Name: "no flags: walkthrough defaults", Input: []string(nil), Want: Config{Options: defaults}},
+		{Name: "all four flags", Input: []string{"-workers=8", "-orders", "100", "-maxprep=250ms", "-seed=42"},
+			Want: Config{Options: order.Options{Orders: 100, Seed: 42, Workers: 8, MaxPrep: 250 * time.Millisecond}, LoadMode: true}},
+		{Name: "one flag keeps other defaults", Input: []string{"-orders=5"},
+			Want: Config{Options: order.Options{Orders: 5, Workers: 4, MaxPrep: time.Second}, LoadMode: true}},
+		{Name: "zero orders: empty batch", Input: []string{"-orders=0"},
+			Want: Config{Options: order.Options{Workers: 4, MaxPrep: time.Second}, LoadMode: true}},
+		{Name: "zero workers rejected", Input: []string{"-workers=0"}, Want: rejected},
+		{Name: "negative orders rejected", Input: []string{"-orders=-1"}, Want: rejected},
+		{Name: "bad duration rejected", Input: []string{"-maxprep=fast"}, Want: rejected},
+		{Name: "unknown flag rejected", Input: []string{"-chefs=3"}, Want: rejected},
+		{Name: "non-numeric seed rejected", Input: []string{"-seed=abc"}, Want: rejected},
+		{Name: "-chatty alone keeps walkthrough", Input: []string{"-chatty"}, Want: Config{Options: defaults, Chatty: true}},
+	}, func(t *testing.T, tc testutil.TestCase) {
+		got, err := parseConfig(tc.Input.([]string), io.Discard)
+		if tc.Want == rejected {
+			if err == nil {
+				t.Errorf("parseConfig = %+v, want an error", got)
+			}
+			return
+		}
+		if err != nil || got != tc.Want {
+			t.Errorf("parseConfig = %+v, %v, want %+v", got, err, tc.Want)
+		}
+	})
+}
+
+// generate is order.Generate's batch of n orders with prep times up to
+// maxPrep, drawn from seed
+func generate(t *testing.T, n int, maxPrep time.Duration, seed int64) []Order {
+	t.Helper()
+	orders, err := order.Generate(order.Options{Orders: n, Seed: seed, MaxPrep: maxPrep})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return orders
+}
+
+// The same seed gives the same orders, and with one worker the same
+// completion order. A different seed gives different orders.
+func TestSameSeedSameRun(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	onFakeClock(func() {
+		a, b := generate(t, 100, time.Second, 42), generate(t, 100, time.Second, 42)
+		if !slices.Equal(a, b) {
+			t.Error("two batches from seed 42 differ")
+		}
+		if c := generate(t, 100, time.Second, 43); slices.Equal(a, c) {
+			t.Error("seeds 42 and 43 gave the same batch")
+		}
+
+		completionOrder := func(seed int64) []int {
+			done, _ := cookAll(generate(t, 10, 5*time.Millisecond, seed), 1)
+			ids := make([]int, len(done))
+			for i, r := range done {
+				ids[i] = r.Order.ID
+			}
+			return ids
+		}
+		if first, second := completionOrder(7), completionOrder(7); !slices.Equal(first, second) {
+			t.Errorf("one worker, seed 7: completed %v then %v", first, second)
+		}
+	})
+}
+
+// Every Result finishes after it starts and takes at least its prep time.
+// One worker cooks in sequence, so each order starts after the last one
+// finished.
+func TestResultTimestampsMonotonic(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	onFakeClock(func() {
+		done, _ := cookAll(generate(t, 20, 3*time.Millisecond, 1), 1)
+		if len(done) != 20 {
+			t.Fatalf("cookAll returned %d results, want 20", len(done))
+		}
+		for i, r := range done {
+			if r.FinishedAt.Before(r.StartedAt) || r.Latency() < r.Order.PrepTime {
+				t.Errorf("order %d: started %v, finished %v, prep %v", r.Order.ID, r.StartedAt, r.FinishedAt, r.Order.PrepTime)
+			}
+			if i > 0 && r.StartedAt.Before(done[i-1].FinishedAt) {
+				t.Errorf("order %d started at %v, before order %d finished at %v",
+					r.Order.ID, r.StartedAt, done[i-1].Order.ID, done[i-1].FinishedAt)
+			}
+		}
+	})
+}
+
+// -output=json, as lesson.Parse reads it, runs the load generator and
+// prints one report.Run on one line and nothing else. One worker on the fake
+// clock cooks every order for exactly its prep time, one after another.
+func TestJSONOutput(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	got := testutil.RunLesson(t, Run, "-output=json", "-orders=20", "-seed=7", "-workers=1", "-maxprep=100ms")
+	if !json.Valid([]byte(got)) || strings.Count(got, "\n") != 1 || !strings.HasPrefix(got, "{") {
+		t.Fatalf("want one JSON object on one line, got %q", got)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(got), &fields); err != nil {
+		t.Fatal(err)
+	}
+	if keys := slices.Sorted(maps.Keys(fields)); !slices.Equal(keys, []string{"lesson", "options", "orders", "schema", "stats"}) {
+		t.Errorf("top-level keys %v, want pkg/report's", keys)
+	}
+
+	runs, err := report.Decode(strings.NewReader(got))
+	if err != nil || len(runs) != 1 {
+		t.Fatalf("Decode = %d runs, %v", len(runs), err)
+	}
+	r := runs[0]
+	want := report.Options{Orders: 20, Workers: 1, Seed: 7, MaxPrep: 100 * time.Millisecond, Speed: 1}
+	if r.Lesson != "02-goroutines-and-waitgroups" || r.Options != want {
+		t.Errorf("lesson %q, options %+v; want %+v", r.Lesson, r.Options, want)
+	}
+	if len(r.Orders) != 20 {
+		t.Fatalf("%d orders, want 20", len(r.Orders))
+	}
+	var prep time.Duration
+	for i, o := range r.Orders {
+		prep += o.Prep
+		if o.ID != i+1 {
+			t.Errorf("order %d at index %d, want sorted by ID", o.ID, i)
+		}
+		if o.Finish-o.Start != o.Duration || o.Duration != o.Prep {
+			t.Errorf("order %d: start %v, finish %v, duration %v, prep %v", o.ID, o.Start, o.Finish, o.Duration, o.Prep)
+		}
+	}
+	if r.Stats.Wall != prep {
+		t.Errorf("one worker: wall %v, want the sum of preps %v", r.Stats.Wall, prep)
+	}
+}
+
+// capture runs fn with the lesson's output going to a buffer instead, and
+// returns what fn printed
+func capture(fn func()) string {
+	saved := out
+	defer func() { out = saved }()
+
+	var buf bytes.Buffer
+	out = display.NewPrinter(&buf)
+	fn()
+	out.Close()
+	return buf.String()
+}
+
+// Nil and empty slices log and return at once; a zero-value or malformed
+// order comes back with order.ErrInvalidOrder and no latency instead of
+// being cooked. The cases share out and clk, so they run one at a time.
+func TestProcessConcurrentlyValidation(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	tests := []struct {
+		name     string
+		orders   []Order
+		wantLog  string // Printed by processConcurrently, "" for nothing
+		wantErrs []bool // One per order: should its Result carry order.ErrInvalidOrder?
+	}{
+		{"nil slice", nil, "📭 No orders to process\n", nil},
+		{"empty slice", []Order{}, "📭 No orders to process\n", nil},
+		{"zero-value order", []Order{{}}, "", []bool{true}},
+		{"missing prep time", []Order{{ID: 7}}, "", []bool{true}},
+		{"negative prep time", []Order{{ID: 8, PrepTime: -time.Second}}, "", []bool{true}},
+		{"valid order", []Order{{ID: 1, PrepTime: time.Millisecond}}, "", []bool{false}},
+		{"bad order among good ones", []Order{{ID: 1, PrepTime: time.Millisecond}, {}, {ID: 3, PrepTime: time.Millisecond}},
+			"", []bool{false, true, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var results []Result
+			var logged string
+			onFakeClock(func() {
+				logged = capture(func() { results = processConcurrently(tt.orders) })
+			})
+			if logged != tt.wantLog {
+				t.Errorf("printed %q, want %q", logged, tt.wantLog)
+			}
+			if len(results) != len(tt.wantErrs) {
+				t.Fatalf("%d results, want %d", len(results), len(tt.wantErrs))
+			}
+			for i, r := range results {
+				if got := errors.Is(r.Err, order.ErrInvalidOrder); got != tt.wantErrs[i] {
+					t.Errorf("result %d: err %v, want invalid %v", i, r.Err, tt.wantErrs[i])
+				}
+				if r.Err != nil && r.Latency() != 0 {
+					t.Errorf("result %d: rejected order took %v, want 0", i, r.Latency())
+				}
+			}
+		})
+	}
+}
+
+// A scaled clock sleeps d/speed on the clock under it and reports d
+func TestScaledSleep(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	tests := []struct {
+		name         string
+		speed        float64
+		d, wantSlept time.Duration
+	}{
+		{"speed 10: 2s sleeps 200ms", 10, 2 * time.Second, 200 * time.Millisecond},
+		{"speed 0.5: 1s sleeps 2s", 0.5, time.Second, 2 * time.Second},
+		{"speed 3: 1s sleeps a third", 3, time.Second, time.Second / 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var slept, nominal time.Duration
+			onFakeClock(func() {
+				base := clk
+				c := clock.Scaled(base, tt.speed)
+				start, baseStart := c.Now(), base.Now()
+				c.Sleep(context.Background(), tt.d)
+				slept, nominal = base.Since(baseStart), c.Since(start)
+			})
+			if (slept - tt.wantSlept).Abs() >= time.Microsecond {
+				t.Errorf("slept %v, want %v", slept, tt.wantSlept)
+			}
+			if (nominal - tt.d).Abs() >= time.Microsecond { // Rounding stays under 1µs
+				t.Errorf("reported %v, want %v", nominal, tt.d)
+			}
+		})
+	}
+}
+
+// A 1s order at -speed=20 on the real clock reports a 1s latency but
+// really takes about 50ms
+func TestSpeedKeepsLatencyNominal(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	saved := clk
+	defer func() { clk = saved }()
+	clk = clock.Scaled(clock.Real(), 20)
+
+	startTime := time.Now()
+	r := processOrder(Order{ID: 1, PrepTime: time.Second}, 0)
+	took := time.Since(startTime)
+	if r.Latency() < time.Second || r.Latency() >= 1500*time.Millisecond {
+		t.Errorf("latency %v, want about 1s", r.Latency())
+	}
+	if took >= 500*time.Millisecond {
+		t.Errorf("really took %v, want about 50ms", took)
+	}
+}
+
+// The pool is capped at one worker per order, and a pool still above
+// goroutineWarnAt is warned about
+func TestPlanWorkers(t *testing.T) {
+	type want struct {
+		workers        int
+		capped, warned bool
+	}
+	testutil.RunParallel(t, []testutil.TestCase{
+		{Name: "fewer workers than orders", Input: [2]int{4, 12}, Want: want{4, false, false}},
+		{Name: "one order, one worker", Input: [2]int{1, 1}, Want: want{1, false, false}},
+		{Name: "more workers than orders", Input: [2]int{50, 12}, Want: want{12, true, false}},
+		{Name: "empty batch keeps the workers", Input: [2]int{4, 0}, Want: want{4, false, false}},
+		{Name: "at the warning threshold", Input: [2]int{goroutineWarnAt, 5000}, Want: want{goroutineWarnAt, false, false}},
+		{Name: "above the threshold", Input: [2]int{5000, 10000}, Want: want{5000, false, true}},
+		{Name: "capped, still above it", Input: [2]int{20000, 5000}, Want: want{5000, true, true}},
+	}, func(t *testing.T, tc testutil.TestCase) {
+		in := tc.Input.([2]int)
+		workers, notes := planWorkers(in[0], in[1])
+		got := want{
+			workers: workers,
+			capped:  slices.ContainsFunc(notes, func(n string) bool { return strings.HasPrefix(n, "🔧") }),
+			warned:  slices.ContainsFunc(notes, func(n string) bool { return strings.HasPrefix(n, "⚠️") }),
+		}
+		if got != tc.Want {
+			t.Errorf("planWorkers(%d, %d) = %+v, notes %q; want %+v", in[0], in[1], got, notes, tc.Want)
+		}
+	})
+}
+
+// Each case checks, through WaitForGoroutines, that the generator goroutine
+// has exited by the time it ends
+func TestGenerateOrdersCtx(t *testing.T) {
+	opts := order.Options{Orders: 1000, Seed: lesson.DeterministicSeed, MaxPrep: time.Second}
+
+	t.Run("cancel after 3", func(t *testing.T) {
+		testutil.WaitForGoroutines(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		orders := generateOrdersCtx(ctx, opts)
+		var ids []int
+		for range 3 {
+			ids = append(ids, (<-orders).ID)
+		}
+		cancel()
+		// The generator is blocked sending order 4. With this reader ready
+		// too, its select may still pick the send once, but never another.
+		for o := range orders {
+			ids = append(ids, o.ID)
+		}
+		if len(ids) > 4 || !slices.Equal(ids[:3], []int{1, 2, 3}) {
+			t.Errorf("read %v after cancelling at 3", ids)
+		}
+	})
+
+	t.Run("already cancelled", func(t *testing.T) {
+		testutil.WaitForGoroutines(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		sent := 0
+		for range generateOrdersCtx(ctx, opts) {
+			sent++
+		}
+		if sent != 0 {
+			t.Errorf("%d order(s) sent after cancel, want 0", sent)
+		}
+	})
+
+	t.Run("full read matches order.Generate", func(t *testing.T) {
+		testutil.WaitForGoroutines(t)
+		small := opts
+		small.Orders = 50
+		var got []Order
+		for o := range generateOrdersCtx(context.Background(), small) {
+			got = append(got, o)
+		}
+		if want := generate(t, 50, time.Second, lesson.DeterministicSeed); !slices.Equal(got, want) {
+			t.Errorf("generator sent %d orders that differ from order.Generate's %d", len(got), len(want))
+		}
+	})
+}
+
+// The demos used to sleep a fixed 3s or 5s and hope the goroutines were done.
+// Here the fake clock moves 2s before the orders start, as if the machine
+// had stalled, so the old fixed sleep returns with orders still cooking.
+// The WaitGroup waits for every order however late it starts.
+func TestWaitGroupWaitsForSlowOrders(t *testing.T) {
+	const stall = 2 * time.Second
+	tests := []struct {
+		name       string
+		section    func()
+		sleepers   int           // Orders cooking at once
+		fixedSleep time.Duration // What the demo used to sleep instead of wg.Wait
+		want       string        // PrintSummary's totals once every order is done
+	}{
+		{"simple goroutine", simpleGoroutine, 1, 3 * time.Second, "📦 1 order(s), 0 failed"},
+		{"multiple goroutines", multipleGoroutines, 5, 5 * time.Second, "📦 5 order(s), 0 failed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutil.WaitForGoroutines(t)
+			savedClk, savedOut := clk, out
+			defer func() { clk, out = savedClk, savedOut }()
+			fake := clock.NewFake(testutil.Epoch)
+			var buf bytes.Buffer
+			clk, out = fake, display.NewPrinter(&buf)
+
+			// The old version: start the orders, then sleep a fixed time
+			slept := make(chan struct{})
+			go func() {
+				defer close(slept)
+				clk.Sleep(context.Background(), tt.fixedSleep)
+			}()
+			fake.BlockUntil(1)
+			fake.Advance(stall)
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				tt.section()
+			}()
+			fake.BlockUntil(1 + tt.sleepers)
+			fake.Advance(tt.fixedSleep - stall)
+			<-slept
+			select {
+			case <-done:
+				t.Fatal("the orders finished inside the fixed sleep; the stall should have made it too short")
+			case <-time.After(100 * time.Millisecond):
+			}
+
+			fake.Advance(4 * time.Second) // The longest prep time
+			<-done
+			out.Close()
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("want %q once the orders finish, printed:\n%s", tt.want, buf.String())
+			}
+		})
+	}
+}
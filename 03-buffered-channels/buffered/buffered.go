@@ -0,0 +1,201 @@
+// Package buffered is lesson 03: Buffered Channels & Backpressure.
+package buffered
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+}
+
+// ErrQueueFull is returned when an order can't be queued in time
+var ErrQueueFull = errors.New("queue full")
+
+// BoundedQueue is a fixed-size order buffer that sheds load when the kitchen falls behind.
+// It wraps a buffered channel: the channel's capacity is the maximum backlog.
+type BoundedQueue struct {
+	orders       chan Order
+	blockTimeout time.Duration // How long Submit waits for space (0 = reject immediately)
+}
+
+// NewBoundedQueue creates a queue holding at most capacity orders
+func NewBoundedQueue(capacity int, blockTimeout time.Duration) *BoundedQueue {
+	return &BoundedQueue{
+		orders:       make(chan Order, capacity),
+		blockTimeout: blockTimeout,
+	}
+}
+
+// Submit queues an order, waiting up to blockTimeout for space.
+// It returns ErrQueueFull if the buffer is still full after the timeout.
+func (q *BoundedQueue) Submit(order Order) error {
+	// Fast path: there is room right now
+	select {
+	case q.orders <- order:
+		return nil
+	default:
+	}
+
+	if q.blockTimeout <= 0 {
+		return ErrQueueFull
+	}
+
+	// Slow path: wait for a worker to free up a slot, but not forever
+	timer := clk.NewTimer(q.blockTimeout)
+	defer timer.Stop()
+
+	select {
+	case q.orders <- order:
+		return nil
+	case <-timer.C():
+		return fmt.Errorf("order %d: %w after %v", order.ID, ErrQueueFull, q.blockTimeout)
+	}
+}
+
+// Orders returns the receive side of the queue for workers
+func (q *BoundedQueue) Orders() <-chan Order {
+	return q.orders
+}
+
+// Len returns the number of orders waiting in the buffer
+func (q *BoundedQueue) Len() int {
+	return len(q.orders)
+}
+
+// Close stops accepting orders; workers drain what's left and exit
+func (q *BoundedQueue) Close() {
+	close(q.orders)
+}
+
+// Buffered channel basics: sends don't block until the buffer is full
+func bufferedChannelBasics() {
+	out.Printf("\n=== 1. BUFFERED CHANNEL BASICS ===\n\n")
+
+	orders := make(chan Order, 3) // Room for 3 orders
+
+	for i := 1; i <= 3; i++ {
+		orders <- Order{ID: i, PrepTime: time.Second} // Doesn't block - there is space
+		out.Printf("📥 Queued order %d (len=%d, cap=%d)\n", i, len(orders), cap(orders))
+	}
+
+	// A 4th send would block forever here because nobody is receiving
+	select {
+	case orders <- Order{ID: 4}:
+		out.Printf("📥 Queued order 4\n")
+	default:
+		out.Printf("🚫 Order 4: buffer full, send would block\n")
+	}
+
+	close(orders)
+	for order := range orders {
+		out.Printf("📤 Received order %d\n", order.ID)
+	}
+}
+
+// runKitchen starts workers that drain the queue until it is closed
+func runKitchen(queue *BoundedQueue, workers int, wg *sync.WaitGroup) {
+	for w := 1; w <= workers; w++ {
+		wg.Add(1)
+		go func(workerID int) {
+			defer wg.Done()
+			for order := range queue.Orders() {
+				clk.Sleep(context.Background(), order.PrepTime)
+				out.Printf("✅ Chef %d: Order %d ready\n", workerID, order.ID)
+			}
+		}(w)
+	}
+}
+
+// Load shedding: reject orders immediately when the buffer is full
+func loadShedding() {
+	out.Printf("\n=== 2. LOAD SHEDDING (Reject immediately) ===\n\n")
+
+	queue := NewBoundedQueue(3, 0)
+	var wg sync.WaitGroup
+	runKitchen(queue, 1, &wg)
+
+	accepted, rejected := 0, 0
+
+	// A rush of 10 orders arrives at once - far more than 1 chef can handle
+	for i := 1; i <= 10; i++ {
+		err := queue.Submit(Order{ID: i, PrepTime: 300 * time.Millisecond})
+		if errors.Is(err, ErrQueueFull) {
+			rejected++
+			out.Printf("🚫 Order %d: Kitchen overwhelmed, please try again later\n", i)
+			continue
+		}
+		accepted++
+		out.Printf("📥 Order %d: Accepted (backlog %d)\n", i, queue.Len())
+	}
+
+	queue.Close()
+	wg.Wait()
+
+	out.Printf("\n📊 Accepted: %d | Rejected: %d\n", accepted, rejected)
+}
+
+// Backpressure: block the customer for a short while before giving up
+func blockWithTimeout() {
+	out.Printf("\n=== 3. BACKPRESSURE (Block with timeout) ===\n\n")
+
+	queue := NewBoundedQueue(2, 250*time.Millisecond)
+	var wg sync.WaitGroup
+	runKitchen(queue, 2, &wg)
+
+	accepted, rejected := 0, 0
+
+	for i := 1; i <= 10; i++ {
+		start := clk.Now()
+		err := queue.Submit(Order{ID: i, PrepTime: 400 * time.Millisecond})
+		if err != nil {
+			rejected++
+			out.Printf("⏳ %v\n", err)
+			continue
+		}
+		accepted++
+		out.Printf("📥 Order %d: Accepted after waiting %v\n", i, clk.Since(start).Round(time.Millisecond))
+	}
+
+	queue.Close()
+	wg.Wait()
+
+	out.Printf("\n📊 Accepted: %d | Rejected: %d\n", accepted, rejected)
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Buffered Channels & Backpressure")
+	out.Println("==========================================")
+
+	bufferedChannelBasics()
+	loadShedding()
+	blockWithTimeout()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ A buffered channel's capacity is a natural backlog limit")
+	out.Println("✅ select with default turns a blocking send into a try-send")
+	out.Println("✅ A timer in select bounds how long producers are held back")
+	out.Println("✅ Rejecting work early beats an unbounded, ever-growing queue")
+	return nil
+}
@@ -0,0 +1,195 @@
+package buffered
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// onManualClock points the lesson at a fake clock that only moves when the
+// test advances it, and a printer that discards
+func onManualClock(t *testing.T) *clock.FakeClock {
+	fake := clock.NewFake(testutil.Epoch)
+	savedClk := clk
+	clk, out = fake, display.NewPrinter(io.Discard)
+	t.Cleanup(func() {
+		out.Close()
+		clk = savedClk
+	})
+	return fake
+}
+
+// fill submits orders until the queue is at capacity, with nobody receiving
+func fill(t *testing.T, q *BoundedQueue) {
+	t.Helper()
+	for id := 1; q.Len() < cap(q.orders); id++ {
+		if err := q.Submit(Order{ID: id}); err != nil {
+			t.Fatalf("Submit with room in the buffer: %v", err)
+		}
+	}
+}
+
+func TestSubmitRejectsAtOnceWithoutTimeout(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	onManualClock(t)
+	q := NewBoundedQueue(3, 0)
+	fill(t, q)
+	if err := q.Submit(Order{ID: 4}); err != ErrQueueFull {
+		t.Errorf("Submit to a full queue = %v, want ErrQueueFull", err)
+	}
+	if n := q.Len(); n != 3 {
+		t.Errorf("Len = %d after a rejected Submit, want 3", n)
+	}
+}
+
+// With the buffer full, Submit waits out its timeout and no longer, then
+// reports queue full
+func TestSubmitQueueFullAfterTimeout(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := onManualClock(t)
+	q := NewBoundedQueue(2, 250*time.Millisecond)
+	fill(t, q)
+
+	errc := make(chan error)
+	go func() { errc <- q.Submit(Order{ID: 3}) }()
+	fake.BlockUntil(1) // Submit's timer
+	fake.Advance(249 * time.Millisecond)
+	select {
+	case err := <-errc:
+		t.Fatalf("Submit returned %v before its 250ms timeout", err)
+	default:
+	}
+
+	fake.Advance(time.Millisecond)
+	err := <-errc
+	if !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("Submit = %v, want ErrQueueFull", err)
+	}
+	if want := "order 3: queue full after 250ms"; err.Error() != want {
+		t.Errorf("error %q, want %q", err, want)
+	}
+	if n := q.Len(); n != 2 {
+		t.Errorf("Len = %d, want the 2 orders that filled it", n)
+	}
+}
+
+// A slot that frees up before the timeout lets the waiting Submit in
+func TestSubmitWaitsForSpace(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := onManualClock(t)
+	q := NewBoundedQueue(2, 250*time.Millisecond)
+	fill(t, q)
+
+	errc := make(chan error)
+	go func() { errc <- q.Submit(Order{ID: 3}) }()
+	fake.BlockUntil(1)
+	fake.Advance(100 * time.Millisecond)
+	if o := <-q.Orders(); o.ID != 1 {
+		t.Errorf("received order %d, want 1 first", o.ID)
+	}
+	if err := <-errc; err != nil {
+		t.Errorf("Submit with a slot freed in time = %v", err)
+	}
+
+	q.Close()
+	var ids []int
+	for o := range q.Orders() {
+		ids = append(ids, o.ID)
+	}
+	if !slices.Equal(ids, []int{2, 3}) {
+		t.Errorf("drained %v after Close, want [2 3]", ids)
+	}
+}
+
+// Many producers against a slow kitchen: every order is either cooked or
+// rejected, never lost or cooked twice. Run with -race.
+func TestConcurrentSubmit(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	savedClk := clk
+	clk, out = testutil.FakeClock(t), display.NewPrinter(io.Discard)
+	t.Cleanup(func() {
+		out.Close()
+		clk = savedClk
+	})
+
+	q := NewBoundedQueue(4, 50*time.Millisecond)
+	var cooked sync.Map
+	var kitchen sync.WaitGroup
+	for range 2 {
+		kitchen.Add(1)
+		go func() {
+			defer kitchen.Done()
+			for o := range q.Orders() {
+				clk.Sleep(t.Context(), 30*time.Millisecond)
+				if _, dup := cooked.LoadOrStore(o.ID, true); dup {
+					t.Errorf("order %d cooked twice", o.ID)
+				}
+			}
+		}()
+	}
+
+	var rejected sync.Map
+	var producers sync.WaitGroup
+	for p := range 10 {
+		producers.Add(1)
+		go func() {
+			defer producers.Done()
+			for i := range 10 {
+				id := p*10 + i
+				if err := q.Submit(Order{ID: id}); errors.Is(err, ErrQueueFull) {
+					rejected.Store(id, true)
+				} else if err != nil {
+					t.Errorf("Submit = %v", err)
+				}
+			}
+		}()
+	}
+	producers.Wait()
+	q.Close()
+	kitchen.Wait()
+
+	for id := range 100 {
+		_, c := cooked.Load(id)
+		_, r := rejected.Load(id)
+		if c == r {
+			t.Errorf("order %d: cooked %v, rejected %v; want exactly one", id, c, r)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	got := testutil.RunLesson(t, Run)
+	for _, want := range []string{
+		"📥 Queued order 3 (len=3, cap=3)",
+		"🚫 Order 4: buffer full, send would block",
+		"📤 Received order 3",
+		"queue full after 250ms",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	// Both kitchens account for all 10 orders, one way or the other
+	summaries := 0
+	for _, line := range strings.Split(got, "\n") {
+		var accepted, rejected int
+		if n, _ := fmt.Sscanf(line, "📊 Accepted: %d | Rejected: %d", &accepted, &rejected); n == 2 {
+			summaries++
+			if accepted+rejected != 10 || rejected == 0 {
+				t.Errorf("%q: want 10 orders with some rejected", line)
+			}
+		}
+	}
+	if summaries != 2 {
+		t.Errorf("%d summaries, want 2", summaries)
+	}
+}
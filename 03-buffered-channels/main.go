@@ -0,0 +1,10 @@
+package main
+
+import (
+	"github.com/Ajay2521/go-concurrency/03-buffered-channels/buffered"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+func main() {
+	lesson.Main(buffered.Run)
+}
@@ -0,0 +1,10 @@
+package main
+
+import (
+	"github.com/Ajay2521/go-concurrency/04-worker-pools/workerpool"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+func main() {
+	lesson.Main(workerpool.Run)
+}
@@ -0,0 +1,1225 @@
+// Package workerpool is lesson 04: Worker Pools.
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+	"github.com/Ajay2521/go-concurrency/pkg/order"
+	"github.com/Ajay2521/go-concurrency/pkg/report"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+// chatty is set by -chatty: chefs also print each order from inside their
+// goroutines as it finishes, in whatever order the scheduler runs them
+var chatty bool
+
+// Order is the shared order type, so a load run cooks what order.Generate makes
+type Order = order.Order
+
+// Result is the shared result type: a finished order reported back by a
+// worker. Err is context.Canceled if the order was cancelled with
+// CancelOrder or abandoned.
+type Result = order.Result
+
+// job is a queued order plus the future waiting for it, if any
+type job struct {
+	order  Order
+	future *Future // nil for orders sent with Submit
+}
+
+// Future is the pending Result of an order sent with SubmitAsync
+type Future struct {
+	done   chan struct{} // Closed once result is set
+	result Result
+}
+
+// Wait blocks until the order finishes. Every call returns the same Result.
+func (f *Future) Wait() Result {
+	<-f.done
+	return f.result
+}
+
+// complete sets the result and releases every Wait; it is called exactly once
+func (f *Future) complete(r Result) {
+	f.result = r
+	close(f.done)
+}
+
+// ErrShutdown is returned by Submit once Shutdown has been called
+var ErrShutdown = errors.New("processor is shut down")
+
+// Processor is a fixed-size pool of chefs with an explicit lifecycle:
+// submit orders, read results, then Shutdown to drain and stop.
+type Processor struct {
+	orders  chan job
+	results chan Result
+	errs    chan error // Fatal worker errors (e.g. a panic while cooking)
+
+	ctx    context.Context // Cancelled to abandon remaining work
+	cancel context.CancelFunc
+
+	mu     sync.RWMutex // Guards closed against concurrent Submit/Shutdown
+	closed bool
+
+	closing     chan struct{} // Closed as Shutdown starts, so a Submit blocked on a full queue lets go of mu
+	closingOnce sync.Once
+	wg          sync.WaitGroup
+	done        chan struct{} // Closed when every worker has exited
+
+	pauseMu sync.Mutex
+	resumed *sync.Cond // Signalled on Resume and on cancellation
+	paused  bool
+
+	abandonedMu sync.Mutex
+	abandoned   []Order // Taken by a worker but dropped on cancellation
+
+	stats []WorkerStat // stats[id-1] is written only by worker id
+
+	inFlightMu sync.Mutex
+	inFlight   map[int]context.CancelFunc // Order ID → cancels just that order
+
+	failures order.FailureConfig
+}
+
+// ProcessorOption customizes NewProcessor
+type ProcessorOption func(*Processor)
+
+// WithFailures fails orders on purpose as described by fc. Every order gets
+// one attempt, and a failed one is delivered with its *order.OrderError in Err.
+func WithFailures(fc order.FailureConfig) ProcessorOption {
+	return func(p *Processor) {
+		p.failures = fc
+	}
+}
+
+// WorkerStat is how much one worker did over the processor's lifetime
+type WorkerStat struct {
+	WorkerID int
+	Orders   int
+	Busy     time.Duration // Total time spent cooking
+}
+
+// NewProcessor starts workers chefs and returns the processor along with its
+// read-only results and fatal-error channels. Both channels are closed once
+// every worker has exited. Cancelling ctx stops workers without draining.
+func NewProcessor(ctx context.Context, workers int, opts ...ProcessorOption) (*Processor, <-chan Result, <-chan error) {
+	ctx, cancel := context.WithCancel(ctx)
+	p := &Processor{
+		orders:  make(chan job, workers*4), // Room for a few waiting orders per chef
+		results: make(chan Result, workers),
+		errs:    make(chan error, workers),
+		ctx:     ctx,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		closing: make(chan struct{}),
+		stats:   make([]WorkerStat, workers),
+
+		inFlight: make(map[int]context.CancelFunc),
+	}
+	for i := range p.stats {
+		p.stats[i].WorkerID = i + 1
+	}
+	p.resumed = sync.NewCond(&p.pauseMu)
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	// Wake paused workers when the processor is cancelled so they can exit
+	context.AfterFunc(ctx, func() {
+		p.pauseMu.Lock()
+		p.resumed.Broadcast()
+		p.pauseMu.Unlock()
+	})
+
+	for id := 1; id <= workers; id++ {
+		p.wg.Add(1)
+		go p.worker(id)
+	}
+
+	// Close the output channels only after the last worker is done writing
+	go func() {
+		p.wg.Wait()
+		close(p.results)
+		close(p.errs)
+		close(p.done)
+	}()
+
+	return p, p.results, p.errs
+}
+
+// Submit queues an order, blocking while the queue is full.
+// It returns ErrShutdown after Shutdown has been called.
+func (p *Processor) Submit(order Order) error {
+	return p.enqueue(job{order: order})
+}
+
+// SubmitAsync queues an order and returns a Future for its Result. The
+// result goes to the future instead of the results channel. If the order
+// can't be queued, or is abandoned on shutdown, Wait returns a Result whose
+// Err says why.
+func (p *Processor) SubmitAsync(order Order) *Future {
+	f := &Future{done: make(chan struct{})}
+	if err := p.enqueue(job{order: order, future: f}); err != nil {
+		f.complete(Result{Order: order, Err: err})
+	}
+	return f
+}
+
+// enqueue sends j to the workers, blocking while the queue is full. It
+// holds mu for reading across the send, so Shutdown can't close the queue
+// under it; Shutdown closes closing first to wake it.
+func (p *Processor) enqueue(j job) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return ErrShutdown
+	}
+
+	select {
+	case p.orders <- j:
+		return nil
+	case <-p.closing:
+		return ErrShutdown
+	case <-p.ctx.Done():
+		return p.ctx.Err()
+	}
+}
+
+// Shutdown stops intake and blocks until workers finish every queued order.
+// If ctx expires first, remaining work is abandoned and an error wrapping
+// ctx.Err() is returned.
+func (p *Processor) Shutdown(ctx context.Context) error {
+	// A Submit blocked on a full queue holds mu until it gives up; with the
+	// workers paused that's never, so tell it to give up before locking
+	p.closingOnce.Do(func() { close(p.closing) })
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.orders)
+	}
+	p.mu.Unlock()
+
+	var err error
+	select {
+	case <-p.done:
+	case <-ctx.Done():
+		p.cancel() // Tell workers to stop cooking
+		<-p.done
+		err = fmt.Errorf("shutdown before workers drained: %w", ctx.Err())
+	}
+	p.cancel()
+
+	// Workers left these in the queue; the queue is closed, so this stops
+	for j := range p.orders {
+		p.abandon(j)
+	}
+	return err
+}
+
+// Drain stops intake and waits up to timeout for queued and in-flight orders
+// to finish. It returns the orders that didn't make it - in flight when time
+// ran out, or still queued - so they can be re-enqueued elsewhere.
+func (p *Processor) Drain(timeout time.Duration) []Order {
+	ctx, cancel := clk.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	p.Shutdown(ctx) // Every worker has exited and the queue is emptied
+
+	p.abandonedMu.Lock()
+	defer p.abandonedMu.Unlock()
+	return slices.Clone(p.abandoned)
+}
+
+// WorkerStats reports per-worker counts and busy time, which reveals load
+// imbalance. Workers update only their own entry, so no lock is needed; the
+// stats are returned once every worker has exited (after Shutdown or Drain),
+// and nil before that.
+func (p *Processor) WorkerStats() []WorkerStat {
+	select {
+	case <-p.done: // Every worker's writes happen before done is closed
+		return slices.Clone(p.stats)
+	default:
+		return nil
+	}
+}
+
+// CancelOrder stops a single order that a worker is cooking right now. Its
+// result is still delivered, with Err set to context.Canceled. It reports
+// false if the order isn't cooking - still queued, already finished, or unknown.
+func (p *Processor) CancelOrder(id int) bool {
+	p.inFlightMu.Lock()
+	defer p.inFlightMu.Unlock()
+
+	cancel, ok := p.inFlight[id]
+	if ok {
+		cancel()
+		delete(p.inFlight, id)
+	}
+	return ok
+}
+
+// track registers an order as cooking and returns its context and a cleanup func
+func (p *Processor) track(order Order) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(p.ctx)
+
+	p.inFlightMu.Lock()
+	p.inFlight[order.ID] = cancel
+	p.inFlightMu.Unlock()
+
+	return ctx, func() {
+		p.inFlightMu.Lock()
+		delete(p.inFlight, order.ID)
+		p.inFlightMu.Unlock()
+		cancel()
+	}
+}
+
+// abandon records an order that will never be cooked and releases its future
+func (p *Processor) abandon(j job) {
+	p.abandonedMu.Lock()
+	p.abandoned = append(p.abandoned, j.order)
+	p.abandonedMu.Unlock()
+
+	if j.future != nil {
+		j.future.complete(Result{Order: j.order, Err: context.Canceled})
+	}
+}
+
+// Pause stops workers from starting new orders. Orders already cooking
+// finish; queued and newly submitted orders wait until Resume.
+func (p *Processor) Pause() {
+	p.pauseMu.Lock()
+	p.paused = true
+	p.pauseMu.Unlock()
+}
+
+// Resume lets workers pick up orders again
+func (p *Processor) Resume() {
+	p.pauseMu.Lock()
+	p.paused = false
+	p.pauseMu.Unlock()
+	p.resumed.Broadcast()
+}
+
+// waitWhilePaused blocks until the processor is resumed or cancelled.
+// It reports false if the processor was cancelled.
+func (p *Processor) waitWhilePaused() bool {
+	p.pauseMu.Lock()
+	defer p.pauseMu.Unlock()
+	for p.paused && p.ctx.Err() == nil {
+		p.resumed.Wait()
+	}
+	return p.ctx.Err() == nil
+}
+
+// worker cooks orders until the queue is closed or the processor is cancelled
+func (p *Processor) worker(id int) {
+	defer p.wg.Done()
+
+	for {
+		if !p.waitWhilePaused() {
+			return
+		}
+
+		select {
+		case <-p.ctx.Done():
+			return
+		case j, ok := <-p.orders:
+			if !ok {
+				return // Queue closed and drained
+			}
+			order := j.order
+
+			// A worker already blocked on the queue when Pause was called
+			// holds this order until Resume instead of cooking it
+			if !p.waitWhilePaused() {
+				p.abandon(j)
+				return
+			}
+
+			ctx, untrack := p.track(order)
+			result, err := p.cook(ctx, id, order)
+			untrack()
+			if err != nil {
+				p.reportFatal(err)
+				if j.future != nil {
+					j.future.complete(Result{Order: order, Worker: id, Err: err})
+				}
+				return // This chef is out; the others keep going
+			}
+			if result.Err != nil && p.ctx.Err() != nil {
+				p.abandon(j)
+				return // The whole processor was cancelled mid-order
+			}
+
+			if result.Err == nil {
+				stat := &p.stats[id-1]
+				stat.Orders++
+				stat.Busy += result.Latency()
+			}
+
+			if j.future != nil {
+				j.future.complete(*result)
+				continue
+			}
+			select {
+			case p.results <- *result:
+			case <-p.ctx.Done():
+				p.abandon(j) // Cooked, but nobody will ever see the result
+				return
+			}
+		}
+	}
+}
+
+// cook prepares one order. If ctx ends first the result's Err is ctx.Err(),
+// and an injected failure is its *order.OrderError; err is non-nil only if
+// the chef panicked.
+func (p *Processor) cook(ctx context.Context, workerID int, order Order) (result *Result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("worker %d: panic on order %d: %v", workerID, order.ID, r)
+		}
+	}()
+
+	if order.PrepTime < 0 {
+		panic("negative prep time")
+	}
+
+	start := clk.Now()
+	select {
+	case <-clk.After(order.PrepTime):
+		return &Result{Order: order, Worker: workerID, StartedAt: start, FinishedAt: clk.Now(), Err: p.failures.Failure(order.ID, 1)}, nil
+	case <-ctx.Done():
+		return &Result{Order: order, Worker: workerID, StartedAt: start, FinishedAt: clk.Now(), Err: ctx.Err()}, nil
+	}
+}
+
+// reportFatal delivers err without ever blocking a worker
+func (p *Processor) reportFatal(err error) {
+	select {
+	case p.errs <- err:
+	default: // Buffer full: the caller isn't reading errors
+	}
+}
+
+// Stats records order durations and reports percentiles on demand.
+// A single goroutine owns the samples; Record and the percentile queries
+// reach it through one channel, so there are no locks and a query always
+// sees every sample recorded before it.
+type Stats struct {
+	ops  chan statsOp
+	done chan struct{}
+}
+
+// statsOp is either a sample to record (reply == nil) or a percentile query
+type statsOp struct {
+	sample     time.Duration
+	percentile float64
+	reply      chan time.Duration
+}
+
+// NewStats starts the stats goroutine
+func NewStats() *Stats {
+	s := &Stats{
+		ops:  make(chan statsOp, 64),
+		done: make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+func (s *Stats) run() {
+	defer close(s.done)
+
+	var samples []time.Duration
+	sorted := true
+
+	for op := range s.ops {
+		if op.reply == nil {
+			samples = append(samples, op.sample)
+			sorted = false
+			continue
+		}
+
+		// Sort only when a query arrives after new samples
+		if !sorted {
+			slices.Sort(samples)
+			sorted = true
+		}
+		op.reply <- percentile(samples, op.percentile)
+	}
+}
+
+// percentile returns the nearest-rank p-th percentile of sorted samples
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank-1, 0)]
+}
+
+// Record adds one completed order's duration. It must not be called after Close.
+func (s *Stats) Record(d time.Duration) {
+	s.ops <- statsOp{sample: d}
+}
+
+// Percentile returns the p-th percentile (0-100) of the durations recorded so far
+func (s *Stats) Percentile(p float64) time.Duration {
+	reply := make(chan time.Duration, 1)
+	s.ops <- statsOp{percentile: p, reply: reply}
+	return <-reply
+}
+
+func (s *Stats) P50() time.Duration { return s.Percentile(50) }
+func (s *Stats) P95() time.Duration { return s.Percentile(95) }
+func (s *Stats) P99() time.Duration { return s.Percentile(99) }
+
+// Close stops the stats goroutine
+func (s *Stats) Close() {
+	close(s.ops)
+	<-s.done
+}
+
+// Pool is the worker pool with the kitchen taken out: workers goroutines
+// apply fn to every submitted job and publish the outputs on Results.
+type Pool[In, Out any] struct {
+	jobs    chan In
+	results chan Out
+	fn      func(In) Out
+	wg      sync.WaitGroup
+}
+
+// NewPool starts workers goroutines that run fn for each submitted job
+func NewPool[In, Out any](workers int, fn func(In) Out) *Pool[In, Out] {
+	p := &Pool[In, Out]{
+		jobs:    make(chan In, workers),
+		results: make(chan Out, workers),
+		fn:      fn,
+	}
+
+	for i := 0; i < workers; i++ {
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			for job := range p.jobs {
+				p.results <- p.fn(job)
+			}
+		}()
+	}
+
+	// Results is closed once every worker has drained the jobs channel
+	go func() {
+		p.wg.Wait()
+		close(p.results)
+	}()
+
+	return p
+}
+
+// Submit queues a job, blocking while the queue is full.
+// Submit must not be called after Close.
+func (p *Pool[In, Out]) Submit(job In) {
+	p.jobs <- job
+}
+
+// Close stops intake; Results closes after the remaining jobs finish
+func (p *Pool[In, Out]) Close() {
+	close(p.jobs)
+}
+
+// Results returns the channel of outputs, in completion order
+func (p *Pool[In, Out]) Results() <-chan Out {
+	return p.results
+}
+
+// NewOrderPool is the kitchen specialisation used by the demos: each job is
+// an Order and each output is the Result of cooking it
+func NewOrderPool(workers int) *Pool[Order, Result] {
+	return NewPool(workers, func(order Order) Result {
+		start := clk.Now()
+		clk.Sleep(context.Background(), order.PrepTime)
+		return Result{Order: order, StartedAt: start, FinishedAt: clk.Now()}
+	})
+}
+
+// ErrChunkSize is returned by processInChunks for a chunk size below 1
+var ErrChunkSize = errors.New("chunk size must be at least 1")
+
+// chunkStats is what processInChunks reports once every chunk is done
+type chunkStats struct {
+	Chunks    int
+	Completed int
+	Longest   time.Duration // Slowest single order
+}
+
+// processInChunks cooks orders chunkSize at a time, each chunk on a fresh
+// pool of workers. A chunk's results are folded into the stats and dropped
+// before the next chunk starts, so memory holds one chunk's worth of results
+// rather than one per order in the slice.
+func processInChunks(orders []Order, chunkSize, workers int) (chunkStats, error) {
+	var stats chunkStats
+	if chunkSize < 1 {
+		return stats, fmt.Errorf("%w, got %d", ErrChunkSize, chunkSize)
+	}
+
+	for chunk := range slices.Chunk(orders, chunkSize) {
+		pool := NewOrderPool(workers)
+		go func() {
+			for _, o := range chunk {
+				pool.Submit(o)
+			}
+			pool.Close()
+		}()
+		for r := range pool.Results() {
+			stats.Completed++
+			stats.Longest = max(stats.Longest, r.Latency())
+		}
+		stats.Chunks++
+	}
+	return stats, nil
+}
+
+// Basic worker pool: a fixed number of chefs share one order channel. The
+// chefs send Results back and the caller prints them once they're all in.
+func basicWorkerPool() {
+	out.Printf("\n=== 1. BASIC WORKER POOL ===\n\n")
+
+	orders := make(chan Order, 6)
+	results := make(chan Result, 6)
+	var wg sync.WaitGroup
+	startTime := clk.Now()
+
+	// 3 chefs
+	for id := 1; id <= 3; id++ {
+		wg.Add(1)
+		go func(workerID int) {
+			defer wg.Done()
+			for order := range orders {
+				r := Result{Order: order, Worker: workerID, StartedAt: clk.Now()}
+				clk.Sleep(context.Background(), order.PrepTime)
+				r.FinishedAt = clk.Now()
+				if chatty {
+					out.Printf("✅ Chef %d: Order %d ready\n", workerID, order.ID)
+				}
+				results <- r
+			}
+		}(id)
+	}
+
+	for i := 1; i <= 6; i++ {
+		orders <- Order{ID: i, PrepTime: 200 * time.Millisecond}
+	}
+	close(orders) // No more orders: chefs exit their range loops
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var done []Result
+	for r := range results {
+		done = append(done, r)
+	}
+	if chatty {
+		out.Printf("\n") // Set the table apart from the inline prints
+	}
+	order.PrintSummary(out, done)
+	out.Printf("\n⏱️  6 orders, 3 chefs: %v\n", clk.Since(startTime).Round(10*time.Millisecond))
+}
+
+// Clean lifecycle: submit, read results, Shutdown drains everything
+func cleanShutdown() {
+	out.Printf("\n=== 2. PROCESSOR: CLEAN SHUTDOWN ===\n\n")
+
+	processor, results, errs := NewProcessor(context.Background(), 3)
+
+	var collected sync.WaitGroup
+	collected.Add(1)
+	go func() {
+		defer collected.Done()
+		for r := range results {
+			out.Printf("✅ Chef %d: Order %d ready in %v\n", r.Worker, r.Order.ID, r.Latency().Round(10*time.Millisecond))
+		}
+	}()
+
+	for i := 1; i <= 6; i++ {
+		processor.Submit(Order{ID: i, PrepTime: 100 * time.Millisecond})
+	}
+
+	ctx, cancel := clk.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	err := processor.Shutdown(ctx)
+	collected.Wait()
+	out.Printf("\n🛑 Shutdown: %v\n", err)
+
+	for err := range errs {
+		out.Printf("💥 %v\n", err)
+	}
+	out.Printf("🚫 Submit after shutdown: %v\n", processor.Submit(Order{ID: 7}))
+}
+
+// Shutdown gives up when the deadline passes before the queue drains
+func deadlineExceededShutdown() {
+	out.Printf("\n=== 3. PROCESSOR: SHUTDOWN DEADLINE EXCEEDED ===\n\n")
+
+	processor, results, _ := NewProcessor(context.Background(), 2)
+
+	done := make(chan int)
+	go func() {
+		count := 0
+		for range results {
+			count++
+		}
+		done <- count
+	}()
+
+	// 6 slow orders on 2 chefs need ~900ms, but we only allow 400ms
+	for i := 1; i <= 6; i++ {
+		processor.Submit(Order{ID: i, PrepTime: 300 * time.Millisecond})
+	}
+
+	ctx, cancel := clk.WithTimeout(context.Background(), 400*time.Millisecond)
+	defer cancel()
+
+	start := clk.Now()
+	err := processor.Shutdown(ctx)
+	out.Printf("🛑 Shutdown after %v: %v\n", clk.Since(start).Round(10*time.Millisecond), err)
+	out.Printf("   errors.Is(err, context.DeadlineExceeded): %v\n", errors.Is(err, context.DeadlineExceeded))
+	out.Printf("📦 Orders finished before the deadline: %d of 6\n", <-done)
+}
+
+// A panicking worker surfaces on the error channel instead of crashing the program
+func fatalWorkerError() {
+	out.Printf("\n=== 4. PROCESSOR: FATAL WORKER ERRORS ===\n\n")
+
+	processor, results, errs := NewProcessor(context.Background(), 2)
+
+	printed := make(chan struct{})
+	go func() {
+		defer close(printed)
+		for r := range results {
+			out.Printf("✅ Chef %d: Order %d ready\n", r.Worker, r.Order.ID)
+		}
+	}()
+
+	processor.Submit(Order{ID: 1, PrepTime: 50 * time.Millisecond})
+	processor.Submit(Order{ID: 2, PrepTime: -1}) // Corrupt order makes its chef panic
+	processor.Submit(Order{ID: 3, PrepTime: 50 * time.Millisecond})
+
+	processor.Shutdown(context.Background())
+	<-printed
+
+	for err := range errs {
+		out.Printf("💥 %v\n", err)
+	}
+}
+
+// Tail latency: the average hides the few orders that take much longer
+func tailLatency() {
+	out.Printf("\n=== 5. TAIL LATENCY (P50 / P95 / P99) ===\n\n")
+
+	// Real pool: most orders take 20ms, every 20th is a 150ms special
+	processor, results, _ := NewProcessor(context.Background(), 4)
+	stats := NewStats()
+	defer stats.Close()
+
+	var sum time.Duration
+	var count int
+	collected := make(chan struct{})
+	go func() {
+		defer close(collected)
+		for r := range results {
+			stats.Record(r.Latency())
+			sum += r.Latency()
+			count++
+		}
+	}()
+
+	for i := 1; i <= 100; i++ {
+		prep := 20 * time.Millisecond
+		if i%20 == 0 {
+			prep = 150 * time.Millisecond
+		}
+		processor.Submit(Order{ID: i, PrepTime: prep})
+	}
+	processor.Shutdown(context.Background())
+	<-collected
+
+	out.Printf("📊 %d orders | Mean %v\n", count, (sum / time.Duration(count)).Round(time.Millisecond))
+	out.Printf("   P50 %v | P95 %v | P99 %v\n",
+		stats.P50().Round(time.Millisecond), stats.P95().Round(time.Millisecond), stats.P99().Round(time.Millisecond))
+}
+
+// Pause halts cooking without losing queued orders; Resume picks up where it left off
+func pauseAndResume() {
+	out.Printf("\n=== 6. PAUSE AND RESUME ===\n\n")
+
+	processor, results, _ := NewProcessor(context.Background(), 2)
+	var completed atomic.Int64
+	start := clk.Now()
+
+	collected := make(chan struct{})
+	go func() {
+		defer close(collected)
+		for r := range results {
+			completed.Add(1)
+			out.Printf("✅ [+%3dms] Chef %d: Order %d ready\n", clk.Since(start).Milliseconds(), r.Worker, r.Order.ID)
+		}
+	}()
+
+	processor.Pause()
+	out.Println("⏸️  Kitchen emergency: paused")
+
+	for i := 1; i <= 6; i++ {
+		if err := processor.Submit(Order{ID: i, PrepTime: 50 * time.Millisecond}); err != nil {
+			out.Printf("❌ Order %d: %v\n", i, err)
+		}
+	}
+	out.Println("📥 Submitted 6 orders while paused")
+
+	clk.Sleep(context.Background(), 300*time.Millisecond)
+	duringPause := completed.Load()
+	out.Printf("📊 Completed while paused: %d\n", duringPause)
+
+	processor.Resume()
+	out.Printf("▶️  [+%3dms] Resumed\n", clk.Since(start).Milliseconds())
+
+	processor.Shutdown(context.Background())
+	<-collected
+
+	out.Printf("📊 Completed after Resume: %d\n", completed.Load()-duringPause)
+
+	// Paused with a full queue and a Submit blocked on it: Shutdown still
+	// returns at its deadline, and the blocked Submit gets ErrShutdown
+	stuck, _, _ := NewProcessor(context.Background(), 1)
+	stuck.Pause()
+	blocked := make(chan error, 1)
+	go func() {
+		// The queue holds 4 and the paused chef at most 1, so the 6th blocks
+		var err error
+		for i := 1; i <= 6; i++ {
+			err = stuck.Submit(Order{ID: i, PrepTime: 50 * time.Millisecond})
+		}
+		blocked <- err
+	}()
+
+	ctx, cancel := clk.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	err := stuck.Shutdown(ctx)
+	out.Printf("\n🛑 Paused with a full queue, Shutdown: %v\n", err)
+	out.Printf("🚫 The Submit blocked on the full queue: %v\n", <-blocked)
+}
+
+// The same Pool type runs kitchen orders and plain integer jobs
+func genericPool() {
+	out.Printf("\n=== 7. GENERIC POOL[In, Out] ===\n\n")
+
+	// Order jobs through the kitchen specialisation
+	orders := NewOrderPool(3)
+	go func() {
+		for i := 1; i <= 6; i++ {
+			orders.Submit(Order{ID: i, PrepTime: time.Duration(i) * 10 * time.Millisecond})
+		}
+		orders.Close()
+	}()
+
+	seen := map[int]bool{}
+	for r := range orders.Results() {
+		seen[r.Order.ID] = true
+	}
+	out.Printf("🍳 Pool[Order, Result]: %d of 6 orders cooked\n", len(seen))
+
+	// int jobs: square each number
+	squares := NewPool(4, func(n int) int { return n * n })
+	go func() {
+		for n := 1; n <= 10; n++ {
+			squares.Submit(n)
+		}
+		squares.Close()
+	}()
+	sum, count := 0, 0
+	for sq := range squares.Results() {
+		sum += sq
+		count++
+	}
+	out.Printf("🔢 Pool[int, int]:      %d squares of 1..10, sum %d\n", count, sum)
+}
+
+// Drain before a redeploy: whatever can't finish in time comes back to the caller
+func drainForRedeploy() {
+	out.Printf("\n=== 8. DRAIN WITH TIMEOUT ===\n\n")
+
+	runs := []struct {
+		name    string
+		orders  int
+		prep    time.Duration
+		timeout time.Duration
+	}{
+		// 2 chefs finish orders 1-2 by 300ms; 3-4 are mid-cook and 5-6 still queued at 400ms
+		{"long orders, short timeout", 6, 300 * time.Millisecond, 400 * time.Millisecond},
+		{"short orders, long timeout", 6, 20 * time.Millisecond, time.Second},
+	}
+
+	for _, tt := range runs {
+		processor, results, _ := NewProcessor(context.Background(), 2)
+		finished := make(chan int)
+		go func() {
+			count := 0
+			for range results {
+				count++
+			}
+			finished <- count
+		}()
+
+		for i := 1; i <= tt.orders; i++ {
+			processor.Submit(Order{ID: i, PrepTime: tt.prep})
+		}
+
+		start := clk.Now()
+		undone := processor.Drain(tt.timeout)
+		took := clk.Since(start)
+		done := <-finished
+
+		var ids []int
+		for _, o := range undone {
+			ids = append(ids, o.ID)
+		}
+		slices.Sort(ids)
+
+		out.Printf("🚚 %s: drained in %v, %d finished, undone %v\n",
+			tt.name, took.Round(10*time.Millisecond), done, ids)
+	}
+	out.Println("\n♻️  The undone orders can be re-enqueued on the new deployment")
+}
+
+// Per-worker stats show when one chef is stuck with the slow order
+func perWorkerStats() {
+	out.Printf("\n=== 9. PER-WORKER STATS ===\n\n")
+
+	processor, results, _ := NewProcessor(context.Background(), 3)
+	go func() {
+		for range results {
+		}
+	}()
+
+	out.Printf("📊 WorkerStats before Shutdown is nil: %v\n\n", processor.WorkerStats() == nil)
+
+	// One 500ms banquet order, then 20 quick ones
+	processor.Submit(Order{ID: 1, PrepTime: 500 * time.Millisecond})
+	for i := 2; i <= 21; i++ {
+		processor.Submit(Order{ID: i, PrepTime: 20 * time.Millisecond})
+	}
+	processor.Shutdown(context.Background())
+
+	stats := processor.WorkerStats()
+	busiest, idlest := stats[0], stats[0]
+	for _, st := range stats {
+		out.Printf("   Chef %d: %2d orders, busy %v\n", st.WorkerID, st.Orders, st.Busy.Round(10*time.Millisecond))
+		if st.Orders > busiest.Orders {
+			busiest = st
+		}
+		if st.Orders < idlest.Orders {
+			idlest = st
+		}
+	}
+	out.Printf("\n⚖️  Busiest chef %d cooked %d orders, idlest chef %d cooked %d\n",
+		busiest.WorkerID, busiest.Orders, idlest.WorkerID, idlest.Orders)
+}
+
+// A customer changes their mind: cancel one order by ID while it cooks
+func cancelSingleOrder() {
+	out.Printf("\n=== 10. CANCEL ONE ORDER BY ID ===\n\n")
+
+	processor, results, _ := NewProcessor(context.Background(), 2)
+	processor.Submit(Order{ID: 1, PrepTime: 2 * time.Second}) // Slow-roasted brisket
+	processor.Submit(Order{ID: 2, PrepTime: 200 * time.Millisecond})
+	processor.Submit(Order{ID: 3, PrepTime: 200 * time.Millisecond}) // Queued behind 2
+
+	clk.Sleep(context.Background(), 50*time.Millisecond) // Orders 1 and 2 are on the stove
+	out.Printf("🔪 CancelOrder(3), still queued: %v\n", processor.CancelOrder(3))
+	out.Printf("🔪 CancelOrder(1), cooking:      %v\n\n", processor.CancelOrder(1))
+	collected := make(chan struct{})
+	go func() {
+		defer close(collected)
+		for r := range results {
+			if r.Err != nil {
+				out.Printf("🚫 Order %d cancelled after %v: %v\n", r.Order.ID, r.Latency().Round(10*time.Millisecond), r.Err)
+			} else {
+				out.Printf("✅ Order %d ready by chef %d\n", r.Order.ID, r.Worker)
+			}
+		}
+	}()
+
+	start := clk.Now()
+	processor.Shutdown(context.Background())
+	<-collected
+	out.Printf("\n🛑 Shutdown took %v, not the brisket's 2s\n", clk.Since(start).Round(10*time.Millisecond))
+	out.Printf("🔪 CancelOrder(1) again:         %v\n", processor.CancelOrder(1))
+}
+
+// Futures: await specific orders, in whatever order the caller likes
+func awaitFutures() {
+	out.Printf("\n=== 11. FUTURES FROM SubmitAsync ===\n\n")
+
+	processor, results, _ := NewProcessor(context.Background(), 3)
+	var onResults atomic.Int64
+	go func() {
+		for range results {
+			onResults.Add(1)
+		}
+	}()
+
+	// Completion order is 2, 3, 1; the caller awaits 1, 2, 3
+	start := clk.Now()
+	futures := []*Future{
+		processor.SubmitAsync(Order{ID: 1, PrepTime: 300 * time.Millisecond}),
+		processor.SubmitAsync(Order{ID: 2, PrepTime: 100 * time.Millisecond}),
+		processor.SubmitAsync(Order{ID: 3, PrepTime: 200 * time.Millisecond}),
+	}
+	got := make([]Result, len(futures))
+	waited := make([]time.Duration, len(futures))
+	for i, f := range futures {
+		got[i] = f.Wait()
+		waited[i] = clk.Since(start)
+		out.Printf("⏳ [+%3dms] Wait on order %d: cooked by chef %d in %v\n",
+			waited[i].Milliseconds(), got[i].Order.ID, got[i].Worker, got[i].Latency().Round(10*time.Millisecond))
+	}
+	again := futures[0].Wait()
+	processor.Shutdown(context.Background())
+	late := processor.SubmitAsync(Order{ID: 4, PrepTime: time.Second}).Wait()
+
+	out.Printf("\n🔁 A second Wait on order 1 returns the same Result: %v\n", again == got[0])
+	out.Printf("📭 Results on the channel: %d, the futures got them all\n", onResults.Load())
+	out.Printf("🚪 SubmitAsync after Shutdown: %v\n", late.Err)
+}
+
+// A huge batch goes through in chunks; only one chunk's results exist at a time
+func chunkedBatches() {
+	out.Printf("\n=== 12. VERY LARGE BATCHES IN CHUNKS ===\n\n")
+
+	huge := make([]Order, 200_000)
+	for i := range huge {
+		huge[i] = Order{ID: i + 1}
+	}
+	start := clk.Now()
+	stats, err := processInChunks(huge, 10_000, 8)
+	out.Printf("📦 %d orders in %d chunks of 10000 on 8 workers: %d completed in %v (error: %v)\n",
+		len(huge), stats.Chunks, stats.Completed, clk.Since(start).Round(10*time.Millisecond), err)
+}
+
+// Injected failures come back in Result.Err like any other, and the same seed fails the same orders
+func injectedFailures() {
+	out.Printf("\n=== 13. INJECTED FAILURES ===\n\n")
+
+	fc := order.FailureConfig{Rate: 0.4, Kinds: []error{order.ErrBurnt, order.ErrOvenBusy, order.ErrOutOfStock}, Seed: 14}
+	processor, results, _ := NewProcessor(context.Background(), 4, WithFailures(fc))
+	go func() {
+		for i := 1; i <= 10; i++ {
+			processor.Submit(Order{ID: i, PrepTime: 50 * time.Millisecond})
+		}
+		processor.Shutdown(context.Background())
+	}()
+
+	var done []Result
+	for r := range results {
+		done = append(done, r)
+	}
+	order.PrintSummary(out, done)
+
+	var retryable int
+	for _, r := range done {
+		if order.Retryable(r.Err) {
+			retryable++
+		}
+	}
+	out.Printf("🔁 %d of the failures could be retried; out of stock can't\n", retryable)
+}
+
+// Config is what the command line asks for
+type Config struct {
+	order.Options
+	Chatty   bool
+	LoadMode bool // Any flag but -chatty: skip the walkthrough and run one batch
+}
+
+// parseOptions reads -orders, -workers, -seed, -maxprep and -chatty from
+// args. Usage and parse errors are written to errOut.
+func parseOptions(args []string, errOut io.Writer) (Config, error) {
+	cfg := Config{Options: order.Options{Orders: 100, Workers: 4, MaxPrep: 100 * time.Millisecond}}
+	fs := flag.NewFlagSet("worker-pools", flag.ContinueOnError)
+	fs.SetOutput(errOut)
+	cfg.AddFlags(fs)
+	fs.BoolVar(&cfg.Chatty, "chatty", false, "also print each order as a chef finishes it")
+	if err := fs.Parse(args); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	fs.Visit(func(f *flag.Flag) {
+		cfg.LoadMode = cfg.LoadMode || f.Name != "chatty" // -chatty changes how orders print, not what runs
+	})
+	return cfg, nil
+}
+
+// loadReport is what a load run measured
+type loadReport struct {
+	Cooked        int
+	Wall, Prep    time.Duration // Prep is the sum of every order's prep time
+	P50, P95, P99 time.Duration
+	PerWorker     []int // Orders cooked by each chef
+	Results       []Result
+}
+
+// runLoad cooks a generated batch on a Processor with opts.Workers chefs and
+// waits for every order
+func runLoad(opts order.Options) loadReport {
+	orders, _ := order.Generate(opts) // parseOptions has checked opts
+	processor, results, _ := NewProcessor(context.Background(), opts.Workers)
+	stats := NewStats()
+	defer stats.Close()
+
+	var report loadReport
+	collected := make(chan struct{})
+	go func() {
+		defer close(collected)
+		for r := range results {
+			if chatty {
+				out.Printf("✅ Chef %d: Order %d ready in %s\n", r.Worker, r.Order.ID, out.Duration(r.Latency()))
+			}
+			stats.Record(r.Latency())
+			report.Cooked++ // Only this goroutine writes report, and only until collected is closed
+			report.Results = append(report.Results, r)
+		}
+	}()
+
+	startTime := clk.Now()
+	for _, o := range orders {
+		report.Prep += o.PrepTime
+		processor.Submit(o)
+	}
+	processor.Shutdown(context.Background()) // No deadline: cook everything
+	<-collected
+	report.Wall = clk.Since(startTime)
+
+	report.P50, report.P95, report.P99 = stats.P50(), stats.P95(), stats.P99()
+	for _, w := range processor.WorkerStats() {
+		report.PerWorker = append(report.PerWorker, w.Orders)
+	}
+	return report
+}
+
+// loadRun is what the program does when given any flag: one batch of
+// opts.Orders orders, drawn from opts.Seed, through the Processor, then the
+// numbers. A deterministic printer leaves out how many orders each chef
+// took, which is up to the scheduler.
+func loadRun(opts order.Options) {
+	out.Printf("\n=== LOAD RUN (%d orders, %d workers, prep up to %v) ===\n\n", opts.Orders, opts.Workers, opts.MaxPrep)
+
+	out.Printf("🎲 Seed %d (pass -seed=%d to get the same prep times again)\n\n", opts.Seed, opts.Seed)
+	if opts.Orders == 0 {
+		out.Println("📭 No orders to cook")
+		return
+	}
+
+	r := runLoad(opts)
+	if chatty {
+		out.Printf("\n") // Set the numbers apart from the inline prints
+	}
+	out.Printf("✅ Cooked:              %d orders\n", r.Cooked)
+	out.Printf("⏱️  Sequential time:     %s\n", out.Duration(r.Prep))
+	out.Printf("🎯 Wall time:           %s\n", out.Duration(r.Wall))
+	if out.Deterministic() {
+		out.Printf("🚀 Speedup:             ~%.0fx\n", r.Prep.Seconds()/r.Wall.Seconds())
+		out.Printf("📊 P50 / P95 / P99:     %s / %s / %s\n", out.Duration(r.P50), out.Duration(r.P95), out.Duration(r.P99))
+		return
+	}
+	out.Printf("🚀 Speedup:             %.1fx\n", r.Prep.Seconds()/r.Wall.Seconds())
+	out.Printf("📊 P50 / P95 / P99:     %s / %s / %s\n", out.Duration(r.P50), out.Duration(r.P95), out.Duration(r.P99))
+	out.Printf("👨‍🍳 Orders per chef:     %v\n", r.PerWorker)
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	cfg, err := parseOptions(opts.Args, os.Stderr)
+	if err != nil {
+		return lesson.Usage(err)
+	}
+	cfg.Seed = opts.Seed(cfg.Seed)
+	chatty = cfg.Chatty && !opts.Deterministic && !opts.JSON() // Inline prints come in whatever order the scheduler picks
+
+	// The walkthrough is narrative through and through; a JSON report is of a load run
+	if opts.JSON() {
+		r := runLoad(cfg.Options)
+		ro := report.Options{Orders: cfg.Orders, Workers: cfg.Workers, Seed: cfg.Seed, MaxPrep: cfg.MaxPrep, Speed: opts.Speed()}
+		return report.Encode(opts.Out(), report.FromResults("04-worker-pools", ro, r.Results))
+	}
+
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Worker Pools")
+	out.Println("==========================================")
+
+	// With any flag, skip the walkthrough and run one batch of that size
+	if cfg.LoadMode {
+		loadRun(cfg.Options)
+		return nil
+	}
+
+	basicWorkerPool()
+	cleanShutdown()
+	deadlineExceededShutdown()
+	fatalWorkerError()
+	tailLatency()
+	pauseAndResume()
+	genericPool()
+	drainForRedeploy()
+	perWorkerStats()
+	cancelSingleOrder()
+	awaitFutures()
+	chunkedBatches()
+	injectedFailures()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ A fixed number of workers bounds concurrency")
+	out.Println("✅ Closing the jobs channel tells workers to finish up")
+	out.Println("✅ Only the owner closes the results channel, after all workers exit")
+	out.Println("✅ Shutdown(ctx) replaces time.Sleep with a real lifecycle")
+	out.Println("✅ Fatal errors travel on their own channel instead of crashing the program")
+	out.Println("✅ Percentiles reveal tail latency that the mean hides")
+	out.Println("✅ sync.Cond lets workers sleep until Resume without busy-waiting")
+	out.Println("✅ Type parameters let one pool run any kind of job")
+	out.Println("✅ Drain hands back unfinished orders instead of losing them")
+	out.Println("✅ Per-worker stats expose load imbalance; each worker owns its own entry")
+	out.Println("✅ A child context per order lets one order be cancelled without the rest")
+	out.Println("✅ A future is a channel closed on completion plus the value it guards")
+	out.Println("✅ Chunking a huge batch bounds how many results are held at once")
+	out.Println("✅ Flags plus a seeded generator show how the same pool behaves at any scale")
+	out.Println("✅ A seeded FailureConfig makes failures repeatable, however the workers interleave")
+	return nil
+}
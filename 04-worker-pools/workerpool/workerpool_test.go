@@ -0,0 +1,648 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/order"
+	"github.com/Ajay2521/go-concurrency/pkg/report"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// A load run on one chef and a fake clock: the seed fixes the prep times and
+// one chef fixes the order they're cooked in, so every number is exact
+func TestGoldenLoadRun(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	got := testutil.RunLesson(t, Run, "-orders=20", "-workers=1", "-seed=7")
+	if again := testutil.RunLesson(t, Run, "-orders=20", "-workers=1", "-seed=7"); again != got {
+		t.Fatalf("two runs differ: %s", testutil.FirstDiff(again, got))
+	}
+	testutil.Golden(t, "testdata/golden.txt", got)
+}
+
+// Shutdown with no deadline while a Submit waits on a full queue: the
+// blocked Submit is refused and every queued order still cooks
+func TestShutdownReleasesBlockedSubmit(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	clk = clock.Real() // These orders cook; a golden run leaves clk on a stopped fake
+	p, results, _ := NewProcessor(context.Background(), 1)
+	p.Pause()
+	blocked := make(chan error, 1)
+	var queued atomic.Int64
+	go func() {
+		var err error
+		for i := 1; i <= 6; i++ {
+			if err = p.Submit(Order{ID: i, PrepTime: time.Millisecond}); err == nil {
+				queued.Add(1)
+			}
+		}
+		blocked <- err
+	}()
+	for len(p.orders) < cap(p.orders) {
+		time.Sleep(time.Millisecond)
+	}
+
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- p.Shutdown(context.Background()) }()
+	if err := <-blocked; !errors.Is(err, ErrShutdown) {
+		t.Errorf("blocked Submit = %v, want ErrShutdown", err)
+	}
+	p.Resume()
+
+	cooked := 0
+	for range results {
+		cooked++
+	}
+	if err := <-shutdown; err != nil {
+		t.Errorf("Shutdown = %v", err)
+	}
+	if want := int(queued.Load()); cooked != want {
+		t.Errorf("cooked %d orders, want the %d queued before Shutdown", cooked, want)
+	}
+}
+
+// Paused with a full queue and a Submit blocked on it, Shutdown used to wait
+// for mu forever: the Submit held it and the paused chef would never make room
+func TestShutdownPausedWithFullQueue(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	p, _, _ := NewProcessor(context.Background(), 1)
+	p.Pause()
+
+	blocked := make(chan error, 1)
+	go func() {
+		// The queue holds 4 and the paused chef at most 1, so the 6th blocks
+		var err error
+		for i := 1; i <= 6; i++ {
+			err = p.Submit(Order{ID: i, PrepTime: time.Millisecond})
+		}
+		blocked <- err
+	}()
+	for len(p.orders) < cap(p.orders) {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- p.Shutdown(ctx) }()
+
+	select {
+	case err := <-shutdown:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Shutdown = %v, want context.DeadlineExceeded", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Shutdown still blocked 5s after its 50ms deadline")
+	}
+	if err := <-blocked; !errors.Is(err, ErrShutdown) {
+		t.Errorf("blocked Submit = %v, want ErrShutdown", err)
+	}
+	if err := p.Submit(Order{ID: 7}); !errors.Is(err, ErrShutdown) {
+		t.Errorf("Submit after Shutdown = %v, want ErrShutdown", err)
+	}
+}
+
+// Shutdown waits for every worker and the goroutine that closes the output
+// channels, so nothing the processor started outlives it
+func TestShutdownLeaksNothing(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	clk = clock.Real()
+	p, results, _ := NewProcessor(context.Background(), 4)
+	go func() {
+		for range results {
+		}
+	}()
+	for id := 1; id <= 8; id++ {
+		if err := p.Submit(Order{ID: id, PrepTime: time.Millisecond}); err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+	}
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown = %v", err)
+	}
+}
+
+// -deterministic fixes the seed, so no -seed is needed, prints durations in
+// coarse buckets, and leaves out which chef took what. One chef keeps the
+// fake clock from jumping while a chef is between orders.
+func TestGoldenDeterministicLoadRun(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	got := testutil.RunLesson(t, Run, "-orders=20", "-workers=1", "-maxprep=2s", "-deterministic")
+	if again := testutil.RunLesson(t, Run, "-orders=20", "-workers=1", "-maxprep=2s", "-deterministic"); again != got {
+		t.Fatalf("two runs differ: %s", testutil.FirstDiff(again, got))
+	}
+	if strings.Contains(got, "Orders per chef") {
+		t.Error("a deterministic run printed the orders per chef")
+	}
+	testutil.Golden(t, "testdata/deterministic.txt", got)
+}
+
+// Every Result a chef sends back has its order, its chef, and a finish no
+// earlier than its start plus its prep time
+func TestProcessorResultTimestamps(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	clk = clock.Real()
+	p, results, _ := NewProcessor(context.Background(), 2)
+	for id := 1; id <= 6; id++ {
+		if err := p.Submit(Order{ID: id, PrepTime: time.Duration(id) * time.Millisecond}); err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+	}
+	go p.Shutdown(context.Background())
+
+	seen := map[int]bool{}
+	for r := range results {
+		seen[r.Order.ID] = true
+		if r.Err != nil || r.Worker < 1 || r.Worker > 2 {
+			t.Errorf("order %d: worker %d, err %v", r.Order.ID, r.Worker, r.Err)
+		}
+		if r.FinishedAt.Before(r.StartedAt) || r.Latency() < r.Order.PrepTime {
+			t.Errorf("order %d: started %v, finished %v, prep %v", r.Order.ID, r.StartedAt, r.FinishedAt, r.Order.PrepTime)
+		}
+	}
+	if len(seen) != 6 {
+		t.Errorf("got results for %d orders, want 6", len(seen))
+	}
+}
+
+// -chatty only changes how orders print, so on its own it keeps the
+// walkthrough; any other flag starts a load run
+func TestParseOptions(t *testing.T) {
+	testutil.RunParallel(t, []testutil.TestCase{
+		{Name: "no flags", Input: []string{}, Want: Config{Options: order.Options{Orders: 100, Workers: 4, MaxPrep: 100 * time.Millisecond}}},
+		{Name: "chatty alone keeps the walkthrough", Input: []string{"-chatty"},
+			Want: Config{Options: order.Options{Orders: 100, Workers: 4, MaxPrep: 100 * time.Millisecond}, Chatty: true}},
+		{Name: "a batch flag starts a load run", Input: []string{"-orders=20", "-chatty"},
+			Want: Config{Options: order.Options{Orders: 20, Workers: 4, MaxPrep: 100 * time.Millisecond}, Chatty: true, LoadMode: true}},
+		{Name: "bad flag rejected", Input: []string{"-workers=0"}, Want: "-workers must be at least 1"},
+	}, func(t *testing.T, tc testutil.TestCase) {
+		cfg, err := parseOptions(tc.Input.([]string), io.Discard)
+		if msg, ok := tc.Want.(string); ok {
+			if err == nil || err.Error() != msg {
+				t.Errorf("parseOptions = %+v, %v, want error %q", cfg, err, msg)
+			}
+			return
+		}
+		if err != nil || cfg != tc.Want.(Config) {
+			t.Errorf("parseOptions = %+v, %v, want %+v", cfg, err, tc.Want)
+		}
+	})
+}
+
+// -output=json runs the load and prints one report.Run on one line and no
+// prose. One chef on the fake clock cooks each order for exactly its prep time.
+func TestJSONOutput(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	got := testutil.RunLesson(t, Run, "-output=json", "-orders=20", "-workers=1", "-seed=7")
+	if strings.Count(got, "\n") != 1 || !strings.HasPrefix(got, "{") {
+		t.Fatalf("want one JSON object on one line, got %q", got)
+	}
+	runs, err := report.Decode(strings.NewReader(got))
+	if err != nil || len(runs) != 1 {
+		t.Fatalf("Decode = %d runs, %v", len(runs), err)
+	}
+	r := runs[0]
+	want := report.Options{Orders: 20, Workers: 1, Seed: 7, MaxPrep: 100 * time.Millisecond, Speed: 1}
+	if r.Lesson != "04-worker-pools" || r.Options != want {
+		t.Errorf("lesson %q, options %+v; want %+v", r.Lesson, r.Options, want)
+	}
+	if r.Stats.Orders != 20 || r.Stats.Failed != 0 {
+		t.Errorf("stats %+v, want 20 orders and none failed", r.Stats)
+	}
+	for i, o := range r.Orders {
+		if o.ID != i+1 || o.Worker != 1 || o.Duration != o.Prep {
+			t.Errorf("order at index %d: %+v, want ID %d cooked by chef 1 for its prep time", i, o, i+1)
+		}
+	}
+}
+
+// ms returns n milliseconds for each n, in the order given
+func ms(ns ...int) []time.Duration {
+	ds := make([]time.Duration, len(ns))
+	for i, n := range ns {
+		ds[i] = time.Duration(n) * time.Millisecond
+	}
+	return ds
+}
+
+// Nearest rank: P50, P95 and P99 are always one of the recorded samples
+func TestStatsPercentiles(t *testing.T) {
+	oneTo100 := make([]int, 100)
+	for i := range oneTo100 {
+		oneTo100[i] = i + 1
+	}
+	testutil.RunParallel(t, []testutil.TestCase{
+		{Name: "no samples", Input: ms(), Want: ms(0, 0, 0)},
+		{Name: "one sample", Input: ms(42), Want: ms(42, 42, 42)},
+		{Name: "known 1-100ms", Input: ms(oneTo100...), Want: ms(50, 95, 99)},
+		{Name: "ten samples: P95 and P99 are the slowest", Input: ms(1, 2, 3, 4, 5, 6, 7, 8, 9, 100), Want: ms(5, 100, 100)},
+		{Name: "recorded out of order", Input: ms(30, 10, 20), Want: ms(20, 30, 30)},
+	}, func(t *testing.T, tc testutil.TestCase) {
+		s := NewStats()
+		defer s.Close()
+		for _, d := range tc.Input.([]time.Duration) {
+			s.Record(d)
+		}
+		if got := []time.Duration{s.P50(), s.P95(), s.P99()}; !slices.Equal(got, tc.Want.([]time.Duration)) {
+			t.Errorf("P50, P95, P99 = %v, want %v", got, tc.Want)
+		}
+	})
+}
+
+// Time passes while the kitchen is paused, far more than the orders need,
+// and nothing is cooked. After Resume every order is.
+func TestPauseCooksNothing(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := clock.NewFake(testutil.Epoch)
+	clk = fake
+	defer func() { clk = clock.Real() }()
+
+	p, results, _ := NewProcessor(context.Background(), 2)
+	p.Pause()
+	for id := 1; id <= 6; id++ {
+		if err := p.Submit(Order{ID: id, PrepTime: 50 * time.Millisecond}); err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+	}
+	for range 10 {
+		fake.Advance(time.Second)
+	}
+	select {
+	case r := <-results:
+		t.Fatalf("order %d cooked while paused", r.Order.ID)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if n := fake.Waiters(); n != 0 {
+		t.Errorf("%d chef(s) waiting on the clock while paused, want 0", n)
+	}
+
+	p.Resume()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go fake.AdvanceWhenIdle(ctx, time.Millisecond)
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- p.Shutdown(context.Background()) }()
+	cooked := 0
+	for range results {
+		cooked++
+	}
+	if err := <-shutdown; err != nil || cooked != 6 {
+		t.Errorf("after Resume: cooked %d of 6, Shutdown = %v", cooked, err)
+	}
+}
+
+func TestCancelOrder(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := clock.NewFake(testutil.Epoch)
+	clk = fake
+	defer func() { clk = clock.Real() }()
+
+	p, results, _ := NewProcessor(context.Background(), 2)
+	p.Submit(Order{ID: 1, PrepTime: 2 * time.Second})
+	p.Submit(Order{ID: 2, PrepTime: 200 * time.Millisecond})
+	p.Submit(Order{ID: 3, PrepTime: 200 * time.Millisecond})
+	fake.BlockUntil(2) // Orders 1 and 2 are cooking, 3 is queued
+
+	if p.CancelOrder(3) {
+		t.Error("CancelOrder(3) = true for a queued order, want false")
+	}
+	if !p.CancelOrder(1) {
+		t.Fatal("CancelOrder(1) = false for a cooking order, want true")
+	}
+	r := <-results
+	if r.Order.ID != 1 || !errors.Is(r.Err, context.Canceled) {
+		t.Fatalf("first result = order %d, err %v; want order 1 with context.Canceled", r.Order.ID, r.Err)
+	}
+	if r.Latency() != 0 {
+		t.Errorf("cancelled order took %v, want 0 on a clock that never moved", r.Latency())
+	}
+	if p.CancelOrder(1) {
+		t.Error("CancelOrder(1) = true a second time, want false")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go fake.AdvanceWhenIdle(ctx, time.Millisecond)
+	go p.Shutdown(context.Background())
+	cooked := map[int]error{}
+	for r := range results {
+		cooked[r.Order.ID] = r.Err
+	}
+	if len(cooked) != 2 || cooked[2] != nil || cooked[3] != nil {
+		t.Errorf("after the cancel: results %v, want orders 2 and 3 cooked", cooked)
+	}
+	if took := fake.Since(testutil.Epoch); took >= 2*time.Second {
+		t.Errorf("Shutdown waited %v on the fake clock, want less than the cancelled order's 2s", took)
+	}
+}
+
+func TestSubmitAsync(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := clock.NewFake(testutil.Epoch)
+	clk = fake
+	defer func() { clk = clock.Real() }()
+
+	p, results, _ := NewProcessor(context.Background(), 3)
+	futures := []*Future{
+		p.SubmitAsync(Order{ID: 1, PrepTime: 300 * time.Millisecond}),
+		p.SubmitAsync(Order{ID: 2, PrepTime: 100 * time.Millisecond}),
+		p.SubmitAsync(Order{ID: 3, PrepTime: 200 * time.Millisecond}),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go fake.AdvanceWhenIdle(ctx, time.Millisecond)
+
+	// Awaited 1, 2, 3 but finished 2, 3, 1
+	for i, f := range futures {
+		r := f.Wait()
+		if want := i + 1; r.Order.ID != want || r.Err != nil {
+			t.Errorf("future %d: order %d, err %v; want order %d cooked", i, r.Order.ID, r.Err, want)
+		}
+		if want := r.Order.PrepTime; r.FinishedAt.Sub(testutil.Epoch) != want {
+			t.Errorf("order %d finished at +%v, want +%v", r.Order.ID, r.FinishedAt.Sub(testutil.Epoch), want)
+		}
+	}
+	if again, first := futures[0].Wait(), futures[0].Wait(); again != first {
+		t.Errorf("second Wait = %+v, want the first's %+v", again, first)
+	}
+
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if r, ok := <-results; ok {
+		t.Errorf("order %d went to the results channel, want only futures", r.Order.ID)
+	}
+	late := p.SubmitAsync(Order{ID: 4, PrepTime: time.Second}).Wait()
+	if late.Order.ID != 4 || !errors.Is(late.Err, ErrShutdown) {
+		t.Errorf("SubmitAsync after Shutdown: order %d, err %v; want order 4 with ErrShutdown", late.Order.ID, late.Err)
+	}
+}
+
+func TestSubmitAsyncAbandoned(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := clock.NewFake(testutil.Epoch)
+	clk = fake
+	defer func() { clk = clock.Real() }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p, _, _ := NewProcessor(ctx, 1)
+	cooking := p.SubmitAsync(Order{ID: 1, PrepTime: time.Second})
+	queued := p.SubmitAsync(Order{ID: 2, PrepTime: time.Second})
+	fake.BlockUntil(1) // Order 1 is cooking, 2 is queued
+
+	cancel()
+	p.Shutdown(context.Background())
+	for _, f := range []*Future{cooking, queued} {
+		if r := f.Wait(); !errors.Is(r.Err, context.Canceled) {
+			t.Errorf("order %d: err %v, want context.Canceled", r.Order.ID, r.Err)
+		}
+	}
+}
+
+func TestDrain(t *testing.T) {
+	tests := []struct {
+		name       string
+		prep       time.Duration
+		timeout    time.Duration
+		wantUndone []int
+	}{
+		// 2 chefs finish orders 1-2 at 300ms; 3-4 are mid-cook and 5-6 still queued at 400ms
+		{"in flight and queued at the timeout", 300 * time.Millisecond, 400 * time.Millisecond, []int{3, 4, 5, 6}},
+		{"nothing finished by the timeout", time.Second, 100 * time.Millisecond, []int{1, 2, 3, 4, 5, 6}},
+		{"everything finished in time", 20 * time.Millisecond, time.Second, nil},
+	}
+
+	// Each case swaps clk, so they run one at a time
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutil.WaitForGoroutines(t)
+			fake := clock.NewFake(testutil.Epoch)
+			clk = fake
+			defer func() { clk = clock.Real() }()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go fake.AdvanceWhenIdle(ctx, time.Millisecond)
+
+			p, results, _ := NewProcessor(context.Background(), 2)
+			for id := 1; id <= 6; id++ {
+				p.Submit(Order{ID: id, PrepTime: tt.prep})
+			}
+			finished := make(chan []int)
+			go func() {
+				var ids []int
+				for r := range results {
+					ids = append(ids, r.Order.ID)
+				}
+				finished <- ids
+			}()
+
+			var undone []int
+			for _, o := range p.Drain(tt.timeout) {
+				undone = append(undone, o.ID)
+			}
+			slices.Sort(undone)
+			if !slices.Equal(undone, tt.wantUndone) {
+				t.Errorf("Drain returned %v, want %v", undone, tt.wantUndone)
+			}
+
+			// Every order either finished or came back, never both
+			all := append(<-finished, undone...)
+			slices.Sort(all)
+			if want := []int{1, 2, 3, 4, 5, 6}; !slices.Equal(all, want) {
+				t.Errorf("finished plus undone = %v, want each of %v once", all, want)
+			}
+		})
+	}
+}
+
+func TestWorkerStats(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := clock.NewFake(testutil.Epoch)
+	clk = fake
+	defer func() { clk = clock.Real() }()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p, results, _ := NewProcessor(context.Background(), 3)
+	go func() {
+		for range results {
+		}
+	}()
+	if stats := p.WorkerStats(); stats != nil {
+		t.Errorf("WorkerStats before Shutdown = %v, want nil", stats)
+	}
+
+	// One 500ms order, then 20 quick ones: 1.5s of cooking in all. The
+	// queue fills up, so the clock has to run while they're submitted.
+	go fake.AdvanceWhenIdle(ctx, time.Millisecond)
+	p.Submit(Order{ID: 1, PrepTime: 500 * time.Millisecond})
+	for id := 2; id <= 21; id++ {
+		p.Submit(Order{ID: id, PrepTime: 50 * time.Millisecond})
+	}
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	stats := p.WorkerStats()
+	if len(stats) != 3 {
+		t.Fatalf("WorkerStats has %d entries, want 3", len(stats))
+	}
+	var orders int
+	var busy time.Duration
+	for i, st := range stats {
+		if st.WorkerID != i+1 {
+			t.Errorf("stats[%d].WorkerID = %d, want %d", i, st.WorkerID, i+1)
+		}
+		orders += st.Orders
+		busy += st.Busy
+	}
+	if orders != 21 {
+		t.Errorf("stats add up to %d orders, want 21", orders)
+	}
+	if busy < 1500*time.Millisecond {
+		t.Errorf("stats add up to busy %v, want at least the 1.5s of prep", busy)
+	}
+}
+
+// Seed 14 fails the same orders on any number of chefs, each with its
+// *order.OrderError in the Result, and failed orders don't count as cooked
+func TestInjectedFailures(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	clk = clock.Real()
+	fc := order.FailureConfig{Rate: 0.4, Kinds: []error{order.ErrBurnt, order.ErrOvenBusy, order.ErrOutOfStock}, Seed: 14}
+	want := []int{1, 2, 4, 5, 6, 8, 9, 11, 12, 13, 15}
+
+	for _, workers := range []int{1, 4, 8} {
+		p, results, _ := NewProcessor(context.Background(), workers, WithFailures(fc))
+		go func() {
+			for id := 1; id <= 20; id++ {
+				p.Submit(Order{ID: id, PrepTime: time.Millisecond})
+			}
+			p.Shutdown(context.Background())
+		}()
+
+		var failed []int
+		for r := range results {
+			if r.Err == nil {
+				continue
+			}
+			var oe *order.OrderError
+			if !errors.As(r.Err, &oe) || oe.OrderID != r.Order.ID || oe.Attempt != 1 {
+				t.Errorf("%d chefs: order %d failed with %v, want its first attempt's *order.OrderError", workers, r.Order.ID, r.Err)
+			}
+			failed = append(failed, r.Order.ID)
+		}
+		slices.Sort(failed)
+		if !slices.Equal(failed, want) {
+			t.Errorf("%d chefs: failed %v, want %v", workers, failed, want)
+		}
+
+		var cooked int
+		for _, st := range p.WorkerStats() {
+			cooked += st.Orders
+		}
+		if cooked != 20-len(want) {
+			t.Errorf("%d chefs: stats count %d cooked, want %d", workers, cooked, 20-len(want))
+		}
+	}
+}
+
+func TestPool(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	tests := []struct {
+		name    string
+		workers int
+		inputs  []int
+		want    int // Sum of squares
+	}{
+		{"one worker", 1, []int{1, 2, 3}, 14},
+		{"more workers than jobs", 8, []int{4, 5}, 41},
+		{"no jobs", 2, nil, 0},
+		{"hundred jobs", 4, seq(1, 100), 338350},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			squares := NewPool(tt.workers, func(n int) int { return n * n })
+			go func() {
+				for _, n := range tt.inputs {
+					squares.Submit(n)
+				}
+				squares.Close()
+			}()
+
+			sum, count := 0, 0
+			for sq := range squares.Results() {
+				sum += sq
+				count++
+			}
+			if count != len(tt.inputs) || sum != tt.want {
+				t.Errorf("%d results summing to %d, want %d summing to %d", count, sum, len(tt.inputs), tt.want)
+			}
+		})
+	}
+}
+
+// Every order in every chunk is cooked, the last chunk may be short, and a
+// chunk size below one is refused before anything cooks.
+func TestProcessInChunks(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	clk = testutil.FakeClock(t)
+	defer func() { clk = clock.Real() }()
+
+	orders := make([]Order, 1000)
+	for i := range orders {
+		orders[i] = Order{ID: i + 1, PrepTime: time.Duration(i%3) * time.Millisecond}
+	}
+	tests := []struct {
+		name       string
+		orders     []Order
+		chunkSize  int
+		wantChunks int
+		wantErr    error
+	}{
+		{"1000 orders, chunks of 100", orders, 100, 10, nil},
+		{"uneven last chunk", orders, 300, 4, nil},
+		{"chunk bigger than the slice", orders, 5000, 1, nil},
+		{"no orders", nil, 100, 0, nil},
+		{"zero chunk size", orders, 0, 0, ErrChunkSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, err := processInChunks(tt.orders, tt.chunkSize, 4)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if stats != (chunkStats{}) {
+					t.Errorf("refused run reported %+v, want zero stats", stats)
+				}
+				return
+			}
+			if stats.Chunks != tt.wantChunks || stats.Completed != len(tt.orders) {
+				t.Errorf("%d chunks, %d completed, want %d chunks, %d completed",
+					stats.Chunks, stats.Completed, tt.wantChunks, len(tt.orders))
+			}
+			if len(tt.orders) > 0 && stats.Longest < 2*time.Millisecond {
+				t.Errorf("Longest = %v, want at least the 2ms slowest prep time", stats.Longest)
+			}
+		})
+	}
+}
+
+// seq returns the integers from..to inclusive
+func seq(from, to int) []int {
+	var out []int
+	for i := from; i <= to; i++ {
+		out = append(out, i)
+	}
+	return out
+}
@@ -0,0 +1,130 @@
+// Package fanin is lesson 05: Fan-In.
+package fanin
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	Station  string
+	PrepTime time.Duration
+}
+
+// merge fans in any number of channels into one. Each input gets its own
+// forwarding goroutine; the output is closed only after every input has
+// been closed and drained, coordinated by a WaitGroup.
+func merge[T any](chans ...<-chan T) <-chan T {
+	out := make(chan T)
+	var wg sync.WaitGroup
+
+	for _, ch := range chans {
+		wg.Add(1)
+		go func(ch <-chan T) {
+			defer wg.Done()
+			for v := range ch {
+				out <- v
+			}
+		}(ch)
+	}
+
+	// Close out once the last forwarder is done
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// station cooks count orders at its own pace and sends them as they finish
+func station(name string, firstID, count int, prepTime time.Duration) <-chan Order {
+	ready := make(chan Order)
+	go func() {
+		defer close(ready)
+		for i := 0; i < count; i++ {
+			clk.Sleep(context.Background(), prepTime)
+			ready <- Order{ID: firstID + i, Station: name, PrepTime: prepTime}
+		}
+	}()
+	return ready
+}
+
+// Three stations, one pass: merge delivers dishes in the order they finish
+func mergeStations() {
+	out.Printf("\n=== 1. MERGING THREE STATIONS ===\n\n")
+
+	grill := station("grill", 100, 3, 150*time.Millisecond)
+	fryer := station("fryer", 200, 5, 60*time.Millisecond)
+	salad := station("salad", 300, 2, 100*time.Millisecond)
+
+	startTime := clk.Now()
+	counts := map[string]int{}
+	total := 0
+
+	for order := range merge(grill, fryer, salad) {
+		counts[order.Station]++
+		total++
+		out.Printf("🔔 [+%3dms] Order %d from the %s\n", clk.Since(startTime).Milliseconds(), order.ID, order.Station)
+	}
+
+	out.Printf("\n📊 Received %d orders (grill %d, fryer %d, salad %d)\n", total, counts["grill"], counts["fryer"], counts["salad"])
+}
+
+// merge is generic: the same helper fans in any element type
+func mergeAnyType() {
+	out.Printf("\n=== 2. GENERIC MERGE ===\n\n")
+
+	source := func(values ...string) <-chan string {
+		ch := make(chan string, len(values))
+		for _, v := range values {
+			ch <- v
+		}
+		close(ch)
+		return ch
+	}
+
+	var got []string
+	for msg := range merge(source("table 1: water"), source("table 2: coffee", "table 2: tea"), source()) {
+		got = append(got, msg)
+	}
+	out.Printf("🧾 %d drink requests merged: %q\n", len(got), got)
+
+	// No inputs at all: the output closes immediately
+	_, open := <-merge[int]()
+	out.Printf("🈳 merge() with no inputs is closed: %v\n", !open)
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Fan-In")
+	out.Println("==========================================")
+
+	mergeStations()
+	mergeAnyType()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ Fan-in combines many channels into one")
+	out.Println("✅ One forwarding goroutine per input keeps every source flowing")
+	out.Println("✅ A WaitGroup decides when it's safe to close the output")
+	out.Println("✅ Generics make merge reusable for any element type")
+	out.Println("✅ Interleaving is nondeterministic; totals are not")
+	return nil
+}
@@ -0,0 +1,101 @@
+package fanin
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// numbers sends the n values base, base+1, ... on its own goroutine, then closes
+func numbers(base, n int) <-chan int {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for i := range n {
+			ch <- base + i
+		}
+	}()
+	return ch
+}
+
+// However the inputs interleave, the merged output holds every input value
+// exactly once, so its count is the sum of the inputs'
+func TestMerge(t *testing.T) {
+	testutil.RunParallel(t, []testutil.TestCase{
+		{Name: "three channels", Input: []int{1000, 250, 4000}, Want: 5250},
+		{Name: "one channel", Input: []int{10}, Want: 10},
+		{Name: "empty inputs", Input: []int{0, 3, 0}, Want: 3},
+	}, func(t *testing.T, tc testutil.TestCase) {
+		sizes := tc.Input.([]int)
+		for run := range 20 {
+			var chans []<-chan int
+			for i, n := range sizes {
+				chans = append(chans, numbers(i*1_000_000, n))
+			}
+			seen := make(map[int]bool)
+			for v := range merge(chans...) {
+				if seen[v] {
+					t.Fatalf("run %d: value %d delivered twice", run, v)
+				}
+				seen[v] = true
+			}
+			if len(seen) != tc.Want.(int) {
+				t.Fatalf("run %d: %d values, want %d", run, len(seen), tc.Want)
+			}
+		}
+	})
+}
+
+func TestMergeNoInputs(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	select {
+	case _, open := <-merge[int]():
+		if open {
+			t.Error("merge() with no inputs sent a value")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("merge() with no inputs never closed")
+	}
+}
+
+// The three stations on a fake clock: the pass gets each dish when its
+// station finishes it, and all 10 arrive
+func TestMergeStations(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	saved := clk
+	clk = testutil.FakeClock(t)
+	t.Cleanup(func() { clk = saved })
+
+	start := clk.Now()
+	merged := merge(
+		station("grill", 100, 3, 150*time.Millisecond),
+		station("fryer", 200, 5, 60*time.Millisecond),
+		station("salad", 300, 2, 100*time.Millisecond),
+	)
+	counts := map[string]int{}
+	for o := range merged {
+		counts[o.Station]++
+		if want := time.Duration(counts[o.Station]) * o.PrepTime; clk.Since(start) != want {
+			t.Errorf("order %d from the %s arrived at %v, want %v", o.ID, o.Station, clk.Since(start), want)
+		}
+	}
+	if counts["grill"] != 3 || counts["fryer"] != 5 || counts["salad"] != 2 {
+		t.Errorf("received %v, want grill 3, fryer 5, salad 2", counts)
+	}
+}
+
+func TestRun(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	got := testutil.RunLesson(t, Run)
+	for _, want := range []string{
+		"🔔 [+ 60ms] Order 200 from the fryer",
+		"📊 Received 10 orders (grill 3, fryer 5, salad 2)",
+		"🈳 merge() with no inputs is closed: true",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package main
+
+import (
+	"github.com/Ajay2521/go-concurrency/05-fan-in/fanin"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+func main() {
+	lesson.Main(fanin.Run)
+}
@@ -0,0 +1,193 @@
+// Package dispatch is lesson 06: Select Statement.
+package dispatch
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	Channel  string // "dine-in", "takeaway" or "delivery"
+	PrepTime time.Duration
+}
+
+// produceOrders sends count orders of one kind at a fixed interval, then closes the channel
+func produceOrders(kind string, firstID, count int, interval time.Duration) <-chan Order {
+	out := make(chan Order)
+	go func() {
+		defer close(out)
+		for i := 0; i < count; i++ {
+			clk.Sleep(context.Background(), interval)
+			out <- Order{ID: firstID + i, Channel: kind, PrepTime: 100 * time.Millisecond}
+		}
+	}()
+	return out
+}
+
+// dispatch merges three order sources into a single processing stream.
+// A closed source is set to nil so select stops choosing it; when every
+// source is closed (or nothing arrives for idleTimeout) the stream is closed.
+func dispatch(dineIn, takeaway, delivery <-chan Order, idleTimeout time.Duration) <-chan Order {
+	stream := make(chan Order)
+	go func() {
+		defer close(stream)
+		for dineIn != nil || takeaway != nil || delivery != nil {
+			select {
+			case order, ok := <-dineIn:
+				if !ok {
+					dineIn = nil // A nil channel blocks forever, so select skips it
+					continue
+				}
+				stream <- order
+			case order, ok := <-takeaway:
+				if !ok {
+					takeaway = nil
+					continue
+				}
+				stream <- order
+			case order, ok := <-delivery:
+				if !ok {
+					delivery = nil
+					continue
+				}
+				stream <- order
+			case <-clk.After(idleTimeout):
+				out.Printf("💤 Dispatcher: no orders for %v, closing for the night\n", idleTimeout)
+				return
+			}
+		}
+		out.Printf("🔒 Dispatcher: all order channels closed\n")
+	}()
+	return stream
+}
+
+// Basic select: whichever channel is ready first wins
+func basicSelect() {
+	out.Printf("\n=== 1. BASIC SELECT (First ready wins) ===\n\n")
+
+	fast := make(chan string)
+	slow := make(chan string)
+
+	go func() {
+		clk.Sleep(context.Background(), 200*time.Millisecond)
+		fast <- "🥗 Salad (200ms)"
+	}()
+	go func() {
+		clk.Sleep(context.Background(), 500*time.Millisecond)
+		slow <- "🍝 Pasta (500ms)"
+	}()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case dish := <-fast:
+			out.Printf("✅ Ready: %s\n", dish)
+		case dish := <-slow:
+			out.Printf("✅ Ready: %s\n", dish)
+		}
+	}
+}
+
+// Non-blocking receive with default
+func nonBlockingSelect() {
+	out.Printf("\n=== 2. NON-BLOCKING SELECT (default branch) ===\n\n")
+
+	orders := make(chan Order, 1)
+
+	// Nothing queued yet - default runs immediately instead of blocking
+	select {
+	case order := <-orders:
+		out.Printf("📥 Got order %d\n", order.ID)
+	default:
+		out.Printf("🤷 No order waiting - chef tidies the station instead\n")
+	}
+
+	orders <- Order{ID: 1, Channel: "dine-in"}
+
+	select {
+	case order := <-orders:
+		out.Printf("📥 Got order %d (%s)\n", order.ID, order.Channel)
+	default:
+		out.Printf("🤷 No order waiting\n")
+	}
+}
+
+// Multi-source dispatcher with idle timeout
+func multiSourceDispatch() {
+	out.Printf("\n=== 3. MULTI-SOURCE DISPATCHER ===\n\n")
+
+	dineIn := produceOrders("dine-in", 100, 4, 150*time.Millisecond)
+	takeaway := produceOrders("takeaway", 200, 3, 250*time.Millisecond)
+	delivery := produceOrders("delivery", 300, 2, 400*time.Millisecond)
+
+	stream := dispatch(dineIn, takeaway, delivery, 1*time.Second)
+
+	// A single kitchen processes the merged stream
+	var wg sync.WaitGroup
+	processed := map[string]int{}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for order := range stream {
+			out.Printf("📝 Order %d (%s): Started processing\n", order.ID, order.Channel)
+			clk.Sleep(context.Background(), order.PrepTime)
+			processed[order.Channel]++
+		}
+	}()
+
+	wg.Wait()
+
+	out.Printf("\n📊 Processed - dine-in: %d, takeaway: %d, delivery: %d\n",
+		processed["dine-in"], processed["takeaway"], processed["delivery"])
+}
+
+// Idle timeout: a source that goes quiet without closing
+func idleTimeout() {
+	out.Printf("\n=== 4. IDLE TIMEOUT (time.After branch) ===\n\n")
+
+	dineIn := make(chan Order) // Never closed - the restaurant just gets quiet
+	go func() {
+		dineIn <- Order{ID: 1, Channel: "dine-in"}
+		dineIn <- Order{ID: 2, Channel: "dine-in"}
+	}()
+
+	for order := range dispatch(dineIn, nil, nil, 500*time.Millisecond) {
+		out.Printf("📝 Order %d (%s): Dispatched\n", order.ID, order.Channel)
+	}
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Select Statement")
+	out.Println("==========================================")
+
+	basicSelect()
+	nonBlockingSelect()
+	multiSourceDispatch()
+	idleTimeout()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ select waits on several channel operations at once")
+	out.Println("✅ If several cases are ready, select picks one at random")
+	out.Println("✅ default makes a select non-blocking")
+	out.Println("✅ time.After adds a timeout branch")
+	out.Println("✅ Setting a closed channel to nil disables its case")
+	return nil
+}
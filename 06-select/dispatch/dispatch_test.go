@@ -0,0 +1,130 @@
+package dispatch
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// onFakeClock points the lesson at a self-advancing fake clock and a
+// printer into the returned buffer. Flush out before reading it.
+func onFakeClock(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	savedClk := clk
+	clk, out = testutil.FakeClock(t), display.NewPrinter(&buf)
+	t.Cleanup(func() {
+		out.Close()
+		clk = savedClk
+	})
+	return &buf
+}
+
+func TestDispatchMergesEverySource(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	buf := onFakeClock(t)
+	stream := dispatch(
+		produceOrders("dine-in", 100, 4, 150*time.Millisecond),
+		produceOrders("takeaway", 200, 3, 250*time.Millisecond),
+		produceOrders("delivery", 300, 2, 400*time.Millisecond),
+		time.Second,
+	)
+
+	got := map[string][]int{}
+	for order := range stream {
+		got[order.Channel] = append(got[order.Channel], order.ID)
+	}
+	want := map[string][]int{"dine-in": {100, 101, 102, 103}, "takeaway": {200, 201, 202}, "delivery": {300, 301}}
+	for kind, ids := range want {
+		// Each source's orders keep their order through the merge
+		if !slices.Equal(got[kind], ids) {
+			t.Errorf("%s: got %v, want %v", kind, got[kind], ids)
+		}
+	}
+
+	out.Flush()
+	if !strings.Contains(buf.String(), "all order channels closed") || strings.Contains(buf.String(), "no orders for") {
+		t.Errorf("want the stream closed because every source closed, got:\n%s", buf)
+	}
+}
+
+// Interleaving follows arrival time: the select takes whichever source is
+// ready, not the first case listed
+func TestDispatchTakesWhicheverIsReady(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	onFakeClock(t)
+	stream := dispatch(
+		produceOrders("dine-in", 100, 1, 300*time.Millisecond),
+		produceOrders("takeaway", 200, 1, 100*time.Millisecond),
+		produceOrders("delivery", 300, 1, 200*time.Millisecond),
+		time.Second,
+	)
+	var ids []int
+	for order := range stream {
+		ids = append(ids, order.ID)
+	}
+	if want := []int{200, 300, 100}; !slices.Equal(ids, want) {
+		t.Errorf("dispatched %v, want %v by arrival", ids, want)
+	}
+}
+
+func TestDispatchIdleTimeout(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	buf := onFakeClock(t)
+	dineIn := make(chan Order) // Never closed
+	go func() {
+		dineIn <- Order{ID: 1, Channel: "dine-in"}
+		dineIn <- Order{ID: 2, Channel: "dine-in"}
+	}()
+
+	start := clk.Now()
+	var ids []int
+	for order := range dispatch(dineIn, nil, nil, 500*time.Millisecond) {
+		ids = append(ids, order.ID)
+	}
+	if !slices.Equal(ids, []int{1, 2}) {
+		t.Errorf("dispatched %v, want [1 2]", ids)
+	}
+	if waited := clk.Since(start); waited != 500*time.Millisecond {
+		t.Errorf("closed after %v, want the 500ms idle timeout", waited)
+	}
+	out.Flush()
+	if !strings.Contains(buf.String(), "💤 Dispatcher: no orders for 500ms") {
+		t.Errorf("want the idle message, got:\n%s", buf)
+	}
+}
+
+// With no sources at all there's nothing to wait for, not even the timeout
+func TestDispatchNoSources(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	onFakeClock(t)
+	start := clk.Now()
+	for order := range dispatch(nil, nil, nil, time.Hour) {
+		t.Errorf("dispatched %+v from no sources", order)
+	}
+	if waited := clk.Since(start); waited != 0 {
+		t.Errorf("waited %v, want 0", waited)
+	}
+}
+
+func TestRun(t *testing.T) {
+	got := testutil.RunLesson(t, Run)
+	salad, pasta := strings.Index(got, "Salad"), strings.Index(got, "Pasta")
+	if salad < 0 || pasta < salad {
+		t.Errorf("want the 200ms salad before the 500ms pasta, got:\n%s", got)
+	}
+	for _, want := range []string{
+		"🤷 No order waiting - chef tidies the station instead",
+		"📥 Got order 1 (dine-in)",
+		"📊 Processed - dine-in: 4, takeaway: 3, delivery: 2",
+		"💤 Dispatcher: no orders for 500ms",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package main
+
+import (
+	"github.com/Ajay2521/go-concurrency/08-sync-map/syncmap"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+func main() {
+	lesson.Main(syncmap.Run)
+}
@@ -0,0 +1,165 @@
+// Package syncmap is lesson 08: sync.Map & Deduplication.
+package syncmap
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+}
+
+// DedupingProcessor cooks each order ID at most once, silently dropping
+// duplicate submissions (e.g. a client retrying after a timeout).
+type DedupingProcessor struct {
+	seen      sync.Map     // Order ID → struct{}
+	processed atomic.Int64 // Orders actually cooked
+	dropped   atomic.Int64 // Duplicates ignored
+}
+
+// Submit processes the order unless its ID has been seen before.
+// LoadOrStore checks and marks the ID in one atomic step, so two concurrent
+// submissions of the same ID can never both slip through.
+func (p *DedupingProcessor) Submit(order Order) bool {
+	if _, loaded := p.seen.LoadOrStore(order.ID, struct{}{}); loaded {
+		p.dropped.Add(1)
+		return false
+	}
+
+	clk.Sleep(context.Background(), order.PrepTime)
+	p.processed.Add(1)
+	return true
+}
+
+// DroppedCount returns how many duplicate submissions were ignored
+func (p *DedupingProcessor) DroppedCount() int {
+	return int(p.dropped.Load())
+}
+
+// ProcessedCount returns how many orders were actually cooked
+func (p *DedupingProcessor) ProcessedCount() int {
+	return int(p.processed.Load())
+}
+
+// sync.Map basics: Store, Load, LoadOrStore, Range
+func syncMapBasics() {
+	out.Printf("\n=== 1. SYNC.MAP BASICS ===\n\n")
+
+	var statuses sync.Map
+	var wg sync.WaitGroup
+
+	// Concurrent writers need no extra locking
+	for id := 1; id <= 3; id++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			statuses.Store(id, "cooking")
+		}(id)
+	}
+	wg.Wait()
+
+	if status, ok := statuses.Load(2); ok {
+		out.Printf("🔍 Order 2 status: %v\n", status)
+	}
+
+	actual, loaded := statuses.LoadOrStore(2, "received")
+	out.Printf("🔁 LoadOrStore(2): %v (already present: %v)\n", actual, loaded)
+
+	statuses.Range(func(key, value any) bool {
+		out.Printf("📋 Order %v: %v\n", key, value)
+		return true // Keep iterating
+	})
+}
+
+// Check-then-store is a race: two duplicates can both pass the check
+func naiveDedup() {
+	out.Printf("\n=== 2. NAIVE DEDUP (Load, then Store) ===\n\n")
+
+	var seen sync.Map
+	var cooked atomic.Int64
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, ok := seen.Load(42); ok { // Check...
+				return
+			}
+			clk.Sleep(context.Background(), time.Millisecond) // ...others check here too...
+			seen.Store(42, struct{}{})                        // ...then act
+			cooked.Add(1)
+		}()
+	}
+
+	wg.Wait()
+
+	out.Printf("⚠️  Order 42 cooked %d times (expected 1)\n", cooked.Load())
+}
+
+// LoadOrStore makes "seen" atomic
+func dedupingProcessor() {
+	out.Printf("\n=== 3. DEDUPING PROCESSOR (LoadOrStore) ===\n\n")
+
+	processor := &DedupingProcessor{}
+	var wg sync.WaitGroup
+
+	// The same order is submitted from 10 goroutines at once (aggressive client retries)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			processor.Submit(Order{ID: 42, PrepTime: 100 * time.Millisecond})
+		}()
+	}
+
+	// Plus a few distinct orders, some submitted twice
+	for _, id := range []int{1, 2, 2, 3, 1} {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			processor.Submit(Order{ID: id, PrepTime: 100 * time.Millisecond})
+		}(id)
+	}
+
+	wg.Wait()
+
+	out.Printf("✅ Orders cooked: %d\n", processor.ProcessedCount())
+	out.Printf("🗑️  Duplicates dropped: %d\n", processor.DroppedCount())
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: sync.Map & Deduplication")
+	out.Println("==========================================")
+
+	syncMapBasics()
+	naiveDedup()
+	dedupingProcessor()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ sync.Map is safe for concurrent use without extra locks")
+	out.Println("✅ Load followed by Store is a check-then-act race")
+	out.Println("✅ LoadOrStore checks and inserts in a single atomic step")
+	out.Println("✅ sync.Map shines when keys are written once and read many times")
+	return nil
+}
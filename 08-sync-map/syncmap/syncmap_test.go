@@ -0,0 +1,84 @@
+package syncmap
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// onFakeClock points the lesson at a self-advancing fake clock until the
+// test ends
+func onFakeClock(t *testing.T) {
+	saved := clk
+	clk = testutil.FakeClock(t)
+	t.Cleanup(func() { clk = saved })
+}
+
+// 10 goroutines submit order 42 at the same moment, 100 times over: each
+// time exactly one of them cooks it and the other 9 are dropped
+func TestDedupConcurrentSubmits(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	onFakeClock(t)
+	for round := range 100 {
+		p := &DedupingProcessor{}
+		start := make(chan struct{})
+		var cooked sync.Map // Goroutine → whether its Submit cooked
+		var wg sync.WaitGroup
+		for g := range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				cooked.Store(g, p.Submit(Order{ID: 42, PrepTime: 100 * time.Millisecond}))
+			}()
+		}
+		close(start)
+		wg.Wait()
+
+		won := 0
+		cooked.Range(func(_, ok any) bool {
+			if ok.(bool) {
+				won++
+			}
+			return true
+		})
+		if won != 1 || p.ProcessedCount() != 1 || p.DroppedCount() != 9 {
+			t.Fatalf("round %d: %d Submits cooked, ProcessedCount %d, DroppedCount %d; want 1, 1, 9",
+				round, won, p.ProcessedCount(), p.DroppedCount())
+		}
+	}
+}
+
+// Different IDs are each cooked once however many times they come in
+func TestDedupDistinctIDs(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	onFakeClock(t)
+	p := &DedupingProcessor{}
+	var wg sync.WaitGroup
+	for _, id := range []int{1, 2, 2, 3, 1, 1} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.Submit(Order{ID: id, PrepTime: 100 * time.Millisecond})
+		}()
+	}
+	wg.Wait()
+	if p.ProcessedCount() != 3 || p.DroppedCount() != 3 {
+		t.Errorf("ProcessedCount %d, DroppedCount %d; want 3 and 3", p.ProcessedCount(), p.DroppedCount())
+	}
+	if !p.Submit(Order{ID: 4}) || p.Submit(Order{ID: 4}) {
+		t.Error("a new ID was dropped, or its resubmission was cooked")
+	}
+}
+
+func TestRun(t *testing.T) {
+	got := testutil.RunLesson(t, Run)
+	for _, want := range []string{"✅ Orders cooked: 4", "🗑️  Duplicates dropped: 11"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Package once is lesson 09: sync.Once Lazy Kitchen.
+package once
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+}
+
+// LazyKitchen defers its expensive setup until the first order arrives.
+// sync.Once guarantees the setup runs exactly once, no matter how many
+// goroutines call Process at the same time.
+type LazyKitchen struct {
+	initialize sync.Once
+
+	setupRuns   atomic.Int64 // How many times setup actually ran
+	ready       atomic.Bool  // Set at the very end of setup
+	earlyOrders atomic.Int64 // Orders that started before setup finished (should stay 0)
+	processed   atomic.Int64 // Orders completed
+}
+
+// setup simulates a costly initialization (heating ovens, loading recipes)
+func (k *LazyKitchen) setup() {
+	k.setupRuns.Add(1)
+	out.Printf("🔥 Kitchen setup: heating ovens and loading recipes...\n")
+	clk.Sleep(context.Background(), 500*time.Millisecond)
+	k.ready.Store(true)
+	out.Printf("✅ Kitchen setup complete\n\n")
+}
+
+// Process makes sure the kitchen is set up, then prepares the order.
+// Every caller blocks inside Do until the first setup call returns.
+func (k *LazyKitchen) Process(order Order) {
+	k.initialize.Do(k.setup)
+
+	if !k.ready.Load() {
+		k.earlyOrders.Add(1) // Would mean Do returned before setup finished
+	}
+
+	clk.Sleep(context.Background(), order.PrepTime)
+	k.processed.Add(1)
+}
+
+// Naive lazy initialization with a plain flag - racy and may run setup many times
+func naiveLazyInit() {
+	out.Printf("\n=== 1. NAIVE LAZY INIT (Check-then-act race) ===\n\n")
+
+	var initialized bool // Unsynchronized flag
+	var mu sync.Mutex    // Only used to protect the counter for display
+	setupRuns := 0
+	var wg sync.WaitGroup
+
+	for i := 1; i <= 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			mu.Lock()
+			needsSetup := !initialized // Check...
+			mu.Unlock()
+
+			if needsSetup {
+				clk.Sleep(context.Background(), 10*time.Millisecond) // ...other goroutines check here too...
+				mu.Lock()
+				initialized = true // ...then act
+				setupRuns++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	out.Printf("⚠️  Setup ran %d times (expected 1)\n", setupRuns)
+}
+
+// LazyKitchen with sync.Once under 50 concurrent orders
+func lazyKitchenWithOnce() {
+	out.Printf("\n=== 2. LAZY KITCHEN WITH SYNC.ONCE ===\n\n")
+
+	kitchen := &LazyKitchen{}
+	var wg sync.WaitGroup
+	startTime := clk.Now()
+
+	for i := 1; i <= 50; i++ {
+		wg.Add(1)
+		go func(o Order) {
+			defer wg.Done()
+			kitchen.Process(o)
+		}(Order{ID: i, PrepTime: 100 * time.Millisecond})
+	}
+
+	wg.Wait()
+
+	out.Printf("🔁 Setup ran: %d time(s)\n", kitchen.setupRuns.Load())
+	out.Printf("🚫 Orders processed before setup finished: %d\n", kitchen.earlyOrders.Load())
+	out.Printf("📦 Orders processed: %d\n", kitchen.processed.Load())
+	out.Printf("⏱️  Total time: %v (setup 500ms + one 100ms prep)\n", clk.Since(startTime))
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: sync.Once Lazy Kitchen")
+	out.Println("==========================================")
+
+	naiveLazyInit()
+	lazyKitchenWithOnce()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ sync.Once runs its function exactly once across all goroutines")
+	out.Println("✅ Callers of Do block until the first call has finished")
+	out.Println("✅ Check-then-act on a plain flag lets setup run many times")
+	out.Println("✅ Lazy initialization keeps startup fast until work actually arrives")
+	return nil
+}
@@ -0,0 +1,92 @@
+package once
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// onFakeClock points the lesson at a self-advancing fake clock, and its
+// printer at nothing
+func onFakeClock(t *testing.T) {
+	savedClk := clk
+	clk, out = testutil.FakeClock(t), display.NewPrinter(io.Discard)
+	t.Cleanup(func() {
+		out.Close()
+		clk = savedClk
+	})
+}
+
+// 200 goroutines hit a cold kitchen at once. Run with -race: setup runs
+// once, nobody cooks before it has finished, and everyone waits for it
+// rather than for each other.
+func TestLazyKitchenConcurrent(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	onFakeClock(t)
+	const orders = 200
+	kitchen := &LazyKitchen{}
+	start := clk.Now()
+
+	var wg sync.WaitGroup
+	for id := 1; id <= orders; id++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			kitchen.Process(Order{ID: id, PrepTime: 100 * time.Millisecond})
+		}()
+	}
+	wg.Wait()
+
+	if n := kitchen.setupRuns.Load(); n != 1 {
+		t.Errorf("setup ran %d times, want 1", n)
+	}
+	if n := kitchen.earlyOrders.Load(); n != 0 {
+		t.Errorf("%d orders started before setup finished", n)
+	}
+	if n := kitchen.processed.Load(); n != orders {
+		t.Errorf("processed %d orders, want %d", n, orders)
+	}
+	if took := clk.Since(start); took != 600*time.Millisecond {
+		t.Errorf("took %v, want 600ms: one 500ms setup, then every 100ms prep at once", took)
+	}
+}
+
+// A warm kitchen doesn't set up again
+func TestLazyKitchenSetsUpOnce(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	onFakeClock(t)
+	kitchen := &LazyKitchen{}
+	kitchen.Process(Order{ID: 1})
+	start := clk.Now()
+	kitchen.Process(Order{ID: 2, PrepTime: 100 * time.Millisecond})
+	if n := kitchen.setupRuns.Load(); n != 1 {
+		t.Errorf("setup ran %d times over two orders, want 1", n)
+	}
+	if took := clk.Since(start); took != 100*time.Millisecond {
+		t.Errorf("second order took %v, want just its 100ms prep", took)
+	}
+}
+
+func TestRun(t *testing.T) {
+	got := testutil.RunLesson(t, Run)
+	for _, want := range []string{
+		"🔁 Setup ran: 1 time(s)",
+		"🚫 Orders processed before setup finished: 0",
+		"📦 Orders processed: 50",
+		"⏱️  Total time: 600ms",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	// The naive flag lets every goroutine that checked before the first
+	// set it run setup too
+	if strings.Contains(got, "Setup ran 1 times") {
+		t.Errorf("the naive version ran setup once; the race it shows didn't happen:\n%s", got)
+	}
+}
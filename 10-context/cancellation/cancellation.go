@@ -0,0 +1,345 @@
+// Package cancellation is lesson 10: Context Cancellation & Deadlines.
+package cancellation
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+}
+
+// traceIDKey is unexported, so no other package can read or overwrite the trace ID
+type traceIDKey struct{}
+
+// noTraceID is logged for orders whose context carries no trace ID
+const noTraceID = "-"
+
+// WithTraceID returns a copy of ctx that carries id. Contexts derived from it,
+// with a timeout or cancel, carry it too.
+func WithTraceID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, traceIDKey{}, id)
+}
+
+// TraceID returns the trace ID in ctx, or "" if there is none
+func TraceID(ctx context.Context) string {
+	id, _ := ctx.Value(traceIDKey{}).(string)
+	return id
+}
+
+// logOut is where processOrderCtx logs. Run points it at out; tests swap
+// in a buffer.
+var logOut io.Writer
+
+// tracer starts processOrderCtx's spans. It is a no-op until SetTracer is
+// called, so spans cost nothing unless someone is collecting them.
+var tracer trace.Tracer = noop.NewTracerProvider().Tracer("")
+
+// SetTracer replaces the tracer, usually with otel.Tracer or a
+// TracerProvider's Tracer; nil restores the no-op one. Call it before
+// orders are processed, like logOut.
+func SetTracer(t trace.Tracer) {
+	if t == nil {
+		t = noop.NewTracerProvider().Tracer("")
+	}
+	tracer = t
+}
+
+// processOrderCtx prepares an order but stops early if ctx is cancelled.
+// Every log line carries the order's trace ID, and each call is one
+// "process_order" span.
+func processOrderCtx(ctx context.Context, order Order) error {
+	// Timestamps come from clk, so spans line up with the lesson's clock
+	ctx, span := tracer.Start(ctx, "process_order",
+		trace.WithTimestamp(clk.Now()),
+		trace.WithAttributes(
+			attribute.Int("order.id", order.ID),
+			attribute.Int64("order.prep_ms", order.PrepTime.Milliseconds()),
+		),
+	)
+	defer func() { span.End(trace.WithTimestamp(clk.Now())) }()
+
+	traceID := TraceID(ctx)
+	if traceID == "" {
+		traceID = noTraceID
+	}
+	fmt.Fprintf(logOut, "📝 [trace %s] Order %d: Started processing\n", traceID, order.ID)
+
+	select {
+	case <-clk.After(order.PrepTime):
+		fmt.Fprintf(logOut, "✅ [trace %s] Order %d: Ready for pickup! Time taken: %v\n", traceID, order.ID, order.PrepTime)
+		return nil
+	case <-ctx.Done():
+		err := ctx.Err()
+		fmt.Fprintf(logOut, "🛑 [trace %s] Order %d: Stopped (%v)\n", traceID, order.ID, err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+}
+
+// processBatch processes all orders concurrently under one shared deadline.
+// Orders still cooking when the deadline passes are cancelled.
+// It returns how many orders completed and how many were cancelled.
+func processBatch(orders []Order, deadline time.Duration) (done int, cancelled int) {
+	ctx, cancel := clk.WithTimeout(context.Background(), deadline)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var doneCount, cancelledCount atomic.Int64
+
+	for _, order := range orders {
+		wg.Add(1)
+		go func(o Order) {
+			defer wg.Done()
+			if err := processOrderCtx(ctx, o); err != nil {
+				cancelledCount.Add(1)
+				return
+			}
+			doneCount.Add(1)
+		}(order)
+	}
+
+	wg.Wait()
+
+	return int(doneCount.Load()), int(cancelledCount.Load())
+}
+
+func sampleOrders() []Order {
+	return []Order{
+		{ID: 1, PrepTime: 2 * time.Second},
+		{ID: 2, PrepTime: 3 * time.Second},
+		{ID: 3, PrepTime: 1 * time.Second},
+		{ID: 4, PrepTime: 4 * time.Second},
+		{ID: 5, PrepTime: 2 * time.Second},
+	}
+}
+
+// Cancel every in-flight order at once, e.g. when the kitchen closes
+func manualCancellation() {
+	out.Printf("\n=== 1. MANUAL CANCELLATION (WithCancel) ===\n\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+
+	for _, order := range sampleOrders() {
+		wg.Add(1)
+		go func(o Order) {
+			defer wg.Done()
+			processOrderCtx(ctx, o)
+		}(order)
+	}
+
+	clk.Sleep(context.Background(), 1500*time.Millisecond)
+	out.Printf("\n🔥 Kitchen fire alarm! Cancelling all orders\n\n")
+	cancel() // Every goroutine watching ctx.Done() wakes up
+
+	wg.Wait()
+}
+
+// Each order gets its own time limit
+func perOrderTimeout() {
+	out.Printf("\n=== 2. PER-ORDER TIMEOUT (WithTimeout) ===\n\n")
+
+	var wg sync.WaitGroup
+
+	for _, order := range sampleOrders() {
+		wg.Add(1)
+		go func(o Order) {
+			defer wg.Done()
+
+			// Every order must be ready within 2.5 seconds of starting
+			ctx, cancel := clk.WithTimeout(context.Background(), 2500*time.Millisecond)
+			defer cancel()
+
+			if err := processOrderCtx(ctx, o); errors.Is(err, context.DeadlineExceeded) {
+				out.Printf("⏰ Order %d: Missed its own 2.5s SLA\n", o.ID)
+			}
+		}(order)
+	}
+
+	wg.Wait()
+}
+
+// The whole batch shares one deadline
+func batchDeadline() {
+	out.Printf("\n=== 3. BATCH-LEVEL DEADLINE (Shared context) ===\n\n")
+
+	orders := sampleOrders()
+	startTime := clk.Now()
+
+	done, cancelled := processBatch(orders, 2500*time.Millisecond)
+
+	out.Printf("\n📊 Completed: %d | Cancelled: %d | Total: %d\n", done, cancelled, len(orders))
+	out.Printf("⏱️  Batch finished in %v (deadline 2.5s)\n", clk.Since(startTime).Round(time.Millisecond))
+}
+
+// Each order's trace ID rides along in its context, through every derived context
+func traceIDs() {
+	out.Printf("\n=== 4. REQUEST-SCOPED VALUES (WithValue) ===\n\n")
+
+	var wg sync.WaitGroup
+	for _, order := range sampleOrders()[:3] {
+		wg.Add(1)
+		go func(o Order) {
+			defer wg.Done()
+
+			// The front desk tags the order; the kitchen adds its own timeout on top
+			ctx := WithTraceID(context.Background(), fmt.Sprintf("desk-%03d", o.ID*7))
+			ctx, cancel := clk.WithTimeout(ctx, 1500*time.Millisecond)
+			defer cancel()
+			processOrderCtx(ctx, o)
+		}(order)
+	}
+	wg.Wait()
+}
+
+// intAttr returns the span's int attribute named key, or -1 if it has none
+func intAttr(s sdktrace.ReadOnlySpan, key attribute.Key) int64 {
+	for _, kv := range s.Attributes() {
+		if kv.Key == key {
+			return kv.Value.AsInt64()
+		}
+	}
+	return -1
+}
+
+// recordedError is the error the span recorded, from its exception event
+func recordedError(s sdktrace.ReadOnlySpan) string {
+	for _, e := range s.Events() {
+		if e.Name != semconv.ExceptionEventName {
+			continue
+		}
+		for _, kv := range e.Attributes {
+			if kv.Key == semconv.ExceptionMessageKey {
+				return kv.Value.AsString()
+			}
+		}
+	}
+	return ""
+}
+
+// One span per order, with its attributes and, for cancelled orders, the
+// error, collected by the OTel SDK's in-memory tracetest.SpanRecorder
+func spansPerOrder() {
+	out.Printf("\n=== 5. A SPAN PER ORDER (OpenTelemetry, in-memory recorder) ===\n\n")
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	SetTracer(provider.Tracer("kitchen"))
+	saved := logOut
+	logOut = io.Discard
+	defer func() {
+		SetTracer(nil)
+		logOut = saved
+		provider.Shutdown(context.Background())
+	}()
+
+	orders := []Order{
+		{ID: 1, PrepTime: 20 * time.Millisecond},
+		{ID: 2, PrepTime: 60 * time.Millisecond},
+		{ID: 3, PrepTime: 10 * time.Millisecond},
+		{ID: 4, PrepTime: 80 * time.Millisecond},
+		{ID: 5, PrepTime: 30 * time.Millisecond},
+	}
+	processBatch(orders, 45*time.Millisecond)
+
+	spans := recorder.Ended()
+	slices.SortFunc(spans, func(a, b sdktrace.ReadOnlySpan) int {
+		return cmp.Compare(intAttr(a, "order.id"), intAttr(b, "order.id"))
+	})
+	out.Printf("%-14s %9s %14s %9s  %s\n", "span", "order.id", "order.prep_ms", "duration", "status")
+	for _, s := range spans {
+		status := s.Status().Code.String()
+		if s.Status().Code == codes.Error {
+			status += ": " + s.Status().Description
+		}
+		out.Printf("%-14s %9d %14d %9v  %s\n", s.Name(), intAttr(s, "order.id"), intAttr(s, "order.prep_ms"),
+			s.EndTime().Sub(s.StartTime()).Round(time.Millisecond), status)
+	}
+	out.Println()
+
+	check := func(name string, ok bool, detail string) {
+		status := "✅"
+		if !ok {
+			status = "❌"
+		}
+		out.Printf("%s %-42s %s\n", status, name, detail)
+	}
+
+	var attrsMatch, named, errorsMatch int
+	for i, s := range spans {
+		o := orders[i]
+		if intAttr(s, "order.id") == int64(o.ID) && intAttr(s, "order.prep_ms") == o.PrepTime.Milliseconds() {
+			attrsMatch++
+		}
+		if s.Name() == "process_order" && !s.EndTime().IsZero() {
+			named++
+		}
+		cancelled := o.PrepTime > 45*time.Millisecond
+		failed := s.Status().Code == codes.Error && recordedError(s) == context.DeadlineExceeded.Error()
+		if cancelled == failed && (cancelled || s.Status().Code == codes.Unset) {
+			errorsMatch++
+		}
+	}
+	n := len(orders)
+	check("One span per order:", len(spans) == n, fmt.Sprintf("%d spans for %d orders", len(spans), n))
+	check("Named process_order and ended:", named == n, fmt.Sprintf("%d/%d", named, n))
+	check("order.id and order.prep_ms match:", attrsMatch == n, fmt.Sprintf("%d/%d", attrsMatch, n))
+	check("Errors recorded on cancelled orders only:", errorsMatch == n, fmt.Sprintf("%d/%d (orders 2 and 4 missed the 45ms deadline)", errorsMatch, n))
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	logOut = out
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Context Cancellation & Deadlines")
+	out.Println("==========================================")
+
+	manualCancellation()
+	perOrderTimeout()
+	batchDeadline()
+	traceIDs()
+	spansPerOrder()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ context.WithCancel stops many goroutines with one call")
+	out.Println("✅ context.WithTimeout gives a single operation its own deadline")
+	out.Println("✅ One shared context enforces a batch-level SLA")
+	out.Println("✅ Always defer cancel() to release the context's resources")
+	out.Println("✅ Workers must select on ctx.Done() to actually stop early")
+	out.Println("✅ context.WithValue carries request-scoped data like trace IDs through derived contexts")
+	out.Println("✅ Use an unexported key type so no other package can clash with your value")
+	out.Println("✅ A swappable OTel tracer with a no-op default lets checks record spans in memory")
+	return nil
+}
@@ -0,0 +1,252 @@
+package cancellation
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/testutil"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// onFakeClock points the lesson at a self-advancing fake clock, with its
+// printer and log discarded
+func onFakeClock(t *testing.T) {
+	savedClk, savedLog := clk, logOut
+	clk, out, logOut = testutil.FakeClock(t), display.NewPrinter(io.Discard), io.Discard
+	t.Cleanup(func() {
+		out.Close()
+		clk, logOut = savedClk, savedLog
+	})
+}
+
+// recordSpans installs a tracer whose finished spans land in the returned
+// in-memory recorder, and restores the no-op tracer when the test ends
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	SetTracer(provider.Tracer("kitchen"))
+	t.Cleanup(func() {
+		SetTracer(nil)
+		provider.Shutdown(context.Background())
+	})
+	return recorder
+}
+
+// The deadline is shorter than the batch's total prep time: the orders that
+// fit finish, the rest are cancelled when it passes, every order is counted
+// once, and no goroutine outlives the batch
+func TestProcessBatchDeadline(t *testing.T) {
+	tests := []struct {
+		name            string
+		orders          []Order
+		deadline        time.Duration
+		done, cancelled int
+		took            time.Duration
+	}{
+		{"deadline cuts the batch", sampleOrders(), 2500 * time.Millisecond, 3, 2, 2500 * time.Millisecond},
+		{"deadline after the slowest order", sampleOrders(), 5 * time.Second, 5, 0, 4 * time.Second},
+		{"deadline already passed", sampleOrders(), 0, 0, 5, 0},
+		{"empty batch", nil, time.Second, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutil.WaitForGoroutines(t)
+			onFakeClock(t)
+			start := clk.Now()
+			done, cancelled := processBatch(tt.orders, tt.deadline)
+			if done+cancelled != len(tt.orders) {
+				t.Errorf("%d done + %d cancelled, want %d orders", done, cancelled, len(tt.orders))
+			}
+			if done != tt.done || cancelled != tt.cancelled {
+				t.Errorf("processBatch = %d done, %d cancelled; want %d and %d", done, cancelled, tt.done, tt.cancelled)
+			}
+			if took := clk.Since(start); took != tt.took {
+				t.Errorf("batch took %v, want %v", took, tt.took)
+			}
+		})
+	}
+}
+
+func TestSpanPerOrder(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	onFakeClock(t)
+	recorder := recordSpans(t)
+
+	orders := []Order{
+		{ID: 1, PrepTime: 20 * time.Millisecond},
+		{ID: 2, PrepTime: 60 * time.Millisecond},
+		{ID: 3, PrepTime: 10 * time.Millisecond},
+	}
+	if done, cancelled := processBatch(orders, 45*time.Millisecond); done != 2 || cancelled != 1 {
+		t.Fatalf("processBatch = %d done, %d cancelled; want 2 and 1", done, cancelled)
+	}
+
+	spans := map[int64]sdktrace.ReadOnlySpan{}
+	for _, s := range recorder.Ended() {
+		if s.Name() != "process_order" {
+			t.Errorf("span named %q, want process_order", s.Name())
+		}
+		spans[intAttr(s, "order.id")] = s
+	}
+	if len(spans) != len(orders) || len(recorder.Ended()) != len(orders) {
+		t.Fatalf("%d spans for order IDs %v, want one for each of %d orders", len(recorder.Ended()), spans, len(orders))
+	}
+
+	for _, o := range orders {
+		s := spans[int64(o.ID)]
+		if got := intAttr(s, "order.prep_ms"); got != o.PrepTime.Milliseconds() {
+			t.Errorf("order %d: order.prep_ms = %d, want %d", o.ID, got, o.PrepTime.Milliseconds())
+		}
+		wantTook := min(o.PrepTime, 45*time.Millisecond)
+		if took := s.EndTime().Sub(s.StartTime()); took != wantTook {
+			t.Errorf("order %d: span lasted %v on the lesson's clock, want %v", o.ID, took, wantTook)
+		}
+
+		if o.ID == 2 {
+			if s.Status().Code != codes.Error || recordedError(s) != context.DeadlineExceeded.Error() {
+				t.Errorf("cancelled order: status %v, recorded %q; want an error and context deadline exceeded", s.Status(), recordedError(s))
+			}
+			continue
+		}
+		if s.Status().Code != codes.Unset || len(s.Events()) != 0 {
+			t.Errorf("order %d finished but its span has status %v and %d events", o.ID, s.Status(), len(s.Events()))
+		}
+	}
+}
+
+// The span's context still carries the order's trace ID, and spans started
+// under one parent share its OTel trace
+func TestSpanKeepsContext(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	onFakeClock(t)
+	recorder := recordSpans(t)
+
+	var buf strings.Builder
+	logOut = &buf
+	parent, root := tracer.Start(WithTraceID(context.Background(), "abc-123"), "batch")
+	processOrderCtx(parent, Order{ID: 7, PrepTime: time.Millisecond})
+	root.End()
+
+	if !strings.Contains(buf.String(), "[trace abc-123] Order 7") {
+		t.Errorf("log lost the trace ID:\n%s", buf.String())
+	}
+	var child sdktrace.ReadOnlySpan
+	for _, s := range recorder.Ended() {
+		if s.Name() == "process_order" {
+			child = s
+		}
+	}
+	if child == nil || child.Parent().SpanID() != root.SpanContext().SpanID() {
+		t.Errorf("process_order span isn't a child of the caller's span")
+	}
+}
+
+// Without SetTracer, or after SetTracer(nil), spans go nowhere
+func TestNoopTracerByDefault(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	onFakeClock(t)
+	recorder := recordSpans(t)
+	SetTracer(nil)
+	processOrderCtx(context.Background(), Order{ID: 1, PrepTime: time.Millisecond})
+	if n := len(recorder.Ended()); n != 0 {
+		t.Errorf("%d spans recorded after SetTracer(nil), want 0", n)
+	}
+	if _, span := tracer.Start(context.Background(), "x"); span.IsRecording() {
+		t.Error("the default tracer's spans are recording")
+	}
+}
+
+// logged runs one order and returns its log lines. Call onFakeClock first.
+func logged(ctx context.Context, o Order) []string {
+	var buf bytes.Buffer
+	logOut = &buf
+	processOrderCtx(ctx, o)
+	return strings.Split(strings.TrimSpace(buf.String()), "\n")
+}
+
+// Every log line of an order carries its trace ID, finished or cancelled,
+// and an order without one logs the placeholder
+func TestTraceIDInLogs(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	quick := Order{ID: 9, PrepTime: 10 * time.Millisecond}
+	tests := []struct {
+		name  string
+		ctx   func() (context.Context, context.CancelFunc)
+		order Order
+		want  []string
+	}{
+		{"finished order", func() (context.Context, context.CancelFunc) {
+			return WithTraceID(context.Background(), "abc-123"), func() {}
+		}, quick, []string{
+			"📝 [trace abc-123] Order 9: Started processing",
+			"✅ [trace abc-123] Order 9: Ready for pickup! Time taken: 10ms",
+		}},
+		{"cancelled order", func() (context.Context, context.CancelFunc) {
+			return clk.WithTimeout(WithTraceID(context.Background(), "def-456"), 5*time.Millisecond)
+		}, Order{ID: 10, PrepTime: time.Second}, []string{
+			"📝 [trace def-456] Order 10: Started processing",
+			"🛑 [trace def-456] Order 10: Stopped (context deadline exceeded)",
+		}},
+		{"no trace ID", func() (context.Context, context.CancelFunc) {
+			return context.Background(), func() {}
+		}, quick, []string{
+			"📝 [trace -] Order 9: Started processing",
+			"✅ [trace -] Order 9: Ready for pickup! Time taken: 10ms",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			onFakeClock(t) // Before tt.ctx, so its timeout runs on the fake clock
+			ctx, cancel := tt.ctx()
+			defer cancel()
+			if got := logged(ctx, tt.order); !slices.Equal(got, tt.want) {
+				t.Errorf("logged %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTraceIDKey(t *testing.T) {
+	if id := TraceID(context.Background()); id != "" {
+		t.Errorf("TraceID of a bare context = %q, want empty", id)
+	}
+	// A plain string key set elsewhere can't collide with the unexported key type
+	other := context.WithValue(context.Background(), "traceID", "not-ours") //nolint:staticcheck // The collision is the point
+	if id := TraceID(other); id != "" {
+		t.Errorf("TraceID read %q from another package's key", id)
+	}
+	inner := WithTraceID(WithTraceID(context.Background(), "outer"), "inner")
+	if id := TraceID(inner); id != "inner" {
+		t.Errorf("TraceID = %q, want the nearest WithTraceID, inner", id)
+	}
+	derived, cancel := context.WithCancel(inner)
+	defer cancel()
+	if id := TraceID(derived); id != "inner" {
+		t.Errorf("TraceID of a derived context = %q, want inner", id)
+	}
+}
+
+func TestRun(t *testing.T) {
+	got := testutil.RunLesson(t, Run)
+	for _, want := range []string{
+		"process_order          2             60      45ms  Error: context deadline exceeded",
+		"✅ One span per order:                        5 spans for 5 orders",
+		"✅ Errors recorded on cancelled orders only:  5/5",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "❌") {
+		t.Errorf("a check failed:\n%s", got)
+	}
+}
@@ -0,0 +1,525 @@
+// Package atomics is lesson 11: Atomic Operations.
+package atomics
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+	"github.com/Ajay2521/go-concurrency/pkg/order"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+}
+
+// processOrder simulates preparing an order (no printing, so it can be benchmarked)
+func processOrder(order Order) {
+	clk.Sleep(context.Background(), order.PrepTime)
+}
+
+// ThroughputTracker counts completed orders and reports orders-per-second.
+// Workers call Record concurrently; a reporter goroutine swaps the counter
+// back to zero every interval, so each report covers exactly one window.
+type ThroughputTracker struct {
+	count    int64 // Orders completed in the current window (atomic access only)
+	total    int64 // Orders completed since Start (atomic access only)
+	interval time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewThroughputTracker creates a tracker that reports every interval
+func NewThroughputTracker(interval time.Duration) *ThroughputTracker {
+	return &ThroughputTracker{
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Record notes one completed order - a single atomic add, safe from any goroutine
+func (t *ThroughputTracker) Record() {
+	atomic.AddInt64(&t.count, 1)
+	atomic.AddInt64(&t.total, 1)
+}
+
+// Start launches the reporter goroutine
+func (t *ThroughputTracker) Start() {
+	go func() {
+		defer close(t.done)
+
+		ticker := clk.NewTicker(t.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C():
+				// Read and reset in one step - no Record between the two can be lost
+				n := atomic.SwapInt64(&t.count, 0)
+				rate := float64(n) / t.interval.Seconds()
+				out.Printf("📈 Throughput: %.0f orders/sec (total %d)\n", rate, atomic.LoadInt64(&t.total))
+			case <-t.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends reporting and waits for the reporter goroutine to exit
+func (t *ThroughputTracker) Stop() {
+	close(t.stop)
+	<-t.done
+}
+
+// Total returns the number of orders recorded since Start
+func (t *ThroughputTracker) Total() int64 {
+	return atomic.LoadInt64(&t.total)
+}
+
+// ProgressReporter writes "done out of total" to out every interval, reading
+// completed without locks. Run it in its own goroutine; when done closes it
+// writes one final line and returns.
+func ProgressReporter(completed *atomic.Int64, total int64, interval time.Duration, done <-chan struct{}, out io.Writer) {
+	ticker := clk.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C():
+			n := completed.Load()
+			fmt.Fprintf(out, "⏳ Progress: %d/%d orders (%d%%)\n", n, total, percent(n, total))
+		case <-done:
+			fmt.Fprintf(out, "🏁 Finished: %d/%d orders\n", completed.Load(), total)
+			return
+		}
+	}
+}
+
+// percent is n out of total as a whole percentage. An empty batch has
+// nothing left to do, so it is 100% rather than a division by zero.
+func percent(n, total int64) int64 {
+	if total <= 0 {
+		return 100
+	}
+	return n * 100 / total
+}
+
+// OrderLedger maps order IDs to statuses. Readers load the current map with
+// no lock; Update copies the map, changes the copy, and publishes it with
+// CompareAndSwap, retrying if another writer got there first. A published
+// map is never modified, so readers can't race with writers.
+type OrderLedger struct {
+	statuses atomic.Pointer[map[int]string]
+}
+
+// NewOrderLedger creates an empty ledger
+func NewOrderLedger() *OrderLedger {
+	l := &OrderLedger{}
+	empty := make(map[int]string)
+	l.statuses.Store(&empty)
+	return l
+}
+
+// Update sets an order's status. Each call copies the whole map, so writes
+// cost O(n) and suit ledgers that are read far more often than written.
+func (l *OrderLedger) Update(id int, status string) {
+	for {
+		old := l.statuses.Load()
+		next := maps.Clone(*old)
+		next[id] = status
+		if l.statuses.CompareAndSwap(old, &next) {
+			return
+		}
+		// Another Update published first; retry on top of its map
+	}
+}
+
+// Read returns an order's status, or "" if the ledger hasn't seen it
+func (l *OrderLedger) Read(id int) string {
+	return (*l.statuses.Load())[id]
+}
+
+// Len returns how many orders the ledger holds
+func (l *OrderLedger) Len() int {
+	return len(*l.statuses.Load())
+}
+
+// ThroughputMeter keeps an exponential moving average of orders per second.
+// Each Record folds in the rate since the previous completion, weighted by how
+// long that gap was, so a burst of close completions can't swing the average.
+// The state is swapped in with CompareAndSwap, like OrderLedger.
+type ThroughputMeter struct {
+	tau   time.Duration // Smoothing window: older gaps fade with weight e^(-age/tau)
+	state atomic.Pointer[meterState]
+}
+
+type meterState struct {
+	last time.Time
+	rate float64
+}
+
+// NewThroughputMeter creates a meter that smooths over roughly tau
+func NewThroughputMeter(tau time.Duration) *ThroughputMeter {
+	m := &ThroughputMeter{tau: tau}
+	m.state.Store(&meterState{last: clk.Now()})
+	return m
+}
+
+// Record notes one completed order. Safe to call from any goroutine.
+func (m *ThroughputMeter) Record() {
+	m.recordAt(clk.Now())
+}
+
+// Rate returns the smoothed orders per second, decayed for the time since the
+// last completion so an idle kitchen reads as slowing down
+func (m *ThroughputMeter) Rate() float64 {
+	return m.rateAt(clk.Now())
+}
+
+func (m *ThroughputMeter) recordAt(now time.Time) {
+	for {
+		old := m.state.Load()
+		last := now
+		gap := now.Sub(old.last)
+		if gap <= 0 {
+			// Simultaneous completions, or another worker stamped a later time
+			// between our time.Now and this Load: count it, but don't move last back
+			gap, last = time.Nanosecond, old.last
+		}
+		// Blend in this gap's rate (1/gap) with weight 1-e^(-gap/tau)
+		alpha := 1 - math.Exp(-gap.Seconds()/m.tau.Seconds())
+		rate := old.rate + alpha*(1/gap.Seconds()-old.rate)
+		if m.state.CompareAndSwap(old, &meterState{last: last, rate: rate}) {
+			return
+		}
+	}
+}
+
+func (m *ThroughputMeter) rateAt(now time.Time) float64 {
+	s := m.state.Load()
+	idle := max(now.Sub(s.last), 0)
+	return s.rate * math.Exp(-idle.Seconds()/m.tau.Seconds())
+}
+
+// A plain counter loses updates; an atomic one doesn't. The plain counter
+// is a real data race, so -race fails the run on it: it only runs with
+// -racy, and without it the lesson is race-free.
+func racyVsAtomicCounter(racyToo bool) {
+	out.Printf("\n=== 1. RACY VS ATOMIC COUNTER ===\n\n")
+
+	var racy int64
+	var safe int64
+	var wg sync.WaitGroup
+
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if racyToo {
+				racy++ // Read-modify-write: not atomic (data race!)
+			}
+			atomic.AddInt64(&safe, 1) // Single indivisible operation
+		}()
+	}
+
+	wg.Wait()
+
+	if racyToo {
+		out.Printf("⚠️  Racy counter:  %d (expected 1000, may be lower)\n", racy)
+	} else {
+		out.Println("⏭️  Racy counter:  skipped (run with -racy to add it, and -race to see it caught)")
+	}
+	out.Printf("✅ Atomic counter: %d\n", atomic.LoadInt64(&safe))
+}
+
+// Workers record completions while the tracker reports every second
+func throughputTracking() {
+	out.Printf("\n=== 2. REAL-TIME THROUGHPUT TRACKER ===\n\n")
+
+	tracker := NewThroughputTracker(1 * time.Second)
+	tracker.Start()
+
+	orders := make(chan Order)
+	var wg sync.WaitGroup
+
+	// 4 workers, each order takes 50ms → about 80 orders/sec
+	for w := 1; w <= 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for order := range orders {
+				processOrder(order)
+				tracker.Record()
+			}
+		}()
+	}
+
+	// Feed orders for ~3.5 seconds, slowing down halfway through
+	deadline := clk.Now().Add(3500 * time.Millisecond)
+	for id := 1; clk.Now().Before(deadline); id++ {
+		prep := 50 * time.Millisecond
+		if deadline.Sub(clk.Now()) < 1500*time.Millisecond {
+			prep = 200 * time.Millisecond // Kitchen slows down
+		}
+		orders <- Order{ID: id, PrepTime: prep}
+	}
+
+	close(orders)
+	wg.Wait()
+	tracker.Stop()
+
+	out.Printf("\n📦 Orders completed: %d\n", tracker.Total())
+}
+
+// Recording adds only a few nanoseconds per order
+func recordingOverhead() {
+	out.Printf("\n=== 3. RECORDING OVERHEAD (testing.Benchmark) ===\n\n")
+
+	order := Order{ID: 1} // Zero prep time so only the bookkeeping is measured
+
+	without := testing.Benchmark(func(b *testing.B) {
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				processOrder(order)
+			}
+		})
+	})
+
+	tracker := NewThroughputTracker(time.Hour) // Never reports during the benchmark
+	with := testing.Benchmark(func(b *testing.B) {
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				processOrder(order)
+				tracker.Record()
+			}
+		})
+	})
+
+	out.Printf("⏱️  processOrder:          %6d ns/op\n", without.NsPerOp())
+	out.Printf("⏱️  processOrder + Record: %6d ns/op\n", with.NsPerOp())
+	out.Printf("📏 Overhead per order:    %6d ns\n", with.NsPerOp()-without.NsPerOp())
+}
+
+// A long batch reports progress instead of running silently
+func progressReporting() {
+	out.Printf("\n=== 4. PROGRESS REPORTER ===\n\n")
+
+	const total = 40
+	var completed atomic.Int64
+	done := make(chan struct{})
+	var reporter sync.WaitGroup
+
+	reporter.Add(1)
+	go func() {
+		defer reporter.Done()
+		ProgressReporter(&completed, total, 250*time.Millisecond, done, out)
+	}()
+
+	orders := make(chan Order)
+	var wg sync.WaitGroup
+	for w := 1; w <= 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for order := range orders {
+				processOrder(order)
+				completed.Add(1)
+			}
+		}()
+	}
+	for id := 1; id <= total; id++ {
+		orders <- Order{ID: id, PrepTime: time.Duration(50+id%4*25) * time.Millisecond}
+	}
+	close(orders)
+	wg.Wait()
+
+	close(done)
+	reporter.Wait()
+}
+
+// Chefs move orders along while the front desk reads the ledger with no lock
+func orderLedger() {
+	out.Printf("\n=== 5. LOCK-FREE ORDER LEDGER (copy-on-write + CAS) ===\n\n")
+
+	ledger := NewOrderLedger()
+	const orders = 200
+
+	// 8 chefs each move their own orders through three statuses
+	var chefs sync.WaitGroup
+	for chef := 0; chef < 8; chef++ {
+		chefs.Add(1)
+		go func(chef int) {
+			defer chefs.Done()
+			for id := chef + 1; id <= orders; id += 8 {
+				for _, status := range []string{"received", "cooking", "ready"} {
+					ledger.Update(id, status)
+				}
+			}
+		}(chef)
+	}
+
+	// The front desk keeps reading while the chefs write
+	stop := make(chan struct{})
+	var reads atomic.Int64
+	var desks sync.WaitGroup
+	for desk := 0; desk < 2; desk++ {
+		desks.Add(1)
+		go func() {
+			defer desks.Done()
+			for id := 1; ; id = id%orders + 1 {
+				select {
+				case <-stop:
+					return
+				default:
+					ledger.Read(id)
+					reads.Add(1)
+				}
+			}
+		}()
+	}
+
+	chefs.Wait()
+	close(stop)
+	desks.Wait()
+
+	ready := 0
+	for id := 1; id <= orders; id++ {
+		if ledger.Read(id) == "ready" {
+			ready++
+		}
+	}
+	out.Printf("📒 %d updates by 8 chefs, %d lock-free reads by the front desk\n", orders*3, reads.Load())
+
+	out.Printf("📦 Orders ready: %d/%d\n", ready, orders)
+}
+
+// Bursty completions, smooth dashboard: the EMA moves with the trend, not each burst
+func emaDashboard() {
+	out.Printf("\n=== 6. SMOOTHED THROUGHPUT (EMA) ===\n\n")
+
+	meter := NewThroughputMeter(500 * time.Millisecond)
+	stop := make(chan struct{})
+	var dashboard sync.WaitGroup
+
+	dashboard.Add(1)
+	go func() {
+		defer dashboard.Done()
+		ticker := clk.NewTicker(250 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C():
+				out.Printf("📊 Dashboard: %5.1f orders/sec\n", meter.Rate())
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	// Orders finish in bursts of 4 (one per worker), about 40/sec, then the kitchen slows to about 10/sec
+	orders := make(chan Order)
+	var wg sync.WaitGroup
+	for w := 1; w <= 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for order := range orders {
+				processOrder(order)
+				meter.Record()
+			}
+		}()
+	}
+	start := clk.Now()
+	for id := 1; clk.Since(start) < 2500*time.Millisecond; id++ {
+		prep := 100 * time.Millisecond
+		if clk.Since(start) > 1250*time.Millisecond {
+			prep = 400 * time.Millisecond
+		}
+		orders <- Order{ID: id, PrepTime: prep}
+	}
+	close(orders)
+	wg.Wait()
+	close(stop)
+	dashboard.Wait()
+}
+
+// order.Metrics is a set of atomic counters several goroutines update with
+// no lock; the bulkhead lesson keeps one per pool
+func orderMetrics() {
+	out.Printf("\n=== 7. ORDER METRICS (order.Metrics) ===\n\n")
+
+	var m order.Metrics
+	var wg sync.WaitGroup
+	for chef := 1; chef <= 4; chef++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 250 {
+				if i%10 == 0 {
+					m.Reject() // The host turns one in ten away
+				}
+				m.Submit()
+				m.Complete(time.Duration(i%20) * time.Millisecond) // How long the order sat in the queue
+			}
+		}()
+	}
+	wg.Wait()
+
+	s := m.Snapshot()
+	out.Printf("📦 Submitted %d | Rejected %d | Completed %d\n", s.Submitted, s.Rejected, s.Completed)
+	out.Printf("⏳ Avg wait %v | Max wait %v (raised with CompareAndSwap)\n", s.AvgWait, s.MaxWait)
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	fs := flag.NewFlagSet("atomic", flag.ContinueOnError)
+	racy := fs.Bool("racy", false, "also increment a plain counter from 1000 goroutines, a data race -race reports")
+	if err := fs.Parse(opts.Args); err != nil {
+		return lesson.Usage(err)
+	}
+
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Atomic Operations")
+	out.Println("==========================================")
+
+	racyVsAtomicCounter(*racy)
+	throughputTracking()
+	recordingOverhead()
+	progressReporting()
+	orderLedger()
+	emaDashboard()
+	orderMetrics()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ counter++ is a read-modify-write and races across goroutines")
+	out.Println("✅ atomic.AddInt64 is a single indivisible update")
+	out.Println("✅ atomic.SwapInt64 reads and resets a counter in one step")
+	out.Println("✅ Atomic counters add only nanoseconds per operation")
+	out.Println("✅ A reporter goroutine can Load a counter on a ticker without slowing workers")
+	out.Println("✅ atomic.Pointer + CompareAndSwap publishes copy-on-write data to lock-free readers")
+	out.Println("✅ A time-weighted EMA smooths bursty throughput without a lock")
+	out.Println("✅ A CompareAndSwap loop keeps a running maximum without a lock")
+	return nil
+}
@@ -0,0 +1,103 @@
+package atomics
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// BenchmarkCounter increments one shared counter from every P at once,
+// behind a sync.Mutex, with atomic.AddInt64, and through
+// ThroughputTracker.Record, which makes two atomic adds
+func BenchmarkCounter(b *testing.B) {
+	b.Run("mutex", func(b *testing.B) {
+		var mu sync.Mutex
+		var n int64
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				mu.Lock()
+				n++
+				mu.Unlock()
+			}
+		})
+	})
+
+	b.Run("atomic", func(b *testing.B) {
+		var n int64
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				atomic.AddInt64(&n, 1)
+			}
+		})
+	})
+
+	b.Run("tracker", func(b *testing.B) {
+		tracker := NewThroughputTracker(0) // Never started, so it never reports
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				tracker.Record()
+			}
+		})
+		if got := tracker.Total(); got != int64(b.N) {
+			b.Errorf("tracker counted %d of %d records", got, b.N)
+		}
+	})
+}
+
+// mutexLedger is OrderLedger's map guarded by a sync.RWMutex instead, for
+// comparison
+type mutexLedger struct {
+	mu       sync.RWMutex
+	statuses map[int]string
+}
+
+func (l *mutexLedger) Update(id int, status string) {
+	l.mu.Lock()
+	l.statuses[id] = status
+	l.mu.Unlock()
+}
+
+func (l *mutexLedger) Read(id int) string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.statuses[id]
+}
+
+// BenchmarkLedger reads and updates a 10-order and a 1000-order ledger from
+// every P at once, copy-on-write with CompareAndSwap against a sync.RWMutex
+// map. CAS reads take no lock; CAS updates copy the whole map.
+func BenchmarkLedger(b *testing.B) {
+	type ledger interface {
+		Update(id int, status string)
+		Read(id int) string
+	}
+	for _, size := range []int{10, 1000} {
+		for _, impl := range []struct {
+			name string
+			new  func() ledger
+		}{
+			{"cas", func() ledger { return NewOrderLedger() }},
+			{"rwmutex", func() ledger { return &mutexLedger{statuses: make(map[int]string)} }},
+		} {
+			l := impl.new()
+			for id := range size {
+				l.Update(id, "received")
+			}
+			b.Run(fmt.Sprintf("orders=%d/%s/read", size, impl.name), func(b *testing.B) {
+				b.RunParallel(func(pb *testing.PB) {
+					for id := 0; pb.Next(); id++ {
+						l.Read(id % size)
+					}
+				})
+			})
+			b.Run(fmt.Sprintf("orders=%d/%s/update", size, impl.name), func(b *testing.B) {
+				b.RunParallel(func(pb *testing.PB) {
+					for id := 0; pb.Next(); id++ {
+						l.Update(id%size, "cooking")
+					}
+				})
+			})
+		}
+	}
+}
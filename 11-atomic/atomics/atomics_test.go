@@ -0,0 +1,263 @@
+package atomics
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// lineWriter sends every line ProgressReporter writes on its channel, so a
+// test can wait for the line a tick produced instead of polling a buffer
+type lineWriter chan string
+
+func (w lineWriter) Write(p []byte) (int, error) {
+	w <- strings.TrimSuffix(string(p), "\n")
+	return len(p), nil
+}
+
+// startReporter points the lesson at a fake clock that only moves when the
+// test advances it, and runs ProgressReporter on it with a 10ms interval.
+// stop closes done and waits for the reporter to return.
+func startReporter(t *testing.T, completed *atomic.Int64, total int64) (fake *clock.FakeClock, lines lineWriter, stop func()) {
+	saved := clk
+	fake = clock.NewFake(testutil.Epoch)
+	clk = fake
+	t.Cleanup(func() { clk = saved })
+
+	lines = make(lineWriter, 1)
+	done := make(chan struct{})
+	returned := make(chan struct{})
+	go func() {
+		defer close(returned)
+		ProgressReporter(completed, total, 10*time.Millisecond, done, lines)
+	}()
+	fake.BlockUntil(1) // The ticker is armed
+	return fake, lines, func() {
+		close(done)
+		<-returned
+	}
+}
+
+// Each tick prints the counter as it is at that moment, and closing done
+// prints the final line and stops the ticker
+func TestProgressReporter(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	var completed atomic.Int64
+	fake, lines, stop := startReporter(t, &completed, 10)
+
+	for _, n := range []int64{3, 7, 10} {
+		completed.Store(n)
+		fake.Advance(10 * time.Millisecond)
+		want := fmt.Sprintf("⏳ Progress: %d/10 orders (%d%%)", n, n*10)
+		if got := <-lines; got != want {
+			t.Errorf("tick at %d done: %q, want %q", n, got, want)
+		}
+	}
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		stop()
+	}()
+	if got := <-lines; got != "🏁 Finished: 10/10 orders" {
+		t.Errorf("last line %q, want 🏁 Finished: 10/10 orders", got)
+	}
+	<-stopped
+	if n := fake.Waiters(); n != 0 {
+		t.Errorf("%d ticker(s) still armed after the reporter returned", n)
+	}
+}
+
+// An empty batch still ticks, at 100% rather than a division by zero
+func TestProgressReporterEmptyBatch(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	var completed atomic.Int64
+	fake, lines, stop := startReporter(t, &completed, 0)
+
+	fake.Advance(10 * time.Millisecond)
+	if got := <-lines; got != "⏳ Progress: 0/0 orders (100%)" {
+		t.Errorf("first line %q, want ⏳ Progress: 0/0 orders (100%%)", got)
+	}
+	go stop()
+	if got := <-lines; got != "🏁 Finished: 0/0 orders" {
+		t.Errorf("last line %q, want 🏁 Finished: 0/0 orders", got)
+	}
+}
+
+// 8 workers add to the counter while the reporter ticks. Under -race this
+// fails on any unsynchronized read, and the counts printed never go backwards.
+func TestProgressReporterConcurrentWorkers(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	const total = 8 * 1000
+	var completed atomic.Int64
+	fake, lines, stop := startReporter(t, &completed, total)
+
+	var workers sync.WaitGroup
+	for range 8 {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for range 1000 {
+				completed.Add(1)
+			}
+		}()
+	}
+
+	last := int64(-1)
+	for range 20 {
+		fake.Advance(10 * time.Millisecond)
+		var n int64
+		if _, err := fmt.Sscanf(<-lines, "⏳ Progress: %d/", &n); err != nil {
+			t.Fatalf("progress line: %v", err)
+		}
+		if n < last || n > total {
+			t.Errorf("progress went from %d to %d of %d", last, n, total)
+		}
+		last = n
+	}
+	workers.Wait()
+	go stop()
+	if got, want := <-lines, fmt.Sprintf("🏁 Finished: %d/%d orders", total, total); got != want {
+		t.Errorf("last line %q, want %q", got, want)
+	}
+}
+
+// 8 chefs move 200 orders through three statuses while 2 front-desk
+// goroutines read with no lock. Under -race this fails on any unsynchronized
+// access; every order must end "ready", so no CompareAndSwap lost an update.
+func TestOrderLedgerConcurrent(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	ledger := NewOrderLedger()
+	const orders = 200
+
+	var chefs sync.WaitGroup
+	for chef := range 8 {
+		chefs.Add(1)
+		go func() {
+			defer chefs.Done()
+			for id := chef + 1; id <= orders; id += 8 {
+				for _, status := range []string{"received", "cooking", "ready"} {
+					ledger.Update(id, status)
+				}
+			}
+		}()
+	}
+
+	stop := make(chan struct{})
+	var desks sync.WaitGroup
+	for range 2 {
+		desks.Add(1)
+		go func() {
+			defer desks.Done()
+			for id := 1; ; id = id%orders + 1 {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				switch s := ledger.Read(id); s {
+				case "", "received", "cooking", "ready":
+				default:
+					t.Errorf("order %d read as %q", id, s)
+					return
+				}
+			}
+		}()
+	}
+
+	chefs.Wait()
+	close(stop)
+	desks.Wait()
+
+	if ledger.Len() != orders {
+		t.Errorf("ledger holds %d orders, want %d", ledger.Len(), orders)
+	}
+	for id := 1; id <= orders; id++ {
+		if s := ledger.Read(id); s != "ready" {
+			t.Errorf("order %d is %q, want ready", id, s)
+		}
+	}
+}
+
+// A map published to readers is never written again: a copy taken before
+// an Update still shows the old status
+func TestOrderLedgerCopyOnWrite(t *testing.T) {
+	ledger := NewOrderLedger()
+	ledger.Update(1, "received")
+	before := *ledger.statuses.Load()
+	ledger.Update(1, "ready")
+	if before[1] != "received" || ledger.Read(1) != "ready" {
+		t.Errorf("old map says %q, ledger says %q; want received and ready", before[1], ledger.Read(1))
+	}
+	if ledger.Read(2) != "" {
+		t.Errorf("unknown order read as %q, want empty", ledger.Read(2))
+	}
+}
+
+// Completions at a known rate, on fake timestamps, pull the EMA to within 5%
+// of that rate, even when they come in bursts
+func TestThroughputMeterConverges(t *testing.T) {
+	type feed struct {
+		gaps  []time.Duration // Repeated pattern of gaps between completions
+		after time.Duration   // How long to feed completions
+	}
+	testutil.RunParallel(t, []testutil.TestCase{
+		{Name: "steady 100/sec", Input: feed{[]time.Duration{10 * time.Millisecond}, 5 * time.Second}, Want: 100.0},
+		{Name: "steady 8/sec", Input: feed{[]time.Duration{125 * time.Millisecond}, 5 * time.Second}, Want: 8.0},
+		{Name: "bursts of 2, 100/sec average", Input: feed{[]time.Duration{time.Millisecond, 19 * time.Millisecond}, 5 * time.Second}, Want: 100.0},
+	}, func(t *testing.T, tc testutil.TestCase) {
+		f, want := tc.Input.(feed), tc.Want.(float64)
+		m := NewThroughputMeter(time.Second)
+		now := m.state.Load().last
+		end := now.Add(f.after)
+		for i := 0; now.Before(end); i++ {
+			now = now.Add(f.gaps[i%len(f.gaps)])
+			m.recordAt(now)
+		}
+		if got := m.rateAt(now); math.Abs(got-want)/want > 0.05 {
+			t.Errorf("rate %.1f, want %.1f ± 5%%", got, want)
+		}
+	})
+}
+
+// 8 goroutines Record 1000 completions each: every CompareAndSwap retry
+// lands on a whole state, so the rate is never torn into NaN, infinity or
+// a negative number
+func TestThroughputMeterConcurrentRecords(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	m := NewThroughputMeter(time.Second)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 1000 {
+				m.Record()
+			}
+		}()
+	}
+	wg.Wait()
+	if rate := m.Rate(); math.IsNaN(rate) || math.IsInf(rate, 0) || rate <= 0 {
+		t.Errorf("rate %v, want finite and positive", rate)
+	}
+}
+
+// Three idle seconds with tau 1s decay the rate by e^-3, below a tenth
+func TestThroughputMeterIdleDecays(t *testing.T) {
+	m := NewThroughputMeter(time.Second)
+	now := m.state.Load().last
+	for range 500 {
+		now = now.Add(10 * time.Millisecond)
+		m.recordAt(now)
+	}
+	busy, idle := m.rateAt(now), m.rateAt(now.Add(3*time.Second))
+	if want := busy * math.Exp(-3); math.Abs(idle-want) > 1e-9 || idle > busy*0.1 {
+		t.Errorf("%.1f → %.3f after 3s idle, want %.3f", busy, idle, want)
+	}
+}
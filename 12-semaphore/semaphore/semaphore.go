@@ -0,0 +1,170 @@
+// Package semaphore is lesson 12: Semaphores.
+package semaphore
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+}
+
+// BoundedLimiter is a counting semaphore built on a buffered channel.
+// Each slot in the channel is one permit; at most cap(slots) holders at a time.
+type BoundedLimiter struct {
+	slots chan struct{}
+}
+
+// NewBoundedLimiter creates a limiter with n permits
+func NewBoundedLimiter(n int) *BoundedLimiter {
+	return &BoundedLimiter{slots: make(chan struct{}, n)}
+}
+
+// Acquire takes a permit, waiting until one is free or ctx is done.
+// On cancellation it returns ctx.Err() and does not hold a permit.
+func (l *BoundedLimiter) Acquire(ctx context.Context) error {
+	// Fail fast if the caller already gave up, even if a slot is free
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release returns a permit. It must be called exactly once per successful Acquire.
+func (l *BoundedLimiter) Release() {
+	select {
+	case <-l.slots:
+	default:
+		panic("semaphore: Release called without a matching Acquire")
+	}
+}
+
+// Basic semaphore: only 2 ovens, 5 orders
+func basicSemaphore() {
+	out.Printf("\n=== 1. SEMAPHORE LIMITS CONCURRENCY ===\n\n")
+
+	ovens := NewBoundedLimiter(2)
+	var wg sync.WaitGroup
+	var inUse, maxInUse atomic.Int64
+	startTime := clk.Now()
+
+	for id := 1; id <= 5; id++ {
+		wg.Add(1)
+		go func(o Order) {
+			defer wg.Done()
+
+			if err := ovens.Acquire(context.Background()); err != nil {
+				return
+			}
+			defer ovens.Release()
+
+			now := inUse.Add(1)
+			for cur := maxInUse.Load(); now > cur && !maxInUse.CompareAndSwap(cur, now); cur = maxInUse.Load() {
+			}
+
+			out.Printf("🔥 [+%4dms] Order %d: Got an oven (%d in use)\n", clk.Since(startTime).Milliseconds(), o.ID, now)
+			clk.Sleep(context.Background(), o.PrepTime)
+			inUse.Add(-1)
+		}(Order{ID: id, PrepTime: 300 * time.Millisecond})
+	}
+
+	wg.Wait()
+
+	out.Printf("\n📊 Max ovens in use at once: %d\n", maxInUse.Load())
+	out.Printf("⏱️  Total time: %v (3 rounds of 300ms)\n", clk.Since(startTime).Round(10*time.Millisecond))
+}
+
+// Impatient orders give up if they can't get an oven in time
+func acquireWithDeadline() {
+	out.Printf("\n=== 2. ACQUIRE WITH A DEADLINE ===\n\n")
+
+	ovens := NewBoundedLimiter(2)
+	var wg sync.WaitGroup
+	var inUse, maxInUse atomic.Int64
+	var served, gaveUp atomic.Int64
+
+	// Orders 4 and 5 are impatient - they arrive after both ovens are taken
+	// and only wait 100ms, while the ovens are busy for 300ms
+	patience := map[int]time.Duration{
+		1: time.Second, 2: time.Second, 3: time.Second, 4: 100 * time.Millisecond, 5: 100 * time.Millisecond,
+	}
+
+	for id := 1; id <= 5; id++ {
+		wg.Add(1)
+		go func(o Order) {
+			defer wg.Done()
+
+			ctx, cancel := clk.WithTimeout(context.Background(), patience[o.ID])
+			defer cancel()
+
+			if err := ovens.Acquire(ctx); err != nil {
+				gaveUp.Add(1)
+				out.Printf("⌛ Order %d: No oven within %v (%v)\n", o.ID, patience[o.ID], err)
+				return
+			}
+			defer ovens.Release()
+
+			now := inUse.Add(1)
+			for cur := maxInUse.Load(); now > cur && !maxInUse.CompareAndSwap(cur, now); cur = maxInUse.Load() {
+			}
+
+			out.Printf("🔥 Order %d: Cooking\n", o.ID)
+			clk.Sleep(context.Background(), o.PrepTime)
+			inUse.Add(-1)
+			served.Add(1)
+		}(Order{ID: id, PrepTime: 300 * time.Millisecond})
+
+		clk.Sleep(context.Background(), 10*time.Millisecond) // Orders arrive in ID order
+	}
+
+	wg.Wait()
+
+	out.Printf("\n📊 Served: %d | Gave up: %d | Max concurrent: %d\n", served.Load(), gaveUp.Load(), maxInUse.Load())
+	if maxInUse.Load() == 2 && gaveUp.Load() == 2 {
+		out.Println("✅ Never more than 2 ovens in use, impatient orders left without holding one")
+	} else {
+		out.Println("❌ Unexpected result")
+	}
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Semaphores")
+	out.Println("==========================================")
+
+	basicSemaphore()
+	acquireWithDeadline()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ A buffered channel of size N is a counting semaphore")
+	out.Println("✅ Sending acquires a permit, receiving releases it")
+	out.Println("✅ select with ctx.Done() makes acquisition cancellable")
+	out.Println("✅ A failed Acquire must not be followed by Release")
+	out.Println("✅ defer Release() right after a successful Acquire")
+	return nil
+}
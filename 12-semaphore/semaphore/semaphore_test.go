@@ -0,0 +1,144 @@
+package semaphore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// 20 orders share n ovens on a self-advancing fake clock. Every order is
+// cooked, and the most ovens in use at once is exactly n.
+func TestLimiterNeverExceedsN(t *testing.T) {
+	for _, n := range []int{1, 2, 4} {
+		t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+			testutil.WaitForGoroutines(t)
+			saved := clk
+			clk = testutil.FakeClock(t)
+			defer func() { clk = saved }()
+
+			ovens := NewBoundedLimiter(n)
+			var inUse, maxInUse, cooked atomic.Int64
+			var wg sync.WaitGroup
+			for range 20 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if err := ovens.Acquire(context.Background()); err != nil {
+						t.Error(err)
+						return
+					}
+					defer ovens.Release()
+					now := inUse.Add(1)
+					for cur := maxInUse.Load(); now > cur && !maxInUse.CompareAndSwap(cur, now); cur = maxInUse.Load() {
+					}
+					clk.Sleep(context.Background(), 300*time.Millisecond)
+					inUse.Add(-1)
+					cooked.Add(1)
+				}()
+			}
+			wg.Wait()
+			if maxInUse.Load() != int64(n) || cooked.Load() != 20 {
+				t.Errorf("max %d in use, %d cooked; want %d and 20", maxInUse.Load(), cooked.Load(), n)
+			}
+		})
+	}
+}
+
+// Both ovens are taken. Two of the three waiting orders only wait 100ms:
+// they fail with DeadlineExceeded and hold nothing, and the patient one
+// gets the first oven released.
+func TestAcquireDeadline(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	saved := clk
+	fake := clock.NewFake(testutil.Epoch)
+	clk = fake
+	defer func() { clk = saved }()
+
+	ovens := NewBoundedLimiter(2)
+	for range 2 {
+		if err := ovens.Acquire(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	patience := []time.Duration{100 * time.Millisecond, 100 * time.Millisecond, time.Second}
+	errs := make([]error, len(patience))
+	var impatient, patient sync.WaitGroup
+	for i, p := range patience {
+		wg := &impatient
+		if p == time.Second {
+			wg = &patient
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := clk.WithTimeout(context.Background(), p)
+			defer cancel()
+			if errs[i] = ovens.Acquire(ctx); errs[i] == nil {
+				ovens.Release()
+			}
+		}()
+	}
+	fake.BlockUntil(len(patience)) // Each waiter's deadline is on the clock
+	fake.Advance(100 * time.Millisecond)
+	impatient.Wait() // Both gave up while the ovens were still taken
+	ovens.Release()
+	patient.Wait()
+
+	for i, err := range errs[:2] {
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("impatient order %d: %v, want DeadlineExceeded", i+1, err)
+		}
+	}
+	if errs[2] != nil {
+		t.Errorf("patient order: %v, want an oven", errs[2])
+	}
+	if held := len(ovens.slots); held != 1 {
+		t.Errorf("%d ovens held afterwards, want only the test's own 1", held)
+	}
+}
+
+// A caller that has already given up doesn't take a free oven
+func TestAcquireCancelledContext(t *testing.T) {
+	ovens := NewBoundedLimiter(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := ovens.Acquire(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Acquire = %v, want Canceled", err)
+	}
+	if len(ovens.slots) != 0 {
+		t.Error("a cancelled Acquire took a permit")
+	}
+}
+
+func TestReleaseWithoutAcquirePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Release with no permit held didn't panic")
+		}
+	}()
+	NewBoundedLimiter(1).Release()
+}
+
+func TestRun(t *testing.T) {
+	got := testutil.RunLesson(t, Run)
+	for _, want := range []string{
+		"📊 Max ovens in use at once: 2",
+		"📊 Served: 3 | Gave up: 2 | Max concurrent: 2",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "❌") {
+		t.Errorf("a check failed:\n%s", got)
+	}
+}
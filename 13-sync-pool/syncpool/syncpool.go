@@ -0,0 +1,140 @@
+// Package syncpool is lesson 13: sync.Pool.
+package syncpool
+
+import (
+	"context"
+	"hash/crc32"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+// payloadSize is the size of each order's attached data (receipt image, notes, ...)
+const payloadSize = 128 * 1024
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+	Payload  []byte
+}
+
+// allocations counts how many Orders the pool had to create from scratch
+var allocations atomic.Int64
+
+// orderPool hands out reusable *Order values with a payload buffer already allocated
+var orderPool = sync.Pool{
+	New: func() any {
+		allocations.Add(1)
+		return &Order{Payload: make([]byte, 0, payloadSize)}
+	},
+}
+
+// getOrder takes an Order from the pool and sets it up for id
+func getOrder(id int) *Order {
+	o := orderPool.Get().(*Order)
+	o.ID = id
+	return o
+}
+
+// putOrder wipes the order and returns it to the pool. Clearing the payload
+// means the next customer can never see the previous customer's data.
+func putOrder(o *Order) {
+	clear(o.Payload)
+	o.Payload = o.Payload[:0]
+	o.ID = 0
+	o.PrepTime = 0
+	orderPool.Put(o)
+}
+
+// fillPayload writes order-specific bytes into the payload
+func fillPayload(o *Order) {
+	o.Payload = o.Payload[:payloadSize]
+	for i := range o.Payload {
+		o.Payload[i] = byte(o.ID + i)
+	}
+}
+
+// processOrder "cooks" the order by checksumming its payload
+func processOrder(o *Order) uint32 {
+	clk.Sleep(context.Background(), o.PrepTime)
+	return crc32.ChecksumIEEE(o.Payload)
+}
+
+// Get returns a pooled value (or a new one); Put makes it available again
+func poolBasics() {
+	out.Printf("\n=== 1. SYNC.POOL BASICS ===\n\n")
+
+	allocations.Store(0)
+
+	first := getOrder(1)
+	out.Printf("📦 Got order %d (payload cap %d KB), allocations so far: %d\n", first.ID, cap(first.Payload)/1024, allocations.Load())
+	putOrder(first)
+
+	second := getOrder(2)
+	out.Printf("♻️  Got order %d, allocations so far: %d (same buffer: %v)\n", second.ID, allocations.Load(), first == second)
+	putOrder(second)
+
+	out.Println("\n💡 The pool may drop items at any GC, so a reused buffer is likely, not guaranteed")
+}
+
+// Many chefs share the pool: far fewer allocations than orders
+func concurrentReuse() {
+	out.Printf("\n=== 2. CONCURRENT REUSE ===\n\n")
+
+	allocations.Store(0)
+	const orders, chefs = 1000, 8
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for c := 0; c < chefs; c++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range jobs {
+				o := getOrder(id)
+				fillPayload(o)
+				processOrder(o)
+				putOrder(o)
+			}
+		}()
+	}
+
+	for id := 1; id <= orders; id++ {
+		jobs <- id
+	}
+	close(jobs)
+	wg.Wait()
+
+	out.Printf("📊 %d orders, %d chefs, %d payload buffers allocated\n", orders, chefs, allocations.Load())
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: sync.Pool")
+	out.Println("==========================================")
+
+	poolBasics()
+	concurrentReuse()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ sync.Pool reuses expensive objects across goroutines")
+	out.Println("✅ Fewer allocations means less GC work")
+	out.Println("✅ Always reset an object before Put - stale data is a real leak")
+	out.Println("✅ The pool may be emptied at any GC; never rely on it for state")
+	return nil
+}
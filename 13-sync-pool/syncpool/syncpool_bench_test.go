@@ -0,0 +1,46 @@
+package syncpool
+
+import (
+	"runtime"
+	"testing"
+)
+
+// BenchmarkOrder fills and cooks one order per iteration, allocating a
+// fresh 128 KB payload each time or taking one from orderPool. The GCs
+// metric is how many collections the run triggered.
+func BenchmarkOrder(b *testing.B) {
+	b.Run("without-pool", func(b *testing.B) {
+		b.ReportAllocs()
+		gcs := countGCs(b)
+		for i := 0; b.Loop(); i++ {
+			o := &Order{ID: i, Payload: make([]byte, 0, payloadSize)}
+			fillPayload(o)
+			processOrder(o)
+		}
+		gcs()
+	})
+
+	b.Run("with-pool", func(b *testing.B) {
+		b.ReportAllocs()
+		gcs := countGCs(b)
+		for i := 0; b.Loop(); i++ {
+			o := getOrder(i)
+			fillPayload(o)
+			processOrder(o)
+			putOrder(o)
+		}
+		gcs()
+	})
+}
+
+// countGCs notes the GC count now; the returned func reports the
+// collections since as the GCs metric
+func countGCs(b *testing.B) func() {
+	var before runtime.MemStats
+	runtime.ReadMemStats(&before)
+	return func() {
+		var after runtime.MemStats
+		runtime.ReadMemStats(&after)
+		b.ReportMetric(float64(after.NumGC-before.NumGC), "GCs")
+	}
+}
@@ -0,0 +1,76 @@
+package syncpool
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// putOrder wipes every byte the order used, not just its length, and zeroes
+// the other fields before the order goes back in the pool
+func TestPutOrderResets(t *testing.T) {
+	o := getOrder(42)
+	o.PrepTime = 7
+	fillPayload(o)
+	full := o.Payload[:cap(o.Payload)] // The whole backing array, which the pool keeps
+	putOrder(o)
+
+	if o.ID != 0 || o.PrepTime != 0 || len(o.Payload) != 0 {
+		t.Errorf("after putOrder: ID %d, PrepTime %v, payload len %d; want all zero", o.ID, o.PrepTime, len(o.Payload))
+	}
+	for i, b := range full {
+		if b != 0 {
+			t.Fatalf("byte %d of the recycled payload is %d, want 0", i, b)
+		}
+	}
+}
+
+// 8 chefs share the pool for 200 orders. Every order they get must look
+// new: no ID, no prep time, and a backing array of zeros, whichever chef
+// used it last.
+func TestNoStaleDataAcrossChefs(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range jobs {
+				o := getOrder(id)
+				for i, b := range o.Payload[:cap(o.Payload)] {
+					if b != 0 {
+						t.Errorf("order %d got a payload with stale byte %d at %d", id, b, i)
+						break
+					}
+				}
+				if len(o.Payload) != 0 || o.PrepTime != 0 {
+					t.Errorf("order %d got payload len %d, PrepTime %v; want both zero", id, len(o.Payload), o.PrepTime)
+				}
+				fillPayload(o)
+				processOrder(o)
+				putOrder(o)
+			}
+		}()
+	}
+	for id := 1; id <= 200; id++ {
+		jobs <- id
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+func TestRun(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	got := testutil.RunLesson(t, Run)
+	for _, want := range []string{
+		"📦 Got order 1 (payload cap 128 KB)",
+		"📊 1000 orders, 8 chefs",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+// Package pubsub is lesson 14: Pub/Sub.
+package pubsub
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+	Burnt    bool
+}
+
+// Order lifecycle event kinds
+const (
+	EventStarted   = "started"
+	EventCompleted = "completed"
+	EventFailed    = "failed"
+)
+
+type Event struct {
+	OrderID int
+	Kind    string
+}
+
+// EventBus fans each published event out to every subscriber. Each subscriber
+// gets its own buffered channel; when a buffer is full the event is dropped
+// for that subscriber and counted, so a slow reader never blocks a worker.
+type EventBus struct {
+	mu      sync.RWMutex
+	subs    []chan Event
+	buffer  int
+	closed  bool
+	dropped atomic.Int64
+}
+
+// NewEventBus creates a bus whose subscribers can fall buffer events behind
+func NewEventBus(buffer int) *EventBus {
+	return &EventBus{buffer: buffer}
+}
+
+// Subscribe returns a channel that receives every event published from now on.
+// The channel closes when the bus does.
+func (b *EventBus) Subscribe() <-chan Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan Event, b.buffer)
+	if b.closed {
+		close(ch)
+		return ch
+	}
+	b.subs = append(b.subs, ch)
+	return ch
+}
+
+// Publish delivers e to every subscriber without waiting on any of them
+func (b *EventBus) Publish(e Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return
+	}
+	for _, ch := range b.subs {
+		select {
+		case ch <- e:
+		default:
+			b.dropped.Add(1) // This subscriber is behind; don't wait for it
+		}
+	}
+}
+
+// Dropped returns how many deliveries were skipped because a subscriber was full
+func (b *EventBus) Dropped() int64 {
+	return b.dropped.Load()
+}
+
+// Close closes every subscriber channel. Later publishes are ignored.
+func (b *EventBus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for _, ch := range b.subs {
+		close(ch)
+	}
+}
+
+// cook publishes started, then completed or failed
+func cook(bus *EventBus, order Order) {
+	bus.Publish(Event{OrderID: order.ID, Kind: EventStarted})
+	clk.Sleep(context.Background(), order.PrepTime)
+	if order.Burnt {
+		bus.Publish(Event{OrderID: order.ID, Kind: EventFailed})
+		return
+	}
+	bus.Publish(Event{OrderID: order.ID, Kind: EventCompleted})
+}
+
+// Chefs publish once; the screen, the stats board and the SMS sender each hear it
+func kitchenEvents() {
+	out.Printf("\n=== 1. ONE PUBLISH, THREE SUBSCRIBERS ===\n\n")
+
+	bus := NewEventBus(16)
+	screen := bus.Subscribe()
+	stats := bus.Subscribe()
+	sms := bus.Subscribe()
+
+	var subscribers sync.WaitGroup
+	subscribers.Add(3)
+
+	// The kitchen screen prints everything
+	go func() {
+		defer subscribers.Done()
+		icons := map[string]string{EventStarted: "🔥", EventCompleted: "✅", EventFailed: "💥"}
+		for e := range screen {
+			out.Printf("📺 Screen: %s order %d %s\n", icons[e.Kind], e.OrderID, e.Kind)
+		}
+	}()
+
+	// The stats board only counts
+	counts := make(map[string]int)
+	go func() {
+		defer subscribers.Done()
+		for e := range stats {
+			counts[e.Kind]++
+		}
+	}()
+
+	// The SMS sender only cares about finished orders, and is slow
+	var texted []int
+	go func() {
+		defer subscribers.Done()
+		for e := range sms {
+			if e.Kind == EventCompleted {
+				clk.Sleep(context.Background(), 20*time.Millisecond) // Talking to the SMS gateway
+				texted = append(texted, e.OrderID)
+			}
+		}
+	}()
+
+	var chefs sync.WaitGroup
+	for id := 1; id <= 6; id++ {
+		chefs.Add(1)
+		go func(o Order) {
+			defer chefs.Done()
+			cook(bus, o)
+		}(Order{ID: id, PrepTime: time.Duration(id*40) * time.Millisecond, Burnt: id == 4})
+	}
+	chefs.Wait()
+	bus.Close()
+	subscribers.Wait()
+
+	out.Printf("\n📊 Stats board: %d started, %d completed, %d failed\n",
+		counts[EventStarted], counts[EventCompleted], counts[EventFailed])
+	out.Printf("📱 SMS sent for orders %v\n", texted)
+	out.Printf("🗑️  Dropped deliveries: %d\n", bus.Dropped())
+}
+
+// A subscriber that never reads fills its buffer, then loses events; the chef doesn't wait
+func slowSubscriber() {
+	out.Printf("\n=== 2. A STUCK SUBSCRIBER DOESN'T STALL THE KITCHEN ===\n\n")
+
+	bus := NewEventBus(5)
+	live := bus.Subscribe()
+	_ = bus.Subscribe() // Never read: a crashed display
+
+	var seen atomic.Int64
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range live {
+			seen.Add(1)
+		}
+	}()
+
+	startTime := clk.Now()
+	for id := 1; id <= 100; id++ {
+		bus.Publish(Event{OrderID: id, Kind: EventStarted})
+		clk.Sleep(context.Background(), time.Millisecond) // Leave the live subscriber time to keep up
+	}
+	bus.Close()
+	<-done
+
+	out.Printf("⚡ 100 events published in %dms\n", clk.Since(startTime).Milliseconds())
+	out.Printf("📺 Live subscriber saw %d\n", seen.Load())
+	out.Printf("🗑️  Stuck subscriber kept 5, dropped %d\n", bus.Dropped())
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Pub/Sub")
+	out.Println("==========================================")
+
+	kitchenEvents()
+	slowSubscriber()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ Give every subscriber its own channel, so each one gets every event")
+	out.Println("✅ Publish with a non-blocking send, so a slow subscriber can't stall workers")
+	out.Println("✅ Count dropped events, so losses are visible")
+	out.Println("✅ Closing the bus closes every subscription, so range loops end")
+	return nil
+}
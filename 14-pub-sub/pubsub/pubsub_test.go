@@ -0,0 +1,106 @@
+package pubsub
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// collect reads a subscription until the bus closes
+func collect(ch <-chan Event) <-chan []string {
+	out := make(chan []string, 1)
+	go func() {
+		var kinds []string
+		for e := range ch {
+			kinds = append(kinds, fmt.Sprintf("%d:%s", e.OrderID, e.Kind))
+		}
+		out <- kinds
+	}()
+	return out
+}
+
+// Two subscribers each see one order's full lifecycle, in order
+func TestEventBusTwoSubscribers(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	saved := clk
+	clk = testutil.FakeClock(t)
+	t.Cleanup(func() { clk = saved })
+
+	tests := []struct {
+		name  string
+		order Order
+		want  []string
+	}{
+		{"completed", Order{ID: 7, PrepTime: 10 * time.Millisecond}, []string{"7:started", "7:completed"}},
+		{"failed", Order{ID: 8, PrepTime: 10 * time.Millisecond, Burnt: true}, []string{"8:started", "8:failed"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := NewEventBus(4)
+			first, second := collect(bus.Subscribe()), collect(bus.Subscribe())
+			cook(bus, tt.order)
+			bus.Close()
+			if a := <-first; !slices.Equal(a, tt.want) {
+				t.Errorf("first subscriber saw %v, want %v", a, tt.want)
+			}
+			if b := <-second; !slices.Equal(b, tt.want) {
+				t.Errorf("second subscriber saw %v, want %v", b, tt.want)
+			}
+			if n := bus.Dropped(); n != 0 {
+				t.Errorf("%d deliveries dropped, want 0", n)
+			}
+		})
+	}
+}
+
+// A full subscriber costs a drop, not a wait
+func TestEventBusFullSubscriberDrops(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	bus := NewEventBus(2)
+	stuck := bus.Subscribe()
+	published := make(chan struct{})
+	go func() {
+		defer close(published)
+		for id := 1; id <= 10; id++ {
+			bus.Publish(Event{OrderID: id, Kind: EventStarted})
+		}
+	}()
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("Publish blocked on a full subscriber")
+	}
+	if n := bus.Dropped(); n != 8 || len(stuck) != 2 {
+		t.Errorf("%d buffered, %d dropped, want 2 buffered, 8 dropped", len(stuck), n)
+	}
+	bus.Close()
+}
+
+// Subscribing after Close gets a closed channel instead of hanging forever
+func TestEventBusSubscribeAfterClose(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	bus := NewEventBus(1)
+	bus.Close()
+	bus.Close()
+	if _, ok := <-bus.Subscribe(); ok {
+		t.Error("Subscribe after Close returned an open channel")
+	}
+	bus.Publish(Event{OrderID: 1, Kind: EventStarted}) // Ignored, not a panic
+}
+
+func TestRun(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	got := testutil.RunLesson(t, Run)
+	for _, want := range []string{
+		"📊 Stats board: 6 started, 5 completed, 1 failed",
+		"🗑️  Stuck subscriber kept 5, dropped 95",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+// Package ratelimit is lesson 15: Rate Limiting.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	Customer string
+}
+
+// ErrRateLimited is returned when ctx ends before a token is available
+var ErrRateLimited = errors.New("rate limited")
+
+// TokenBucket allows bursts of up to capacity calls, then one call per refillRate.
+// Tokens are values in a buffered channel; a background goroutine adds one
+// every refillRate and drops it if the bucket is already full.
+type TokenBucket struct {
+	tokens chan struct{}
+	stop   chan struct{}
+	once   sync.Once
+}
+
+// NewTokenBucket creates a full bucket and starts its refill goroutine.
+// Call Stop when done with it.
+func NewTokenBucket(capacity int, refillRate time.Duration) *TokenBucket {
+	tb := &TokenBucket{
+		tokens: make(chan struct{}, capacity),
+		stop:   make(chan struct{}),
+	}
+	for i := 0; i < capacity; i++ {
+		tb.tokens <- struct{}{}
+	}
+
+	ticker := clk.NewTicker(refillRate) // Started here, so refills are timed from NewTokenBucket
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C():
+				select {
+				case tb.tokens <- struct{}{}:
+				default: // Full - unused capacity doesn't pile up
+				}
+			case <-tb.stop:
+				return
+			}
+		}
+	}()
+	return tb
+}
+
+// Consume takes one token, waiting for a refill if the bucket is empty.
+// It returns ErrRateLimited (wrapping ctx.Err()) if ctx is done first.
+func (tb *TokenBucket) Consume(ctx context.Context) error {
+	// Don't spend a token on a caller that already gave up
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%w: %w", ErrRateLimited, err)
+	}
+
+	select {
+	case <-tb.tokens:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%w: %w", ErrRateLimited, ctx.Err())
+	}
+}
+
+// Stop ends the refill goroutine. It is safe to call more than once.
+func (tb *TokenBucket) Stop() {
+	tb.once.Do(func() { close(tb.stop) })
+}
+
+// TickerLimiter is the simplest limiter: wait for the next tick.
+// It enforces the rate but has no burst allowance.
+type TickerLimiter struct {
+	ticker clock.Ticker
+}
+
+// NewTickerLimiter lets one call through per rate
+func NewTickerLimiter(rate time.Duration) *TickerLimiter {
+	return &TickerLimiter{ticker: clk.NewTicker(rate)}
+}
+
+// Wait blocks until the next tick or until ctx is done
+func (l *TickerLimiter) Wait(ctx context.Context) error {
+	select {
+	case <-l.ticker.C():
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%w: %w", ErrRateLimited, ctx.Err())
+	}
+}
+
+// Stop releases the ticker
+func (l *TickerLimiter) Stop() { l.ticker.Stop() }
+
+// The online ordering API accepts a burst of 5, then one order every 200ms
+func onlineOrderRush() {
+	out.Printf("\n=== 1. BURST OF 8 ORDERS (capacity 5, refill 200ms) ===\n\n")
+
+	bucket := NewTokenBucket(5, 200*time.Millisecond)
+	defer bucket.Stop()
+
+	customers := []string{"Ana", "Ben", "Caro", "Dev", "Eli", "Fay", "Gus", "Hana"}
+	startTime := clk.Now()
+
+	for i, name := range customers {
+		order := Order{ID: i + 1, Customer: name}
+		if err := bucket.Consume(context.Background()); err != nil {
+			out.Printf("❌ Order %d: %v\n", order.ID, err)
+			continue
+		}
+		out.Printf("📥 [+%4dms] Order %d from %s accepted\n", clk.Since(startTime).Milliseconds(), order.ID, order.Customer)
+	}
+}
+
+// The same rush through a ticker: every order waits for a tick
+func tickerRush() {
+	out.Printf("\n=== 2. SAME RUSH THROUGH A TICKER (every 200ms, no burst) ===\n\n")
+
+	limiter := NewTickerLimiter(200 * time.Millisecond)
+	defer limiter.Stop()
+
+	startTime := clk.Now()
+	for id := 1; id <= 8; id++ {
+		limiter.Wait(context.Background())
+		out.Printf("📥 [+%4dms] Order %d accepted\n", clk.Since(startTime).Milliseconds(), id)
+	}
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Rate Limiting")
+	out.Println("==========================================")
+
+	onlineOrderRush()
+	tickerRush()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ A buffered channel of tokens is a token bucket: its capacity is the burst size")
+	out.Println("✅ Refill with a non-blocking send so unused capacity never exceeds the bucket")
+	out.Println("✅ A ticker enforces the rate but makes every caller wait, even after idle time")
+	out.Println("✅ Take a context so callers can give up instead of queueing forever")
+	return nil
+}
@@ -0,0 +1,54 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// BenchmarkLimiter compares the token bucket with the ticker at a 1ms rate
+// on the real clock. Under steady load both run at the rate. A burst of 5
+// after an idle spell passes at once through the bucket, while the ticker
+// still spaces it one period apart.
+func BenchmarkLimiter(b *testing.B) {
+	const rate = time.Millisecond
+	limiters := []struct {
+		name string
+		new  func() (wait func(), stop func())
+	}{
+		{"bucket", func() (func(), func()) {
+			bucket := NewTokenBucket(5, rate)
+			return func() { bucket.Consume(context.Background()) }, bucket.Stop
+		}},
+		{"ticker", func() (func(), func()) {
+			limiter := NewTickerLimiter(rate)
+			return func() { limiter.Wait(context.Background()) }, limiter.Stop
+		}},
+	}
+
+	for _, l := range limiters {
+		b.Run("steady/"+l.name, func(b *testing.B) {
+			wait, stop := l.new()
+			defer stop()
+			for b.Loop() {
+				wait()
+			}
+		})
+	}
+
+	for _, l := range limiters {
+		b.Run("burst/"+l.name, func(b *testing.B) {
+			wait, stop := l.new()
+			defer stop()
+			for b.Loop() {
+				b.StopTimer()
+				time.Sleep(10 * rate) // Long enough to refill
+				b.StartTimer()
+				for range 5 {
+					wait()
+				}
+			}
+			b.ReportMetric(float64(b.Elapsed().Microseconds())/float64(b.N), "µs/burst")
+		})
+	}
+}
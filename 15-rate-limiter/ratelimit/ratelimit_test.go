@@ -0,0 +1,114 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// onFakeClock points the lesson at a self-advancing fake clock until the
+// test ends
+func onFakeClock(t *testing.T) {
+	saved := clk
+	clk = testutil.FakeClock(t)
+	t.Cleanup(func() { clk = saved })
+}
+
+// admitted counts how many of n back-to-back calls get a token within 1ms
+func admitted(bucket *TokenBucket, n int) int {
+	count := 0
+	for range n {
+		ctx, cancel := clk.WithTimeout(context.Background(), time.Millisecond)
+		if bucket.Consume(ctx) == nil {
+			count++
+		}
+		cancel()
+	}
+	return count
+}
+
+// Bursts up to capacity pass at once, and idle time never saves up more
+// than capacity
+func TestTokenBucketBurst(t *testing.T) {
+	tests := []struct {
+		name  string
+		idle  time.Duration // Wait before the burst
+		burst int
+		want  int
+	}{
+		{"burst of 5 on a full bucket", 0, 5, 5},
+		{"burst of 6: the 6th is limited", 0, 6, 5},
+		{"idle 1s (10 refills), burst of 8", 1050 * time.Millisecond, 8, 5}, // Burst lands between ticks
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutil.WaitForGoroutines(t)
+			onFakeClock(t)
+			bucket := NewTokenBucket(5, 100*time.Millisecond)
+			defer bucket.Stop()
+			clk.Sleep(context.Background(), tt.idle)
+			if got := admitted(bucket, tt.burst); got != tt.want {
+				t.Errorf("%d admitted, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// An empty bucket limits a caller that won't wait for the refill, and hands
+// the refilled token to one that does
+func TestTokenBucketEmpty(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	onFakeClock(t)
+	bucket := NewTokenBucket(5, 100*time.Millisecond)
+	defer bucket.Stop()
+	admitted(bucket, 5)
+
+	ctx, cancel := clk.WithTimeout(context.Background(), 50*time.Millisecond)
+	err := bucket.Consume(ctx)
+	cancel()
+	if !errors.Is(err, ErrRateLimited) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Consume = %v, want ErrRateLimited wrapping DeadlineExceeded", err)
+	}
+
+	start := clk.Now()
+	if err := bucket.Consume(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if waited := clk.Since(start); waited != 50*time.Millisecond {
+		t.Errorf("got a token after %v more, want 50ms", waited)
+	}
+}
+
+// A caller that already gave up doesn't spend a token
+func TestTokenBucketCancelledCaller(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	onFakeClock(t)
+	bucket := NewTokenBucket(1, time.Hour)
+	defer bucket.Stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := bucket.Consume(ctx); !errors.Is(err, ErrRateLimited) || !errors.Is(err, context.Canceled) {
+		t.Errorf("Consume = %v, want ErrRateLimited wrapping Canceled", err)
+	}
+	if len(bucket.tokens) != 1 {
+		t.Error("a cancelled caller took the only token")
+	}
+	bucket.Stop() // Safe to call twice
+}
+
+func TestRun(t *testing.T) {
+	got := testutil.RunLesson(t, Run)
+	for _, want := range []string{
+		"📥 [+   0ms] Order 5 from Eli accepted",
+		"📥 [+ 200ms] Order 6 from Fay accepted",
+		"📥 [+1600ms] Order 8 accepted",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// Package cache is lesson 26: Stale-While-Revalidate Cache.
+package cache
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+// entry is a cached order status and when it was fetched
+type entry struct {
+	status    string
+	fetchedAt time.Time
+}
+
+// StaleCache serves order statuses using stale-while-revalidate:
+// Get always answers from the cache immediately, and if the value is older
+// than the TTL it kicks off a background refresh for the next caller.
+type StaleCache struct {
+	mu         sync.RWMutex
+	entries    map[int]entry
+	refreshing map[int]bool // Keys with a refresh already in flight
+	ttl        time.Duration
+	fetch      func(id int) string // Slow source of truth
+
+	refreshes sync.WaitGroup // Tracks background refresh goroutines
+}
+
+// NewStaleCache creates a cache whose entries go stale after ttl
+func NewStaleCache(ttl time.Duration, fetch func(id int) string) *StaleCache {
+	return &StaleCache{
+		entries:    make(map[int]entry),
+		refreshing: make(map[int]bool),
+		ttl:        ttl,
+		fetch:      fetch,
+	}
+}
+
+// Get returns the cached status for id without ever waiting on fetch.
+// A stale or missing entry triggers a background refresh.
+func (c *StaleCache) Get(id int) (string, bool) {
+	c.mu.RLock()
+	e, found := c.entries[id]
+	c.mu.RUnlock()
+
+	if !found || clk.Since(e.fetchedAt) > c.ttl {
+		c.refresh(id)
+	}
+	return e.status, found
+}
+
+// refresh starts a background fetch for id unless one is already running
+func (c *StaleCache) refresh(id int) {
+	c.mu.Lock()
+	if c.refreshing[id] {
+		c.mu.Unlock()
+		return
+	}
+	c.refreshing[id] = true
+	c.mu.Unlock()
+
+	c.refreshes.Add(1)
+	go func() {
+		defer c.refreshes.Done()
+
+		status := c.fetch(id) // Slow call happens outside the lock
+
+		c.mu.Lock()
+		c.entries[id] = entry{status: status, fetchedAt: clk.Now()}
+		delete(c.refreshing, id)
+		c.mu.Unlock()
+	}()
+}
+
+// Wait blocks until all in-flight refreshes have finished
+func (c *StaleCache) Wait() {
+	c.refreshes.Wait()
+}
+
+// KitchenDB simulates the slow order status source (300ms per lookup)
+type KitchenDB struct {
+	lookups   atomic.Int64
+	startedAt time.Time
+}
+
+// Status reports the order's status based on how long it has been cooking
+func (db *KitchenDB) Status(id int) string {
+	db.lookups.Add(1)
+	clk.Sleep(context.Background(), 300*time.Millisecond)
+
+	switch elapsed := clk.Since(db.startedAt); {
+	case elapsed < 500*time.Millisecond:
+		return "received"
+	case elapsed < 1200*time.Millisecond:
+		return "cooking"
+	default:
+		return "ready"
+	}
+}
+
+// Callers get an answer immediately even while the value is being refreshed
+func staleWhileRevalidate() {
+	out.Printf("\n=== 1. STALE-WHILE-REVALIDATE ===\n\n")
+
+	db := &KitchenDB{startedAt: clk.Now()}
+	cache := NewStaleCache(200*time.Millisecond, db.Status)
+
+	// First Get is a miss - it returns nothing but starts a fetch
+	status, found := cache.Get(1)
+	out.Printf("🔍 Get #1: %q (found=%v) - cache miss, refresh started\n", status, found)
+	cache.Wait()
+
+	for i := 2; i <= 6; i++ {
+		clk.Sleep(context.Background(), 350*time.Millisecond) // Let the entry go stale
+
+		start := clk.Now()
+		status, found = cache.Get(1)
+		out.Printf("🔍 Get #%d: %q (found=%v) in %v\n", i, status, found, clk.Since(start))
+	}
+
+	cache.Wait()
+	status, _ = cache.Get(1)
+	out.Printf("\n📦 Latest cached status: %q\n", status)
+	out.Printf("📚 Database lookups: %d\n", db.lookups.Load())
+}
+
+// Many concurrent readers of a stale entry trigger exactly one refresh
+func concurrentReaders() {
+	out.Printf("\n=== 2. CONCURRENT READERS OF A STALE ENTRY ===\n\n")
+
+	db := &KitchenDB{startedAt: clk.Now()}
+	cache := NewStaleCache(100*time.Millisecond, db.Status)
+
+	cache.Get(1)
+	cache.Wait()
+	clk.Sleep(context.Background(), 150*time.Millisecond) // Entry is now stale
+	lookupsBefore := db.lookups.Load()
+
+	var wg sync.WaitGroup
+	var slowest atomic.Int64
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := clk.Now()
+			cache.Get(1)
+			took := int64(clk.Since(start))
+			for cur := slowest.Load(); took > cur && !slowest.CompareAndSwap(cur, took); cur = slowest.Load() {
+			}
+		}()
+	}
+	wg.Wait()
+	cache.Wait()
+
+	out.Printf("👥 100 readers served, slowest Get took %v\n", time.Duration(slowest.Load()))
+	out.Printf("📚 Refreshes triggered: %d\n", db.lookups.Load()-lookupsBefore)
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Stale-While-Revalidate Cache")
+	out.Println("==========================================")
+
+	staleWhileRevalidate()
+	concurrentReaders()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ Serving stale data keeps reads fast while a refresh runs")
+	out.Println("✅ sync.RWMutex lets many readers share the cache concurrently")
+	out.Println("✅ Slow fetches run outside the lock in a background goroutine")
+	out.Println("✅ A 'refreshing' set prevents duplicate refreshes for the same key")
+	return nil
+}
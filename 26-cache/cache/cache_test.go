@@ -0,0 +1,200 @@
+package cache
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// onClock points the lesson at c and its printer at nothing
+func onClock(t *testing.T, c clock.Clock) {
+	savedClk := clk
+	clk, out = c, display.NewPrinter(io.Discard)
+	t.Cleanup(func() {
+		out.Close()
+		clk = savedClk
+	})
+}
+
+// gatedFetch is a fetch whose calls block until the test lets them finish,
+// so a test can look at the cache while a refresh is in flight
+type gatedFetch struct {
+	calls   atomic.Int64
+	status  atomic.Value // string returned by the next call
+	started chan int     // Receives the ID of each call as it starts
+	release chan struct{}
+}
+
+func newGatedFetch(status string) *gatedFetch {
+	f := &gatedFetch{started: make(chan int, 100), release: make(chan struct{})}
+	f.status.Store(status)
+	return f
+}
+
+func (f *gatedFetch) fetch(id int) string {
+	f.calls.Add(1)
+	f.started <- id
+	<-f.release
+	return f.status.Load().(string)
+}
+
+// finish lets one in-flight call return
+func (f *gatedFetch) finish() { f.release <- struct{}{} }
+
+func TestMissStartsRefresh(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	onClock(t, clock.NewFake(testutil.Epoch))
+	f := newGatedFetch("received")
+	c := NewStaleCache(time.Second, f.fetch)
+
+	if status, found := c.Get(1); found || status != "" {
+		t.Errorf("first Get = %q, %v; want a miss", status, found)
+	}
+	<-f.started
+	f.finish()
+	c.Wait()
+
+	if status, found := c.Get(1); !found || status != "received" {
+		t.Errorf("Get after the refresh = %q, %v; want received", status, found)
+	}
+	if n := f.calls.Load(); n != 1 {
+		t.Errorf("%d fetches, want 1", n)
+	}
+}
+
+func TestFreshEntryNotRefetched(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := clock.NewFake(testutil.Epoch)
+	onClock(t, fake)
+	f := newGatedFetch("received")
+	c := NewStaleCache(time.Second, f.fetch)
+	c.Get(1)
+	<-f.started
+	f.finish()
+	c.Wait()
+
+	fake.Advance(time.Second) // Exactly the TTL is still fresh
+	for range 10 {
+		c.Get(1)
+	}
+	c.Wait()
+	if n := f.calls.Load(); n != 1 {
+		t.Errorf("%d fetches within the TTL, want 1", n)
+	}
+}
+
+// Past the TTL, Get still answers at once with the stale value while the
+// refresh runs, and the refresh replaces it
+func TestStaleServedWhileRefreshing(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := clock.NewFake(testutil.Epoch)
+	onClock(t, fake)
+	f := newGatedFetch("received")
+	c := NewStaleCache(time.Second, f.fetch)
+	c.Get(1)
+	<-f.started
+	f.finish()
+	c.Wait()
+
+	fake.Advance(time.Second + time.Millisecond)
+	f.status.Store("cooking")
+	if status, found := c.Get(1); !found || status != "received" {
+		t.Errorf("stale Get = %q, %v; want the stale received", status, found)
+	}
+	<-f.started // The refresh is in flight and blocked
+
+	if status, _ := c.Get(1); status != "received" {
+		t.Errorf("Get during the refresh = %q, want received", status)
+	}
+	f.finish()
+	c.Wait()
+	if status, _ := c.Get(1); status != "cooking" {
+		t.Errorf("Get after the refresh = %q, want cooking", status)
+	}
+
+	// The new value is fresh from when the refresh finished
+	fake.Advance(time.Second)
+	c.Get(1)
+	c.Wait()
+	if n := f.calls.Load(); n != 2 {
+		t.Errorf("%d fetches, want 2", n)
+	}
+}
+
+func TestKeysRefreshIndependently(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	onClock(t, clock.NewFake(testutil.Epoch))
+	f := newGatedFetch("received")
+	c := NewStaleCache(time.Second, f.fetch)
+	c.Get(1)
+	c.Get(2)
+	started := map[int]bool{<-f.started: true, <-f.started: true}
+	if !started[1] || !started[2] {
+		t.Errorf("refreshes started for %v, want 1 and 2", started)
+	}
+	f.finish()
+	f.finish()
+	c.Wait()
+}
+
+// 100 goroutines read one stale entry at once. Run with -race: none of them
+// waits on the fetch, and only one refresh starts.
+func TestConcurrentReadersOneRefresh(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := clock.NewFake(testutil.Epoch)
+	onClock(t, fake)
+	f := newGatedFetch("received")
+	c := NewStaleCache(time.Second, f.fetch)
+	c.Get(1)
+	<-f.started
+	f.finish()
+	c.Wait()
+	fake.Advance(2 * time.Second)
+
+	var wg sync.WaitGroup
+	var stale atomic.Int64
+	for range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if status, found := c.Get(1); found && status == "received" {
+				stale.Add(1)
+			}
+		}()
+	}
+	wg.Wait() // Every Get returned while the fetch is still blocked
+
+	if n := stale.Load(); n != 100 {
+		t.Errorf("%d of 100 readers got the stale value", n)
+	}
+	<-f.started // The refresh goroutine may not have called fetch yet
+	if n := f.calls.Load(); n != 2 {
+		t.Errorf("%d fetches, want the first and one refresh", n)
+	}
+	f.finish()
+	c.Wait()
+	if n := f.calls.Load(); n != 2 {
+		t.Errorf("%d fetches once the refresh finished, want 2", n)
+	}
+}
+
+func TestRun(t *testing.T) {
+	got := testutil.RunLesson(t, Run)
+	for _, want := range []string{
+		`🔍 Get #1: "" (found=false) - cache miss, refresh started`,
+		`📦 Latest cached status: "ready"`,
+		"📚 Refreshes triggered: 1",
+		"slowest Get took 0s",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+// Package dynamicpool is lesson 27: Dynamic Worker Pool.
+package dynamicpool
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+}
+
+// DynamicPool grows and shrinks its number of chefs based on how many
+// orders are waiting. A monitor goroutine checks the queue depth on every
+// tick: above the high-water mark it hires a chef, below the low-water
+// mark it sends one home, always staying within [minWorkers, maxWorkers].
+type DynamicPool struct {
+	queue      chan Order
+	minWorkers int
+	maxWorkers int
+	highWater  int // Queue depth that triggers scaling up
+	lowWater   int // Queue depth that triggers scaling down
+
+	workers atomic.Int32  // Current (target) number of workers
+	nextID  atomic.Int32  // For naming chefs
+	retire  chan struct{} // Each value tells one idle worker to exit
+	stop    chan struct{} // Stops the monitor
+	wg      sync.WaitGroup
+}
+
+// NewDynamicPool creates a pool with room for queueSize waiting orders
+func NewDynamicPool(minWorkers, maxWorkers, highWater, lowWater, queueSize int) *DynamicPool {
+	return &DynamicPool{
+		queue:      make(chan Order, queueSize),
+		minWorkers: minWorkers,
+		maxWorkers: maxWorkers,
+		highWater:  highWater,
+		lowWater:   lowWater,
+		retire:     make(chan struct{}, maxWorkers),
+		stop:       make(chan struct{}),
+	}
+}
+
+// Start launches the minimum number of workers and the queue monitor
+func (p *DynamicPool) Start(checkInterval time.Duration) {
+	for i := 0; i < p.minWorkers; i++ {
+		p.addWorker()
+	}
+
+	p.wg.Add(1)
+	go p.monitor(checkInterval)
+}
+
+// Submit queues an order (blocks if the queue is full)
+func (p *DynamicPool) Submit(order Order) {
+	p.queue <- order
+}
+
+// Shutdown stops intake, lets workers drain the queue, and waits for everyone
+func (p *DynamicPool) Shutdown() {
+	close(p.stop)
+	close(p.queue)
+	p.wg.Wait()
+}
+
+// Workers returns the current number of workers
+func (p *DynamicPool) Workers() int {
+	return int(p.workers.Load())
+}
+
+// addWorker starts one more chef goroutine
+func (p *DynamicPool) addWorker() {
+	p.workers.Add(1)
+	id := p.nextID.Add(1)
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		for {
+			select {
+			case order, ok := <-p.queue:
+				if !ok {
+					return // Queue closed and drained
+				}
+				clk.Sleep(context.Background(), order.PrepTime)
+				out.Printf("✅ Chef %d: Order %d ready\n", id, order.ID)
+			case <-p.retire:
+				out.Printf("👋 Chef %d: Going home (queue is quiet)\n", id)
+				return
+			}
+		}
+	}()
+}
+
+// monitor checks the queue depth every interval and scales the pool
+func (p *DynamicPool) monitor(interval time.Duration) {
+	defer p.wg.Done()
+
+	ticker := clk.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C():
+			depth := len(p.queue)
+			workers := p.Workers()
+
+			switch {
+			case depth > p.highWater && workers < p.maxWorkers:
+				p.addWorker()
+				out.Printf("📈 Queue depth %d > %d: hired a chef (%d → %d)\n", depth, p.highWater, workers, workers+1)
+			case depth < p.lowWater && workers > p.minWorkers:
+				// Count the worker as gone now so the next tick doesn't retire it twice
+				p.workers.Add(-1)
+				p.retire <- struct{}{}
+				out.Printf("📉 Queue depth %d < %d: sending a chef home (%d → %d)\n", depth, p.lowWater, workers, workers-1)
+			default:
+				out.Printf("📊 Queue depth %d, chefs %d\n", depth, workers)
+			}
+		}
+	}
+}
+
+// A lunch rush of 20 orders makes the pool scale up, then back down
+func lunchRush() {
+	out.Printf("\n=== DYNAMIC SCALING DURING A LUNCH RUSH ===\n\n")
+
+	pool := NewDynamicPool(1, 5, 5, 1, 50)
+	pool.Start(1 * time.Second)
+	startTime := clk.Now()
+
+	out.Printf("🔥 Burst: 20 orders arrive at once\n\n")
+	for i := 1; i <= 20; i++ {
+		pool.Submit(Order{ID: i, PrepTime: 800 * time.Millisecond})
+	}
+
+	// Keep the restaurant open long enough to see the pool shrink again
+	clk.Sleep(context.Background(), 10*time.Second)
+	pool.Shutdown()
+
+	out.Printf("\n👩‍🍳 Chefs at closing: %d\n", pool.Workers())
+	out.Printf("⏱️  Total time: %v\n", clk.Since(startTime).Round(time.Millisecond))
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Dynamic Worker Pool")
+	out.Println("==========================================")
+
+	lunchRush()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ len(channel) gives a cheap snapshot of queue depth")
+	out.Println("✅ A ticker-driven monitor can scale workers up and down")
+	out.Println("✅ Atomics track the worker count without extra locks")
+	out.Println("✅ Retiring via a channel lets a worker finish its current order first")
+	out.Println("✅ High/low water marks prevent constant scaling back and forth")
+	return nil
+}
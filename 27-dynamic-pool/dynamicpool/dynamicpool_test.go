@@ -0,0 +1,150 @@
+package dynamicpool
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// onFakeClock points the lesson at a self-advancing fake clock and a
+// printer into the returned buffer. Flush out before reading it.
+func onFakeClock(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	savedClk := clk
+	clk, out = testutil.FakeClock(t), display.NewPrinter(&buf)
+	t.Cleanup(func() {
+		out.Close()
+		clk = savedClk
+	})
+	return &buf
+}
+
+// readyIDs is every order ID the chefs reported ready, sorted
+func readyIDs(t *testing.T, printed string) []int {
+	t.Helper()
+	var ids []int
+	for _, line := range strings.Split(printed, "\n") {
+		var chef, id int
+		if n, _ := fmt.Sscanf(line, "✅ Chef %d: Order %d ready", &chef, &id); n == 2 {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func seq(n int) []int {
+	ids := make([]int, n)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+	return ids
+}
+
+// A burst grows the pool to its maximum and no further; once the queue is
+// quiet it shrinks back to its minimum, and every order is cooked once.
+// The lesson's 20 orders drain before the fifth chef is needed, so this
+// burst is bigger.
+func TestResizeUpAndDown(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	buf := onFakeClock(t)
+	pool := NewDynamicPool(1, 5, 5, 1, 50)
+	pool.Start(time.Second)
+	for id := 1; id <= 40; id++ {
+		pool.Submit(Order{ID: id, PrepTime: 800 * time.Millisecond})
+	}
+
+	peak := 0
+	for range 15 {
+		clk.Sleep(t.Context(), time.Second)
+		peak = max(peak, pool.Workers())
+	}
+	if peak != 5 {
+		t.Errorf("peak of %d chefs, want the maximum of 5", peak)
+	}
+	if n := pool.Workers(); n != 1 {
+		t.Errorf("%d chefs once the queue is quiet, want the minimum of 1", n)
+	}
+	pool.Shutdown()
+	out.Flush()
+
+	printed := buf.String()
+	if hired, home := strings.Count(printed, "hired a chef"), strings.Count(printed, "sending a chef home"); hired != 4 || home != 4 {
+		t.Errorf("hired %d and sent home %d, want 4 and 4", hired, home)
+	}
+	if strings.Contains(printed, "(5 → 6)") {
+		t.Error("hired past the maximum of 5")
+	}
+	if ids := readyIDs(t, printed); !slices.Equal(ids, seq(40)) {
+		t.Errorf("orders ready: %v, want each of 1..40 once", ids)
+	}
+}
+
+// Between the water marks the pool stays the size it is
+func TestNoResizeBetweenWaterMarks(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	buf := onFakeClock(t)
+	pool := NewDynamicPool(2, 5, 10, 0, 50)
+	pool.Start(time.Second)
+	for id := 1; id <= 8; id++ {
+		pool.Submit(Order{ID: id, PrepTime: 3 * time.Second})
+	}
+	for range 4 {
+		clk.Sleep(t.Context(), time.Second)
+		if n := pool.Workers(); n != 2 {
+			t.Fatalf("%d chefs with 6 queued, below the high-water mark of 10", n)
+		}
+	}
+	pool.Shutdown()
+	out.Flush()
+	if ids := readyIDs(t, buf.String()); !slices.Equal(ids, seq(8)) {
+		t.Errorf("orders ready: %v, want each of 1..8 once", ids)
+	}
+}
+
+// Orders submitted from many goroutines while the pool resizes, then a
+// Shutdown with work still queued: nothing is lost. Run with -race.
+func TestNoLostWork(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	buf := onFakeClock(t)
+	pool := NewDynamicPool(1, 4, 3, 1, 10)
+	pool.Start(100 * time.Millisecond)
+
+	var wg sync.WaitGroup
+	for g := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 10 {
+				pool.Submit(Order{ID: g*10 + i + 1, PrepTime: time.Duration(50+i*30) * time.Millisecond})
+				clk.Sleep(t.Context(), time.Duration(g*20)*time.Millisecond)
+			}
+		}()
+	}
+	wg.Wait()
+	pool.Shutdown() // The queue may still hold orders: Shutdown drains it
+	out.Flush()
+
+	if ids := readyIDs(t, buf.String()); !slices.Equal(ids, seq(100)) {
+		t.Errorf("%d orders ready, want each of 1..100 once", len(ids))
+	}
+}
+
+func TestRun(t *testing.T) {
+	got := testutil.RunLesson(t, Run)
+	for _, want := range []string{"hired a chef (1 → 2)", "sending a chef home (2 → 1)", "👩‍🍳 Chefs at closing: 1"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	if ids := readyIDs(t, got); !slices.Equal(ids, seq(20)) {
+		t.Errorf("orders ready: %v, want each of 1..20 once", ids)
+	}
+}
@@ -0,0 +1,266 @@
+// Package scheduler is lesson 28: Scheduled Order Processing.
+package scheduler
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+}
+
+// job is an order waiting for its scheduled time
+type job struct {
+	at    time.Time
+	order Order
+}
+
+// jobHeap is a min-heap of jobs ordered by scheduled time (implements heap.Interface)
+type jobHeap []job
+
+func (h jobHeap) Len() int           { return len(h) }
+func (h jobHeap) Less(i, j int) bool { return h[i].at.Before(h[j].at) }
+func (h jobHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *jobHeap) Push(x any)        { *h = append(*h, x.(job)) }
+func (h *jobHeap) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+// Scheduler dispatches orders at specific future times.
+// Jobs live in a min-heap so Run only ever needs one timer: the one for the earliest job.
+type Scheduler struct {
+	mu       sync.Mutex
+	jobs     jobHeap
+	wake     chan struct{} // Nudges Run when a new job might be the earliest
+	dispatch func(Order)   // Called in its own goroutine when a job is due
+	inFlight sync.WaitGroup
+}
+
+// NewScheduler creates a scheduler that hands due orders to dispatch
+func NewScheduler(dispatch func(Order)) *Scheduler {
+	return &Scheduler{
+		wake:     make(chan struct{}, 1),
+		dispatch: dispatch,
+	}
+}
+
+// Schedule queues order to be dispatched at the given time (safe to call while Run is running)
+func (s *Scheduler) Schedule(at time.Time, order Order) {
+	s.mu.Lock()
+	heap.Push(&s.jobs, job{at: at, order: order})
+	s.mu.Unlock()
+
+	// Non-blocking: one pending wake-up is enough for Run to re-check the heap
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Pending returns the number of jobs not yet dispatched
+func (s *Scheduler) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.jobs.Len()
+}
+
+// Run dispatches jobs as they come due until ctx is cancelled.
+// Jobs still pending at cancellation are not dispatched; jobs already
+// dispatched are waited for before Run returns.
+func (s *Scheduler) Run(ctx context.Context) {
+	defer s.inFlight.Wait()
+
+	timer := clk.NewTimer(time.Hour)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		// Dispatch everything that is due and find the next deadline
+		s.mu.Lock()
+		now := clk.Now()
+		for s.jobs.Len() > 0 && !s.jobs[0].at.After(now) {
+			j := heap.Pop(&s.jobs).(job)
+			s.inFlight.Add(1)
+			go func() {
+				defer s.inFlight.Done()
+				s.dispatch(j.order)
+			}()
+		}
+		var next <-chan time.Time
+		if s.jobs.Len() > 0 {
+			timer.Reset(s.jobs[0].at.Sub(clk.Now()))
+			next = timer.C()
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-next: // Earliest job is due (nil channel when the heap is empty)
+		case <-s.wake:
+			timer.Stop() // A new job arrived - recompute the earliest deadline
+		}
+	}
+}
+
+// CronJob runs a function repeatedly on a fixed interval, like a cron entry
+type CronJob struct {
+	fn   func()
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// Every runs fn every interval on its own goroutine until cancel is called.
+// Runs never overlap: if fn takes longer than interval, missed ticks are
+// dropped. cancel stops the ticker and waits for a running fn to finish; it is
+// safe to call more than once and from several goroutines.
+func Every(interval time.Duration, fn func()) (cancel func()) {
+	j := &CronJob{fn: fn, stop: make(chan struct{}), done: make(chan struct{})}
+	go j.run(interval)
+	return j.cancel
+}
+
+func (j *CronJob) run(interval time.Duration) {
+	defer close(j.done)
+
+	ticker := clk.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C():
+			j.fn()
+		case <-j.stop:
+			return
+		}
+	}
+}
+
+func (j *CronJob) cancel() {
+	j.once.Do(func() { close(j.stop) })
+	<-j.done // fn is not running once done is closed
+}
+
+// Orders placed for specific pickup times start cooking exactly when due
+func scheduledOrders() {
+	out.Printf("\n=== 1. SCHEDULED ORDERS ===\n\n")
+
+	startTime := clk.Now()
+	var mu sync.Mutex
+	scheduledAt := map[int]time.Time{}
+
+	scheduler := NewScheduler(func(order Order) {
+		mu.Lock()
+		drift := clk.Since(scheduledAt[order.ID])
+		mu.Unlock()
+
+		out.Printf("⏰ [+%4dms] Order %d: Started cooking (drift %v)\n",
+			clk.Since(startTime).Milliseconds(), order.ID, drift.Round(100*time.Microsecond))
+		clk.Sleep(context.Background(), order.PrepTime)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		scheduler.Run(ctx)
+		close(done)
+	}()
+
+	// Scheduled out of order on purpose - the heap sorts them out
+	for _, s := range []struct {
+		id    int
+		delay time.Duration
+	}{{1, 600 * time.Millisecond}, {2, 100 * time.Millisecond}, {3, 300 * time.Millisecond}, {4, 100 * time.Millisecond}} {
+		at := startTime.Add(s.delay)
+		mu.Lock()
+		scheduledAt[s.id] = at
+		mu.Unlock()
+		scheduler.Schedule(at, Order{ID: s.id, PrepTime: 50 * time.Millisecond})
+		out.Printf("📅 Order %d scheduled for +%v\n", s.id, s.delay)
+	}
+	out.Println()
+
+	clk.Sleep(context.Background(), 800*time.Millisecond)
+	cancel()
+	<-done
+}
+
+// Cancelling before a job is due means it never fires
+func cancelBeforeFiring() {
+	out.Printf("\n=== 2. CANCELLATION BEFORE FIRING ===\n\n")
+
+	scheduler := NewScheduler(func(order Order) {
+		out.Printf("🔥 Order %d fired\n", order.ID)
+	})
+
+	ctx, cancel := clk.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	scheduler.Schedule(clk.Now().Add(500*time.Millisecond), Order{ID: 99})
+	scheduler.Run(ctx) // Returns when the 100ms context expires
+
+	out.Printf("🛑 Run returned at its 100ms deadline, order 99 still pending (%d job)\n", scheduler.Pending())
+}
+
+// The manager wants a kitchen report every 250ms while orders cook
+func periodicReports() {
+	out.Printf("\n=== 3. PERIODIC KITCHEN REPORTS (CronJob) ===\n\n")
+
+	var cooked atomic.Int64
+	startTime := clk.Now()
+
+	cancel := Every(250*time.Millisecond, func() {
+		out.Printf("📋 [+%4dms] Report: %d orders cooked\n", clk.Since(startTime).Milliseconds(), cooked.Load())
+	})
+
+	for id := 1; id <= 10; id++ {
+		clk.Sleep(context.Background(), 100*time.Millisecond) // One order every 100ms
+		cooked.Add(1)
+	}
+
+	cancel()
+	out.Printf("🛑 [+%4dms] Reports stopped after %d orders\n", clk.Since(startTime).Milliseconds(), cooked.Load())
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Scheduled Order Processing")
+	out.Println("==========================================")
+
+	scheduledOrders()
+	cancelBeforeFiring()
+	periodicReports()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ A min-heap keeps the next due job at the top")
+	out.Println("✅ One reusable timer is enough, no matter how many jobs are queued")
+	out.Println("✅ A buffered wake channel lets Schedule interrupt a long wait")
+	out.Println("✅ Selecting on ctx.Done() makes the run loop cancellable")
+	out.Println("✅ A repeating job's cancel should wait for the run in progress")
+	return nil
+}
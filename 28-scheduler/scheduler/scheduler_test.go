@@ -0,0 +1,197 @@
+package scheduler
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// onManualClock points the lesson at a fake clock that only moves when the
+// test advances it
+func onManualClock(t *testing.T) *clock.FakeClock {
+	saved := clk
+	fake := clock.NewFake(testutil.Epoch)
+	clk = fake
+	t.Cleanup(func() { clk = saved })
+	return fake
+}
+
+// A job scheduled 100ms ahead stays queued through 90ms and is dispatched
+// once the clock reaches 100ms, within the ±10ms the scheduler promises
+func TestSchedulerFiresOnTime(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := onManualClock(t)
+
+	fired := make(chan time.Time, 1)
+	scheduler := NewScheduler(func(Order) { fired <- clk.Now() })
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		scheduler.Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	scheduler.Schedule(clk.Now().Add(100*time.Millisecond), Order{ID: 1})
+	fake.BlockUntil(1) // Run has armed its timer for the job
+
+	for elapsed := 10 * time.Millisecond; elapsed < 100*time.Millisecond; elapsed += 10 * time.Millisecond {
+		fake.Advance(10 * time.Millisecond)
+		select {
+		case at := <-fired:
+			t.Fatalf("job fired at %v, want 100ms", at.Sub(testutil.Epoch))
+		default:
+		}
+	}
+	fake.Advance(10 * time.Millisecond)
+	select {
+	case at := <-fired:
+		if drift := at.Sub(testutil.Epoch) - 100*time.Millisecond; drift < -10*time.Millisecond || drift > 10*time.Millisecond {
+			t.Errorf("job fired %v off its 100ms slot, want within ±10ms", drift)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("job never fired at 100ms")
+	}
+	if n := scheduler.Pending(); n != 0 {
+		t.Errorf("%d jobs pending after the only one fired", n)
+	}
+}
+
+// Cancelling Run before a job is due: Run returns, the job never fires and
+// stays pending, and no timer is left armed
+func TestSchedulerCancelBeforeFiring(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := onManualClock(t)
+
+	fired := make(chan Order, 1)
+	scheduler := NewScheduler(func(order Order) { fired <- order })
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		scheduler.Run(ctx)
+		close(done)
+	}()
+
+	scheduler.Schedule(clk.Now().Add(500*time.Millisecond), Order{ID: 99})
+	fake.BlockUntil(1)
+	fake.Advance(100 * time.Millisecond)
+	cancel()
+	<-done
+
+	fake.Advance(time.Second) // Well past the job's slot
+	if len(fired) != 0 {
+		t.Errorf("order %d fired after Run was cancelled", (<-fired).ID)
+	}
+	if n := scheduler.Pending(); n != 1 {
+		t.Errorf("%d jobs pending, want the cancelled one", n)
+	}
+	if n := fake.Waiters(); n != 0 {
+		t.Errorf("%d timer(s) still armed after Run returned", n)
+	}
+}
+
+// Every 100ms over a 1.05s window: one run at each tick from 100ms to
+// 1000ms, and none once cancel has returned
+func TestEveryRunCount(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := onManualClock(t)
+
+	ran := make(chan time.Time, 20)
+	cancel := Every(100*time.Millisecond, func() { ran <- clk.Now() })
+	fake.BlockUntil(1) // The ticker is armed
+
+	runs := 0
+	for elapsed := 10 * time.Millisecond; elapsed <= 1050*time.Millisecond; elapsed += 10 * time.Millisecond {
+		fake.Advance(10 * time.Millisecond)
+		if elapsed%(100*time.Millisecond) != 0 {
+			continue
+		}
+		select {
+		case at := <-ran:
+			if got := at.Sub(testutil.Epoch); got != elapsed {
+				t.Errorf("run %d at %v, want %v", runs+1, got, elapsed)
+			}
+			runs++
+		case <-time.After(time.Second):
+			t.Fatalf("no run at the %v tick", elapsed)
+		}
+	}
+	cancel()
+	if runs != 10 || len(ran) != 0 {
+		t.Errorf("%d runs plus %d unexpected, want 10 in a 1s window", runs, len(ran))
+	}
+
+	fake.Advance(250 * time.Millisecond)
+	if len(ran) != 0 {
+		t.Errorf("%d run(s) after cancel", len(ran))
+	}
+	if n := fake.Waiters(); n != 0 {
+		t.Errorf("%d ticker(s) still armed after cancel", n)
+	}
+}
+
+// Five goroutines cancel while fn is mid-run: none returns before fn does,
+// and each reads fn's plain bool with no race
+func TestEveryConcurrentCancelWaitsForRun(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := onManualClock(t)
+
+	started, release := make(chan struct{}), make(chan struct{})
+	finished := false // Plain bool: cancel's wait is the only synchronization
+	cancel := Every(10*time.Millisecond, func() {
+		close(started) // A second run would panic here
+		<-release      // A slow report
+		finished = true
+	})
+	fake.BlockUntil(1)
+	fake.Advance(10 * time.Millisecond)
+	<-started
+
+	var returned atomic.Int32
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cancel()
+			if !finished {
+				t.Error("cancel returned while fn was still running")
+			}
+			returned.Add(1)
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond) // Real time for any cancel that doesn't wait to return
+	if n := returned.Load(); n != 0 {
+		t.Errorf("%d cancel(s) returned before fn finished", n)
+	}
+	close(release)
+	wg.Wait()
+	cancel() // Once more after the job has stopped
+	if n := fake.Waiters(); n != 0 {
+		t.Errorf("%d ticker(s) still armed after cancel", n)
+	}
+}
+
+func TestRun(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	got := testutil.RunLesson(t, Run)
+	for _, want := range []string{
+		"🛑 Run returned at its 100ms deadline, order 99 still pending (1 job)",
+		"📋 [+ 250ms] Report: 2 orders cooked",
+		"🛑 [+1000ms] Reports stopped after 10 orders",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// Package aggregation is lesson 29: Result Aggregation.
+package aggregation
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+}
+
+// OrderResult is what a chef reports after cooking an order
+type OrderResult struct {
+	OrderID  int
+	ChefID   int
+	PrepTime time.Duration
+}
+
+// Stats summarises the prep times of a batch of orders
+type Stats struct {
+	Count int
+	Min   time.Duration
+	Max   time.Duration
+	Mean  time.Duration
+	P95   time.Duration
+}
+
+// aggregateResults consumes results until the channel is closed and returns
+// summary statistics. It runs in the caller's goroutine and is the only
+// reader, so its running totals need no locks. Arrival order doesn't matter:
+// min, max and sum are order-independent, and P95 is computed from a sorted copy.
+func aggregateResults(results <-chan OrderResult) Stats {
+	var stats Stats
+	var sum time.Duration
+	var samples []time.Duration
+
+	for r := range results {
+		if stats.Count == 0 || r.PrepTime < stats.Min {
+			stats.Min = r.PrepTime
+		}
+		if r.PrepTime > stats.Max {
+			stats.Max = r.PrepTime
+		}
+		sum += r.PrepTime
+		samples = append(samples, r.PrepTime)
+		stats.Count++
+	}
+
+	if stats.Count == 0 {
+		return stats
+	}
+
+	stats.Mean = sum / time.Duration(stats.Count)
+
+	// Nearest-rank 95th percentile
+	slices.Sort(samples)
+	rank := int(math.Ceil(0.95 * float64(len(samples))))
+	stats.P95 = samples[rank-1]
+
+	return stats
+}
+
+// runKitchen cooks orders with a pool of chefs and streams results as they finish
+func runKitchen(orders []Order, chefs int) <-chan OrderResult {
+	queue := make(chan Order)
+	results := make(chan OrderResult)
+	var wg sync.WaitGroup
+
+	for id := 1; id <= chefs; id++ {
+		wg.Add(1)
+		go func(chefID int) {
+			defer wg.Done()
+			for order := range queue {
+				clk.Sleep(context.Background(), order.PrepTime)
+				results <- OrderResult{OrderID: order.ID, ChefID: chefID, PrepTime: order.PrepTime}
+			}
+		}(id)
+	}
+
+	go func() {
+		for _, order := range orders {
+			queue <- order
+		}
+		close(queue)
+	}()
+
+	// Close results once every chef is done so aggregateResults can finish
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
+
+// Results arrive out of order from the pool, but the stats come out right
+func aggregateFromPool() {
+	out.Printf("\n=== 1. AGGREGATING RESULTS FROM A WORKER POOL ===\n\n")
+
+	rng := rand.New(rand.NewSource(7))
+	orders := make([]Order, 40)
+	for i := range orders {
+		orders[i] = Order{ID: i + 1, PrepTime: time.Duration(10+rng.Intn(90)) * time.Millisecond}
+	}
+
+	// Tap the stream to show the arrival order before aggregating
+	var arrival []int
+	tapped := make(chan OrderResult)
+	go func() {
+		defer close(tapped)
+		for r := range runKitchen(orders, 5) {
+			arrival = append(arrival, r.OrderID)
+			tapped <- r
+		}
+	}()
+
+	stats := aggregateResults(tapped)
+
+	out.Printf("📥 Arrival order (first 12): %v\n\n", arrival[:12])
+	out.Printf("📊 Orders: %d\n", stats.Count)
+	out.Printf("   Min:  %v\n", stats.Min)
+	out.Printf("   Max:  %v\n", stats.Max)
+	out.Printf("   Mean: %v\n", stats.Mean)
+	out.Printf("   P95:  %v\n", stats.P95)
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Result Aggregation")
+	out.Println("==========================================")
+
+	aggregateFromPool()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ One goroutine consuming a channel can aggregate without locks")
+	out.Println("✅ Close the results channel after all producers finish, so the consumer knows when to stop")
+	out.Println("✅ Min, max and mean don't care about arrival order")
+	out.Println("✅ Percentiles need the samples sorted, not the arrival order")
+	return nil
+}
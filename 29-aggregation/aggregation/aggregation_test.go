@@ -0,0 +1,105 @@
+package aggregation
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// feed streams orders as results in their original order
+func feed(orders []Order) <-chan OrderResult {
+	ch := make(chan OrderResult, len(orders))
+	for _, o := range orders {
+		ch <- OrderResult{OrderID: o.ID, PrepTime: o.PrepTime}
+	}
+	close(ch)
+	return ch
+}
+
+// ms builds one order per prep time, in milliseconds
+func ms(values ...int) []Order {
+	orders := make([]Order, len(values))
+	for i, v := range values {
+		orders[i] = Order{ID: i + 1, PrepTime: time.Duration(v) * time.Millisecond}
+	}
+	return orders
+}
+
+func TestAggregateResults(t *testing.T) {
+	hundred := make([]int, 100)
+	for i := range hundred {
+		hundred[len(hundred)-1-i] = i + 1 // 100, 99, ..., 1 - reversed on purpose
+	}
+
+	tests := []struct {
+		name   string
+		orders []Order
+		want   Stats
+	}{
+		{"empty", nil, Stats{}},
+		{"single", ms(40), Stats{Count: 1, Min: 40 * time.Millisecond, Max: 40 * time.Millisecond, Mean: 40 * time.Millisecond, P95: 40 * time.Millisecond}},
+		{"min/max out of order", ms(30, 10, 50, 20), Stats{Count: 4, Min: 10 * time.Millisecond, Max: 50 * time.Millisecond, Mean: 27500 * time.Microsecond, P95: 50 * time.Millisecond}},
+		{"mean", ms(10, 20, 30), Stats{Count: 3, Min: 10 * time.Millisecond, Max: 30 * time.Millisecond, Mean: 20 * time.Millisecond, P95: 30 * time.Millisecond}},
+		{"p95 of 1..100 reversed", ms(hundred...), Stats{Count: 100, Min: time.Millisecond, Max: 100 * time.Millisecond, Mean: 50500 * time.Microsecond, P95: 95 * time.Millisecond}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := aggregateResults(feed(tt.orders)); got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Five chefs finish 40 orders out of order: the stats still match the
+// sequential calculation over the input
+func TestAggregateFromPool(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	saved := clk
+	clk = testutil.FakeClock(t)
+	t.Cleanup(func() { clk = saved })
+
+	rng := rand.New(rand.NewSource(7))
+	orders := make([]Order, 40)
+	for i := range orders {
+		orders[i] = Order{ID: i + 1, PrepTime: time.Duration(10+rng.Intn(90)) * time.Millisecond}
+	}
+
+	var arrival []int
+	tapped := make(chan OrderResult)
+	go func() {
+		defer close(tapped)
+		for r := range runKitchen(orders, 5) {
+			arrival = append(arrival, r.OrderID)
+			tapped <- r
+		}
+	}()
+	got := aggregateResults(tapped)
+
+	if want := aggregateResults(feed(orders)); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	inOrder := true
+	for i, id := range arrival {
+		inOrder = inOrder && id == i+1
+	}
+	if inOrder {
+		t.Errorf("results arrived in submission order %v; the test needs them shuffled", arrival)
+	}
+}
+
+func TestRun(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	got := testutil.RunLesson(t, Run)
+	for _, want := range []string{
+		"📊 Orders: 40",
+		"   P95:  92ms",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+// Package statemachine is lesson 30: State Machines.
+package statemachine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+// State is where an order is in its lifecycle
+type State int
+
+const (
+	Pending State = iota
+	InProgress
+	Ready
+	Cancelled
+)
+
+func (s State) String() string {
+	switch s {
+	case Pending:
+		return "Pending"
+	case InProgress:
+		return "InProgress"
+	case Ready:
+		return "Ready"
+	case Cancelled:
+		return "Cancelled"
+	default:
+		return fmt.Sprintf("State(%d)", int(s))
+	}
+}
+
+// ErrIllegalTransition is returned when a transition isn't allowed from the current state
+var ErrIllegalTransition = errors.New("illegal transition")
+
+// transitions lists the states reachable from each state.
+// Ready and Cancelled are terminal.
+var transitions = map[State][]State{
+	Pending:    {InProgress, Cancelled},
+	InProgress: {Ready, Cancelled},
+}
+
+// OrderStateMachine tracks one order's state. The mutex makes "check the
+// current state, then change it" a single step, so two goroutines can't
+// both see InProgress and move it to Ready and Cancelled.
+type OrderStateMachine struct {
+	mu      sync.Mutex
+	orderID int
+	state   State
+	history []State // Every state the order has been in, oldest first
+}
+
+// NewOrderStateMachine creates a machine for orderID in the Pending state
+func NewOrderStateMachine(orderID int) *OrderStateMachine {
+	return &OrderStateMachine{orderID: orderID, state: Pending, history: []State{Pending}}
+}
+
+// Transition moves the order to the given state if that is allowed from the
+// current state, and returns an error wrapping ErrIllegalTransition otherwise
+func (m *OrderStateMachine) Transition(to State) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, allowed := range transitions[m.state] {
+		if allowed == to {
+			m.state = to
+			m.history = append(m.history, to)
+			return nil
+		}
+	}
+	return fmt.Errorf("order %d: %w %v → %v", m.orderID, ErrIllegalTransition, m.state, to)
+}
+
+// State returns the current state
+func (m *OrderStateMachine) State() State {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state
+}
+
+// History returns a copy of the states the order has been through
+func (m *OrderStateMachine) History() []State {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]State(nil), m.history...)
+}
+
+// The happy path: Pending → InProgress → Ready
+func happyPath() {
+	out.Printf("\n=== 1. NORMAL LIFECYCLE ===\n\n")
+
+	order := NewOrderStateMachine(1)
+	for _, to := range []State{InProgress, Ready} {
+		from := order.State()
+		if err := order.Transition(to); err != nil {
+			out.Printf("❌ %v\n", err)
+			continue
+		}
+		out.Printf("➡️  Order 1: %v → %v\n", from, to)
+	}
+	out.Printf("📜 History: %v\n", order.History())
+}
+
+// Each state allows only the moves listed in transitions
+func illegalTransitions() {
+	out.Printf("\n=== 2. TRANSITION RULES ===\n\n")
+
+	tries := []struct {
+		path []State // Transitions applied before the one shown
+		to   State
+	}{
+		{nil, InProgress},
+		{nil, Ready}, // Can't skip cooking
+		{[]State{InProgress}, Pending},
+		{[]State{InProgress, Ready}, Cancelled},
+		{[]State{Cancelled}, InProgress},
+	}
+
+	for i, try := range tries {
+		order := NewOrderStateMachine(i + 1)
+		for _, s := range try.path {
+			order.Transition(s)
+		}
+		from := order.State()
+
+		if err := order.Transition(try.to); err != nil {
+			out.Printf("🚫 %-10v → %-10v rejected: %v\n", from, try.to, err)
+		} else {
+			out.Printf("➡️  %-10v → %-10v allowed\n", from, try.to)
+		}
+	}
+}
+
+// Chefs and the customer race on the same order; exactly one outcome wins
+func racingTransitions() {
+	out.Printf("\n=== 3. RACING GOROUTINES ===\n\n")
+
+	const rounds = 1000
+	outcomes := map[State]int{}
+
+	for round := 0; round < rounds; round++ {
+		order := NewOrderStateMachine(round)
+		order.Transition(InProgress)
+
+		var wg sync.WaitGroup
+
+		// 5 chefs try to mark it Ready while 5 customer retries try to cancel it
+		for i := 0; i < 5; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				order.Transition(Ready)
+			}()
+			go func() {
+				defer wg.Done()
+				order.Transition(Cancelled)
+			}()
+		}
+		wg.Wait()
+
+		outcomes[order.State()]++
+	}
+
+	out.Printf("🏁 %d rounds, 10 goroutines each\n", rounds)
+	out.Printf("   Ready won:     %d\n", outcomes[Ready])
+	out.Printf("   Cancelled won: %d\n", outcomes[Cancelled])
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: State Machines")
+	out.Println("==========================================")
+
+	happyPath()
+	illegalTransitions()
+	racingTransitions()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ A transition table makes the legal moves explicit")
+	out.Println("✅ Check-and-set under one lock keeps the state consistent")
+	out.Println("✅ Terminal states reject every further transition")
+	out.Println("✅ Wrapped sentinel errors let callers use errors.Is")
+	return nil
+}
@@ -0,0 +1,118 @@
+package statemachine
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+func TestTransition(t *testing.T) {
+	tests := []struct {
+		path    []State // Transitions applied before the one under test
+		to      State
+		allowed bool
+	}{
+		{nil, InProgress, true},
+		{nil, Cancelled, true},
+		{nil, Ready, false}, // Can't skip cooking
+		{nil, Pending, false},
+		{[]State{InProgress}, Ready, true},
+		{[]State{InProgress}, Cancelled, true},
+		{[]State{InProgress}, Pending, false},
+		{[]State{InProgress, Ready}, Cancelled, false},
+		{[]State{InProgress, Ready}, InProgress, false},
+		{[]State{Cancelled}, InProgress, false},
+		{[]State{Cancelled}, Ready, false},
+	}
+	for _, tt := range tests {
+		from := Pending
+		if len(tt.path) > 0 {
+			from = tt.path[len(tt.path)-1]
+		}
+		t.Run(fmt.Sprintf("%v→%v", from, tt.to), func(t *testing.T) {
+			order := NewOrderStateMachine(1)
+			for _, s := range tt.path {
+				if err := order.Transition(s); err != nil {
+					t.Fatalf("setting up %v: %v", tt.path, err)
+				}
+			}
+
+			err := order.Transition(tt.to)
+			if tt.allowed {
+				if err != nil {
+					t.Fatalf("Transition(%v) = %v, want allowed", tt.to, err)
+				}
+				if order.State() != tt.to {
+					t.Errorf("state %v after Transition(%v)", order.State(), tt.to)
+				}
+				return
+			}
+			if !errors.Is(err, ErrIllegalTransition) {
+				t.Fatalf("Transition(%v) = %v, want ErrIllegalTransition", tt.to, err)
+			}
+			if order.State() != from {
+				t.Errorf("state %v after a rejected transition, want %v", order.State(), from)
+			}
+			if want := append([]State{Pending}, tt.path...); !slices.Equal(order.History(), want) {
+				t.Errorf("history %v after a rejected transition, want %v", order.History(), want)
+			}
+		})
+	}
+}
+
+// Five goroutines mark the order Ready while five cancel it: in every round
+// exactly one wins, and the history ends in the state the order is in
+func TestRacingTransitions(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	for round := range 200 {
+		order := NewOrderStateMachine(round)
+		if err := order.Transition(InProgress); err != nil {
+			t.Fatal(err)
+		}
+
+		var wins atomic.Int64
+		var wg sync.WaitGroup
+		for range 5 {
+			for _, to := range []State{Ready, Cancelled} {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					err := order.Transition(to)
+					switch {
+					case err == nil:
+						wins.Add(1)
+					case !errors.Is(err, ErrIllegalTransition):
+						t.Errorf("Transition(%v) = %v, want ErrIllegalTransition", to, err)
+					}
+				}()
+			}
+		}
+		wg.Wait()
+
+		history := order.History()
+		if wins.Load() != 1 || len(history) != 3 || history[2] != order.State() {
+			t.Fatalf("round %d: %d winning transitions, history %v, state %v; want 1 win ending in the current state",
+				round, wins.Load(), history, order.State())
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	got := testutil.RunLesson(t, Run)
+	for _, want := range []string{
+		"📜 History: [Pending InProgress Ready]",
+		"🚫 Cancelled  → InProgress rejected: order 5: illegal transition Cancelled → InProgress",
+		"🏁 1000 rounds, 10 goroutines each",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+}
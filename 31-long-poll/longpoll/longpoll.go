@@ -0,0 +1,255 @@
+// Package longpoll is lesson 31: Long Polling.
+package longpoll
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+// State is where an order is in its lifecycle
+type State int
+
+const (
+	Pending State = iota
+	InProgress
+	Ready
+	Cancelled
+)
+
+func (s State) String() string {
+	switch s {
+	case Pending:
+		return "Pending"
+	case InProgress:
+		return "InProgress"
+	case Ready:
+		return "Ready"
+	case Cancelled:
+		return "Cancelled"
+	default:
+		return fmt.Sprintf("State(%d)", int(s))
+	}
+}
+
+// Terminal reports whether no further transitions can happen
+func (s State) Terminal() bool {
+	return s == Ready || s == Cancelled
+}
+
+// maxTransitions is how many states an order can move through after Pending.
+// Buffering that many lets a watcher finish even if nobody reads its channel.
+const maxTransitions = 3
+
+// LongPollWatcher lets any number of goroutines block until an order's state
+// changes. Each order keeps its full history so a slow watcher never misses a
+// transition; a single sync.Cond wakes every waiting watcher on each update.
+type LongPollWatcher struct {
+	mu      sync.Mutex
+	changed *sync.Cond
+	history map[int][]State // Order ID → every state it has been in, oldest first
+}
+
+// NewLongPollWatcher creates an empty watcher
+func NewLongPollWatcher() *LongPollWatcher {
+	w := &LongPollWatcher{history: make(map[int][]State)}
+	w.changed = sync.NewCond(&w.mu)
+	return w
+}
+
+// Update records a new state for orderID and wakes every watcher
+func (w *LongPollWatcher) Update(orderID int, state State) {
+	w.mu.Lock()
+	w.history[orderID] = append(w.history[orderID], state)
+	w.mu.Unlock()
+
+	w.changed.Broadcast()
+}
+
+// Watch returns a channel that receives each state transition of orderID
+// after the moment Watch is called. The channel is closed once the order
+// reaches a terminal state (immediately, if it already has).
+func (w *LongPollWatcher) Watch(orderID int) <-chan State {
+	return w.WatchContext(context.Background(), orderID)
+}
+
+// WatchContext is Watch with a way to give up: once ctx is done the channel
+// is closed, whether or not the order has finished. A long poll with a
+// timeout is WatchContext with a context from clock.WithTimeout.
+func (w *LongPollWatcher) WatchContext(ctx context.Context, orderID int) <-chan State {
+	out := make(chan State, maxTransitions)
+
+	w.mu.Lock()
+	h := w.history[orderID]
+	seen := len(h) // Only report what happens from now on
+	finished := seen > 0 && h[seen-1].Terminal()
+	w.mu.Unlock()
+
+	if finished {
+		close(out)
+		return out
+	}
+
+	go func() {
+		defer close(out)
+
+		// A Cond can't wait on a channel, so wake everyone when ctx is done.
+		// Taking the lock first means a watcher between its check and Wait
+		// can't miss the wake-up.
+		stop := context.AfterFunc(ctx, func() {
+			w.mu.Lock()
+			defer w.mu.Unlock()
+			w.changed.Broadcast()
+		})
+		defer stop()
+
+		for {
+			// The long poll: sleep until an update adds to this order's history
+			w.mu.Lock()
+			for len(w.history[orderID]) == seen && ctx.Err() == nil {
+				w.changed.Wait()
+			}
+			fresh := append([]State(nil), w.history[orderID][seen:]...)
+			seen += len(fresh)
+			w.mu.Unlock()
+
+			if len(fresh) == 0 {
+				return // Gave up: ctx is done and nothing new arrived
+			}
+
+			// Send without the lock so a slow reader only delays its own watcher
+			for _, state := range fresh {
+				out <- state
+				if state.Terminal() {
+					return
+				}
+			}
+		}
+	}()
+
+	return out
+}
+
+// Five customers watch the same order; all of them see every transition
+func fiveWatchers() {
+	out.Printf("\n=== 1. FIVE WATCHERS, ONE ORDER ===\n\n")
+
+	watcher := NewLongPollWatcher()
+	watcher.Update(1, Pending)
+	startTime := clk.Now()
+
+	var wg sync.WaitGroup
+	for customer := 1; customer <= 5; customer++ {
+		wg.Add(1)
+		go func(customer int, updates <-chan State) {
+			defer wg.Done()
+			for state := range updates {
+				out.Printf("📱 [+%3dms] Customer %d: order 1 is %v\n", clk.Since(startTime).Milliseconds(), customer, state)
+			}
+			out.Printf("🔕 Customer %d: stopped watching (terminal state)\n", customer)
+		}(customer, watcher.Watch(1))
+	}
+
+	clk.Sleep(context.Background(), 100*time.Millisecond)
+	watcher.Update(1, InProgress)
+	clk.Sleep(context.Background(), 200*time.Millisecond)
+	watcher.Update(1, Ready)
+
+	wg.Wait()
+}
+
+// A watcher that subscribes late only sees what happens after it starts
+func lateAndFinishedWatchers() {
+	out.Printf("\n=== 2. LATE WATCHERS AND FINISHED ORDERS ===\n\n")
+
+	watcher := NewLongPollWatcher()
+	watcher.Update(2, Pending)
+	watcher.Update(2, InProgress)
+
+	late := watcher.Watch(2) // Subscribes after InProgress
+	watcher.Update(2, Cancelled)
+
+	for state := range late {
+		out.Printf("📱 Late watcher: order 2 is %v\n", state)
+	}
+
+	// Order 2 is already terminal, so a new watch closes straight away
+	start := clk.Now()
+	count := 0
+	for range watcher.Watch(2) {
+		count++
+	}
+	out.Printf("🔕 Watching a cancelled order: %d updates, closed after %v\n", count, clk.Since(start).Round(time.Millisecond))
+}
+
+// Two quick updates between wake-ups: the history means nothing is missed
+func burstOfUpdates() {
+	out.Printf("\n=== 3. NO MISSED TRANSITIONS ===\n\n")
+
+	watcher := NewLongPollWatcher()
+	watcher.Update(3, Pending)
+	updates := watcher.Watch(3)
+
+	// Both updates may land before the watcher goroutine even wakes up
+	watcher.Update(3, InProgress)
+	watcher.Update(3, Ready)
+
+	var got []State
+	for state := range updates {
+		got = append(got, state)
+	}
+	out.Printf("📋 Watcher saw: %v\n", got)
+}
+
+// A customer who won't wait forever: the long poll gives up after a timeout
+func watchWithTimeout() {
+	out.Printf("\n=== 4. GIVING UP AFTER A TIMEOUT ===\n\n")
+
+	watcher := NewLongPollWatcher()
+	watcher.Update(4, Pending)
+	ctx, cancel := clk.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	start := clk.Now()
+	count := 0
+	for range watcher.WatchContext(ctx, 4) { // Nobody ever updates order 4
+		count++
+	}
+	out.Printf("⏰ Customer gave up on order 4 after %v: %d updates\n", clk.Since(start).Round(time.Millisecond), count)
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Long Polling")
+	out.Println("==========================================")
+
+	fiveWatchers()
+	lateAndFinishedWatchers()
+	burstOfUpdates()
+	watchWithTimeout()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ sync.Cond lets many goroutines sleep until state changes")
+	out.Println("✅ Broadcast wakes every waiter; each re-checks its own condition")
+	out.Println("✅ Wait in a for loop - a wake-up is a hint, not a guarantee")
+	out.Println("✅ Keeping history means slow watchers never miss a transition")
+	out.Println("✅ Closing the channel tells watchers there is nothing more to wait for")
+	out.Println("✅ context.AfterFunc + Broadcast lets a Cond wait give up on a timeout")
+	return nil
+}
@@ -0,0 +1,183 @@
+package longpoll
+
+import (
+	"context"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// onFakeClock points the lesson at a self-advancing fake clock and a
+// printer that discards
+func onFakeClock(t *testing.T) {
+	savedClk := clk
+	clk, out = testutil.FakeClock(t), display.NewPrinter(io.Discard)
+	t.Cleanup(func() {
+		out.Close()
+		clk = savedClk
+	})
+}
+
+func collect(updates <-chan State) []State {
+	var got []State
+	for state := range updates {
+		got = append(got, state)
+	}
+	return got
+}
+
+// One Update wakes every watcher blocked on the order, and each sees every
+// transition in order
+func TestUpdateWakesEveryWatcher(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	w := NewLongPollWatcher()
+	w.Update(1, Pending)
+
+	var wg sync.WaitGroup
+	seen := make([][]State, 5)
+	for i := range seen {
+		updates := w.Watch(1)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			seen[i] = collect(updates)
+		}()
+	}
+	w.Update(1, InProgress)
+	w.Update(2, Ready) // Another order's update wakes them too, but isn't theirs
+	w.Update(1, Ready)
+	wg.Wait()
+
+	for i, got := range seen {
+		if want := []State{InProgress, Ready}; !slices.Equal(got, want) {
+			t.Errorf("watcher %d saw %v, want %v", i+1, got, want)
+		}
+	}
+}
+
+// A watcher only sees what happens after Watch, and a finished order's
+// watch closes at once
+func TestWatchFromNow(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	w := NewLongPollWatcher()
+	w.Update(2, Pending)
+	w.Update(2, InProgress)
+	late := w.Watch(2)
+	w.Update(2, Cancelled)
+	if got := collect(late); !slices.Equal(got, []State{Cancelled}) {
+		t.Errorf("late watcher saw %v, want [Cancelled]", got)
+	}
+	if got := collect(w.Watch(2)); len(got) != 0 {
+		t.Errorf("watching a cancelled order gave %v, want a closed channel", got)
+	}
+}
+
+// Nothing happens to the order: the watch stays open until exactly its
+// timeout, then closes with nothing delivered
+func TestWatchTimeout(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	onFakeClock(t)
+	w := NewLongPollWatcher()
+	w.Update(4, Pending)
+
+	ctx, cancel := clk.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	start := clk.Now()
+	if got := collect(w.WatchContext(ctx, 4)); len(got) != 0 {
+		t.Errorf("got %v with no updates", got)
+	}
+	if waited := clk.Since(start); waited != 500*time.Millisecond {
+		t.Errorf("closed after %v, want the 500ms timeout", waited)
+	}
+}
+
+// An update before the timeout is delivered; the timeout still ends a
+// watch on an order that hasn't finished
+func TestWatchUpdateThenTimeout(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	onFakeClock(t)
+	w := NewLongPollWatcher()
+	w.Update(5, Pending)
+
+	ctx, cancel := clk.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	updates := w.WatchContext(ctx, 5)
+	start := clk.Now()
+	clk.AfterFunc(300*time.Millisecond, func() { w.Update(5, InProgress) })
+
+	if got := collect(updates); !slices.Equal(got, []State{InProgress}) {
+		t.Errorf("saw %v, want [InProgress]", got)
+	}
+	if waited := clk.Since(start); waited != time.Second {
+		t.Errorf("closed after %v, want the 1s timeout", waited)
+	}
+}
+
+func TestWatchCancelledContext(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	w := NewLongPollWatcher()
+	w.Update(6, Pending)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if got := collect(w.WatchContext(ctx, 6)); len(got) != 0 {
+		t.Errorf("got %v from a cancelled watch", got)
+	}
+}
+
+// Many updaters and watchers at once: every watcher sees the whole history
+// after its Watch, in order. Run with -race.
+func TestConcurrentWatchers(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	w := NewLongPollWatcher()
+	const orders = 20
+	for id := range orders {
+		w.Update(id, Pending)
+	}
+
+	var wg sync.WaitGroup
+	for id := range orders {
+		for range 10 {
+			updates := w.Watch(id)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if got := collect(updates); !slices.Equal(got, []State{InProgress, Ready}) {
+					t.Errorf("order %d: watcher saw %v", id, got)
+				}
+			}()
+		}
+	}
+	for id := range orders {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.Update(id, InProgress)
+			w.Update(id, Ready)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestRun(t *testing.T) {
+	got := testutil.RunLesson(t, Run)
+	for _, want := range []string{
+		"🔕 Customer 5: stopped watching (terminal state)",
+		"📱 Late watcher: order 2 is Cancelled",
+		"🔕 Watching a cancelled order: 0 updates, closed after 0s",
+		"📋 Watcher saw: [InProgress Ready]",
+		"⏰ Customer gave up on order 4 after 500ms: 0 updates",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	if n := strings.Count(got, "order 1 is Ready"); n != 5 {
+		t.Errorf("%d customers saw order 1 ready, want 5", n)
+	}
+}
@@ -0,0 +1,604 @@
+// Package worksteal is lesson 32: Work Stealing.
+package worksteal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+}
+
+// deque is one worker's local queue. The owner takes from the head;
+// thieves take from the tail, so they rarely fight over the same end.
+type deque struct {
+	mu    sync.Mutex
+	items []Order
+}
+
+func (d *deque) pushBack(o Order) {
+	d.mu.Lock()
+	d.items = append(d.items, o)
+	d.mu.Unlock()
+}
+
+func (d *deque) popFront() (Order, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.items) == 0 {
+		return Order{}, false
+	}
+	o := d.items[0]
+	d.items = d.items[1:]
+	return o, true
+}
+
+func (d *deque) popBack() (Order, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	n := len(d.items)
+	if n == 0 {
+		return Order{}, false
+	}
+	o := d.items[n-1]
+	d.items = d.items[:n-1]
+	return o, true
+}
+
+func (d *deque) len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.items)
+}
+
+// WorkStealingPool gives every worker its own deque. A worker drains its own
+// queue first; when it runs dry it steals from the tail of the longest peer.
+// Each lock is shared by at most two goroutines at a time (owner + thief),
+// instead of every worker contending on one shared queue.
+type WorkStealingPool struct {
+	queues []*deque
+	work   func(workerID int, o Order)
+	next   atomic.Int64   // Round-robin cursor for Submit
+	closed atomic.Bool    // No more submissions; workers exit once every queue is empty
+	steals []atomic.Int64 // Per worker
+	done   []atomic.Int64 // Per worker
+	wg     sync.WaitGroup
+}
+
+// NewWorkStealingPool creates a pool of workers that call work for each order
+func NewWorkStealingPool(workers int, work func(workerID int, o Order)) *WorkStealingPool {
+	p := &WorkStealingPool{
+		queues: make([]*deque, workers),
+		work:   work,
+		steals: make([]atomic.Int64, workers),
+		done:   make([]atomic.Int64, workers),
+	}
+	for i := range p.queues {
+		p.queues[i] = &deque{}
+	}
+	return p
+}
+
+// Submit places the order on the next worker's queue, round-robin
+func (p *WorkStealingPool) Submit(o Order) {
+	i := int(p.next.Add(1)-1) % len(p.queues)
+	p.SubmitTo(i, o)
+}
+
+// SubmitTo places the order on a specific worker's queue
+func (p *WorkStealingPool) SubmitTo(worker int, o Order) {
+	p.queues[worker].pushBack(o)
+}
+
+// Start launches one goroutine per queue
+func (p *WorkStealingPool) Start() {
+	for id := range p.queues {
+		p.wg.Add(1)
+		go p.worker(id)
+	}
+}
+
+// Shutdown stops accepting orders and waits until every submitted order is finished.
+// Submit must not be called after Shutdown.
+func (p *WorkStealingPool) Shutdown() {
+	p.closed.Store(true)
+	p.wg.Wait()
+}
+
+func (p *WorkStealingPool) worker(id int) {
+	defer p.wg.Done()
+
+	for {
+		o, ok := p.queues[id].popFront()
+		if !ok {
+			o, ok = p.steal(id)
+		}
+		if ok {
+			p.work(id, o)
+			p.done[id].Add(1)
+			continue
+		}
+
+		// steal found every peer empty. Orders only come from Submit, so once
+		// intake is closed an empty sweep means there is nothing left to do.
+		if p.closed.Load() && p.empty() {
+			return
+		}
+		clk.Sleep(context.Background(), 50*time.Microsecond) // Idle: nothing to run or steal yet
+	}
+}
+
+// empty reports whether every queue is empty
+func (p *WorkStealingPool) empty() bool {
+	for _, q := range p.queues {
+		if q.len() > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// steal takes one order from the tail of the longest peer queue
+func (p *WorkStealingPool) steal(thief int) (Order, bool) {
+	victim, longest := -1, 0
+	for i, q := range p.queues {
+		if i == thief {
+			continue
+		}
+		if n := q.len(); n > longest {
+			victim, longest = i, n
+		}
+	}
+	if victim < 0 {
+		return Order{}, false
+	}
+
+	o, ok := p.queues[victim].popBack() // May lose the race to the owner - that's fine
+	if ok {
+		p.steals[thief].Add(1)
+	}
+	return o, ok
+}
+
+// Task is a unit of work for CacheAwarePool. It may hand follow-up tasks to
+// spawn, which puts them on the stack of the worker running it.
+type Task func(spawn func(Task))
+
+// taskStack is one worker's local stack. The owner pushes and pops at the
+// top, so it runs the task it pushed last, whose data is most likely still
+// in its CPU's cache. Thieves take from the bottom: the oldest task, whose
+// data has most likely gone cold anyway.
+type taskStack struct {
+	mu    sync.Mutex
+	tasks []Task
+}
+
+func (s *taskStack) push(t Task) {
+	s.mu.Lock()
+	s.tasks = append(s.tasks, t)
+	s.mu.Unlock()
+}
+
+func (s *taskStack) popTop() (Task, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := len(s.tasks)
+	if n == 0 {
+		return nil, false
+	}
+	t := s.tasks[n-1]
+	s.tasks[n-1] = nil
+	s.tasks = s.tasks[:n-1]
+	return t, true
+}
+
+func (s *taskStack) popBottom() (Task, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.tasks) == 0 {
+		return nil, false
+	}
+	t := s.tasks[0]
+	s.tasks[0] = nil
+	s.tasks = s.tasks[1:]
+	return t, true
+}
+
+func (s *taskStack) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.tasks)
+}
+
+// CacheAwarePool is work stealing for tasks that spawn follow-ups on the
+// same data. A spawned task goes on the spawning worker's own stack and,
+// being on top, runs next, while the data the two share is still in cache.
+// An idle worker steals from the bottom of the busiest peer's stack, FIFO,
+// taking the task the owner would get to last.
+type CacheAwarePool struct {
+	stacks  []*taskStack
+	fifo    bool         // Owners pop the bottom too; only for comparison
+	next    atomic.Int64 // Round-robin cursor for Submit
+	pending atomic.Int64 // Pushed but not yet finished, spawned tasks included
+	closed  atomic.Bool
+	steals  []atomic.Int64 // Per worker
+	done    []atomic.Int64 // Per worker
+	wg      sync.WaitGroup
+}
+
+// NewCacheAwarePool creates a pool whose workers run their newest task first
+func NewCacheAwarePool(workers int) *CacheAwarePool {
+	p := &CacheAwarePool{
+		stacks: make([]*taskStack, workers),
+		steals: make([]atomic.Int64, workers),
+		done:   make([]atomic.Int64, workers),
+	}
+	for i := range p.stacks {
+		p.stacks[i] = &taskStack{}
+	}
+	return p
+}
+
+// newFIFOTaskPool is the same pool with owners taking their oldest task
+// first, like WorkStealingPool, to measure what LIFO buys
+func newFIFOTaskPool(workers int) *CacheAwarePool {
+	p := NewCacheAwarePool(workers)
+	p.fifo = true
+	return p
+}
+
+// Submit places a task from outside the pool on the next worker's stack,
+// round-robin. Tasks spawned by a running task go on that worker's stack.
+func (p *CacheAwarePool) Submit(t Task) {
+	p.push(int(p.next.Add(1)-1)%len(p.stacks), t)
+}
+
+func (p *CacheAwarePool) push(worker int, t Task) {
+	p.pending.Add(1) // Before the push, so pending never reads 0 while a task waits
+	p.stacks[worker].push(t)
+}
+
+// Start launches one goroutine per stack
+func (p *CacheAwarePool) Start() {
+	for id := range p.stacks {
+		p.wg.Add(1)
+		go p.worker(id)
+	}
+}
+
+// Shutdown stops accepting outside tasks and waits until every task, spawned
+// ones included, has run. Submit must not be called after Shutdown.
+func (p *CacheAwarePool) Shutdown() {
+	p.closed.Store(true)
+	p.wg.Wait()
+}
+
+func (p *CacheAwarePool) worker(id int) {
+	defer p.wg.Done()
+	pop := p.stacks[id].popTop
+	if p.fifo {
+		pop = p.stacks[id].popBottom
+	}
+	spawn := func(t Task) { p.push(id, t) }
+
+	for {
+		t, ok := pop()
+		if !ok {
+			t, ok = p.steal(id)
+		}
+		if ok {
+			t(spawn)
+			p.done[id].Add(1)
+			p.pending.Add(-1)
+			continue
+		}
+
+		// A running task can still spawn more, so an empty sweep isn't enough:
+		// the pool is done when nothing is pushed and not yet finished
+		if p.closed.Load() && p.pending.Load() == 0 {
+			return
+		}
+		clk.Sleep(context.Background(), 50*time.Microsecond)
+	}
+}
+
+// steal takes the oldest task from the bottom of the longest peer stack
+func (p *CacheAwarePool) steal(thief int) (Task, bool) {
+	victim, longest := -1, 0
+	for i, s := range p.stacks {
+		if i == thief {
+			continue
+		}
+		if n := s.len(); n > longest {
+			victim, longest = i, n
+		}
+	}
+	if victim < 0 {
+		return nil, false
+	}
+	t, ok := p.stacks[victim].popBottom()
+	if ok {
+		p.steals[thief].Add(1)
+	}
+	return t, ok
+}
+
+// runSharedChannel is the simple pool from lesson 04: every worker reads one shared channel
+func runSharedChannel(workers int, orders []Order, work func(workerID int, o Order)) {
+	queue := make(chan Order, len(orders))
+	for _, o := range orders {
+		queue <- o
+	}
+	close(queue)
+
+	var wg sync.WaitGroup
+	for id := 0; id < workers; id++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for o := range queue {
+				work(id, o)
+			}
+		}(id)
+	}
+	wg.Wait()
+}
+
+// unevenOrders makes every 4th order (the ones round-robin gives chef 0) 10x slower
+func unevenOrders(n int) []Order {
+	orders := make([]Order, n)
+	for i := range orders {
+		prep := 10 * time.Millisecond
+		if i%4 == 0 {
+			prep = 100 * time.Millisecond
+		}
+		orders[i] = Order{ID: i + 1, PrepTime: prep}
+	}
+	return orders
+}
+
+func cook(_ int, o Order) { clk.Sleep(context.Background(), o.PrepTime) }
+
+// Static assignment leaves chef 0 with all the slow orders; stealing rebalances
+func unevenWorkload() {
+	out.Printf("\n=== 1. UNEVEN WORKLOAD: STATIC VS STEALING ===\n\n")
+
+	const workers = 4
+	orders := unevenOrders(40)
+
+	// Static: round-robin assignment, no stealing
+	start := clk.Now()
+	var wg sync.WaitGroup
+	for id := 0; id < workers; id++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for i := id; i < len(orders); i += workers {
+				cook(id, orders[i])
+			}
+		}(id)
+	}
+	wg.Wait()
+	static := clk.Since(start)
+
+	// Work stealing: same round-robin assignment, idle chefs help out
+	pool := NewWorkStealingPool(workers, cook)
+	for _, o := range orders {
+		pool.Submit(o)
+	}
+	start = clk.Now()
+	pool.Start()
+	pool.Shutdown()
+	stealing := clk.Since(start)
+
+	out.Printf("🐌 Static assignment: %v (chef 0 got every slow order)\n", static.Round(10*time.Millisecond))
+	out.Printf("🥷 Work stealing:     %v\n\n", stealing.Round(10*time.Millisecond))
+	for id := 0; id < workers; id++ {
+		out.Printf("   Chef %d: cooked %2d, stole %2d\n", id, pool.done[id].Load(), pool.steals[id].Load())
+	}
+}
+
+// spin is a tiny CPU-only task so queue overhead dominates the benchmark
+func spin(_ int, o Order) {
+	x := o.ID
+	for i := 0; i < 200; i++ {
+		x = x*31 + i
+	}
+	_ = x
+}
+
+// Queue overhead with many tiny tasks: shared channel vs per-worker deques
+func contentionBenchmark() {
+	out.Printf("\n=== 2. QUEUE CONTENTION (testing.Benchmark) ===\n\n")
+
+	const workers, batch = 8, 10000
+	orders := make([]Order, batch)
+	for i := range orders {
+		orders[i] = Order{ID: i}
+	}
+
+	shared := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			runSharedChannel(workers, orders, spin)
+		}
+	})
+
+	stealing := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			pool := NewWorkStealingPool(workers, spin)
+			// All work lands on one queue, the worst case for stealing
+			for _, o := range orders {
+				pool.SubmitTo(0, o)
+			}
+			pool.Start()
+			pool.Shutdown()
+		}
+	})
+
+	balanced := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			pool := NewWorkStealingPool(workers, spin)
+			for _, o := range orders {
+				pool.Submit(o)
+			}
+			pool.Start()
+			pool.Shutdown()
+		}
+	})
+
+	perOrder := func(r testing.BenchmarkResult) time.Duration {
+		return time.Duration(r.NsPerOp() / batch)
+	}
+	out.Printf("📊 %d tiny orders, %d workers (time per order)\n\n", batch, workers)
+	out.Printf("   Shared channel:                %v\n", perOrder(shared))
+	out.Printf("   Work stealing (round-robin):   %v\n", perOrder(balanced))
+	out.Printf("   Work stealing (all on chef 0): %v\n", perOrder(stealing))
+}
+
+// Pantry is the state a chain of orders shares. Every order in a chain holds
+// the same *Pantry, so the chain's orders alias one block of memory, big
+// enough that one fits in a core's L2 cache but a whole round of them doesn't.
+type Pantry struct {
+	shelf [64 << 10]int64 // 512KB
+}
+
+// stock returns one fresh pantry per chain
+func stock(chains int) []*Pantry {
+	pantries := make([]*Pantry, chains)
+	for i := range pantries {
+		pantries[i] = new(Pantry)
+		for j := range pantries[i].shelf {
+			pantries[i].shelf[j] = int64(i + j)
+		}
+	}
+	return pantries
+}
+
+// chainTask is order number step in a chain of steps orders on one pantry.
+// It reads the whole pantry, like checking stock before cooking, then spawns
+// the chain's next order.
+func chainTask(pantry *Pantry, step, steps int, sink *atomic.Int64) Task {
+	return func(spawn func(Task)) {
+		var sum int64
+		for i := 0; i < len(pantry.shelf); i += 8 { // One read per 64-byte cache line
+			sum += pantry.shelf[i]
+		}
+		sink.Add(sum & 1)
+		if step+1 < steps {
+			spawn(chainTask(pantry, step+1, steps, sink))
+		}
+	}
+}
+
+// One chef, three chains of three orders: LIFO finishes a chain before starting the next
+func executionOrder() {
+	out.Printf("\n=== 3. LIFO VS FIFO: WHICH ORDER RUNS NEXT ===\n\n")
+
+	trace := func(pool *CacheAwarePool) string {
+		var mu sync.Mutex
+		var ran []string
+		var step func(chain string, n int) Task
+		step = func(chain string, n int) Task {
+			return func(spawn func(Task)) {
+				mu.Lock()
+				ran = append(ran, fmt.Sprintf("%s%d", chain, n))
+				mu.Unlock()
+				if n < 3 {
+					spawn(step(chain, n+1))
+				}
+			}
+		}
+		for _, chain := range []string{"A", "B", "C"} {
+			pool.Submit(step(chain, 1))
+		}
+		pool.Start()
+		pool.Shutdown()
+		return strings.Join(ran, " ")
+	}
+
+	out.Printf("📚 LIFO stack: %s\n", trace(NewCacheAwarePool(1)))
+	out.Printf("📬 FIFO queue: %s\n", trace(newFIFOTaskPool(1)))
+	out.Printf("\n💡 LIFO runs each order right after the one that spawned it; FIFO makes it wait behind every other chain\n")
+}
+
+// Chains of orders that alias one pantry each: LIFO keeps the pantry in cache between them
+func cacheBenchmark() {
+	out.Printf("\n=== 4. SHARED STATE: LIFO VS FIFO (testing.Benchmark) ===\n\n")
+
+	const workers, chains, steps = 4, 32, 8
+	pantries := stock(chains)
+	var sink atomic.Int64
+
+	// run benchmarks one pool type, and returns how many orders its last run cooked
+	run := func(newPool func(int) *CacheAwarePool) (testing.BenchmarkResult, int64) {
+		var cooked int64
+		r := testing.Benchmark(func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				pool := newPool(workers)
+				for _, p := range pantries {
+					pool.Submit(chainTask(p, 0, steps, &sink))
+				}
+				pool.Start()
+				pool.Shutdown()
+				cooked = 0
+				for w := range pool.done {
+					cooked += pool.done[w].Load()
+				}
+			}
+		})
+		return r, cooked
+	}
+	lifo, lifoCooked := run(NewCacheAwarePool)
+	fifo, fifoCooked := run(newFIFOTaskPool)
+
+	perOrder := func(r testing.BenchmarkResult) time.Duration {
+		return time.Duration(r.NsPerOp() / (chains * steps))
+	}
+	out.Printf("📊 %d chains × %d orders, each chain sharing one 512KB pantry, %d workers (time per order)\n\n", chains, steps, workers)
+	out.Printf("   LIFO stacks (CacheAwarePool): %-9v (%d orders cooked)\n", perOrder(lifo), lifoCooked)
+	out.Printf("   FIFO queues:                  %-9v (%d orders cooked)\n", perOrder(fifo), fifoCooked)
+	out.Printf("\n⚡ FIFO takes %.1fx as long: with LIFO, the next order finds the pantry still in cache\n",
+		float64(fifo.NsPerOp())/float64(lifo.NsPerOp()))
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Work Stealing")
+	out.Println("==========================================")
+
+	unevenWorkload()
+	contentionBenchmark()
+	executionOrder()
+	cacheBenchmark()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ Per-worker queues avoid every worker fighting over one lock")
+	out.Println("✅ Idle workers steal from the busiest peer to rebalance load")
+	out.Println("✅ Owner and thief use opposite ends of the deque to reduce conflicts")
+	out.Println("✅ Static assignment is only as fast as its unluckiest worker")
+	out.Println("✅ A LIFO local stack runs spawned work while its data is still in cache")
+	out.Println("✅ Thieves take the oldest task, the one whose data has gone cold anyway")
+	return nil
+}
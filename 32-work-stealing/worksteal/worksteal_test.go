@@ -0,0 +1,141 @@
+package worksteal
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/Ajay2521/go-concurrency/04-worker-pools/workerpool"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// heavySpin is spin with every 4th order 10x the work. Round-robin hands
+// all the heavy orders to the same few workers, as in unevenOrders.
+func heavySpin(_ int, o Order) {
+	rounds := 200
+	if o.ID%4 == 0 {
+		rounds = 2000
+	}
+	x := o.ID
+	for i := 0; i < rounds; i++ {
+		x = x*31 + i
+	}
+	_ = x
+}
+
+func batch(n int) []Order {
+	orders := make([]Order, n)
+	for i := range orders {
+		orders[i] = Order{ID: i}
+	}
+	return orders
+}
+
+// runWorkerPool runs the orders through lesson 04's Pool: every worker
+// contends on one shared jobs channel
+func runWorkerPool(workers int, orders []Order, work func(int, Order)) {
+	pool := workerpool.NewPool(workers, func(o Order) struct{} {
+		work(0, o)
+		return struct{}{}
+	})
+	go func() {
+		for _, o := range orders {
+			pool.Submit(o)
+		}
+		pool.Close()
+	}()
+	for range pool.Results() {
+	}
+}
+
+func runStealing(workers int, orders []Order, work func(int, Order), oneQueue bool) *WorkStealingPool {
+	pool := NewWorkStealingPool(workers, work)
+	for _, o := range orders {
+		if oneQueue {
+			pool.SubmitTo(0, o)
+		} else {
+			pool.Submit(o)
+		}
+	}
+	pool.Start()
+	pool.Shutdown()
+	return pool
+}
+
+// BenchmarkPools compares lesson 04's shared-channel pool with work
+// stealing, on even and uneven loads of tiny CPU-bound orders:
+//
+//	go test -bench=Pools -benchmem ./32-work-stealing/...
+func BenchmarkPools(b *testing.B) {
+	const workers = 8
+	orders := batch(10000)
+	for _, load := range []struct {
+		name string
+		work func(int, Order)
+	}{
+		{"even", spin},
+		{"uneven", heavySpin},
+	} {
+		b.Run(load.name+"/workerpool", func(b *testing.B) {
+			for b.Loop() {
+				runWorkerPool(workers, orders, load.work)
+			}
+		})
+		b.Run(load.name+"/stealing", func(b *testing.B) {
+			for b.Loop() {
+				runStealing(workers, orders, load.work, false)
+			}
+		})
+		b.Run(load.name+"/stealing-one-queue", func(b *testing.B) {
+			for b.Loop() {
+				runStealing(workers, orders, load.work, true)
+			}
+		})
+	}
+}
+
+// Every order is cooked exactly once, however it was submitted, and idle
+// workers steal when all the work is on one queue. Run with -race.
+func TestEveryOrderCookedOnce(t *testing.T) {
+	testutil.RunParallel(t, []testutil.TestCase{
+		{Name: "round-robin", Input: false},
+		{Name: "all on one queue", Input: true},
+	}, func(t *testing.T, tc testutil.TestCase) {
+		oneQueue := tc.Input.(bool)
+		var mu sync.Mutex
+		var ids []int
+		pool := runStealing(4, batch(1000), func(_ int, o Order) {
+			heavySpin(0, o)
+			mu.Lock()
+			ids = append(ids, o.ID)
+			mu.Unlock()
+		}, oneQueue)
+
+		slices.Sort(ids)
+		want := make([]int, 1000)
+		for i := range want {
+			want[i] = i
+		}
+		if !slices.Equal(ids, want) {
+			t.Errorf("cooked %d orders, want each of 0..999 once", len(ids))
+		}
+		var done, steals int64
+		for id := range pool.queues {
+			done += pool.done[id].Load()
+			steals += pool.steals[id].Load()
+		}
+		if done != 1000 {
+			t.Errorf("workers counted %d done, want 1000", done)
+		}
+		if oneQueue && steals == 0 {
+			t.Error("no steals with every order on worker 0's queue")
+		}
+	})
+}
+
+// Shutdown returns only once every worker has exited
+func TestShutdownLeavesNoWorkers(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	runStealing(8, batch(100), spin, true)
+	runWorkerPool(8, batch(100), spin)
+}
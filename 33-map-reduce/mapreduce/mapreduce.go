@@ -0,0 +1,139 @@
+// Package mapreduce is lesson 33: Map-Reduce.
+package mapreduce
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	Category string
+	PrepTime time.Duration
+}
+
+// KeyValue is one intermediate pair emitted by the map phase
+type KeyValue struct {
+	Key   string
+	Value int
+}
+
+const mapWorkers = 4
+
+// MapReduce runs mapper over every order on a pool of mapWorkers goroutines,
+// groups the emitted pairs by key (the shuffle), then reduces each key's
+// values in its own goroutine.
+func MapReduce(orders []Order, mapper func(Order) KeyValue, reducer func(string, []int) int) map[string]int {
+	// Map: a worker pool reads orders and emits pairs on one channel
+	jobs := make(chan Order)
+	pairs := make(chan KeyValue, mapWorkers)
+	var mapWG sync.WaitGroup
+
+	for i := 0; i < mapWorkers; i++ {
+		mapWG.Add(1)
+		go func() {
+			defer mapWG.Done()
+			for order := range jobs {
+				pairs <- mapper(order)
+			}
+		}()
+	}
+
+	go func() {
+		for _, order := range orders {
+			jobs <- order
+		}
+		close(jobs)
+	}()
+
+	go func() {
+		mapWG.Wait()
+		close(pairs)
+	}()
+
+	// Shuffle: group values by key. Only this goroutine touches groups.
+	groups := map[string][]int{}
+	for kv := range pairs {
+		groups[kv.Key] = append(groups[kv.Key], kv.Value)
+	}
+
+	// Reduce: one goroutine per key
+	reduced := make(chan KeyValue, len(groups))
+	var reduceWG sync.WaitGroup
+	for key, values := range groups {
+		reduceWG.Add(1)
+		go func(key string, values []int) {
+			defer reduceWG.Done()
+			reduced <- KeyValue{Key: key, Value: reducer(key, values)}
+		}(key, values)
+	}
+	reduceWG.Wait()
+	close(reduced)
+
+	result := make(map[string]int, len(groups))
+	for kv := range reduced {
+		result[kv.Key] = kv.Value
+	}
+	return result
+}
+
+// prepByCategory emits the order's category with its prep time in minutes
+func prepByCategory(o Order) KeyValue {
+	return KeyValue{Key: o.Category, Value: int(o.PrepTime / time.Minute)}
+}
+
+func sum(_ string, values []int) int {
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// Total prep minutes per category for one day's orders
+func prepTimePerCategory() {
+	out.Printf("\n=== 1. PREP TIME PER CATEGORY ===\n\n")
+
+	orders := []Order{
+		{ID: 1, Category: "burgers", PrepTime: 12 * time.Minute},
+		{ID: 2, Category: "pizza", PrepTime: 18 * time.Minute},
+		{ID: 3, Category: "salads", PrepTime: 5 * time.Minute},
+		{ID: 4, Category: "burgers", PrepTime: 10 * time.Minute},
+		{ID: 5, Category: "pizza", PrepTime: 20 * time.Minute},
+		{ID: 6, Category: "desserts", PrepTime: 7 * time.Minute},
+		{ID: 7, Category: "burgers", PrepTime: 11 * time.Minute},
+		{ID: 8, Category: "salads", PrepTime: 6 * time.Minute},
+	}
+
+	totals := MapReduce(orders, prepByCategory, sum)
+	for _, category := range slices.Sorted(maps.Keys(totals)) {
+		out.Printf("🍽️  %-9s %3d min\n", category, totals[category])
+	}
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Map-Reduce")
+	out.Println("==========================================")
+
+	prepTimePerCategory()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ The map phase is embarrassingly parallel: a worker pool handles it")
+	out.Println("✅ The shuffle groups pairs by key in a single goroutine, so no locks")
+	out.Println("✅ Each key reduces independently, so reducers run concurrently")
+	out.Println("✅ Closing channels after wg.Wait() marks the end of each phase")
+	return nil
+}
@@ -0,0 +1,74 @@
+package mapreduce
+
+import (
+	"maps"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+var categories = []string{"burgers", "pizza", "salads", "desserts", "drinks"}
+
+// syntheticOrders builds n orders with a repeatable spread of categories and prep times
+func syntheticOrders(n int) []Order {
+	orders := make([]Order, n)
+	for i := range orders {
+		orders[i] = Order{
+			ID:       i + 1,
+			Category: categories[(i*7)%len(categories)],
+			PrepTime: time.Duration(i%13+1) * time.Minute,
+		}
+	}
+	return orders
+}
+
+// sequentialTotals is the obvious single-goroutine answer, used as the reference
+func sequentialTotals(orders []Order) map[string]int {
+	totals := map[string]int{}
+	for _, o := range orders {
+		totals[o.Category] += int(o.PrepTime / time.Minute)
+	}
+	return totals
+}
+
+// MapReduce must agree with the sequential answer whatever the scheduling,
+// so each case runs 20 times
+func TestMapReduce(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	type job struct {
+		orders  []Order
+		mapper  func(Order) KeyValue
+		reducer func(string, []int) int
+	}
+	count := func(_ string, values []int) int { return len(values) }
+	byCategory := func(o Order) KeyValue { return KeyValue{Key: o.Category, Value: 1} }
+
+	testutil.RunParallel(t, []testutil.TestCase{
+		{Name: "1000 orders, prep minutes", Input: job{syntheticOrders(1000), prepByCategory, sum},
+			Want: sequentialTotals(syntheticOrders(1000))},
+		{Name: "1000 orders, pinned totals", Input: job{syntheticOrders(1000), prepByCategory, sum},
+			Want: map[string]int{"burgers": 1394, "desserts": 1401, "drinks": 1404, "pizza": 1396, "salads": 1399}},
+		{Name: "1000 orders, order count", Input: job{syntheticOrders(1000), byCategory, count},
+			Want: map[string]int{"burgers": 200, "pizza": 200, "salads": 200, "desserts": 200, "drinks": 200}},
+		{Name: "single order", Input: job{syntheticOrders(1), prepByCategory, sum}, Want: map[string]int{"burgers": 1}},
+		{Name: "no orders", Input: job{nil, prepByCategory, sum}, Want: map[string]int{}},
+	}, func(t *testing.T, tc testutil.TestCase) {
+		j, want := tc.Input.(job), tc.Want.(map[string]int)
+		for run := range 20 {
+			if got := MapReduce(j.orders, j.mapper, j.reducer); !maps.Equal(got, want) {
+				t.Fatalf("run %d: got %v, want %v", run, got, want)
+			}
+		}
+	})
+}
+
+func TestRun(t *testing.T) {
+	got := testutil.RunLesson(t, Run)
+	for _, want := range []string{"🍽️  burgers    33 min", "🍽️  pizza      38 min", "🍽️  salads     11 min"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+// Package singleflight is lesson 33: Singleflight.
+package singleflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/conc"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+// RecipeDB simulates a slow recipe database
+type RecipeDB struct {
+	lookups atomic.Int64 // Number of real lookups performed
+}
+
+// PrepTime looks up the preparation time of a dish (slow: 1 second)
+func (db *RecipeDB) PrepTime(dish string) (time.Duration, error) {
+	db.lookups.Add(1)
+	clk.Sleep(context.Background(), 1*time.Second)
+
+	switch dish {
+	case "margherita":
+		return 2 * time.Second, nil
+	case "pepperoni":
+		return 3 * time.Second, nil
+	default:
+		return 0, fmt.Errorf("recipe %q not found", dish)
+	}
+}
+
+// Every order taker looks up the recipe on its own
+func withoutDeduplication() {
+	out.Printf("\n=== 1. WITHOUT DEDUPLICATION ===\n\n")
+
+	db := &RecipeDB{}
+	var wg sync.WaitGroup
+	startTime := clk.Now()
+
+	for i := 1; i <= 50; i++ {
+		wg.Add(1)
+		go func(takerID int) {
+			defer wg.Done()
+			db.PrepTime("margherita")
+		}(i)
+	}
+
+	wg.Wait()
+
+	out.Printf("📚 Recipe lookups performed: %d\n", db.lookups.Load())
+	out.Printf("⏱️  Time taken: %v\n", clk.Since(startTime))
+}
+
+// Order takers share a single in-flight lookup per dish
+func withSingleflight() {
+	out.Printf("\n=== 2. WITH SINGLEFLIGHT GROUP ===\n\n")
+
+	db := &RecipeDB{}
+	var group conc.Group[string, time.Duration]
+	var wg sync.WaitGroup
+	var sharedCount atomic.Int64
+	startTime := clk.Now()
+
+	for i := 1; i <= 50; i++ {
+		wg.Add(1)
+		go func(takerID int) {
+			defer wg.Done()
+			prepTime, err, shared := group.Do("margherita", func() (time.Duration, error) {
+				return db.PrepTime("margherita")
+			})
+			if err != nil {
+				out.Printf("❌ Taker %d: %v\n", takerID, err)
+				return
+			}
+			if shared {
+				sharedCount.Add(1)
+			}
+			if takerID == 1 {
+				out.Printf("🍕 Margherita prep time: %v\n", prepTime)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	out.Printf("📚 Recipe lookups performed: %d\n", db.lookups.Load())
+	out.Printf("🤝 Callers that received a shared result: %d\n", sharedCount.Load())
+	out.Printf("⏱️  Time taken: %v\n", clk.Since(startTime))
+}
+
+// Errors are shared with every waiter, just like values
+func sharedErrors() {
+	out.Printf("\n=== 3. SHARED ERRORS ===\n\n")
+
+	db := &RecipeDB{}
+	var group conc.Group[string, time.Duration]
+	var wg sync.WaitGroup
+	var errCount atomic.Int64
+
+	for i := 1; i <= 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err, _ := group.Do("hawaiian", func() (time.Duration, error) {
+				return db.PrepTime("hawaiian")
+			})
+			if err != nil {
+				errCount.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	out.Printf("📚 Recipe lookups performed: %d\n", db.lookups.Load())
+	out.Printf("❌ Callers that received the error: %d\n", errCount.Load())
+}
+
+// Forget lets a new caller start a fresh lookup instead of joining a stale one
+func forgetKey() {
+	out.Printf("\n=== 4. FORGET ===\n\n")
+
+	db := &RecipeDB{}
+	var group conc.Group[string, time.Duration]
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		group.Do("pepperoni", func() (time.Duration, error) {
+			return db.PrepTime("pepperoni")
+		})
+	}()
+
+	clk.Sleep(context.Background(), 100*time.Millisecond) // Let the first lookup start
+	group.Forget("pepperoni")                             // The recipe changed - don't reuse the in-flight lookup
+
+	_, err, shared := group.Do("pepperoni", func() (time.Duration, error) {
+		return db.PrepTime("pepperoni")
+	})
+	if err != nil {
+		out.Printf("❌ Lookup failed: %v\n", err)
+	}
+
+	wg.Wait()
+
+	out.Printf("🔁 Second caller shared the first result: %v\n", shared)
+	out.Printf("📚 Recipe lookups performed: %d\n", db.lookups.Load())
+}
+
+// A lookup that panics still wakes everyone waiting on it
+func panickingLookup() {
+	out.Printf("\n=== 5. A PANICKING LOOKUP ===\n\n")
+
+	var group conc.Group[string, time.Duration]
+	var wg sync.WaitGroup
+	var panicErrs atomic.Int64
+
+	leaderPanic := make(chan any, 1)
+	go func() {
+		defer func() { leaderPanic <- recover() }()
+		group.Do("calzone", func() (time.Duration, error) {
+			clk.Sleep(context.Background(), 200*time.Millisecond)
+			panic("recipe card is corrupt")
+		})
+	}()
+	clk.Sleep(context.Background(), 50*time.Millisecond) // Let the leader start
+
+	for i := 1; i <= 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err, _ := group.Do("calzone", func() (time.Duration, error) {
+				return 0, nil
+			})
+			var pe *conc.PanicError
+			if errors.As(err, &pe) {
+				panicErrs.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+	out.Printf("😱 Leader panicked again with: %v\n", panicValue(<-leaderPanic))
+	out.Printf("❌ Waiters that got a *PanicError instead of hanging: %d of 5\n", panicErrs.Load())
+}
+
+// panicValue is the value a recovered *conc.PanicError carries
+func panicValue(r any) any {
+	if pe, ok := r.(*conc.PanicError); ok {
+		return pe.Value
+	}
+	return r
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Singleflight Menu Lookups")
+	out.Println("==========================================")
+
+	withoutDeduplication()
+	withSingleflight()
+	sharedErrors()
+	forgetKey()
+	panickingLookup()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ Singleflight collapses identical concurrent calls into one")
+	out.Println("✅ Every waiter receives the leader's value AND error")
+	out.Println("✅ The shared flag tells you whether a result was reused")
+	out.Println("✅ Forget starts a fresh call for the next caller")
+	out.Println("✅ A panic in the leader reaches every waiter as an error")
+	out.Println("✅ Deduplication protects slow backends from thundering herds")
+	return nil
+}
@@ -0,0 +1,288 @@
+// Package actors is lesson 34: Order Actors.
+package actors
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+}
+
+type Status string
+
+const (
+	Pending    Status = "pending"
+	Processing Status = "processing"
+	Ready      Status = "ready"
+	Cancelled  Status = "cancelled"
+	Unknown    Status = "unknown" // The actor has stopped and can't answer
+)
+
+var (
+	ErrMailboxFull = errors.New("mailbox full")
+	ErrStopped     = errors.New("actor stopped")
+)
+
+// Message is anything an OrderActor understands
+type Message interface{ isMessage() }
+
+// StartProcessing asks the kitchen to begin cooking the order
+type StartProcessing struct{}
+
+// CancelOrder cancels the order unless it's already ready
+type CancelOrder struct{}
+
+// GetStatus asks for the current status. Reply should be buffered so the
+// actor never blocks on a caller that has gone away.
+type GetStatus struct{ Reply chan<- Status }
+
+// cookingDone is sent by the actor's own timer when the prep time is up
+type cookingDone struct{}
+
+func (StartProcessing) isMessage() {}
+func (CancelOrder) isMessage()     {}
+func (GetStatus) isMessage()       {}
+func (cookingDone) isMessage()     {}
+
+// OrderActor owns one order's state. Only its goroutine reads or writes
+// status and timer; everyone else sends messages, so no lock is needed.
+type OrderActor struct {
+	order   Order
+	mailbox chan Message
+	stop    chan struct{}
+	done    chan struct{}
+
+	// Owned by the actor goroutine
+	status Status
+	timer  clock.Timer
+}
+
+// NewOrderActor starts the actor goroutine for order
+func NewOrderActor(order Order, mailboxSize int) *OrderActor {
+	a := &OrderActor{
+		order:   order,
+		mailbox: make(chan Message, mailboxSize),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+		status:  Pending,
+	}
+	go a.run()
+	return a
+}
+
+// Send puts msg in the mailbox without blocking. It returns ErrMailboxFull
+// if the mailbox has no room and ErrStopped after Stop.
+func (a *OrderActor) Send(msg Message) error {
+	select {
+	case <-a.stop:
+		return ErrStopped
+	default:
+	}
+
+	select {
+	case a.mailbox <- msg:
+		return nil
+	default:
+		return ErrMailboxFull
+	}
+}
+
+// Status asks the actor for its status and waits for the reply
+func (a *OrderActor) Status() Status {
+	reply := make(chan Status, 1)
+	if err := a.Send(GetStatus{Reply: reply}); err != nil {
+		return Unknown
+	}
+	select {
+	case s := <-reply:
+		return s
+	case <-a.done:
+		return Unknown
+	}
+}
+
+// Stop ends the actor goroutine. Messages still in the mailbox are dropped.
+// Call it once.
+func (a *OrderActor) Stop() {
+	close(a.stop)
+	<-a.done
+}
+
+// run handles one message at a time until Stop
+func (a *OrderActor) run() {
+	defer close(a.done)
+
+	for {
+		select {
+		case <-a.stop:
+			if a.timer != nil {
+				a.timer.Stop()
+			}
+			return
+		case msg := <-a.mailbox:
+			a.handle(msg)
+		}
+	}
+}
+
+func (a *OrderActor) handle(msg Message) {
+	switch m := msg.(type) {
+	case StartProcessing:
+		if a.status != Pending {
+			return // Already started, ready or cancelled
+		}
+		a.status = Processing
+		a.timer = clk.AfterFunc(a.order.PrepTime, func() {
+			// Goes through the mailbox like everyone else, but waits for room:
+			// unlike a caller's message, this one must not be dropped
+			select {
+			case a.mailbox <- cookingDone{}:
+			case <-a.stop:
+			}
+		})
+	case cookingDone:
+		if a.status == Processing {
+			a.status = Ready
+		}
+	case CancelOrder:
+		if a.status == Pending || a.status == Processing {
+			a.status = Cancelled
+			if a.timer != nil {
+				a.timer.Stop() // A cookingDone already queued is ignored above
+			}
+		}
+	case GetStatus:
+		m.Reply <- a.status
+	}
+}
+
+// One order's lifecycle, driven entirely by messages
+func orderLifecycle() {
+	out.Printf("\n=== 1. ONE ORDER, ONE ACTOR ===\n\n")
+
+	actor := NewOrderActor(Order{ID: 1, PrepTime: 100 * time.Millisecond}, 8)
+	out.Printf("📋 Order 1: %s\n", actor.Status())
+
+	actor.Send(StartProcessing{})
+	out.Printf("🔥 Order 1: %s\n", actor.Status())
+
+	clk.Sleep(context.Background(), 150*time.Millisecond)
+	out.Printf("✅ Order 1: %s\n", actor.Status())
+
+	actor.Send(CancelOrder{}) // Too late: a ready order stays ready
+	out.Printf("🚫 Cancel after ready → %s\n", actor.Status())
+
+	actor.Stop()
+	out.Printf("🛑 After Stop: Send → %v, Status → %s\n", actor.Send(GetStatus{}), actor.Status())
+}
+
+// Cancelling while the order cooks stops its timer; the order never becomes ready
+func cancelWhileProcessing() {
+	out.Printf("\n=== 2. CANCEL WHILE PROCESSING ===\n\n")
+
+	actor := NewOrderActor(Order{ID: 2, PrepTime: 100 * time.Millisecond}, 8)
+	defer actor.Stop()
+
+	actor.Send(StartProcessing{})
+	clk.Sleep(context.Background(), 30*time.Millisecond)
+	actor.Send(CancelOrder{})
+	out.Printf("🚫 Order 2 cancelled at +30ms: %s\n", actor.Status())
+
+	clk.Sleep(context.Background(), 120*time.Millisecond) // Past the original prep time
+	status := actor.Status()
+	mark := "✅"
+	if status != Cancelled {
+		mark = "❌"
+	}
+	out.Printf("%s Order 2 after its prep time would have passed: %s\n", mark, status)
+}
+
+// Many goroutines hammer many actors at once - without a single mutex
+func concurrentSenders() {
+	out.Printf("\n=== 3. 100 ACTORS, 20 CONCURRENT SENDERS ===\n\n")
+
+	actors := make([]*OrderActor, 100)
+	for i := range actors {
+		actors[i] = NewOrderActor(Order{ID: i + 1, PrepTime: time.Duration(10+i%5*10) * time.Millisecond}, 64)
+	}
+
+	// The WaitGroup only tracks the senders; the actors themselves need no locks
+	var wg sync.WaitGroup
+	for s := 0; s < 20; s++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(seed))
+			for i := 0; i < 200; i++ {
+				actor := actors[rng.Intn(len(actors))]
+				switch n := rng.Intn(100); {
+				case n == 0:
+					actor.Send(CancelOrder{})
+				case n < 20:
+					actor.Status()
+				default:
+					actor.Send(StartProcessing{})
+				}
+			}
+		}(int64(s))
+	}
+	wg.Wait()
+
+	// Start anything nobody touched, then let every order finish
+	for _, a := range actors {
+		a.Send(StartProcessing{})
+	}
+	clk.Sleep(context.Background(), 100*time.Millisecond)
+
+	counts := map[Status]int{}
+	for _, a := range actors {
+		counts[a.Status()]++
+		a.Stop()
+	}
+	out.Printf("📊 Ready: %d, Cancelled: %d, other: %d\n", counts[Ready], counts[Cancelled], 100-counts[Ready]-counts[Cancelled])
+	if counts[Ready]+counts[Cancelled] == 100 {
+		out.Println("✅ Every order ended ready or cancelled")
+	} else {
+		out.Println("❌ Some orders are stuck")
+	}
+	out.Println("🔍 Run with `go run -race main.go`: no data races, no mutexes")
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Order Actors")
+	out.Println("==========================================")
+
+	orderLifecycle()
+	cancelWhileProcessing()
+	concurrentSenders()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ Each order actor owns its state; only its goroutine touches it")
+	out.Println("✅ Messages are handled one at a time, so no locks are needed")
+	out.Println("✅ A buffered mailbox with select/default makes Send non-blocking")
+	out.Println("✅ Timers report back through the mailbox instead of touching state")
+	out.Println("✅ Reply channels turn a message into a request/response")
+	return nil
+}
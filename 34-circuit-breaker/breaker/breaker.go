@@ -0,0 +1,213 @@
+// Package breaker is lesson 34: Circuit Breaker.
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/conc"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID     int
+	Amount float64
+}
+
+// PaymentService simulates a payment processor that is down for a while and then recovers
+type PaymentService struct {
+	downUntil time.Time
+	calls     atomic.Int64 // Requests that actually reached the service
+}
+
+// Charge takes 50ms and fails while the service is down
+func (p *PaymentService) Charge(order Order) error {
+	p.calls.Add(1)
+	clk.Sleep(context.Background(), 50*time.Millisecond)
+
+	if clk.Now().Before(p.downUntil) {
+		return fmt.Errorf("order %d: payment gateway timeout", order.ID)
+	}
+	return nil
+}
+
+// processOrder charges an order through the breaker, so a failing payment
+// service fails fast with conc.ErrCircuitOpen instead of being hit again
+func processOrder(breaker *conc.Breaker, payments *PaymentService, order Order) error {
+	return breaker.Call(func() error {
+		return payments.Charge(order)
+	})
+}
+
+// A stream of orders hits the payment service while it is down and after it recovers
+func orderStream() {
+	out.Printf("\n=== 1. ORDER STREAM WITH A FLAKY PAYMENT PROCESSOR ===\n\n")
+
+	startTime := clk.Now()
+	elapsed := func() string {
+		return fmt.Sprintf("[+%4.2fs]", clk.Since(startTime).Seconds())
+	}
+
+	payments := &PaymentService{downUntil: startTime.Add(1500 * time.Millisecond)}
+	breaker := conc.NewBreaker(conc.Settings{
+		FailureThreshold: 3,
+		OpenDuration:     1 * time.Second,
+		HalfOpenProbes:   2,
+		Now:              clk.Now,
+		OnStateChange: func(from, to conc.State) {
+			out.Printf("%s 🔌 Breaker: %v → %v\n", elapsed(), from, to)
+		},
+	})
+
+	var paid, failed, rejected int
+
+	for id := 1; id <= 25; id++ {
+		order := Order{ID: id, Amount: 12.50}
+
+		err := processOrder(breaker, payments, order)
+
+		switch {
+		case err == nil:
+			paid++
+			out.Printf("%s ✅ Order %d: Payment accepted\n", elapsed(), order.ID)
+		case errors.Is(err, conc.ErrCircuitOpen):
+			rejected++
+			out.Printf("%s ⚡ Order %d: Fast-failed (breaker open)\n", elapsed(), order.ID)
+		default:
+			failed++
+			out.Printf("%s ❌ Order %d: %v\n", elapsed(), order.ID, err)
+		}
+
+		clk.Sleep(context.Background(), 100*time.Millisecond) // Next customer arrives
+	}
+
+	out.Printf("\n📊 Paid: %d | Failed: %d | Fast-failed: %d\n", paid, failed, rejected)
+	out.Printf("📞 Requests that reached the payment service: %d of 25\n", payments.calls.Load())
+}
+
+// Many checkout goroutines share one breaker
+func concurrentCallers() {
+	out.Printf("\n=== 2. CONCURRENT CALLERS ===\n\n")
+
+	payments := &PaymentService{downUntil: clk.Now().Add(1 * time.Hour)} // Down for the whole demo
+	breaker := conc.NewBreaker(conc.Settings{
+		FailureThreshold: 5,
+		OpenDuration:     1 * time.Minute,
+		HalfOpenProbes:   1,
+		Now:              clk.Now,
+	})
+
+	var wg sync.WaitGroup
+	var failed, rejected atomic.Int64
+
+	for id := 1; id <= 100; id++ {
+		wg.Add(1)
+		go func(order Order) {
+			defer wg.Done()
+			err := processOrder(breaker, payments, order)
+			if errors.Is(err, conc.ErrCircuitOpen) {
+				rejected.Add(1)
+			} else if err != nil {
+				failed.Add(1)
+			}
+		}(Order{ID: id, Amount: 9.99})
+
+		clk.Sleep(context.Background(), 2*time.Millisecond) // Checkouts arrive in a steady stream
+	}
+
+	wg.Wait()
+
+	out.Printf("❌ Failed at the payment service: %d\n", failed.Load())
+	out.Printf("⚡ Fast-failed by the breaker: %d\n", rejected.Load())
+	out.Printf("🔌 Final breaker state: %v\n", breaker.State())
+}
+
+// Open, fast-fail and recovery, driven by a manual clock instead of sleeps
+func breakerChecks() {
+	out.Printf("\n=== 3. BREAKER CHECKS (MANUAL CLOCK) ===\n\n")
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	breaker := conc.NewBreaker(conc.Settings{
+		FailureThreshold: 3,
+		OpenDuration:     30 * time.Second,
+		HalfOpenProbes:   1,
+		Now:              func() time.Time { return now },
+	})
+
+	var calls int
+	failing := func() error { calls++; return errors.New("gateway timeout") }
+	working := func() error { calls++; return nil }
+
+	check := func(name string, ok bool) {
+		status := "✅"
+		if !ok {
+			status = "❌"
+		}
+		out.Printf("%s %s\n", status, name)
+	}
+
+	// 3 consecutive failures trip the breaker
+	for i := 0; i < 3; i++ {
+		breaker.Call(failing)
+	}
+	check("3 consecutive failures open the breaker", breaker.State() == conc.StateOpen)
+
+	// While open, calls fail fast without reaching the service
+	before := calls
+	err := breaker.Call(working)
+	check("Open breaker fast-fails with ErrCircuitOpen", errors.Is(err, conc.ErrCircuitOpen))
+	check("Fast-failed call never reached the service", calls == before)
+
+	// Just before the cooldown ends it is still open
+	now = now.Add(29 * time.Second)
+	check("Still open 1s before the cooldown ends", breaker.State() == conc.StateOpen)
+
+	// After the cooldown a probe is let through; success closes the breaker
+	now = now.Add(time.Second)
+	check("Half-open once the cooldown has passed", breaker.State() == conc.StateHalfOpen)
+	err = breaker.Call(working)
+	check("Successful probe closes the breaker", err == nil && breaker.State() == conc.StateClosed)
+
+	// A failing probe re-opens it for another full cooldown
+	for i := 0; i < 3; i++ {
+		breaker.Call(failing)
+	}
+	now = now.Add(30 * time.Second)
+	breaker.Call(failing)
+	check("Failed probe re-opens the breaker", breaker.State() == conc.StateOpen)
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Circuit Breaker")
+	out.Println("==========================================")
+
+	orderStream()
+	concurrentCallers()
+	breakerChecks()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ Closed: calls flow through and consecutive failures are counted")
+	out.Println("✅ Open: calls fail fast so a broken service isn't hammered")
+	out.Println("✅ Half-Open: a few probes decide whether to close or re-open")
+	out.Println("✅ A mutex keeps state transitions consistent across goroutines")
+	out.Println("✅ An injectable clock makes the timing logic testable")
+	return nil
+}
@@ -0,0 +1,213 @@
+// Package retries is lesson 35: Retries with Backoff.
+package retries
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/conc"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID   int
+	Item string
+}
+
+// Errors returned by the supplier
+var (
+	ErrSupplierBusy = errors.New("supplier busy")     // Transient - worth retrying
+	ErrUnknownItem  = errors.New("unknown menu item") // Permanent - retrying won't help
+)
+
+// Supplier simulates an ingredient supplier that rejects ~40% of requests
+type Supplier struct {
+	mu  sync.Mutex
+	rng *rand.Rand // Seeded so every run of the lesson looks the same
+}
+
+// PlaceOrder takes 50ms and fails transiently 40% of the time
+func (s *Supplier) PlaceOrder(ctx context.Context, order Order) error {
+	select {
+	case <-clk.After(50 * time.Millisecond):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if order.Item == "unicorn steak" {
+		return fmt.Errorf("order %d: %w: %s", order.ID, ErrUnknownItem, order.Item)
+	}
+
+	s.mu.Lock()
+	busy := s.rng.Float64() < 0.4
+	s.mu.Unlock()
+
+	if busy {
+		return fmt.Errorf("order %d: %w", order.ID, ErrSupplierBusy)
+	}
+	return nil
+}
+
+// newPolicy returns the retry policy used by the lesson, printing every retry
+func newPolicy(orderID int) conc.RetryPolicy {
+	return conc.RetryPolicy{
+		MaxAttempts: 5,
+		BaseDelay:   100 * time.Millisecond,
+		MaxDelay:    1 * time.Second,
+		Jitter:      false, // Keep the printed delays predictable for the lesson
+		Clock:       clk,
+		Retryable: func(err error) bool {
+			return errors.Is(err, ErrSupplierBusy)
+		},
+		OnRetry: func(attempt int, err error, delay time.Duration) {
+			out.Printf("🔁 Order %d: attempt %d failed (%v), backing off %v\n", orderID, attempt, err, delay)
+		},
+	}
+}
+
+// Orders to a flaky supplier eventually go through thanks to retries
+func retryTransientFailures() {
+	out.Printf("\n=== 1. RETRYING TRANSIENT FAILURES ===\n\n")
+
+	supplier := &Supplier{rng: rand.New(rand.NewSource(7))}
+	var wg sync.WaitGroup
+	startTime := clk.Now()
+
+	for id := 1; id <= 5; id++ {
+		wg.Add(1)
+		go func(order Order) {
+			defer wg.Done()
+			err := conc.Retry(context.Background(), newPolicy(order.ID), func(ctx context.Context) error {
+				return supplier.PlaceOrder(ctx, order)
+			})
+			if err != nil {
+				out.Printf("❌ Order %d: %v\n", order.ID, err)
+				return
+			}
+			out.Printf("✅ Order %d: Supplier confirmed\n", order.ID)
+		}(Order{ID: id, Item: "flour"})
+	}
+
+	wg.Wait()
+
+	out.Printf("\n⏱️  Total time: %v\n", clk.Since(startTime).Round(time.Millisecond))
+}
+
+// Permanent errors are returned immediately without burning attempts
+func permanentFailure() {
+	out.Printf("\n=== 2. NON-RETRYABLE ERRORS ===\n\n")
+
+	supplier := &Supplier{rng: rand.New(rand.NewSource(7))}
+	order := Order{ID: 6, Item: "unicorn steak"}
+
+	err := conc.Retry(context.Background(), newPolicy(order.ID), func(ctx context.Context) error {
+		return supplier.PlaceOrder(ctx, order)
+	})
+
+	out.Printf("❌ Order %d: %v\n", order.ID, err)
+	out.Printf("🔍 errors.Is(err, ErrUnknownItem): %v\n", errors.Is(err, ErrUnknownItem))
+}
+
+// A deadline stops retries even in the middle of a backoff
+func cancelDuringBackoff() {
+	out.Printf("\n=== 3. CANCELLATION DURING BACKOFF ===\n\n")
+
+	ctx, cancel := clk.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	order := Order{ID: 7, Item: "sugar"}
+	startTime := clk.Now()
+
+	// A supplier that is always busy
+	err := conc.Retry(ctx, newPolicy(order.ID), func(ctx context.Context) error {
+		return fmt.Errorf("order %d: %w", order.ID, ErrSupplierBusy)
+	})
+
+	out.Printf("❌ Order %d: %v\n", order.ID, err)
+	out.Printf("🔍 errors.Is(err, context.DeadlineExceeded): %v\n", errors.Is(err, context.DeadlineExceeded))
+	out.Printf("⏱️  Gave up after %v (deadline 500ms)\n", clk.Since(startTime).Round(time.Millisecond))
+}
+
+// Kitchen workers wrap processOrder with a shared, jittered policy
+func workersWithPolicy() {
+	out.Printf("\n=== 4. WORKERS WRAPPING processOrder WITH A POLICY ===\n\n")
+
+	supplier := &Supplier{rng: rand.New(rand.NewSource(11))}
+	processOrder := func(order Order) error {
+		return supplier.PlaceOrder(context.Background(), order)
+	}
+
+	policy := conc.RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   50 * time.Millisecond,
+		Multiplier:  1.5,
+		MaxDelay:    500 * time.Millisecond,
+		Jitter:      true, // 3 workers hitting the same supplier shouldn't retry in lockstep
+		Retryable:   func(err error) bool { return errors.Is(err, ErrSupplierBusy) },
+		Clock:       clk,
+	}
+
+	orders := make(chan Order)
+	var wg sync.WaitGroup
+	for w := 1; w <= 3; w++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for order := range orders {
+				attempts := 0
+				err := policy.Do(func() error {
+					attempts++
+					return processOrder(order)
+				})
+				if err != nil {
+					out.Printf("❌ Worker %d: order %d: %v\n", worker, order.ID, err)
+					continue
+				}
+				out.Printf("✅ Worker %d: order %d confirmed after %d attempt(s)\n", worker, order.ID, attempts)
+			}
+		}(w)
+	}
+
+	for id := 10; id <= 15; id++ {
+		orders <- Order{ID: id, Item: "butter"}
+	}
+	close(orders)
+	wg.Wait()
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Retries with Backoff")
+	out.Println("==========================================")
+
+	retryTransientFailures()
+	permanentFailure()
+	cancelDuringBackoff()
+	workersWithPolicy()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ Retry transient errors, fail fast on permanent ones")
+	out.Println("✅ Exponential backoff gives a struggling dependency room to recover")
+	out.Println("✅ Jitter spreads out retries from many goroutines")
+	out.Println("✅ Backoff sleeps must respect context cancellation")
+	out.Println("✅ Wrap the last error with %w so callers can still inspect it")
+	out.Println("✅ A policy value with Do keeps retry settings in one place for every worker")
+	return nil
+}
@@ -0,0 +1,199 @@
+// Package signals is lesson 35: Signal Handling.
+package signals
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"os/signal"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+}
+
+var ErrShutdown = errors.New("kitchen is closed")
+
+// WorkerPool is a fixed set of chefs behind one queue, with a drain step
+// for shutting down without silently losing orders
+type WorkerPool struct {
+	orders chan Order
+	ctx    context.Context // Cancelled when the drain deadline passes
+	cancel context.CancelFunc
+
+	mu     sync.RWMutex // Guards closed against concurrent Submit/Drain
+	closed bool
+	wg     sync.WaitGroup
+
+	abandonedMu sync.Mutex
+	abandoned   []Order
+}
+
+// NewWorkerPool starts workers chefs
+func NewWorkerPool(workers int) *WorkerPool {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &WorkerPool{
+		orders: make(chan Order, workers*8),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	for id := 1; id <= workers; id++ {
+		p.wg.Add(1)
+		go p.worker(id)
+	}
+	return p
+}
+
+// Submit queues an order. It returns ErrShutdown once draining has started.
+func (p *WorkerPool) Submit(order Order) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrShutdown
+	}
+	p.orders <- order
+	return nil
+}
+
+// Drain stops intake and waits up to timeout for queued and in-flight
+// orders. It returns the orders that were abandoned when time ran out.
+func (p *WorkerPool) Drain(timeout time.Duration) []Order {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.orders)
+	}
+	p.mu.Unlock()
+
+	finished := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+	case <-clk.After(timeout):
+		p.cancel() // Chefs drop whatever they're cooking
+		<-finished
+	}
+
+	undone := slices.Clone(p.abandoned) // Every worker has exited: no more writers
+	for order := range p.orders {
+		undone = append(undone, order) // Still queued
+	}
+	return undone
+}
+
+func (p *WorkerPool) worker(id int) {
+	defer p.wg.Done()
+	for order := range p.orders {
+		select {
+		case <-clk.After(order.PrepTime):
+			out.Printf("✅ Chef %d: order %d ready\n", id, order.ID)
+		case <-p.ctx.Done():
+			p.abandonedMu.Lock()
+			p.abandoned = append(p.abandoned, order)
+			p.abandonedMu.Unlock()
+			return
+		}
+	}
+}
+
+// gracefulShutdown blocks until ctx is done, which NotifyContext does on
+// SIGINT or SIGTERM, then drains the pool for up to drainTimeout and reports
+// any orders abandoned because the drain timed out
+func gracefulShutdown(ctx context.Context, pool *WorkerPool, drainTimeout time.Duration) {
+	<-ctx.Done()
+
+	out.Printf("\n🛑 Signal received: no new orders, draining for up to %v\n\n", drainTimeout)
+	start := clk.Now()
+	abandoned := pool.Drain(drainTimeout)
+
+	out.Printf("\n🏁 Drained in %v\n", clk.Since(start).Round(100*time.Millisecond))
+	if len(abandoned) == 0 {
+		out.Println("✅ Every accepted order was finished")
+		return
+	}
+
+	ids := make([]int, 0, len(abandoned))
+	for _, o := range abandoned {
+		ids = append(ids, o.ID)
+	}
+	slices.Sort(ids)
+	out.Printf("⚠️  Abandoned orders: %v\n", ids)
+}
+
+// submitOrders keeps the lunch rush coming until the pool stops accepting orders.
+// Every 10th order, starting with order 5, is a slow roast that takes 12 seconds.
+func submitOrders(pool *WorkerPool) {
+	for id := 1; ; id++ {
+		prep := 300 * time.Millisecond
+		if id%10 == 5 {
+			prep = 12 * time.Second
+		}
+		if err := pool.Submit(Order{ID: id, PrepTime: prep}); err != nil {
+			out.Printf("🚫 Order %d refused: %v\n", id, err)
+			return
+		}
+		clk.Sleep(context.Background(), 80*time.Millisecond)
+	}
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	fs := flag.NewFlagSet("signal-handling", flag.ContinueOnError)
+	drainTimeout := fs.Duration("drain-timeout", 10*time.Second, "how long to wait for in-flight orders after a signal")
+	if err := fs.Parse(opts.Args); err != nil {
+		return lesson.Usage(err)
+	}
+
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Signal Handling")
+	out.Println("==========================================")
+	out.Printf("\nPID %d - press Ctrl+C or run `kill -TERM %d`\n", os.Getpid(), os.Getpid())
+	out.Printf("(sending ourselves SIGTERM in 1s for the demo)\n\n")
+
+	// Listen before anything can send the signal: a SIGTERM that arrived
+	// first would kill the process instead of starting the drain
+	sigCtx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	context.AfterFunc(sigCtx, stop) // After the first signal, a second Ctrl+C kills the program at once
+
+	pool := NewWorkerPool(3)
+	go submitOrders(pool)
+
+	// Simulate the orchestrator stopping us mid-batch
+	clk.AfterFunc(time.Second, func() {
+		syscall.Kill(os.Getpid(), syscall.SIGTERM)
+	})
+
+	gracefulShutdown(sigCtx, pool, *drainTimeout)
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ signal.NotifyContext turns SIGINT/SIGTERM into context cancellation")
+	out.Println("✅ Stop intake first, then let in-flight work finish")
+	out.Println("✅ Bound the drain with a timeout so a stuck order can't block exit")
+	out.Println("✅ Report abandoned orders so they can be retried elsewhere")
+	return nil
+}
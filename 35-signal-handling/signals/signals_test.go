@@ -0,0 +1,143 @@
+package signals
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// TestMain starts os/signal's watcher goroutine before any test runs. The
+// first Notify starts it and it never exits, so WaitForGoroutines would
+// otherwise count it against whichever test calls Notify first.
+func TestMain(m *testing.M) {
+	primer := make(chan os.Signal, 1)
+	signal.Notify(primer, syscall.SIGTERM)
+	signal.Stop(primer)
+	os.Exit(m.Run())
+}
+
+// onManualClock points the lesson at a fake clock that only moves when the
+// test advances it, and a printer into the returned buffer. Flush out
+// before reading it.
+func onManualClock(t *testing.T) (*clock.FakeClock, *bytes.Buffer) {
+	var buf bytes.Buffer
+	fake := clock.NewFake(testutil.Epoch)
+	savedClk := clk
+	clk, out = fake, display.NewPrinter(&buf)
+	t.Cleanup(func() {
+		out.Close()
+		clk = savedClk
+	})
+	return fake, &buf
+}
+
+// shutdownOnSignal listens for SIGTERM, runs gracefulShutdown on it, sends
+// this process SIGTERM, and returns a channel closed once gracefulShutdown
+// returns
+func shutdownOnSignal(t *testing.T, pool *WorkerPool, drainTimeout time.Duration) <-chan struct{} {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM)
+	t.Cleanup(stop)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		gracefulShutdown(ctx, pool, drainTimeout)
+	}()
+	if err := syscall.Kill(os.Getpid(), syscall.SIGTERM); err != nil {
+		t.Fatal(err)
+	}
+	return done
+}
+
+// SIGTERM stops intake and every accepted order still gets cooked
+func TestSignalDrainsGracefully(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake, buf := onManualClock(t)
+	pool := NewWorkerPool(3)
+	for id := 1; id <= 6; id++ {
+		if err := pool.Submit(Order{ID: id, PrepTime: 300 * time.Millisecond}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	done := shutdownOnSignal(t, pool, 10*time.Second)
+
+	// Three chefs and the drain timeout are waiting once the signal is in
+	for range 2 {
+		fake.BlockUntil(4)
+		if err := pool.Submit(Order{ID: 99}); !errors.Is(err, ErrShutdown) {
+			t.Errorf("Submit while draining = %v, want ErrShutdown", err)
+		}
+		fake.Advance(300 * time.Millisecond)
+	}
+	<-done
+	out.Flush()
+
+	printed := buf.String()
+	for _, want := range []string{
+		"🛑 Signal received: no new orders, draining for up to 10s",
+		"🏁 Drained in 600ms",
+		"✅ Every accepted order was finished",
+	} {
+		if !strings.Contains(printed, want) {
+			t.Errorf("missing %q in:\n%s", want, printed)
+		}
+	}
+	if n := strings.Count(printed, "ready"); n != 6 {
+		t.Errorf("%d orders ready, want all 6", n)
+	}
+}
+
+// An order that outlasts the drain is abandoned, along with anything still
+// queued behind it, and the drain ends at its timeout
+func TestSignalDrainTimesOut(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake, buf := onManualClock(t)
+	pool := NewWorkerPool(1)
+	pool.Submit(Order{ID: 1, PrepTime: 300 * time.Millisecond})
+	pool.Submit(Order{ID: 2, PrepTime: 12 * time.Second})
+	pool.Submit(Order{ID: 3, PrepTime: 300 * time.Millisecond})
+	done := shutdownOnSignal(t, pool, time.Second)
+
+	fake.BlockUntil(2) // The chef and the drain timeout
+	fake.Advance(300 * time.Millisecond)
+	fake.BlockUntil(2)
+	fake.Advance(700 * time.Millisecond)
+	<-done
+	out.Flush()
+
+	printed := buf.String()
+	for _, want := range []string{
+		"✅ Chef 1: order 1 ready",
+		"🏁 Drained in 1s",
+		"⚠️  Abandoned orders: [2 3]",
+	} {
+		if !strings.Contains(printed, want) {
+			t.Errorf("missing %q in:\n%s", want, printed)
+		}
+	}
+}
+
+// The whole lesson, which signals itself after a second. Run listens for
+// the signal before scheduling it, so it never reaches the default handler.
+func TestRun(t *testing.T) {
+	got := testutil.RunLesson(t, Run)
+
+	for _, want := range []string{
+		"🛑 Signal received: no new orders, draining for up to 10s",
+		"refused: kitchen is closed",
+		"⚠️  Abandoned orders: [5",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+}
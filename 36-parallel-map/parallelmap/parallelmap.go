@@ -0,0 +1,160 @@
+// Package parallelmap is lesson 36: Bounded Parallel Map.
+package parallelmap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/conc"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+	"github.com/Ajay2521/go-concurrency/pkg/order"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+	Distance float64 // Kilometers from the kitchen
+}
+
+// estimateDelivery simulates a call to a routing service (100ms)
+func estimateDelivery(ctx context.Context, order Order) (time.Duration, error) {
+	select {
+	case <-clk.After(100 * time.Millisecond):
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+
+	if order.Distance < 0 {
+		return 0, fmt.Errorf("order %d: invalid address", order.ID)
+	}
+	if order.Distance > 50 {
+		panic(fmt.Sprintf("order %d: routing service crashed on %.0fkm route", order.ID, order.Distance))
+	}
+
+	// 3 minutes per kilometer on top of prep time
+	travel := time.Duration(order.Distance * float64(3*time.Minute))
+	return order.PrepTime + travel, nil
+}
+
+// errRoutingBusy is the failure the routing service is made to return in section 3
+var errRoutingBusy = errors.New("routing service busy")
+
+// makeOrders creates n orders with varying prep times and distances
+func makeOrders(n int) []Order {
+	orders := make([]Order, n)
+	for i := range orders {
+		orders[i] = Order{
+			ID:       i + 1,
+			PrepTime: time.Duration(10+i%4*5) * time.Minute,
+			Distance: float64(1 + i%7),
+		}
+	}
+	return orders
+}
+
+// Compare elapsed time for different concurrency limits
+func compareLimits() {
+	out.Printf("\n=== 1. DELIVERY ESTIMATES FOR 50 ORDERS ===\n\n")
+
+	orders := makeOrders(50)
+
+	for _, limit := range []int{1, 5, 50} {
+		startTime := clk.Now()
+		etas, err := conc.Map(context.Background(), orders, limit, estimateDelivery)
+		if err != nil {
+			out.Printf("❌ limit=%d: %v\n", limit, err)
+			continue
+		}
+		out.Printf("🚚 limit=%-2d → %v (order 1 ETA %v, order 50 ETA %v)\n",
+			limit, clk.Since(startTime).Round(time.Millisecond), etas[0], etas[49])
+	}
+}
+
+// The first error stops new work; CollectErrors gathers them all
+func errorHandling() {
+	out.Printf("\n=== 2. ERROR HANDLING ===\n\n")
+
+	orders := makeOrders(20)
+	orders[3].Distance = -1  // Bad address
+	orders[12].Distance = -1 // Another bad address
+	orders[7].Distance = 99  // Crashes the routing service
+
+	_, err := conc.Map(context.Background(), orders, 2, estimateDelivery)
+	out.Printf("🛑 Fail fast:\n   %v\n", err)
+
+	_, err = conc.Map(context.Background(), orders, 2, estimateDelivery, conc.CollectErrors())
+	out.Printf("📋 Collect all:\n")
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		out.Printf("   %v\n", e)
+	}
+	out.Printf("🔍 errors.Is(err, conc.ErrPanic): %v\n", errors.Is(err, conc.ErrPanic))
+}
+
+// Seeded failures from the routing service: the same orders fail every run
+func injectedFailures() {
+	out.Printf("\n=== 3. INJECTED FAILURES ===\n\n")
+
+	fc := order.FailureConfig{Rate: 0.2, Kinds: []error{errRoutingBusy}, Seed: 14}
+	flaky := func(ctx context.Context, o Order) (time.Duration, error) {
+		eta, err := estimateDelivery(ctx, o)
+		if err == nil {
+			err = fc.Failure(o.ID, 1)
+		}
+		return eta, err
+	}
+
+	_, err := conc.Map(context.Background(), makeOrders(20), 5, flaky, conc.CollectErrors())
+	out.Printf("📋 Seed %d, collect all:\n", fc.Seed)
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		out.Printf("   %v\n", e)
+	}
+	out.Printf("🔍 errors.Is(err, errRoutingBusy): %v\n", errors.Is(err, errRoutingBusy))
+}
+
+// Edge cases: empty input, oversized limit, invalid limit
+func edgeCases() {
+	out.Printf("\n=== 4. EDGE CASES ===\n\n")
+
+	etas, err := conc.Map(context.Background(), []Order{}, 5, estimateDelivery)
+	out.Printf("📭 Empty slice: %d results, err=%v\n", len(etas), err)
+
+	etas, err = conc.Map(context.Background(), makeOrders(3), 100, estimateDelivery)
+	out.Printf("📦 Limit larger than input: %d results, err=%v\n", len(etas), err)
+
+	_, err = conc.Map(context.Background(), makeOrders(3), 0, estimateDelivery)
+	out.Printf("🚫 Limit 0: err=%v\n", err)
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Bounded Parallel Map")
+	out.Println("==========================================")
+
+	compareLimits()
+	errorHandling()
+	injectedFailures()
+	edgeCases()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ A semaphore channel bounds how many goroutines run at once")
+	out.Println("✅ Writing results by index preserves input order without locks")
+	out.Println("✅ Cancelling a shared context stops work after the first error")
+	out.Println("✅ Recovering panics keeps one bad item from crashing the program")
+	out.Println("✅ Generics make the helper reusable for any input and output type")
+	return nil
+}
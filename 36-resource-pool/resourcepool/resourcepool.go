@@ -0,0 +1,265 @@
+// Package resourcepool is lesson 36: Resource Pool.
+package resourcepool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+const poolSize = 3
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+	Burnt    bool // Fails halfway through
+}
+
+// KitchenResource is an expensive, reusable thing an order needs - here a
+// stove burner, like a database connection in database/sql
+type KitchenResource struct {
+	ID   int
+	Uses int // Only touched by whoever currently holds the resource
+}
+
+var ErrBurnt = errors.New("order burnt")
+
+// ResourcePool hands out a fixed set of resources. Idle resources sit in a
+// buffered channel: Get receives one, Put sends it back.
+type ResourcePool struct {
+	idle chan *KitchenResource
+}
+
+// NewResourcePool creates size resources up front
+func NewResourcePool(size int) *ResourcePool {
+	p := &ResourcePool{idle: make(chan *KitchenResource, size)}
+	for i := 1; i <= size; i++ {
+		p.idle <- &KitchenResource{ID: i}
+	}
+	return p
+}
+
+// Get blocks until a resource is free or ctx ends
+func (p *ResourcePool) Get(ctx context.Context) (*KitchenResource, error) {
+	select {
+	case r := <-p.idle:
+		return r, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Put returns r to the pool. Callers should `defer pool.Put(r)` right after
+// a successful Get so every return path gives it back. Putting more
+// resources than the pool holds (a double Put) panics.
+func (p *ResourcePool) Put(r *KitchenResource) {
+	select {
+	case p.idle <- r:
+	default:
+		panic(fmt.Sprintf("resource %d put back twice", r.ID))
+	}
+}
+
+// Available returns how many resources are idle right now
+func (p *ResourcePool) Available() int {
+	return len(p.idle)
+}
+
+// cook holds a burner for the whole order. The deferred Put runs on every
+// return path, including the early return for a burnt order.
+func cook(ctx context.Context, pool *ResourcePool, order Order) error {
+	burner, err := pool.Get(ctx)
+	if err != nil {
+		return err
+	}
+	defer pool.Put(burner)
+
+	burner.Uses++
+	if order.Burnt {
+		clk.Sleep(context.Background(), order.PrepTime/2)
+		return ErrBurnt
+	}
+	clk.Sleep(context.Background(), order.PrepTime)
+	return nil
+}
+
+// DBConnectionPool simulates a database that accepts at most maxConn
+// connections. Unlike ResourcePool there is nothing to hand out: a slot in
+// the semaphore channel is the connection, and holding one is permission to
+// query.
+type DBConnectionPool struct {
+	sem     chan struct{}
+	latency time.Duration
+
+	inFlight atomic.Int64 // Queries holding a connection right now
+	peak     atomic.Int64 // Most queries ever in flight at once
+}
+
+// NewDBConnectionPool allows maxConn simultaneous queries, each taking latency
+func NewDBConnectionPool(maxConn int, latency time.Duration) *DBConnectionPool {
+	return &DBConnectionPool{sem: make(chan struct{}, maxConn), latency: latency}
+}
+
+// Query looks up an order. It waits for a free connection, holds it for the
+// query's latency, then releases it. If ctx ends first, while waiting or
+// mid-query, it returns ctx.Err().
+func (db *DBConnectionPool) Query(ctx context.Context, orderID int) (string, error) {
+	select {
+	case db.sem <- struct{}{}: // Acquire
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	defer func() { <-db.sem }() // Release
+
+	now := db.inFlight.Add(1)
+	defer db.inFlight.Add(-1)
+	for old := db.peak.Load(); now > old && !db.peak.CompareAndSwap(old, now); old = db.peak.Load() {
+	}
+
+	timer := clk.NewTimer(db.latency)
+	defer timer.Stop()
+	select {
+	case <-timer.C():
+		return fmt.Sprintf("order %d: table %d, paid", orderID, orderID%12+1), nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// Peak returns the most queries that have ever run at once
+func (db *DBConnectionPool) Peak() int {
+	return int(db.peak.Load())
+}
+
+// Ten orders share three burners: never more than three cook at once
+func boundedConcurrency() {
+	out.Printf("\n=== 1. %d BURNERS, 10 ORDERS ===\n\n", poolSize)
+
+	pool := NewResourcePool(poolSize)
+	var active, peak atomic.Int64
+	var mu sync.Mutex
+	usedBy := map[int][]int{} // Burner ID → order IDs
+	start := clk.Now()
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 10; i++ {
+		wg.Add(1)
+		go func(orderID int) {
+			defer wg.Done()
+
+			burner, err := pool.Get(context.Background())
+			if err != nil {
+				out.Printf("❌ Order %d: %v\n", orderID, err)
+				return
+			}
+			defer pool.Put(burner)
+
+			now := active.Add(1)
+			for {
+				old := peak.Load()
+				if now <= old || peak.CompareAndSwap(old, now) {
+					break
+				}
+			}
+			mu.Lock()
+			usedBy[burner.ID] = append(usedBy[burner.ID], orderID)
+			mu.Unlock()
+
+			out.Printf("🔥 [+%3dms] Order %2d on burner %d (%d cooking)\n", clk.Since(start).Milliseconds(), orderID, burner.ID, now)
+			clk.Sleep(context.Background(), 100*time.Millisecond)
+			active.Add(-1)
+		}(i)
+	}
+	wg.Wait()
+
+	out.Println()
+	for id := 1; id <= poolSize; id++ {
+		out.Printf("♻️  Burner %d reused for orders %v\n", id, usedBy[id])
+	}
+	out.Printf("📊 Peak concurrency: %d (pool size %d)\n", peak.Load(), poolSize)
+}
+
+// Get gives up when the context ends before a burner frees up
+func getWithTimeout() {
+	out.Printf("\n=== 2. GET WITH A DEADLINE ===\n\n")
+
+	pool := NewResourcePool(1)
+	burner, _ := pool.Get(context.Background())
+	out.Printf("🔥 Burner %d is busy with a long braise\n", burner.ID)
+
+	ctx, cancel := clk.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := clk.Now()
+	_, err := pool.Get(ctx)
+	out.Printf("⏰ Second order gave up after %v: %v\n", clk.Since(start).Round(10*time.Millisecond), err)
+
+	pool.Put(burner)
+}
+
+// Ten callers, three connections: the rest wait their turn
+func throttledQueries() {
+	out.Printf("\n=== 3. DATABASE WITH %d CONNECTIONS, 10 CALLERS ===\n\n", poolSize)
+
+	db := NewDBConnectionPool(poolSize, 100*time.Millisecond)
+	start := clk.Now()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	rows := make([]string, 10)
+	for i := 1; i <= 10; i++ {
+		wg.Add(1)
+		go func(orderID int) {
+			defer wg.Done()
+			row, err := db.Query(context.Background(), orderID)
+			if err != nil {
+				row = err.Error()
+			}
+			mu.Lock()
+			rows[orderID-1] = fmt.Sprintf("🗄️  [+%3dms] %s", clk.Since(start).Milliseconds(), row)
+			mu.Unlock()
+		}(i)
+	}
+	wg.Wait()
+
+	for _, row := range rows {
+		out.Println(row)
+	}
+	out.Printf("\n📊 Peak simultaneous queries: %d of %d allowed; 10 queries took %v instead of 100ms\n",
+		db.Peak(), poolSize, clk.Since(start).Round(10*time.Millisecond))
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Resource Pool")
+	out.Println("==========================================")
+
+	boundedConcurrency()
+	getWithTimeout()
+	throttledQueries()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ A buffered channel of resources is a simple, fixed-size pool")
+	out.Println("✅ Get blocks until a resource is free or the context ends")
+	out.Println("✅ Resources are recycled instead of created per order")
+	out.Println("✅ defer pool.Put(r) right after Get closes every leak path")
+	out.Println("✅ A buffered chan struct{} is a semaphore that caps calls to an external service")
+	return nil
+}
@@ -0,0 +1,199 @@
+package resourcepool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// onFakeClock points the lesson at fake until the test ends
+func onFakeClock(t *testing.T, fake *clock.FakeClock) {
+	saved := clk
+	clk = fake
+	t.Cleanup(func() { clk = saved })
+}
+
+// cookLeaky forgets the defer: the early return for a burnt order never
+// puts its burner back. It is the counterexample the leak test must catch.
+func cookLeaky(ctx context.Context, pool *ResourcePool, order Order) error {
+	burner, err := pool.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	burner.Uses++
+	if order.Burnt {
+		clk.Sleep(context.Background(), order.PrepTime/2)
+		return ErrBurnt // Bug: burner is never returned
+	}
+	clk.Sleep(context.Background(), order.PrepTime)
+	pool.Put(burner)
+	return nil
+}
+
+// Every third order burns: cook still returns every burner, while the
+// manual Put in cookLeaky loses one per burnt order
+func TestCookReturnsEveryBurner(t *testing.T) {
+	tests := []struct {
+		name   string
+		cook   func(context.Context, *ResourcePool, Order) error
+		leaked int
+	}{
+		{"defer Put", cook, 0},
+		{"manual Put", cookLeaky, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutil.WaitForGoroutines(t)
+			onFakeClock(t, testutil.FakeClock(t))
+			pool := NewResourcePool(poolSize)
+
+			var burnt atomic.Int64
+			var wg sync.WaitGroup
+			for id := 1; id <= 6; id++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					o := Order{ID: id, PrepTime: 20 * time.Millisecond, Burnt: id%3 == 0}
+					ctx, cancel := clk.WithTimeout(context.Background(), 300*time.Millisecond)
+					defer cancel()
+					if err := tt.cook(ctx, pool, o); errors.Is(err, ErrBurnt) {
+						burnt.Add(1)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if burnt.Load() != 2 {
+				t.Errorf("%d burnt orders, want 2", burnt.Load())
+			}
+			if leaked := poolSize - pool.Available(); leaked != tt.leaked {
+				t.Errorf("%d of %d burners leaked, want %d", leaked, poolSize, tt.leaked)
+			}
+		})
+	}
+}
+
+// 50 callers arrive at once: the queries run exactly maxConn at a time,
+// none fails, and every connection is free afterwards
+func TestDBConnectionLimit(t *testing.T) {
+	for _, maxConn := range []int{1, 3, 8} {
+		t.Run(fmt.Sprintf("maxConn=%d", maxConn), func(t *testing.T) {
+			testutil.WaitForGoroutines(t)
+			onFakeClock(t, testutil.FakeClock(t))
+			db := NewDBConnectionPool(maxConn, 5*time.Millisecond)
+			var wg sync.WaitGroup
+			for id := 1; id <= 50; id++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if _, err := db.Query(context.Background(), id); err != nil {
+						t.Errorf("order %d: %v", id, err)
+					}
+				}()
+			}
+			wg.Wait()
+			if db.Peak() != maxConn {
+				t.Errorf("peak %d queries at once, want %d", db.Peak(), maxConn)
+			}
+			if held := len(db.sem); held != 0 {
+				t.Errorf("%d connections still held after every query returned", held)
+			}
+		})
+	}
+}
+
+// A slow query holds the only connection: the next caller gives up at its
+// deadline without ever holding one, and the slow query then releases it
+func TestDBConnectionExhausted(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := clock.NewFake(testutil.Epoch)
+	onFakeClock(t, fake)
+	db := NewDBConnectionPool(1, time.Second)
+
+	slow := make(chan error)
+	go func() {
+		_, err := db.Query(context.Background(), 1)
+		slow <- err
+	}()
+	fake.BlockUntil(1) // The slow query holds the connection
+
+	ctx, cancel := clk.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	waiting := make(chan error)
+	go func() {
+		_, err := db.Query(ctx, 2)
+		waiting <- err
+	}()
+	fake.Advance(50 * time.Millisecond)
+	if err := <-waiting; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("waiting caller: %v, want DeadlineExceeded", err)
+	}
+	if db.Peak() != 1 {
+		t.Errorf("peak %d, want 1: the waiting caller held a connection", db.Peak())
+	}
+
+	fake.Advance(time.Second)
+	if err := <-slow; err != nil {
+		t.Errorf("slow query: %v", err)
+	}
+	if held := len(db.sem); held != 0 {
+		t.Errorf("%d connections held after both callers returned", held)
+	}
+}
+
+// A query cancelled mid-flight returns ctx.Err() and gives its connection
+// back, so the next query gets it
+func TestDBQueryCancelledReleases(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := clock.NewFake(testutil.Epoch)
+	onFakeClock(t, fake)
+	db := NewDBConnectionPool(1, time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := db.Query(ctx, 1)
+		done <- err
+	}()
+	fake.BlockUntil(1) // Mid-query
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled query: %v, want Canceled", err)
+	}
+	if held := len(db.sem); held != 0 {
+		t.Fatalf("%d connections held after the cancelled query returned", held)
+	}
+
+	go func() {
+		_, err := db.Query(context.Background(), 2)
+		done <- err
+	}()
+	fake.BlockUntil(1)
+	fake.Advance(time.Second)
+	if err := <-done; err != nil {
+		t.Errorf("next query: %v", err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	got := testutil.RunLesson(t, Run)
+	for _, want := range []string{
+		"📊 Peak concurrency: 3 (pool size 3)",
+		"⏰ Second order gave up after 100ms: context deadline exceeded",
+		"📊 Peak simultaneous queries: 3 of 3 allowed",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+}
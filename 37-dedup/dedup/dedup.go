@@ -0,0 +1,137 @@
+// Package dedup is lesson 37: Deduplication.
+package dedup
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID     int
+	Item   string
+	Tablet string
+}
+
+// Deduplicator forwards each order ID to the kitchen at most once.
+// Seen IDs live in a sync.Map so concurrent Submits don't serialize on a lock
+// for the common case; mu only makes Reset exclusive.
+type Deduplicator struct {
+	mu     sync.RWMutex // Read-held by Submit, write-held by Reset
+	seen   sync.Map     // Order ID → struct{}
+	orders chan Order
+}
+
+// NewDeduplicator creates a deduplicator whose output channel buffers up to size orders
+func NewDeduplicator(size int) *Deduplicator {
+	return &Deduplicator{orders: make(chan Order, size)}
+}
+
+// Submit forwards the order and returns true, or returns false if its ID was
+// already submitted since the last Reset. It blocks while the output is full.
+func (d *Deduplicator) Submit(order Order) bool {
+	d.mu.RLock()
+	_, dup := d.seen.LoadOrStore(order.ID, struct{}{}) // Check and mark in one atomic step
+	d.mu.RUnlock()
+
+	if dup {
+		return false
+	}
+	d.orders <- order
+	return true
+}
+
+// Orders is where unique orders come out
+func (d *Deduplicator) Orders() <-chan Order {
+	return d.orders
+}
+
+// Reset forgets every seen ID. It waits for in-flight checks to finish, so an
+// ID is never half-forgotten while another goroutine is checking it.
+func (d *Deduplicator) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.seen.Clear()
+}
+
+// Close closes the output channel. Call it once, after the last Submit returns.
+func (d *Deduplicator) Close() {
+	close(d.orders)
+}
+
+// Two tablets at the counter; impatient customers tap "Place order" twice
+func doubleTaps() {
+	out.Printf("\n=== 1. 20 SUBMISSIONS, 5 DUPLICATES ===\n\n")
+
+	front := []Order{
+		{ID: 1, Item: "Burger"}, {ID: 2, Item: "Pizza"}, {ID: 2, Item: "Pizza"}, {ID: 3, Item: "Tacos"},
+		{ID: 4, Item: "Ramen"}, {ID: 5, Item: "Salad"}, {ID: 5, Item: "Salad"}, {ID: 6, Item: "Curry"},
+		{ID: 7, Item: "Pasta"}, {ID: 8, Item: "Sushi"},
+	}
+	patio := []Order{
+		{ID: 9, Item: "Wings"}, {ID: 10, Item: "Pho"}, {ID: 11, Item: "Gyro"}, {ID: 11, Item: "Gyro"},
+		{ID: 12, Item: "Bagel"}, {ID: 13, Item: "Falafel"}, {ID: 8, Item: "Sushi"}, {ID: 14, Item: "Steak"},
+		{ID: 15, Item: "Soup"}, {ID: 1, Item: "Burger"}, // Some tap twice on different tablets
+	}
+
+	dedup := NewDeduplicator(20)
+	var wg sync.WaitGroup
+	var rejected atomic.Int64
+
+	for tablet, orders := range map[string][]Order{"front": front, "patio": patio} {
+		wg.Add(1)
+		go func(tablet string, orders []Order) {
+			defer wg.Done()
+			for _, o := range orders {
+				o.Tablet = tablet
+				if !dedup.Submit(o) {
+					rejected.Add(1)
+					out.Printf("🚫 Order %2d (%s, %s tablet): duplicate, ignored\n", o.ID, o.Item, o.Tablet)
+				}
+				clk.Sleep(context.Background(), 5*time.Millisecond)
+			}
+		}(tablet, orders)
+	}
+
+	go func() {
+		wg.Wait()
+		dedup.Close()
+	}()
+
+	cooked := 0
+	for range dedup.Orders() {
+		cooked++
+	}
+	out.Printf("\n📊 Submitted: %d | Cooked: %d | Duplicates ignored: %d\n", len(front)+len(patio), cooked, rejected.Load())
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Deduplication")
+	out.Println("==========================================")
+
+	doubleTaps()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ LoadOrStore checks and marks an ID in one atomic step")
+	out.Println("✅ Load-then-Store would let two goroutines both see \"not seen\"")
+	out.Println("✅ An RWMutex lets Submits run together while Reset waits for them")
+	out.Println("✅ Close the output only after every Submit has returned")
+	return nil
+}
@@ -0,0 +1,92 @@
+package dedup
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// 100 goroutines submit order 42 at the same moment, 20 times over: each
+// time Submit returns true once and the kitchen receives the order once
+func TestSubmitSameIDConcurrently(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	for round := range 20 {
+		dedup := NewDeduplicator(100)
+		var wg sync.WaitGroup
+		var accepted atomic.Int64
+		start := make(chan struct{})
+		for range 100 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start // Release every goroutine together
+				if dedup.Submit(Order{ID: 42, Item: "Ramen"}) {
+					accepted.Add(1)
+				}
+			}()
+		}
+		close(start)
+		wg.Wait()
+		dedup.Close()
+
+		queued := 0
+		for range dedup.Orders() {
+			queued++
+		}
+		if accepted.Load() != 1 || queued != 1 {
+			t.Fatalf("round %d: Submit returned true %d times and %d orders were queued, want 1 and 1", round, accepted.Load(), queued)
+		}
+	}
+}
+
+// A seen ID stays rejected until Reset, and Resets racing with Submits of
+// new IDs neither lose nor double them
+func TestReset(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	dedup := NewDeduplicator(100)
+	if !dedup.Submit(Order{ID: 42}) {
+		t.Fatal("first submission of order 42 rejected")
+	}
+	if dedup.Submit(Order{ID: 42}) {
+		t.Error("resubmission before Reset accepted")
+	}
+
+	var wg sync.WaitGroup
+	var fresh atomic.Int64
+	for i := range 50 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if dedup.Submit(Order{ID: 1000 + i}) {
+				fresh.Add(1)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			dedup.Reset()
+		}()
+	}
+	wg.Wait()
+	if fresh.Load() != 50 {
+		t.Errorf("%d of 50 new IDs accepted during Resets", fresh.Load())
+	}
+
+	dedup.Reset()
+	if !dedup.Submit(Order{ID: 42}) {
+		t.Error("order 42 rejected after Reset")
+	}
+	dedup.Close()
+	if n := len(dedup.Orders()); n != 52 {
+		t.Errorf("%d orders queued, want 52", n)
+	}
+}
+
+func TestRun(t *testing.T) {
+	got := testutil.RunLesson(t, Run)
+	if want := "📊 Submitted: 20 | Cooked: 15 | Duplicates ignored: 5"; !strings.Contains(got, want) {
+		t.Errorf("missing %q in:\n%s", want, got)
+	}
+}
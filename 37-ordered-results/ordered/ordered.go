@@ -0,0 +1,243 @@
+// Package ordered is lesson 37: Ordered Fan-In.
+package ordered
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/conc"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+}
+
+// Result is a finished order as reported by a chef
+type Result struct {
+	OrderID int
+	Seq     int // Position in submission order, starting at 0
+	Chef    int
+	Took    time.Duration
+}
+
+// collectInOrder reads up to n results and returns them sorted by Seq.
+// Results are buffered in a slot per sequence number, so arrival order doesn't
+// matter. If an order was dropped, results closes before n arrive and its slot
+// is simply left out; duplicates and out-of-range sequence numbers are ignored.
+func collectInOrder(results <-chan Result, n int) []Result {
+	slots := make([]*Result, n)
+	received := 0
+
+	for r := range results {
+		if r.Seq < 0 || r.Seq >= n || slots[r.Seq] != nil {
+			continue
+		}
+		slots[r.Seq] = &r
+		received++
+		if received == n {
+			break // Every slot filled - no need to wait for close
+		}
+	}
+
+	ordered := make([]Result, 0, received)
+	for _, r := range slots {
+		if r != nil {
+			ordered = append(ordered, *r)
+		}
+	}
+	return ordered
+}
+
+// ticket pairs an order with its sequence number
+type ticket struct {
+	seq   int
+	order Order
+}
+
+// runKitchen processes orders with a small worker pool and returns the results channel.
+// Each order gets its submission position as Seq. Orders with an ID in drop are "lost"
+// and never produce a result.
+func runKitchen(orders []Order, workers int, drop map[int]bool) <-chan Result {
+	jobs := make(chan ticket)
+	results := make(chan Result)
+	var wg sync.WaitGroup
+
+	for w := 1; w <= workers; w++ {
+		wg.Add(1)
+		go func(chef int) {
+			defer wg.Done()
+			for t := range jobs {
+				clk.Sleep(context.Background(), t.order.PrepTime)
+				if drop[t.order.ID] {
+					continue
+				}
+				results <- Result{OrderID: t.order.ID, Seq: t.seq, Chef: chef, Took: t.order.PrepTime}
+			}
+		}(w)
+	}
+
+	go func() {
+		for i, order := range orders {
+			jobs <- ticket{seq: i, order: order}
+		}
+		close(jobs)
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
+
+// makeOrders creates n orders with shuffled prep times
+func makeOrders(n int, rng *rand.Rand) []Order {
+	orders := make([]Order, n)
+	for i := range orders {
+		orders[i] = Order{ID: i + 1, PrepTime: time.Duration(50+rng.Intn(300)) * time.Millisecond}
+	}
+	return orders
+}
+
+// Results arrive in completion order
+func unorderedResults() {
+	out.Printf("\n=== 1. COMPLETION ORDER (Unordered) ===\n\n")
+
+	orders := makeOrders(8, rand.New(rand.NewSource(1)))
+	for r := range runKitchen(orders, 4, nil) {
+		out.Printf("📣 Order %d ready (chef %d, %v)\n", r.OrderID, r.Chef, r.Took)
+	}
+}
+
+// Reorder announces results strictly by order ID
+func orderedResults() {
+	out.Printf("\n=== 2. ANNOUNCED IN ORDER (Reorder) ===\n\n")
+
+	orders := makeOrders(8, rand.New(rand.NewSource(1)))
+	byID := func(r Result) int { return r.OrderID }
+
+	for r := range conc.Reorder(runKitchen(orders, 4, nil), byID) {
+		out.Printf("📣 Order %d ready (chef %d, %v)\n", r.OrderID, r.Chef, r.Took)
+	}
+}
+
+// A lost order would block announcements forever - a bounded buffer skips the gap
+func boundedBufferWithGap() {
+	out.Printf("\n=== 3. MISSING ORDER WITH A BOUNDED BUFFER ===\n\n")
+
+	orders := makeOrders(12, rand.New(rand.NewSource(2)))
+	byID := func(r Result) int { return r.OrderID }
+	lost := map[int]bool{3: true} // Order 3's ticket fell behind the counter
+
+	results := conc.Reorder(runKitchen(orders, 4, lost), byID,
+		conc.WithMaxBuffer(4, conc.OverflowSkipGap),
+		conc.WithOnSkip(func(err error) {
+			out.Printf("⚠️  %v\n", err)
+		}),
+	)
+
+	for r := range results {
+		out.Printf("📣 Order %d ready\n", r.OrderID)
+	}
+}
+
+// Buffered results are flushed in order when the input closes
+func flushOnClose() {
+	out.Printf("\n=== 4. FLUSH ON CLOSE ===\n\n")
+
+	in := make(chan Result)
+	byID := func(r Result) int { return r.OrderID }
+	ordered := conc.Reorder(in, byID)
+
+	go func() {
+		for _, id := range []int{2, 5, 1, 4} { // Order 3 never arrives
+			in <- Result{OrderID: id}
+		}
+		close(in)
+	}()
+
+	for r := range ordered {
+		out.Printf("📣 Order %d\n", r.OrderID)
+	}
+}
+
+// collectInOrder restores submission order, even when order IDs aren't sequential
+func collectInSubmissionOrder() {
+	out.Printf("\n=== 5. COLLECT IN SUBMISSION ORDER ===\n\n")
+
+	// Ticket numbers are arbitrary; later submissions cook faster, so they finish first
+	orders := []Order{
+		{ID: 107, PrepTime: 250 * time.Millisecond},
+		{ID: 42, PrepTime: 200 * time.Millisecond},
+		{ID: 311, PrepTime: 150 * time.Millisecond},
+		{ID: 8, PrepTime: 100 * time.Millisecond},
+		{ID: 56, PrepTime: 50 * time.Millisecond},
+	}
+	ids := func(results []Result) []int {
+		out := make([]int, len(results))
+		for i, r := range results {
+			out[i] = r.OrderID
+		}
+		return out
+	}
+
+	for _, run := range []struct {
+		name string
+		drop map[int]bool
+	}{
+		{"all orders, reverse completion", nil},
+		{"order 311 dropped", map[int]bool{311: true}},
+	} {
+		var arrived []int
+		results := make(chan Result)
+		go func() {
+			defer close(results)
+			for r := range runKitchen(orders, len(orders), run.drop) {
+				arrived = append(arrived, r.OrderID)
+				results <- r
+			}
+		}()
+
+		got := ids(collectInOrder(results, len(orders)))
+		out.Printf("📦 %-34s arrived %v → collected %v\n", run.name+":", arrived, got)
+	}
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Ordered Fan-In")
+	out.Println("==========================================")
+
+	unorderedResults()
+	orderedResults()
+	boundedBufferWithGap()
+	flushOnClose()
+	collectInSubmissionOrder()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ Concurrent workers finish in any order")
+	out.Println("✅ A reorder stage buffers early arrivals until their turn")
+	out.Println("✅ Only one goroutine owns the buffer, so no locks are needed")
+	out.Println("✅ Bound the buffer - a missing item can otherwise stall output forever")
+	out.Println("✅ Flush leftovers when the input closes so nothing is lost")
+	out.Println("✅ A sequence number assigned at submission orders results whatever their IDs")
+	return nil
+}
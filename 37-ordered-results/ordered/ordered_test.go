@@ -0,0 +1,128 @@
+package ordered
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// orderIDs lists the results' order IDs in slice order
+func orderIDs(results []Result) []int {
+	ids := make([]int, len(results))
+	for i, r := range results {
+		ids[i] = r.OrderID
+	}
+	return ids
+}
+
+// Later submissions cook faster, so the kitchen finishes in reverse; the
+// collector returns submission order, leaving out a dropped order's slot
+func TestCollectInOrderFromKitchen(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	saved := clk
+	clk = testutil.FakeClock(t)
+	defer func() { clk = saved }()
+
+	orders := []Order{
+		{ID: 107, PrepTime: 250 * time.Millisecond},
+		{ID: 42, PrepTime: 200 * time.Millisecond},
+		{ID: 311, PrepTime: 150 * time.Millisecond},
+		{ID: 8, PrepTime: 100 * time.Millisecond},
+		{ID: 56, PrepTime: 50 * time.Millisecond},
+	}
+	tests := []struct {
+		name          string
+		drop          map[int]bool
+		arrived, want []int
+	}{
+		{"all orders, reverse completion", nil, []int{56, 8, 311, 42, 107}, []int{107, 42, 311, 8, 56}},
+		{"order 311 dropped", map[int]bool{311: true}, []int{56, 8, 42, 107}, []int{107, 42, 8, 56}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var arrived []int
+			results := make(chan Result)
+			go func() {
+				defer close(results)
+				for r := range runKitchen(orders, len(orders), tt.drop) {
+					arrived = append(arrived, r.OrderID)
+					results <- r
+				}
+			}()
+			got := collectInOrder(results, len(orders))
+			if !slices.Equal(arrived, tt.arrived) {
+				t.Errorf("arrived %v, want %v: the kitchen didn't finish out of order", arrived, tt.arrived)
+			}
+			if !slices.Equal(orderIDs(got), tt.want) {
+				t.Errorf("collected %v, want %v", orderIDs(got), tt.want)
+			}
+		})
+	}
+}
+
+// Results fed by hand in a fixed arrival order, on a channel closed after
+// the last one
+func TestCollectInOrder(t *testing.T) {
+	type input struct {
+		seqs []int
+		n    int
+	}
+	testutil.RunParallel(t, []testutil.TestCase{
+		{Name: "reverse arrival", Input: input{[]int{3, 2, 1, 0}, 4}, Want: []int{0, 1, 2, 3}},
+		{Name: "gap closes early", Input: input{[]int{2, 0}, 3}, Want: []int{0, 2}},
+		{Name: "duplicate ignored", Input: input{[]int{1, 1, 0}, 2}, Want: []int{0, 1}},
+		{Name: "out of range ignored", Input: input{[]int{-1, 5, 0}, 2}, Want: []int{0}},
+		{Name: "nothing arrives", Input: input{nil, 3}, Want: []int{}},
+	}, func(t *testing.T, tc testutil.TestCase) {
+		in := tc.Input.(input)
+		results := make(chan Result, len(in.seqs))
+		for _, seq := range in.seqs {
+			results <- Result{OrderID: 100 + seq, Seq: seq}
+		}
+		close(results)
+		got := collectInOrder(results, in.n)
+		seqs := make([]int, len(got))
+		for i, r := range got {
+			seqs[i] = r.Seq
+			if r.OrderID != 100+r.Seq {
+				t.Errorf("slot %d holds order %d", r.Seq, r.OrderID)
+			}
+		}
+		if !slices.Equal(seqs, tc.Want.([]int)) {
+			t.Errorf("collected seqs %v, want %v", seqs, tc.Want)
+		}
+	})
+}
+
+// Once all n slots are filled, collectInOrder returns without waiting for
+// the channel to close
+func TestCollectInOrderStopsAtN(t *testing.T) {
+	results := make(chan Result, 2)
+	results <- Result{Seq: 1}
+	results <- Result{Seq: 0}
+	done := make(chan []Result)
+	go func() { done <- collectInOrder(results, 2) }()
+	select {
+	case got := <-done:
+		if len(got) != 2 {
+			t.Errorf("collected %d results, want 2", len(got))
+		}
+	case <-time.After(time.Second):
+		t.Fatal("still waiting for close after every slot was filled")
+	}
+}
+
+func TestRun(t *testing.T) {
+	got := testutil.RunLesson(t, Run)
+	for _, want := range []string{
+		"arrived [56 8 311 42 107] → collected [107 42 311 8 56]",
+		"arrived [56 8 42 107] → collected [107 42 8 56]",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Package scatter is lesson 38: Scatter-Gather.
+package scatter
+
+import (
+	"context"
+	"runtime"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/conc"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+// Quote is a supplier's price for an order's ingredients
+type Quote struct {
+	Supplier string
+	Price    float64
+}
+
+// supplier returns a quote function with a fixed latency
+func supplier(name string, price float64, latency time.Duration) func(ctx context.Context) (Quote, error) {
+	return func(ctx context.Context) (Quote, error) {
+		select {
+		case <-clk.After(latency):
+			return Quote{Supplier: name, Price: price}, nil
+		case <-ctx.Done():
+			return Quote{}, ctx.Err() // Stop working as soon as nobody is listening
+		}
+	}
+}
+
+func suppliers() []func(ctx context.Context) (Quote, error) {
+	return []func(ctx context.Context) (Quote, error){
+		supplier("FreshFarm", 42.50, 120*time.Millisecond),
+		supplier("CityWholesale", 39.90, 250*time.Millisecond),
+		supplier("GreenGrocer", 44.00, 380*time.Millisecond),
+		supplier("MegaFoods", 37.75, 520*time.Millisecond),
+		supplier("LocalMarket", 41.20, 650*time.Millisecond),
+	}
+}
+
+// priceOrder scatters quote requests and picks the best one that arrived in time
+func priceOrder(orderID int, deadline time.Duration) {
+	ctx, cancel := clk.WithTimeout(context.Background(), deadline)
+	defer cancel()
+
+	startTime := clk.Now()
+	results := conc.Gather(ctx, suppliers()...)
+	elapsed := clk.Since(startTime)
+
+	var best *Quote
+	responded := 0
+	for i, r := range results {
+		switch {
+		case r.TimedOut:
+			out.Printf("   ⌛ Supplier #%d   - no quote within %v\n", i+1, deadline)
+		case r.Err != nil:
+			out.Printf("   ❌ %v\n", r.Err)
+		default:
+			responded++
+			out.Printf("   💰 %-13s $%.2f\n", r.Value.Supplier, r.Value.Price)
+			if best == nil || r.Value.Price < best.Price {
+				q := r.Value
+				best = &q
+			}
+		}
+	}
+
+	out.Printf("\n📊 Order %d: %d/5 quotes in %v\n", orderID, responded, elapsed.Round(time.Millisecond))
+	if best != nil {
+		out.Printf("🏆 Best price: %s at $%.2f\n", best.Supplier, best.Price)
+	}
+}
+
+// All suppliers answer within a generous deadline
+func generousDeadline() {
+	out.Printf("\n=== 1. GENEROUS DEADLINE (800ms) ===\n\n")
+	priceOrder(1, 800*time.Millisecond)
+}
+
+// Only the fastest suppliers make a tight deadline
+func tightDeadline() {
+	out.Printf("\n=== 2. TIGHT DEADLINE (300ms) ===\n\n")
+	priceOrder(2, 300*time.Millisecond)
+}
+
+// Stragglers are cancelled and exit instead of leaking
+func noLeakedStragglers() {
+	out.Printf("\n=== 3. STRAGGLER CLEANUP ===\n\n")
+
+	clk.Sleep(context.Background(), 50*time.Millisecond) // Let stragglers from the previous demos finish exiting
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := clk.WithTimeout(context.Background(), 100*time.Millisecond)
+	conc.Gather(ctx, suppliers()...)
+	cancel()
+
+	clk.Sleep(context.Background(), 50*time.Millisecond) // Give cancelled goroutines a moment to return
+	out.Printf("📊 Goroutines before: %d, after: %d\n", before, runtime.NumGoroutine())
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Scatter-Gather")
+	out.Println("==========================================")
+
+	generousDeadline()
+	tightDeadline()
+	noLeakedStragglers()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ Scatter: fire all requests concurrently")
+	out.Println("✅ Gather: collect until everyone answers or the deadline hits")
+	out.Println("✅ Partial results are often better than no result")
+	out.Println("✅ A buffered results channel lets late goroutines exit")
+	out.Println("✅ Cancel the shared context so stragglers stop working")
+	return nil
+}
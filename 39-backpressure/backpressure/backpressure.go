@@ -0,0 +1,154 @@
+// Package backpressure is lesson 39: Backpressure.
+package backpressure
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/conc"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+	PlacedAt time.Time
+}
+
+// burstStats summarises one run of the lunch burst
+type burstStats struct {
+	served    int
+	dropped   int
+	avgWait   time.Duration // Placed → started cooking, for served orders
+	producing time.Duration // How long it took customers to place every order
+}
+
+// runBurst sends 20 orders every 10ms into a queue of 5, while one chef cooks
+// each order in 40ms. Customers outpace the kitchen 4:1.
+func runBurst(policy conc.QueuePolicy) burstStats {
+	const (
+		orders   = 20
+		arrival  = 10 * time.Millisecond
+		prepTime = 40 * time.Millisecond
+	)
+
+	var dropped atomic.Int64
+	queue := conc.NewBoundedQueue(5, policy, func(o Order) {
+		dropped.Add(1)
+		out.Printf("   🙇 Order %2d: Sorry, we couldn't get to your order (evicted)\n", o.ID)
+	})
+
+	ctx := context.Background()
+	var served int
+	var totalWait time.Duration
+	done := make(chan struct{})
+
+	// Single chef
+	go func() {
+		defer close(done)
+		for {
+			order, err := queue.Get(ctx)
+			if err != nil {
+				return // ErrQueueClosed: nothing left to cook
+			}
+			totalWait += clk.Since(order.PlacedAt)
+			served++
+			clk.Sleep(context.Background(), order.PrepTime)
+		}
+	}()
+
+	start := clk.Now()
+	for id := 1; id <= orders; id++ {
+		order := Order{ID: id, PrepTime: prepTime, PlacedAt: clk.Now()}
+		if err := queue.Put(ctx, order); errors.Is(err, conc.ErrDropped) {
+			dropped.Add(1)
+			out.Printf("   🙇 Order %2d: Sorry, the kitchen is full (rejected)\n", id)
+		}
+		clk.Sleep(context.Background(), arrival)
+	}
+	producing := clk.Since(start)
+
+	queue.Close()
+	<-done
+
+	stats := burstStats{served: served, dropped: int(dropped.Load()), producing: producing}
+	if served > 0 {
+		stats.avgWait = totalWait / time.Duration(served)
+	}
+	return stats
+}
+
+// The same burst under each policy
+func comparePolicies() {
+	out.Printf("\n=== 1. SAME BURST, THREE POLICIES ===\n")
+
+	policies := []conc.QueuePolicy{conc.Block, conc.DropNewest, conc.DropOldest}
+	results := make(map[conc.QueuePolicy]burstStats)
+
+	for _, p := range policies {
+		out.Printf("\n▶️  Policy: %v\n", p)
+		results[p] = runBurst(p)
+	}
+
+	out.Printf("\n%-12s %8s %8s %10s %14s\n", "Policy", "Served", "Dropped", "Avg wait", "Intake time")
+	for _, p := range policies {
+		s := results[p]
+		out.Printf("%-12v %8d %8d %10v %14v\n", p, s.served, s.dropped,
+			s.avgWait.Round(time.Millisecond), s.producing.Round(10*time.Millisecond))
+	}
+}
+
+// Blocking Put with a deadline: the producer gives up instead of waiting forever
+func blockWithDeadline() {
+	out.Printf("\n=== 2. BLOCKING PUT WITH A DEADLINE ===\n\n")
+
+	queue := conc.NewBoundedQueue[Order](2, conc.Block, nil)
+	ctx := context.Background()
+	for id := 1; id <= 2; id++ {
+		queue.Put(ctx, Order{ID: id})
+	}
+	out.Printf("📦 Queue: %d/%d\n", queue.Len(), queue.Cap())
+
+	ctx, cancel := clk.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := clk.Now()
+	err := queue.Put(ctx, Order{ID: 3})
+	out.Printf("⌛ Order 3: %v after %v\n", err, clk.Since(start).Round(10*time.Millisecond))
+
+	// FIFO: the first order in is the first order out
+	first, _ := queue.Get(context.Background())
+	out.Printf("🍳 Next order for the chef: %d\n", first.ID)
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Backpressure")
+	out.Println("==========================================")
+
+	comparePolicies()
+	blockWithDeadline()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ A bounded queue turns overload into an explicit decision")
+	out.Println("✅ Block pushes the slowdown back onto producers")
+	out.Println("✅ Drop-newest protects orders already waiting")
+	out.Println("✅ Drop-oldest keeps latency low by favouring fresh orders")
+	out.Println("✅ A context deadline keeps blocking producers from waiting forever")
+	return nil
+}
@@ -0,0 +1,250 @@
+// Package futures is lesson 39: Futures.
+package futures
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	Dish     string
+	PrepTime time.Duration
+	Total    float64
+}
+
+// Future is a result that will be ready later. NewFuture starts the work
+// straight away; Get waits for it. The result is computed once and every Get
+// returns the same value and error.
+type Future[T any] struct {
+	once  sync.Once
+	done  chan struct{} // Closed once value and err are set
+	value T
+	err   error
+}
+
+// NewFuture runs fn in a new goroutine and returns a Future for its result.
+// A panic in fn becomes the Future's error instead of crashing the program.
+func NewFuture[T any](fn func() (T, error)) *Future[T] {
+	f := &Future[T]{done: make(chan struct{})}
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				var zero T
+				f.complete(zero, fmt.Errorf("future panicked: %v", r))
+			}
+		}()
+		f.complete(fn())
+	}()
+	return f
+}
+
+// complete records the result and releases every waiting Get, exactly once
+func (f *Future[T]) complete(value T, err error) {
+	f.once.Do(func() {
+		f.value, f.err = value, err
+		close(f.done)
+	})
+}
+
+// Get waits for the result or for ctx to end. Giving up on ctx doesn't stop
+// fn; a later Get can still collect the result.
+func (f *Future[T]) Get(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err // Safe without a lock: written before done closed
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Done returns a channel that closes when the result is ready
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+var ErrCardDeclined = errors.New("card declined")
+
+type Receipt struct {
+	OrderID int
+	Dish    string
+	Charged float64
+}
+
+// cookOrder and chargeCard are the two slow steps of checkout
+func cookOrder(o Order) (string, error) {
+	clk.Sleep(context.Background(), o.PrepTime)
+	return o.Dish, nil
+}
+
+func chargeCard(o Order, declined bool) (float64, error) {
+	clk.Sleep(context.Background(), 250*time.Millisecond) // Talking to the payment provider
+	if declined {
+		return 0, ErrCardDeclined
+	}
+	return o.Total, nil
+}
+
+// checkout composes two futures: the receipt waits on the dish and the payment,
+// which run at the same time
+func checkout(ctx context.Context, o Order, declined bool) *Future[Receipt] {
+	dish := NewFuture(func() (string, error) { return cookOrder(o) })
+	payment := NewFuture(func() (float64, error) { return chargeCard(o, declined) })
+
+	return NewFuture(func() (Receipt, error) {
+		charged, err := payment.Get(ctx)
+		if err != nil {
+			return Receipt{}, fmt.Errorf("order %d payment: %w", o.ID, err)
+		}
+		name, err := dish.Get(ctx)
+		if err != nil {
+			return Receipt{}, fmt.Errorf("order %d kitchen: %w", o.ID, err)
+		}
+		return Receipt{OrderID: o.ID, Dish: name, Charged: charged}, nil
+	})
+}
+
+// Cooking and payment overlap, so checkout takes the longer of the two, not the sum
+func concurrentCheckout() {
+	out.Printf("\n=== 1. COMPOSING FUTURES: PREP + PAYMENT ===\n\n")
+
+	ctx := context.Background()
+	startTime := clk.Now()
+
+	orders := []struct {
+		order    Order
+		declined bool
+	}{
+		{Order{ID: 1, Dish: "Ramen", PrepTime: 300 * time.Millisecond, Total: 14.50}, false},
+		{Order{ID: 2, Dish: "Tacos", PrepTime: 200 * time.Millisecond, Total: 11.00}, false},
+		{Order{ID: 3, Dish: "Curry", PrepTime: 350 * time.Millisecond, Total: 16.25}, true},
+	}
+
+	receipts := make([]*Future[Receipt], len(orders))
+	for i, o := range orders {
+		receipts[i] = checkout(ctx, o.order, o.declined)
+		out.Printf("🧾 Order %d: %s (prep %v, payment 250ms)\n", o.order.ID, o.order.Dish, o.order.PrepTime)
+	}
+	out.Println()
+
+	for _, f := range receipts {
+		r, err := f.Get(ctx)
+		elapsed := clk.Since(startTime).Milliseconds()
+		if err != nil {
+			out.Printf("❌ [+%3dms] %v\n", elapsed, err)
+			continue
+		}
+		out.Printf("✅ [+%3dms] Order %d: %s, charged $%.2f\n", elapsed, r.OrderID, r.Dish, r.Charged)
+	}
+	out.Printf("\n⏱️  All checkouts done in %dms (sequential would be about 1600ms)\n", clk.Since(startTime).Milliseconds())
+}
+
+// Get is idempotent, cancellable, and safe from many goroutines
+func futureChecks() {
+	out.Printf("\n=== 2. FUTURE CHECKS ===\n\n")
+
+	check := func(name string, ok bool, detail string) {
+		status := "✅"
+		if !ok {
+			status = "❌"
+		}
+		out.Printf("%s %-44s %s\n", status, name, detail)
+	}
+
+	// Repeated Gets share one run of fn
+	var runs atomic.Int64
+	f := NewFuture(func() (int, error) {
+		runs.Add(1)
+		clk.Sleep(context.Background(), 20*time.Millisecond)
+		return 42, nil
+	})
+	a, errA := f.Get(context.Background())
+	b, errB := f.Get(context.Background())
+	check("Two Gets, same result, fn ran once:", a == 42 && b == 42 && errA == nil && errB == nil && runs.Load() == 1,
+		fmt.Sprintf("%d, %d, %d run(s)", a, b, runs.Load()))
+
+	// 100 concurrent Gets on a slow future
+	f = NewFuture(func() (int, error) {
+		runs.Add(1)
+		clk.Sleep(context.Background(), 30*time.Millisecond)
+		return 7, nil
+	})
+	var wg sync.WaitGroup
+	var same atomic.Int64
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := f.Get(context.Background()); v == 7 && err == nil {
+				same.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	check("100 concurrent Gets see one result:", same.Load() == 100 && runs.Load() == 2,
+		fmt.Sprintf("%d/100 got 7", same.Load()))
+
+	// Giving up on ctx doesn't lose the result
+	slow := NewFuture(func() (string, error) {
+		clk.Sleep(context.Background(), 100*time.Millisecond)
+		return "Risotto", nil
+	})
+	ctx, cancel := clk.WithTimeout(context.Background(), 20*time.Millisecond)
+	_, err := slow.Get(ctx)
+	cancel()
+	check("Get returns ctx.Err() when ctx ends first:", errors.Is(err, context.DeadlineExceeded), fmt.Sprint(err))
+	v, err := slow.Get(context.Background())
+	check("A later Get still collects the result:", v == "Risotto" && err == nil, v)
+
+	// Errors and panics come back from Get
+	failed := NewFuture(func() (float64, error) { return 0, ErrCardDeclined })
+	_, err = failed.Get(context.Background())
+	check("fn's error is returned by Get:", errors.Is(err, ErrCardDeclined), fmt.Sprint(err))
+
+	panicky := NewFuture(func() (int, error) { panic("oven on fire") })
+	_, err = panicky.Get(context.Background())
+	check("A panic in fn becomes an error:", err != nil, fmt.Sprint(err))
+
+	// The composed checkout passes the payment error through
+	startTime := clk.Now()
+	_, err = checkout(context.Background(), Order{ID: 9, Dish: "Pho", PrepTime: 200 * time.Millisecond}, true).Get(context.Background())
+	check("Composed future wraps the payment error:", errors.Is(err, ErrCardDeclined),
+		fmt.Sprintf("%v after %dms", err, clk.Since(startTime).Milliseconds()))
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Futures")
+	out.Println("==========================================")
+
+	concurrentCheckout()
+	futureChecks()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ A future is a goroutine plus a channel that closes when the result is set")
+	out.Println("✅ sync.Once guarantees the result is written and published exactly once")
+	out.Println("✅ Closing a channel wakes every waiter, so any number of Gets can share a result")
+	out.Println("✅ Select on ctx.Done() in Get so callers can stop waiting")
+	out.Println("✅ Start futures first, then Get them, so the work overlaps")
+	return nil
+}
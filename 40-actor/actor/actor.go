@@ -0,0 +1,316 @@
+// Package actor is lesson 40: Actors & Confinement.
+package actor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/actor"
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+}
+
+// Status is where an order is in the kitchen
+type Status string
+
+const (
+	StatusUnknown   Status = "unknown"
+	StatusCooking   Status = "cooking"
+	StatusReady     Status = "ready"
+	StatusCancelled Status = "cancelled"
+)
+
+// Messages the kitchen understands. The marker method keeps
+// arbitrary values out of the mailbox.
+type kitchenMsg interface{ kitchenMsg() }
+
+type placeOrder struct{ order Order }
+
+type queryStatus struct {
+	id    int
+	reply chan<- Status
+}
+
+type cancelOrder struct {
+	id    int
+	reply chan<- error
+}
+
+type countOrders struct{ reply chan<- int }
+
+type orderReady struct{ id int } // Sent by the kitchen to itself when a timer fires
+
+func (placeOrder) kitchenMsg()  {}
+func (queryStatus) kitchenMsg() {}
+func (cancelOrder) kitchenMsg() {}
+func (countOrders) kitchenMsg() {}
+func (orderReady) kitchenMsg()  {}
+
+// Kitchen owns the order book. Only the actor goroutine reads or writes
+// orders, so it is a plain map with no mutex.
+type Kitchen struct {
+	actor  *actor.Actor[kitchenMsg]
+	orders map[int]Status
+}
+
+// NewKitchen starts the kitchen actor
+func NewKitchen() *Kitchen {
+	k := &Kitchen{orders: make(map[int]Status)}
+	k.actor = actor.New(64, k.handle)
+	return k
+}
+
+// handle runs inside the actor goroutine - the only place k.orders is touched
+func (k *Kitchen) handle(msg kitchenMsg) {
+	switch m := msg.(type) {
+	case placeOrder:
+		k.orders[m.order.ID] = StatusCooking
+		id := m.order.ID
+		// Cooking happens off the actor; completion comes back as a message
+		clk.AfterFunc(m.order.PrepTime, func() { k.actor.Send(orderReady{id: id}) })
+
+	case orderReady:
+		if k.orders[m.id] == StatusCooking { // Ignore orders cancelled meanwhile
+			k.orders[m.id] = StatusReady
+		}
+
+	case queryStatus:
+		status, ok := k.orders[m.id]
+		if !ok {
+			status = StatusUnknown
+		}
+		m.reply <- status
+
+	case countOrders:
+		m.reply <- len(k.orders)
+
+	case cancelOrder:
+		switch k.orders[m.id] {
+		case StatusCooking:
+			k.orders[m.id] = StatusCancelled
+			m.reply <- nil
+		case StatusReady:
+			m.reply <- fmt.Errorf("order %d is already ready", m.id)
+		default:
+			m.reply <- fmt.Errorf("order %d not found", m.id)
+		}
+	}
+}
+
+// Place queues a new order (fire-and-forget)
+func (k *Kitchen) Place(order Order) error {
+	return k.actor.Send(placeOrder{order: order})
+}
+
+// Status asks the kitchen where an order is
+func (k *Kitchen) Status(ctx context.Context, id int) (Status, error) {
+	return actor.Call(ctx, k.actor, func(reply chan<- Status) kitchenMsg {
+		return queryStatus{id: id, reply: reply}
+	})
+}
+
+// Cancel stops an order that is still cooking
+func (k *Kitchen) Cancel(ctx context.Context, id int) error {
+	err, callErr := actor.Call(ctx, k.actor, func(reply chan<- error) kitchenMsg {
+		return cancelOrder{id: id, reply: reply}
+	})
+	if callErr != nil {
+		return callErr
+	}
+	return err
+}
+
+// Count returns how many orders are in the book
+func (k *Kitchen) Count(ctx context.Context) (int, error) {
+	return actor.Call(ctx, k.actor, func(reply chan<- int) kitchenMsg {
+		return countOrders{reply: reply}
+	})
+}
+
+// Stop shuts the kitchen down after handling every queued message
+func (k *Kitchen) Stop() {
+	k.actor.Stop()
+}
+
+// MutexKitchen is the lock-based version of the same order book
+type MutexKitchen struct {
+	mu     sync.Mutex
+	orders map[int]Status
+}
+
+// Place records a new order under the lock
+func (k *MutexKitchen) Place(order Order) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.orders[order.ID] = StatusCooking
+}
+
+// Len returns how many orders are in the book
+func (k *MutexKitchen) Len() int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return len(k.orders)
+}
+
+// Place, query, cancel: every interaction is a message
+func kitchenActorBasics() {
+	out.Printf("\n=== 1. THE KITCHEN AS AN ACTOR ===\n\n")
+
+	kitchen := NewKitchen()
+	defer kitchen.Stop()
+	ctx := context.Background()
+
+	kitchen.Place(Order{ID: 1, PrepTime: 100 * time.Millisecond})
+	kitchen.Place(Order{ID: 2, PrepTime: 500 * time.Millisecond})
+	kitchen.Place(Order{ID: 3, PrepTime: 500 * time.Millisecond})
+	out.Println("📨 Placed orders 1, 2, 3")
+
+	clk.Sleep(context.Background(), 200*time.Millisecond)
+
+	for id := 1; id <= 4; id++ {
+		status, _ := kitchen.Status(ctx, id)
+		out.Printf("🔍 Order %d: %s\n", id, status)
+	}
+
+	for _, id := range []int{2, 1} {
+		if err := kitchen.Cancel(ctx, id); err != nil {
+			out.Printf("❌ Cancel order %d: %v\n", id, err)
+		} else {
+			out.Printf("✅ Cancel order %d: cancelled\n", id)
+		}
+	}
+
+	status, _ := kitchen.Status(ctx, 2)
+	out.Printf("🔍 Order 2: %s\n", status)
+}
+
+// 1000 goroutines place orders at once - no locks, no races
+func concurrentSenders() {
+	out.Printf("\n=== 2. 1000 CONCURRENT SENDERS: ACTOR VS MUTEX ===\n\n")
+
+	const senders = 1000
+
+	kitchen := NewKitchen()
+	var wg sync.WaitGroup
+	start := clk.Now()
+
+	for id := 1; id <= senders; id++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			kitchen.Place(Order{ID: id, PrepTime: time.Hour})
+		}(id)
+	}
+	wg.Wait()
+
+	// A Call is handled after every earlier message, so this sees all 1000
+	count, _ := kitchen.Count(context.Background())
+	actorTime := clk.Since(start)
+	kitchen.Stop()
+
+	mk := &MutexKitchen{orders: make(map[int]Status)}
+	start = clk.Now()
+	for id := 1; id <= senders; id++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			mk.Place(Order{ID: id})
+		}(id)
+	}
+	wg.Wait()
+	mutexTime := clk.Since(start)
+
+	out.Printf("🎭 Actor: %d orders in the book (%v)\n", count, actorTime.Round(time.Microsecond))
+	out.Printf("🔒 Mutex: %d orders in the book (%v)\n", mk.Len(), mutexTime.Round(time.Microsecond))
+	out.Println("\n💡 Run with -race: the actor's map has no lock, yet no race is reported")
+}
+
+// stockQuery asks the inventory clerk how much of an item is left
+type stockQuery struct {
+	item  string
+	reply chan<- int
+}
+
+// A Call that outlives its context returns ctx.Err() - the actor isn't blocked by the abandoned reply
+func callTimeout() {
+	out.Printf("\n=== 3. CALL WITH A TIMEOUT ===\n\n")
+
+	// An inventory clerk who takes 300ms to answer each question
+	clerk := actor.New(1, func(q stockQuery) {
+		clk.Sleep(context.Background(), 300*time.Millisecond)
+		q.reply <- 42
+	})
+	defer clerk.Stop()
+
+	ask := func(timeout time.Duration) {
+		ctx, cancel := clk.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		start := clk.Now()
+		n, err := actor.Call(ctx, clerk, func(reply chan<- int) stockQuery {
+			return stockQuery{item: "tomatoes", reply: reply}
+		})
+		out.Printf("⏱️  Timeout %v: answer=%d err=%v (after %v)\n", timeout, n, err, clk.Since(start).Round(10*time.Millisecond))
+	}
+
+	ask(100 * time.Millisecond)
+	ask(time.Second)
+}
+
+// Stop drains the mailbox: queued messages are handled, later ones are refused
+func stopWhileQueued() {
+	out.Printf("\n=== 4. STOP WITH MESSAGES STILL QUEUED ===\n\n")
+
+	handled := 0
+	printer := actor.New(10, func(id int) {
+		clk.Sleep(context.Background(), 20*time.Millisecond)
+		handled++ // Confined to the actor goroutine
+	})
+
+	for id := 1; id <= 10; id++ {
+		printer.Send(id)
+	}
+	out.Printf("📬 Queued 10 tickets\n")
+
+	printer.Stop() // Returns only after all 10 are handled
+	out.Printf("🛑 Stopped after handling %d tickets\n", handled)
+	out.Printf("🚫 Send after Stop: %v\n", printer.Send(11))
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Actors & Confinement")
+	out.Println("==========================================")
+
+	kitchenActorBasics()
+	concurrentSenders()
+	callTimeout()
+	stopWhileQueued()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ State owned by one goroutine needs no locks")
+	out.Println("✅ All interaction goes through typed messages on a mailbox")
+	out.Println("✅ Request/response uses a reply channel inside the message")
+	out.Println("✅ Buffer reply channels so an abandoned Call can't block the actor")
+	out.Println("✅ Graceful Stop closes the mailbox and drains what's left")
+	return nil
+}
@@ -0,0 +1,194 @@
+// Package graph is lesson 40: Graph Traversal.
+package graph
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	Name     string
+	PrepTime time.Duration
+}
+
+// concurrentBFS returns every node reachable from start, start included.
+// Each node is visited in its own goroutine. LoadOrStore on the visited set
+// claims a node in one step, so a node reached by two paths, or by a cycle,
+// is queued exactly once. The result order depends on scheduling.
+func concurrentBFS(start int, graph map[int][]int) []int {
+	var visited sync.Map // Node → struct{}
+	var pending sync.WaitGroup
+	var mu sync.Mutex
+	var reached []int
+
+	// Every node is queued at most once, so room for all of them means a send never blocks
+	queue := make(chan int, nodeCount(graph)+1)
+	visited.Store(start, struct{}{})
+	pending.Add(1)
+	queue <- start
+
+	// The queue closes once no node is queued or being visited
+	go func() {
+		pending.Wait()
+		close(queue)
+	}()
+
+	for node := range queue {
+		go func() {
+			defer pending.Done()
+			mu.Lock()
+			reached = append(reached, node)
+			mu.Unlock()
+
+			for _, next := range graph[node] {
+				if _, seen := visited.LoadOrStore(next, struct{}{}); !seen {
+					pending.Add(1) // Before this visit's Done, so the count can't hit zero early
+					queue <- next
+				}
+			}
+		}()
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	return reached
+}
+
+// nodeCount counts distinct nodes, including ones that only appear as neighbors
+func nodeCount(graph map[int][]int) int {
+	nodes := make(map[int]struct{}, len(graph))
+	for node, next := range graph {
+		nodes[node] = struct{}{}
+		for _, n := range next {
+			nodes[n] = struct{}{}
+		}
+	}
+	return len(nodes)
+}
+
+// The order book: supplier orders 1-3 feed kitchen orders 10+.
+// An edge A → B means B can't start until A is complete.
+var orders = map[int]Order{
+	1:  {ID: 1, Name: "Flour delivery", PrepTime: 200 * time.Millisecond},
+	2:  {ID: 2, Name: "Cheese delivery", PrepTime: 100 * time.Millisecond},
+	3:  {ID: 3, Name: "Fish delivery", PrepTime: 300 * time.Millisecond},
+	10: {ID: 10, Name: "Pizza dough", PrepTime: 100 * time.Millisecond},
+	11: {ID: 11, Name: "Margherita x20", PrepTime: 150 * time.Millisecond},
+	12: {ID: 12, Name: "Pasta", PrepTime: 100 * time.Millisecond},
+	13: {ID: 13, Name: "Lasagne tray", PrepTime: 150 * time.Millisecond},
+	14: {ID: 14, Name: "Sushi platter", PrepTime: 100 * time.Millisecond},
+	15: {ID: 15, Name: "Catering box", PrepTime: 50 * time.Millisecond},
+}
+
+var dependsOn = map[int][]int{
+	1:  {10, 12},
+	2:  {11, 13},
+	3:  {14},
+	10: {11},
+	12: {13},
+	11: {15},
+	13: {15},
+	14: {15},
+}
+
+// names turns IDs into a readable, sorted list
+func names(ids []int) string {
+	sorted := slices.Clone(ids)
+	slices.Sort(sorted)
+	parts := make([]string, len(sorted))
+	for i, id := range sorted {
+		parts[i] = fmt.Sprintf("%d %s", id, orders[id].Name)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// A late supplier holds up everything downstream of it
+func blockedOrders() {
+	out.Printf("\n=== 1. WHICH ORDERS WAIT ON A LATE SUPPLIER? ===\n\n")
+
+	for _, supplier := range []int{1, 2, 3} {
+		reached := concurrentBFS(supplier, dependsOn)
+		blocked := slices.DeleteFunc(reached, func(id int) bool { return id == supplier })
+		out.Printf("🚚 %s late → %d blocked: %s\n", orders[supplier].Name, len(blocked), names(blocked))
+	}
+}
+
+// Every order starts as soon as all of its prerequisites are done
+func cookInDependencyOrder() {
+	out.Printf("\n=== 2. COOKING IN DEPENDENCY ORDER ===\n\n")
+
+	// Everything reachable from the suppliers is on today's plan
+	var plan []int
+	for _, supplier := range []int{1, 2, 3} {
+		plan = append(plan, concurrentBFS(supplier, dependsOn)...)
+	}
+	slices.Sort(plan)
+	plan = slices.Compact(plan)
+
+	waitsFor := make(map[int][]int)
+	for from, tos := range dependsOn {
+		for _, to := range tos {
+			waitsFor[to] = append(waitsFor[to], from)
+		}
+	}
+
+	done := make(map[int]chan struct{}, len(plan))
+	for _, id := range plan {
+		done[id] = make(chan struct{})
+	}
+
+	startTime := clk.Now()
+	var wg sync.WaitGroup
+	for _, id := range plan {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, before := range waitsFor[id] {
+				<-done[before]
+			}
+			o := orders[id]
+			out.Printf("🔥 [+%3dms] Start %s\n", clk.Since(startTime).Milliseconds(), o.Name)
+			clk.Sleep(context.Background(), o.PrepTime)
+			close(done[id])
+		}()
+	}
+	wg.Wait()
+	out.Printf("\n✅ All %d orders done in %dms\n", len(plan), clk.Since(startTime).Milliseconds())
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Graph Traversal")
+	out.Println("==========================================")
+
+	blockedOrders()
+	cookInDependencyOrder()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ LoadOrStore claims a node in one step, so no node is queued twice")
+	out.Println("✅ The visited set is what makes cycles safe to traverse")
+	out.Println("✅ A WaitGroup counting queued nodes tells you when the traversal is done")
+	out.Println("✅ Add to the WaitGroup before the current visit's Done, or it can hit zero early")
+	out.Println("✅ A queue sized to the node count never blocks a visitor")
+	return nil
+}
@@ -0,0 +1,101 @@
+package graph
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// sequentialBFS is the plain single-goroutine version, the reference the
+// concurrent one must match
+func sequentialBFS(start int, graph map[int][]int) []int {
+	visited := map[int]bool{start: true}
+	queue := []int{start}
+	var reached []int
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		reached = append(reached, node)
+		for _, next := range graph[node] {
+			if !visited[next] {
+				visited[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return reached
+}
+
+// bfs runs concurrentBFS and returns what it reached, sorted. It fails the
+// test instead of hanging if the traversal never finishes, as it would if a
+// cycle kept queueing nodes.
+func bfs(t *testing.T, start int, graph map[int][]int) []int {
+	t.Helper()
+	result := make(chan []int, 1)
+	go func() { result <- concurrentBFS(start, graph) }()
+	select {
+	case reached := <-result:
+		slices.Sort(reached)
+		return reached
+	case <-time.After(2 * time.Second):
+		t.Fatal("concurrentBFS didn't finish within 2s")
+		return nil
+	}
+}
+
+func TestConcurrentBFS(t *testing.T) {
+	type input struct {
+		start int
+		graph map[int][]int
+	}
+	testutil.RunParallel(t, []testutil.TestCase{
+		{Name: "cycle with a way out", Input: input{1, map[int][]int{1: {2}, 2: {3}, 3: {1, 4}}}, Want: []int{1, 2, 3, 4}},
+		{Name: "self-loops", Input: input{5, map[int][]int{5: {5, 6}, 6: {6}}}, Want: []int{5, 6}},
+		{Name: "two-node cycle", Input: input{1, map[int][]int{1: {2}, 2: {1}}}, Want: []int{1, 2}},
+		{Name: "diamond: shared node once", Input: input{1, map[int][]int{1: {2, 3}, 2: {4}, 3: {4}}}, Want: []int{1, 2, 3, 4}},
+		{Name: "unreachable nodes left out", Input: input{1, map[int][]int{1: {2}, 3: {4}}}, Want: []int{1, 2}},
+		{Name: "start with no edges", Input: input{7, map[int][]int{1: {2}}}, Want: []int{7}},
+		{Name: "late Flour delivery", Input: input{1, dependsOn}, Want: []int{1, 10, 11, 12, 13, 15}},
+	}, func(t *testing.T, tc testutil.TestCase) {
+		in := tc.Input.(input)
+		if got := bfs(t, in.start, in.graph); !slices.Equal(got, tc.Want.([]int)) {
+			t.Errorf("reached %v, want %v", got, tc.Want)
+		}
+	})
+}
+
+// A random graph with plenty of cycles reaches the same nodes as a plain
+// BFS, each exactly once
+func TestConcurrentBFSRandomGraph(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	r := rand.New(rand.NewSource(42))
+	big := make(map[int][]int)
+	for node := range 2000 {
+		for range 3 {
+			big[node] = append(big[node], r.Intn(2500))
+		}
+	}
+	want := sequentialBFS(0, big)
+	slices.Sort(want)
+	for run := range 5 {
+		if got := bfs(t, 0, big); !slices.Equal(got, want) {
+			t.Fatalf("run %d: reached %d nodes, want the %d plain BFS reaches", run, len(got), len(want))
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	got := testutil.RunLesson(t, Run)
+	for _, want := range []string{
+		"🚚 Fish delivery late → 2 blocked: 14 Sushi platter, 15 Catering box",
+		"✅ All 9 orders done in",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+}
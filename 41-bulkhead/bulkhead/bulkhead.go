@@ -0,0 +1,297 @@
+// Package bulkhead is lesson 41: Bulkheads.
+package bulkhead
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+	"github.com/Ajay2521/go-concurrency/pkg/order"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	Category string // "express", "standard" or "bulk"
+	PrepTime time.Duration
+	Ready    chan struct{} // Closed when the order is cooked; nil if nobody waits
+}
+
+var (
+	ErrPoolFull        = errors.New("pool queue is full")
+	ErrUnknownCategory = errors.New("no pool for this category")
+)
+
+// queued is an order and when it entered the queue
+type queued struct {
+	order Order
+	at    time.Time
+}
+
+// WorkerPool is a fixed number of chefs behind a bounded queue. Submit never
+// blocks: when the queue is full the order is rejected, so a flood can only
+// fill this pool's queue, never stall the caller.
+type WorkerPool struct {
+	Name    string
+	Metrics *order.Metrics
+	queue   chan queued
+	wg      sync.WaitGroup
+}
+
+// NewWorkerPool starts workers chefs sharing a queue of queueSize orders
+func NewWorkerPool(name string, workers, queueSize int) *WorkerPool {
+	p := &WorkerPool{Name: name, Metrics: &order.Metrics{}, queue: make(chan queued, queueSize)}
+	for w := 0; w < workers; w++ {
+		p.wg.Add(1)
+		go p.worker()
+	}
+	return p
+}
+
+func (p *WorkerPool) worker() {
+	defer p.wg.Done()
+	for q := range p.queue {
+		wait := clk.Since(q.at)
+		clk.Sleep(context.Background(), q.order.PrepTime)
+		p.Metrics.Complete(wait)
+		if q.order.Ready != nil {
+			close(q.order.Ready)
+		}
+	}
+}
+
+// Submit queues the order, or returns ErrPoolFull without waiting
+func (p *WorkerPool) Submit(o Order) error {
+	select {
+	case p.queue <- queued{order: o, at: clk.Now()}:
+		p.Metrics.Submit()
+		return nil
+	default:
+		p.Metrics.Reject()
+		return ErrPoolFull
+	}
+}
+
+// Close stops intake and waits for queued orders to be cooked
+func (p *WorkerPool) Close() {
+	close(p.queue)
+	p.wg.Wait()
+}
+
+// BulkheadRouter sends each order to the pool for its category. Every pool
+// has its own queue and chefs, so one category filling up can't take
+// capacity from the others, the way a ship's bulkheads keep one flooded
+// compartment from sinking the rest.
+type BulkheadRouter struct {
+	pools map[string]*WorkerPool
+}
+
+// NewBulkheadRouter routes category → pool. Several categories may share a
+// pool, which is how the demo builds the no-bulkhead kitchen for comparison.
+func NewBulkheadRouter(pools map[string]*WorkerPool) *BulkheadRouter {
+	return &BulkheadRouter{pools: pools}
+}
+
+// Route submits the order to its category's pool
+func (r *BulkheadRouter) Route(o Order) error {
+	pool, ok := r.pools[o.Category]
+	if !ok {
+		return fmt.Errorf("order %d, category %q: %w", o.ID, o.Category, ErrUnknownCategory)
+	}
+	return pool.Submit(o)
+}
+
+// Pools returns each distinct pool once, sorted by name
+func (r *BulkheadRouter) Pools() []*WorkerPool {
+	seen := make(map[*WorkerPool]bool)
+	var pools []*WorkerPool
+	for _, p := range r.pools {
+		if !seen[p] {
+			seen[p] = true
+			pools = append(pools, p)
+		}
+	}
+	sort.Slice(pools, func(i, j int) bool { return pools[i].Name < pools[j].Name })
+	return pools
+}
+
+// Close drains every pool
+func (r *BulkheadRouter) Close() {
+	for _, p := range r.Pools() {
+		p.Close()
+	}
+}
+
+// lunchResult is what one lunch rush looked like to the express customers
+type lunchResult struct {
+	express      []time.Duration // Order-to-ready time for each express order
+	bulkRejected int
+	elapsed      time.Duration
+}
+
+// lunchRush floods the router with bulk catering orders, then sends express
+// orders through it while the flood is queued, and measures how long each
+// express customer waits for their food
+func lunchRush(r *BulkheadRouter) lunchResult {
+	var res lunchResult
+	startTime := clk.Now()
+
+	// A catering company drops 200 bulk orders at once
+	for id := 1; id <= 200; id++ {
+		if r.Route(Order{ID: id, Category: "bulk", PrepTime: 50 * time.Millisecond}) != nil {
+			res.bulkRejected++
+		}
+	}
+
+	// Regular customers keep coming during the flood
+	var customers sync.WaitGroup
+	var mu sync.Mutex
+	for i := 0; i < 10; i++ {
+		for _, category := range []string{"express", "standard"} {
+			o := Order{ID: 1000 + 2*i, Category: category, PrepTime: 20 * time.Millisecond, Ready: make(chan struct{})}
+			if category == "standard" {
+				o.ID++
+				o.PrepTime = 40 * time.Millisecond
+			}
+			placed := clk.Now()
+			if err := r.Route(o); err != nil {
+				out.Printf("❌ Order %d (%s): %v\n", o.ID, category, err)
+				continue
+			}
+			if category != "express" {
+				continue
+			}
+			customers.Add(1)
+			go func() {
+				defer customers.Done()
+				<-o.Ready
+				mu.Lock()
+				res.express = append(res.express, clk.Since(placed))
+				mu.Unlock()
+			}()
+		}
+		clk.Sleep(context.Background(), 30*time.Millisecond)
+	}
+	customers.Wait()
+	r.Close()
+	res.elapsed = clk.Since(startTime)
+	return res
+}
+
+// printLunch shows per-pool metrics and the express customers' experience
+func printLunch(r *BulkheadRouter, res lunchResult) {
+	out.Printf("%-10s %9s %9s %9s %12s %10s %10s\n", "Pool", "accepted", "rejected", "completed", "orders/sec", "avg wait", "max wait")
+	for _, p := range r.Pools() {
+		s := p.Metrics.Snapshot()
+		out.Printf("%-10s %9d %9d %9d %12.0f %10v %10v\n", p.Name, s.Submitted, s.Rejected, s.Completed,
+			float64(s.Completed)/res.elapsed.Seconds(), s.AvgWait.Round(time.Millisecond), s.MaxWait.Round(time.Millisecond))
+	}
+
+	sort.Slice(res.express, func(i, j int) bool { return res.express[i] < res.express[j] })
+	if n := len(res.express); n > 0 {
+		out.Printf("\n⚡ Express customers: %d served, fastest %v, slowest %v\n",
+			n, res.express[0].Round(time.Millisecond), res.express[n-1].Round(time.Millisecond))
+	}
+}
+
+// One queue for everything: express orders wait behind the whole catering flood
+func sharedKitchen() {
+	out.Printf("\n=== 1. ONE SHARED POOL: 6 CHEFS, ONE QUEUE ===\n\n")
+
+	shared := NewWorkerPool("shared", 6, 300)
+	r := NewBulkheadRouter(map[string]*WorkerPool{"express": shared, "standard": shared, "bulk": shared})
+	printLunch(r, lunchRush(r))
+}
+
+// Three pools with the same 6 chefs: the flood stays in its own compartment
+func bulkheadKitchen() {
+	out.Printf("\n=== 2. BULKHEADS: A POOL PER CATEGORY, SAME 6 CHEFS ===\n\n")
+
+	r := NewBulkheadRouter(map[string]*WorkerPool{
+		"express":  NewWorkerPool("express", 2, 10),
+		"standard": NewWorkerPool("standard", 1, 20),
+		"bulk":     NewWorkerPool("bulk", 3, 30),
+	})
+	res := lunchRush(r)
+	printLunch(r, res)
+	out.Printf("🚫 Bulk orders turned away at once: %d (the catering company can retry later)\n", res.bulkRejected)
+}
+
+// Routing, rejection and isolation, checked directly
+func bulkheadChecks() {
+	out.Printf("\n=== 3. BULKHEAD CHECKS ===\n\n")
+
+	check := func(name string, ok bool, detail string) {
+		status := "✅"
+		if !ok {
+			status = "❌"
+		}
+		out.Printf("%s %-44s %s\n", status, name, detail)
+	}
+
+	express, bulk := NewWorkerPool("express", 1, 5), NewWorkerPool("bulk", 1, 5)
+	r := NewBulkheadRouter(map[string]*WorkerPool{"express": express, "bulk": bulk})
+
+	err := r.Route(Order{ID: 1, Category: "catering"})
+	check("Unknown category is an error:", errors.Is(err, ErrUnknownCategory), fmt.Sprint(err))
+
+	// Fill bulk: one order cooking, five queued, the rest rejected
+	rejected := 0
+	for id := 1; id <= 20; id++ {
+		if errors.Is(r.Route(Order{ID: id, Category: "bulk", PrepTime: 30 * time.Millisecond}), ErrPoolFull) {
+			rejected++
+		}
+	}
+	check("A full pool rejects instead of blocking:", rejected >= 14 && bulk.Metrics.Snapshot().Rejected == int64(rejected),
+		fmt.Sprintf("%d of 20 rejected", rejected))
+
+	// Express still has room and chefs while bulk is full
+	ready := make(chan struct{})
+	startTime := clk.Now()
+	err = r.Route(Order{ID: 100, Category: "express", PrepTime: 10 * time.Millisecond, Ready: ready})
+	<-ready
+	waited := clk.Since(startTime)
+	check("Express is served while bulk is full:", err == nil && waited < 25*time.Millisecond,
+		fmt.Sprintf("ready in %v", waited.Round(time.Millisecond)))
+
+	r.Close()
+	b, e := bulk.Metrics.Snapshot(), express.Metrics.Snapshot()
+	check("Close drains every accepted order:", b.Completed == b.Submitted && e.Completed == 1,
+		fmt.Sprintf("bulk %d/%d, express %d/%d", b.Completed, b.Submitted, e.Completed, e.Submitted))
+	check("Metrics are per pool:", e.Submitted == 1 && e.Rejected == 0 && b.Submitted+b.Rejected == 20,
+		fmt.Sprintf("express %d accepted, bulk %d accepted + %d rejected", e.Submitted, b.Submitted, b.Rejected))
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Bulkheads")
+	out.Println("==========================================")
+
+	sharedKitchen()
+	bulkheadKitchen()
+	bulkheadChecks()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ A shared queue lets one flood of work delay everyone behind it")
+	out.Println("✅ A pool per category isolates the damage to that category")
+	out.Println("✅ Reject when a pool's queue is full, so a flood can't stall the caller")
+	out.Println("✅ Per-pool atomic metrics show which compartment is under pressure")
+	out.Println("✅ Bulkheads trade peak utilization for predictable latency")
+	return nil
+}
@@ -0,0 +1,182 @@
+// Package supervisor is lesson 41: Supervisors.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/conc"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	Item     string
+	PrepTime time.Duration
+}
+
+// logRestart prints why a chef is being restarted. A panic also gets the
+// line that panicked, picked out of the stack the supervisor recovered.
+func logRestart(name string, restart int, err error, delay time.Duration) {
+	var pe *conc.PanicError
+	if errors.As(err, &pe) {
+		out.Printf("💥 %s crashed: %v\n%s", name, pe.Value, panicSite(pe.Stack))
+		err = fmt.Errorf("panic: %v", pe.Value) // The stack was printed above
+	}
+	out.Printf("🔁 %s: restart #%d in %v after: %v\n", name, restart, delay, err)
+}
+
+// panicSite picks the function and file:line that panicked out of a full stack trace
+func panicSite(stack []byte) string {
+	lines := strings.Split(string(stack), "\n")
+	for i, line := range lines {
+		// The frames right after runtime's panic() are the code that panicked
+		if strings.HasPrefix(line, "panic(") && i+3 < len(lines) {
+			return "   at " + lines[i+2] + "\n     " + strings.TrimSpace(lines[i+3]) + "\n"
+		}
+	}
+	return ""
+}
+
+// cookOrders is a chef: it takes orders until the queue closes.
+// This chef refuses pineapple in the most dramatic way possible.
+func cookOrders(chefName string, orders <-chan Order, served *atomic.Int64) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case order, ok := <-orders:
+				if !ok {
+					return nil // Shift over
+				}
+				if strings.Contains(order.Item, "pineapple") {
+					panic(fmt.Sprintf("order %d has pineapple on it", order.ID))
+				}
+				clk.Sleep(context.Background(), order.PrepTime)
+				served.Add(1)
+				out.Printf("✅ %s: Order %d (%s) ready\n", chefName, order.ID, order.Item)
+			}
+		}
+	}
+}
+
+// The pineapple-hating chef crashes, but the supervisor keeps the kitchen running
+func supervisedKitchen() {
+	out.Printf("\n=== 1. SUPERVISED CHEF ===\n\n")
+
+	items := []string{"margherita", "pepperoni", "hawaiian pineapple", "veggie", "bbq chicken",
+		"pineapple & ham", "four cheese", "diavola", "pineapple salsa", "calzone"}
+
+	orders := make(chan Order, len(items))
+	for i, item := range items {
+		orders <- Order{ID: i + 1, Item: item, PrepTime: 30 * time.Millisecond}
+	}
+	close(orders)
+
+	var served atomic.Int64
+	result := <-conc.Supervise(context.Background(), clk, "chef-marco", cookOrders("chef-marco", orders, &served),
+		conc.WithBackoff(50*time.Millisecond, time.Second),
+		conc.WithRestartBudget(5, 10*time.Second),
+		conc.WithOnRestart(logRestart))
+
+	out.Printf("\n📊 Served %d of %d orders, supervisor result: %v\n", served.Load(), len(items), result)
+}
+
+// A chef who crashes on every order burns through the restart budget
+func restartBudget() {
+	out.Printf("\n=== 2. RESTART BUDGET EXHAUSTED ===\n\n")
+
+	start := clk.Now()
+	err := <-conc.Supervise(context.Background(), clk, "chef-broken",
+		func(ctx context.Context) error { return errors.New("oven on fire") },
+		conc.WithBackoff(20*time.Millisecond, 100*time.Millisecond),
+		conc.WithRestartBudget(3, time.Second),
+		conc.WithOnRestart(logRestart))
+
+	out.Printf("\n🛑 Gave up after %v: %v\n", clk.Since(start).Round(10*time.Millisecond), err)
+	out.Printf("   errors.Is(err, conc.ErrRestartBudget): %v\n", errors.Is(err, conc.ErrRestartBudget))
+}
+
+// Each restart in the window waits twice as long as the one before
+func backoffSchedule() {
+	out.Printf("\n=== 3. BACKOFF SCHEDULE ===\n\n")
+
+	var attempts int
+	var delays []time.Duration
+	start := clk.Now()
+	err := <-conc.Supervise(context.Background(), clk, "chef-flaky",
+		func(ctx context.Context) error {
+			attempts++
+			if attempts <= 3 {
+				return fmt.Errorf("attempt %d: burnt the sauce", attempts)
+			}
+			return nil // Fourth time lucky
+		},
+		conc.WithBackoff(100*time.Millisecond, time.Second),
+		conc.WithRestartBudget(5, time.Minute),
+		conc.WithOnRestart(func(_ string, _ int, _ error, delay time.Duration) { delays = append(delays, delay) }))
+
+	out.Printf("📋 Result: %v | attempts: %d | restarts: %d\n", err, attempts, len(delays))
+	out.Printf("⏱️  Delays: %v, %v in all\n", delays, clk.Since(start).Round(10*time.Millisecond))
+}
+
+// Cancelling the context stops the supervisor, even in the middle of a backoff
+func cancellationStopsRestarts() {
+	out.Printf("\n=== 4. CANCELLATION STOPS RESTARTS ===\n\n")
+
+	ctx, cancel := clk.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+
+	var restarts atomic.Int64
+	err := <-conc.Supervise(ctx, clk, "chef-doomed",
+		func(ctx context.Context) error { return errors.New("dropped the tray") },
+		conc.WithBackoff(100*time.Millisecond, time.Second),
+		conc.WithRestartBudget(100, 0),
+		conc.WithOnRestart(func(name string, n int, err error, delay time.Duration) {
+			restarts.Add(1)
+			out.Printf("🔁 %s: restart #%d in %v\n", name, n, delay)
+		}))
+
+	countAtStop := restarts.Load()
+	clk.Sleep(context.Background(), 300*time.Millisecond) // Long enough for another restart if one were pending
+
+	out.Printf("\n🛑 Supervisor result: %v\n", err)
+	out.Printf("📊 Restarts after cancellation: %d\n", restarts.Load()-countAtStop)
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Supervisors")
+	out.Println("==========================================")
+
+	supervisedKitchen()
+	restartBudget()
+	backoffSchedule()
+	cancellationStopsRestarts()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ recover() only works in the goroutine that panicked - so supervise each goroutine")
+	out.Println("✅ Exponential backoff stops a crash loop from hammering the system")
+	out.Println("✅ A restart budget turns a permanent failure into a clear error")
+	out.Println("✅ Taking the clock as an argument makes timing behaviour checkable without waiting")
+	out.Println("✅ Context cancellation ends supervision, even mid-backoff")
+	return nil
+}
@@ -0,0 +1,212 @@
+// Package ingestion is lesson 42: Streaming Ingestion.
+package ingestion
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID     int    `json:"id"`
+	Dish   string `json:"dish"`
+	PrepMS int    `json:"prep_ms"`
+}
+
+var ErrPoolDrained = errors.New("pool is drained")
+
+// WorkerPool cooks orders from a buffered submission queue. The buffer lets
+// a fast producer, like a decoder, run ahead of the chefs.
+type WorkerPool struct {
+	queue   chan Order
+	process func(worker int, o Order)
+	wg      sync.WaitGroup
+
+	mu      sync.Mutex // Guards drained against concurrent Submit/Drain
+	drained bool
+}
+
+// NewWorkerPool starts workers chefs behind a queue of queueSize orders
+func NewWorkerPool(workers, queueSize int, process func(worker int, o Order)) *WorkerPool {
+	p := &WorkerPool{queue: make(chan Order, queueSize), process: process}
+	for w := 1; w <= workers; w++ {
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			for o := range p.queue {
+				p.process(w, o)
+			}
+		}()
+	}
+	return p
+}
+
+// Submit queues an order and returns without waiting for it to be cooked.
+// It blocks only while the queue is full.
+func (p *WorkerPool) Submit(o Order) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.drained {
+		return ErrPoolDrained
+	}
+	p.queue <- o
+	return nil
+}
+
+// Queued returns how many orders are waiting for a chef
+func (p *WorkerPool) Queued() int {
+	return len(p.queue)
+}
+
+// Drain stops intake and waits until every queued order is cooked. Calling
+// it again is a no-op.
+func (p *WorkerPool) Drain() {
+	p.mu.Lock()
+	if !p.drained {
+		p.drained = true
+		close(p.queue)
+	}
+	p.mu.Unlock()
+	p.wg.Wait()
+}
+
+// IngestOrders decodes a stream of JSON orders from r and submits each one
+// to pool as soon as it's decoded. At the end of the stream it drains the
+// pool. On a decode error it also drains, so orders already accepted are
+// still cooked, and returns the error with the position of the bad order.
+func IngestOrders(r io.Reader, pool *WorkerPool) error {
+	dec := json.NewDecoder(r)
+	for n := 1; ; n++ {
+		var o Order
+		err := dec.Decode(&o)
+		if err == io.EOF {
+			pool.Drain()
+			return nil
+		}
+		if err != nil {
+			pool.Drain()
+			return fmt.Errorf("order #%d (byte %d): %w", n, dec.InputOffset(), err)
+		}
+		if err := pool.Submit(o); err != nil {
+			return fmt.Errorf("order %d: %w", o.ID, err)
+		}
+	}
+}
+
+// encodeOrders writes orders as a stream of JSON objects, one per line
+func encodeOrders(w io.Writer, orders []Order) {
+	enc := json.NewEncoder(w)
+	for _, o := range orders {
+		enc.Encode(o)
+	}
+}
+
+// Orders arrive over a slow connection; chefs start before the feed ends
+func streamingFeed() {
+	out.Printf("\n=== 1. STREAMING FROM A SLOW FEED ===\n\n")
+
+	startTime := clk.Now()
+	since := func() int64 { return clk.Since(startTime).Milliseconds() }
+
+	r, w := io.Pipe()
+	go func() {
+		dishes := []string{"Ramen", "Tacos", "Curry", "Pho", "Burger", "Salad"}
+		for i, dish := range dishes {
+			clk.Sleep(context.Background(), 40*time.Millisecond) // The next order is still on its way
+			encodeOrders(w, []Order{{ID: i + 1, Dish: dish, PrepMS: 60}})
+		}
+		out.Printf("📭 [+%3dms] Feed closed\n", since())
+		w.Close()
+	}()
+
+	pool := NewWorkerPool(2, 4, func(worker int, o Order) {
+		out.Printf("🔥 [+%3dms] Chef %d starts order %d (%s)\n", since(), worker, o.ID, o.Dish)
+		clk.Sleep(context.Background(), time.Duration(o.PrepMS)*time.Millisecond)
+	})
+	err := IngestOrders(r, pool)
+	out.Printf("✅ [+%3dms] All orders cooked (error: %v)\n", since(), err)
+}
+
+// With a big enough queue the decoder finishes long before the chefs do
+func decoderRunsAhead() {
+	out.Printf("\n=== 2. THE DECODER RUNS AHEAD OF THE CHEFS ===\n\n")
+
+	var buf bytes.Buffer
+	orders := make([]Order, 100)
+	for i := range orders {
+		orders[i] = Order{ID: i + 1, Dish: "Dumplings", PrepMS: 5}
+	}
+	encodeOrders(&buf, orders)
+	out.Printf("📄 %d orders, %d bytes of JSON\n", len(orders), buf.Len())
+
+	var cooked atomic.Int64
+	pool := NewWorkerPool(4, 100, func(_ int, o Order) {
+		clk.Sleep(context.Background(), time.Duration(o.PrepMS)*time.Millisecond)
+		cooked.Add(1)
+	})
+
+	startTime := clk.Now()
+	var decoded time.Duration
+	var queuedAtEOF int
+	r := &eofReader{r: &buf, onEOF: func() {
+		decoded = clk.Since(startTime)
+		queuedAtEOF = pool.Queued()
+	}}
+	err := IngestOrders(r, pool)
+
+	out.Printf("📥 Whole stream decoded after %v, %d orders still queued\n", decoded.Round(time.Millisecond), queuedAtEOF)
+	out.Printf("🍳 All %d orders cooked after %v (error: %v)\n", cooked.Load(), clk.Since(startTime).Round(time.Millisecond), err)
+}
+
+// eofReader calls onEOF once when the underlying reader is exhausted
+type eofReader struct {
+	r     io.Reader
+	onEOF func()
+	once  sync.Once
+}
+
+func (c *eofReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	if err == io.EOF {
+		c.once.Do(c.onEOF)
+	}
+	return n, err
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Streaming Ingestion")
+	out.Println("==========================================")
+
+	streamingFeed()
+	decoderRunsAhead()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ json.Decoder reads one value at a time, so orders flow before the stream ends")
+	out.Println("✅ A buffered submission queue lets decoding run ahead of processing")
+	out.Println("✅ io.EOF is the signal to stop intake and drain the pool")
+	out.Println("✅ On a decode error, still drain: accepted orders deserve to be cooked")
+	out.Println("✅ bytes.Buffer and strings.Reader make stream handling easy to check")
+	return nil
+}
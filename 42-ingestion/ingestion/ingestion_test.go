@@ -0,0 +1,118 @@
+package ingestion
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// collect runs IngestOrders into a pool that records every order it cooks
+func collect(r io.Reader) ([]Order, error) {
+	var mu sync.Mutex
+	var got []Order
+	pool := NewWorkerPool(3, 10, func(_ int, o Order) {
+		mu.Lock()
+		got = append(got, o)
+		mu.Unlock()
+	})
+	err := IngestOrders(r, pool)
+	mu.Lock()
+	defer mu.Unlock()
+	return got, err
+}
+
+// 100 orders encoded into a bytes.Buffer come out of the pool once each, intact
+func TestIngestOrders(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	var buf bytes.Buffer
+	want := make(map[int]Order)
+	var orders []Order
+	for id := 1; id <= 100; id++ {
+		o := Order{ID: id, Dish: fmt.Sprintf("Dish %d", id), PrepMS: id % 7}
+		orders = append(orders, o)
+		want[id] = o
+	}
+	encodeOrders(&buf, orders)
+
+	got, err := collect(&buf)
+	if err != nil {
+		t.Fatalf("IngestOrders: %v", err)
+	}
+	if len(got) != len(orders) {
+		t.Errorf("%d orders cooked, want %d", len(got), len(orders))
+	}
+	for _, o := range got {
+		w, ok := want[o.ID]
+		if !ok {
+			t.Errorf("order %d cooked twice or never sent", o.ID)
+			continue
+		}
+		if o != w {
+			t.Errorf("cooked %+v, want %+v", o, w)
+		}
+		delete(want, o.ID)
+	}
+	if len(want) != 0 {
+		t.Errorf("%d orders never cooked", len(want))
+	}
+}
+
+// Bad or empty input: the orders before the bad one are still cooked, and
+// the error says where the stream went wrong
+func TestIngestOrdersBadInput(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	tests := []struct {
+		name       string
+		input      string
+		wantCooked int
+		wantErr    string // Prefix of the error; "" for none
+	}{
+		{"malformed second order", `{"id":1,"dish":"Ramen"}` + "\n" + `{"id":2,"dish":"Tacos",}` + "\n" + `{"id":3}`, 1, "order #2 (byte 23): "},
+		{"wrong field type", `{"id":1,"prep_ms":"soon"}`, 0, "order #1 (byte 25): "},
+		{"empty stream", "", 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := collect(strings.NewReader(tt.input))
+			if len(got) != tt.wantCooked {
+				t.Errorf("%d orders cooked, want %d", len(got), tt.wantCooked)
+			}
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("IngestOrders: %v, want no error", err)
+			case tt.wantErr != "" && (err == nil || !strings.HasPrefix(err.Error(), tt.wantErr)):
+				t.Errorf("IngestOrders: %v, want an error starting %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIngestOrdersDrainedPool(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	pool := NewWorkerPool(1, 1, func(int, Order) {})
+	pool.Drain()
+	pool.Drain() // A no-op the second time
+	if err := IngestOrders(strings.NewReader(`{"id":1}`), pool); !errors.Is(err, ErrPoolDrained) {
+		t.Errorf("IngestOrders into a drained pool: %v, want ErrPoolDrained", err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	got := testutil.RunLesson(t, Run)
+	for _, want := range []string{
+		"📭 [+240ms] Feed closed",
+		"🍳 All 100 orders cooked after",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Package panics is lesson 42: Panics in Goroutines.
+package panics
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/conc"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	Items    []string
+	PrepTime time.Duration
+}
+
+// processOrder cooks an order. Orders with no items are a bug upstream,
+// and indexing into an empty slice panics.
+func processOrder(order Order) {
+	first := order.Items[0] // Panics with "index out of range" on an empty order
+	clk.Sleep(context.Background(), order.PrepTime)
+	out.Printf("✅ Order %d ready (%s + %d more)\n", order.ID, first, len(order.Items)-1)
+}
+
+func sampleOrders() []Order {
+	return []Order{
+		{ID: 1, Items: []string{"burger", "fries"}, PrepTime: 100 * time.Millisecond},
+		{ID: 2, Items: []string{"salad"}, PrepTime: 80 * time.Millisecond},
+		{ID: 3, Items: nil, PrepTime: 50 * time.Millisecond}, // Bad order
+		{ID: 4, Items: []string{"pizza", "soda", "cake"}, PrepTime: 120 * time.Millisecond},
+	}
+}
+
+// A panic in any goroutine kills the whole program - even recover() in main can't stop it
+func bareGoroutineCrash() {
+	out.Printf("\n=== 0. BARE GOROUTINE PANIC (crashes the program) ===\n\n")
+	out.Flush() // The crash takes the printer down too, so get the header out first
+
+	defer func() {
+		// Never runs for the goroutine's panic: recover only works in the panicking goroutine
+		if r := recover(); r != nil {
+			out.Println("recovered in main:", r)
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for _, order := range sampleOrders() {
+		wg.Add(1)
+		go func(o Order) {
+			defer wg.Done()
+			processOrder(o)
+		}(order)
+	}
+	wg.Wait()
+}
+
+// The same batch, but each order runs through Go: the bad one becomes an error
+func recoverAndReport() {
+	out.Printf("\n=== 1. RECOVER AND REPORT WITH Go() ===\n\n")
+
+	orders := sampleOrders()
+	results := make([]<-chan error, len(orders))
+
+	for i, order := range orders {
+		o := order
+		results[i] = conc.Go(func() { processOrder(o) })
+	}
+
+	// Each channel delivers exactly one value, so this also waits for every order
+	failed := 0
+	for i, errc := range results {
+		var pe *conc.PanicError
+		if err := <-errc; errors.As(err, &pe) {
+			failed++
+			out.Printf("💥 Order %d failed: panic: %v\n", orders[i].ID, pe.Value)
+		}
+	}
+
+	out.Printf("\n📊 %d of %d orders cooked, %d failed - and the program is still running\n",
+		len(orders)-failed, len(orders), failed)
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	fs := flag.NewFlagSet("panics", flag.ContinueOnError)
+	crash := fs.Bool("crash", false, "run the bare-goroutine demo that crashes the program")
+	if err := fs.Parse(opts.Args); err != nil {
+		return lesson.Usage(err)
+	}
+
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Panics in Goroutines")
+	out.Println("==========================================")
+
+	if *crash {
+		bareGoroutineCrash() // Does not return
+	}
+
+	recoverAndReport()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ An unrecovered panic in any goroutine crashes the whole program")
+	out.Println("✅ recover() only works inside the goroutine that panicked")
+	out.Println("✅ Wrap goroutines to turn panics into errors with a stack trace")
+	out.Println("✅ A buffered result channel means the goroutine never leaks")
+	out.Println("💡 Run with -crash to see the unprotected version take the program down")
+	return nil
+}
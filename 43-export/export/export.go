@@ -0,0 +1,156 @@
+// Package export is lesson 43: CSV Export.
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"io"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+// OrderResult is one cooked order, as reported by a chef
+type OrderResult struct {
+	OrderID int
+	ChefID  int
+	Dish    string
+	Took    time.Duration
+}
+
+// flushEvery is how many rows the exporter buffers before flushing
+const flushEvery = 100
+
+var csvHeader = []string{"order_id", "chef_id", "dish", "took_ms"}
+
+// ExportResults writes every result from the channel to w as CSV, until the
+// channel is closed. csv.Writer isn't safe for concurrent use, so a single
+// writer goroutine owns it; this goroutine reads results and formats rows
+// while that one writes. The writer flushes every 100 rows and at the end.
+// If writing fails, the remaining results are read and discarded so the
+// producers can finish, and the first error is returned.
+func ExportResults(results <-chan OrderResult, w io.Writer) error {
+	rows := make(chan []string, flushEvery)
+	writeErr := make(chan error, 1)
+
+	go func() {
+		cw := csv.NewWriter(w)
+		err := cw.Write(csvHeader)
+		n := 0
+		for row := range rows {
+			if err != nil {
+				continue // Keep draining so the reader never blocks
+			}
+			if err = cw.Write(row); err != nil {
+				continue
+			}
+			if n++; n%flushEvery == 0 {
+				cw.Flush()
+				err = cw.Error()
+			}
+		}
+		if err == nil {
+			cw.Flush()
+			err = cw.Error()
+		}
+		writeErr <- err
+	}()
+
+	for r := range results {
+		rows <- []string{
+			strconv.Itoa(r.OrderID),
+			strconv.Itoa(r.ChefID),
+			r.Dish,
+			strconv.FormatInt(r.Took.Milliseconds(), 10),
+		}
+	}
+	close(rows)
+	return <-writeErr
+}
+
+// writeRecorder counts the writes that reach it; each csv flush is one write
+// as long as a batch fits in the csv.Writer's buffer
+type writeRecorder struct {
+	buf    bytes.Buffer
+	writes int
+}
+
+func (wr *writeRecorder) Write(p []byte) (int, error) {
+	wr.writes++
+	return wr.buf.Write(p)
+}
+
+// cookConcurrently starts chefs goroutines that split the orders between them
+// and send each result on the returned channel, closed once every chef is done
+func cookConcurrently(chefs, orders int) <-chan OrderResult {
+	results := make(chan OrderResult)
+	dishes := []string{"Ramen", "Tacos", "Pho", "Curry, extra hot"} // The comma needs quoting
+	var wg sync.WaitGroup
+	for c := 1; c <= chefs; c++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(int64(c)))
+			for id := c; id <= orders; id += chefs {
+				results <- OrderResult{OrderID: id, ChefID: c, Dish: dishes[id%len(dishes)], Took: time.Duration(5+rng.Intn(20)) * time.Millisecond}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+	return results
+}
+
+// Four chefs report 250 results at once; one goroutine writes the CSV
+func exportLunch() {
+	out.Printf("\n=== 1. EXPORTING FROM FOUR CHEFS AT ONCE ===\n\n")
+
+	file := &writeRecorder{}
+	startTime := clk.Now()
+	err := ExportResults(cookConcurrently(4, 250), file)
+	took := clk.Since(startTime)
+
+	lines := strings.Split(strings.TrimSpace(file.buf.String()), "\n")
+	for _, line := range lines[:5] {
+		out.Printf("   %s\n", line)
+	}
+	out.Printf("   ...\n\n")
+	out.Printf("📄 %d data rows, %d bytes in %v (error: %v)\n", len(lines)-1, file.buf.Len(), took.Round(time.Millisecond), err)
+	out.Printf("💾 %d writes reached the file: a flush after rows 100 and 200, and one at the end\n", file.writes)
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: CSV Export")
+	out.Println("==========================================")
+
+	exportLunch()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ csv.Writer isn't goroutine-safe, so give it exactly one owner")
+	out.Println("✅ Funnel rows to the owner over a channel instead of sharing a lock")
+	out.Println("✅ Formatting and writing overlap when they run in different goroutines")
+	out.Println("✅ Flush in batches, then once more at the end, and check Error() after each")
+	out.Println("✅ After a write error keep draining, or the producers block forever")
+	return nil
+}
@@ -0,0 +1,109 @@
+package export
+
+import (
+	"encoding/csv"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// failingWriter accepts limit bytes, then fails every write
+type failingWriter struct {
+	limit int
+}
+
+func (fw *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > fw.limit {
+		n := fw.limit
+		fw.limit = 0
+		return n, errors.New("disk full")
+	}
+	fw.limit -= len(p)
+	return len(p), nil
+}
+
+// 8 generators send 1000 results at once; the CSV parses back with a
+// header, one row per order and the quoted dish intact, flushed every 100 rows
+func TestExportResultsConcurrentGenerators(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	file := &writeRecorder{}
+	if err := ExportResults(cookConcurrently(8, 1000), file); err != nil {
+		t.Fatalf("ExportResults: %v", err)
+	}
+	records, err := csv.NewReader(&file.buf).ReadAll()
+	if err != nil {
+		t.Fatalf("parsing the export: %v", err)
+	}
+
+	if !slices.Equal(records[0], csvHeader) {
+		t.Errorf("first row %q, want the header %q", records[0], csvHeader)
+	}
+	if rows := len(records) - 1; rows != 1000 {
+		t.Errorf("%d data rows, want 1000", rows)
+	}
+	ids := make(map[string]bool)
+	curries := 0
+	for _, rec := range records[1:] {
+		if ids[rec[0]] {
+			t.Errorf("order %s exported twice", rec[0])
+		}
+		ids[rec[0]] = true
+		if rec[2] == "Curry, extra hot" {
+			curries++
+		}
+	}
+	if len(ids) != 1000 || curries != 250 {
+		t.Errorf("%d distinct orders and %d curries, want 1000 and 250", len(ids), curries)
+	}
+	// 1000 is a multiple of 100, so the final flush finds nothing left to write
+	if file.writes != 10 {
+		t.Errorf("%d writes for 1000 rows, want one flush per 100", file.writes)
+	}
+}
+
+func TestExportResultsClosedChannel(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	empty := make(chan OrderResult)
+	close(empty)
+	file := &writeRecorder{}
+	if err := ExportResults(empty, file); err != nil {
+		t.Fatalf("ExportResults: %v", err)
+	}
+	if got := file.buf.String(); got != "order_id,chef_id,dish,took_ms\n" {
+		t.Errorf("exported %q, want the header alone", got)
+	}
+}
+
+// The disk fills up early: the error comes back, and the generators still
+// finish because the exporter keeps reading after it
+func TestExportResultsWriteError(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	done := make(chan error)
+	go func() { done <- ExportResults(cookConcurrently(4, 500), &failingWriter{limit: 1000}) }()
+	select {
+	case err := <-done:
+		if err == nil || err.Error() != "disk full" {
+			t.Errorf("ExportResults = %v, want disk full", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("ExportResults deadlocked after a write error")
+	}
+}
+
+func TestRun(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	got := testutil.RunLesson(t, Run)
+	for _, want := range []string{
+		"   order_id,chef_id,dish,took_ms",
+		"📄 250 data rows",
+		"💾 3 writes reached the file",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Package parallelism is lesson 43: Concurrency vs Parallelism.
+package parallelism
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+}
+
+// hashRounds is how many times each receipt is re-hashed (pure CPU work)
+const hashRounds = 150000
+
+// processOrder is the sleep-based "cooking" from earlier lessons: it waits, it doesn't compute
+func processOrder(order Order) {
+	time.Sleep(order.PrepTime)
+}
+
+// hashReceipt is CPU-bound: it hashes the order's receipt over and over
+func hashReceipt(order Order) [32]byte {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("receipt-%d", order.ID)))
+	for i := 0; i < hashRounds; i++ {
+		sum = sha256.Sum256(sum[:])
+	}
+	return sum
+}
+
+// runAll processes every order in its own goroutine and returns the wall-clock time
+func runAll(orders []Order, work func(Order)) time.Duration {
+	start := time.Now()
+	var wg sync.WaitGroup
+	for _, order := range orders {
+		wg.Add(1)
+		go func(o Order) {
+			defer wg.Done()
+			work(o)
+		}(order)
+	}
+	wg.Wait()
+	return time.Since(start)
+}
+
+// procCounts returns 1, 2 and NumCPU, without duplicates
+func procCounts() []int {
+	counts := []int{1, 2, runtime.NumCPU()}
+	slices.Sort(counts)
+	return slices.Compact(counts)
+}
+
+func makeOrders(n int) []Order {
+	orders := make([]Order, n)
+	for i := range orders {
+		orders[i] = Order{ID: i + 1, PrepTime: 100 * time.Millisecond}
+	}
+	return orders
+}
+
+// measurement is one row of the experiment
+type measurement struct {
+	procs int
+	sleep time.Duration
+	cpu   time.Duration
+}
+
+// Same goroutines, different GOMAXPROCS: sleeping overlaps regardless of P, hashing needs cores
+func compareProcs() []measurement {
+	out.Printf("\n=== 1. SLEEP-BOUND VS CPU-BOUND UNDER GOMAXPROCS ===\n\n")
+
+	orders := makeOrders(8)
+	previous := runtime.GOMAXPROCS(0)
+	defer runtime.GOMAXPROCS(previous)
+
+	out.Printf("🖥️  runtime.NumCPU() = %d, %d orders, one goroutine each\n\n", runtime.NumCPU(), len(orders))
+	out.Printf("%-12s %14s %14s\n", "GOMAXPROCS", "Sleep-bound", "CPU-bound")
+
+	var results []measurement
+	for _, procs := range procCounts() {
+		runtime.GOMAXPROCS(procs)
+		m := measurement{
+			procs: procs,
+			sleep: runAll(orders, processOrder),
+			cpu:   runAll(orders, func(o Order) { hashReceipt(o) }),
+		}
+		results = append(results, m)
+		out.Printf("%-12d %14v %14v\n", m.procs, m.sleep.Round(time.Millisecond), m.cpu.Round(time.Millisecond))
+	}
+
+	if runtime.NumCPU() == 1 {
+		out.Println("\n⚠️  Only one CPU is available, so extra Ps can't run in parallel here")
+	}
+	return results
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Concurrency vs Parallelism")
+	out.Println("==========================================")
+
+	results := compareProcs()
+
+	first, last := results[0], results[len(results)-1]
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ Concurrency is structuring work as independent goroutines; parallelism is running them at the same instant")
+	out.Printf("✅ Sleep-bound: %v with 1 P, %v with %d Ps - waiting needs no CPU, so it overlaps either way\n",
+		first.sleep.Round(time.Millisecond), last.sleep.Round(time.Millisecond), last.procs)
+	out.Printf("✅ CPU-bound: %v with 1 P, %v with %d Ps - computing only speeds up with real cores\n",
+		first.cpu.Round(time.Millisecond), last.cpu.Round(time.Millisecond), last.procs)
+	out.Println("✅ GOMAXPROCS caps how many goroutines execute Go code simultaneously")
+	out.Println("✅ More goroutines than cores doesn't make CPU work faster")
+	out.Println("\n💡 For steadier numbers: go test -bench=Hashing ./43-parallelism/...")
+	return nil
+}
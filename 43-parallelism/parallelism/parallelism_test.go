@@ -0,0 +1,68 @@
+package parallelism
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// BenchmarkHashing hashes 8 receipts, one goroutine each, under every
+// GOMAXPROCS from procCounts. It gives steadier numbers than the lesson's
+// single timing of the CPU-bound column:
+//
+//	go test -bench=Hashing ./43-parallelism/...
+func BenchmarkHashing(b *testing.B) {
+	orders := makeOrders(8)
+	previous := runtime.GOMAXPROCS(0)
+	defer runtime.GOMAXPROCS(previous)
+
+	for _, procs := range procCounts() {
+		b.Run(fmt.Sprintf("GOMAXPROCS=%d", procs), func(b *testing.B) {
+			runtime.GOMAXPROCS(procs)
+			for b.Loop() {
+				runAll(orders, func(o Order) { hashReceipt(o) })
+			}
+		})
+	}
+}
+
+func TestProcCounts(t *testing.T) {
+	counts := procCounts()
+	if counts[0] != 1 || counts[len(counts)-1] != max(2, runtime.NumCPU()) {
+		t.Errorf("procCounts() = %v, want 1 up to max(2, NumCPU)", counts)
+	}
+	if !slices.IsSorted(counts) || len(slices.Compact(slices.Clone(counts))) != len(counts) {
+		t.Errorf("procCounts() = %v, want sorted without duplicates", counts)
+	}
+}
+
+// Every goroutine runAll starts finishes before it returns
+func TestRunAllWaitsForEveryOrder(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	done := make(chan int, 20)
+	runAll(makeOrders(20), func(o Order) { done <- o.ID })
+	close(done)
+	var ids []int
+	for id := range done {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	for i, id := range ids {
+		if id != i+1 {
+			t.Fatalf("orders run: %v, want each of 1..20 once", ids)
+		}
+	}
+	if len(ids) != 20 {
+		t.Errorf("%d orders run, want 20", len(ids))
+	}
+}
+
+func TestHashReceiptIsPerOrder(t *testing.T) {
+	one, again, two := hashReceipt(Order{ID: 1}), hashReceipt(Order{ID: 1}), hashReceipt(Order{ID: 2})
+	if one != again || one == two {
+		t.Error("want the same hash for the same order and a different one for another")
+	}
+}
@@ -0,0 +1,347 @@
+// Package middleware is lesson 44: Middleware Chains.
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	Dish     string
+	PrepTime time.Duration
+}
+
+// OrderProcessor handles one order
+type OrderProcessor func(ctx context.Context, order Order) error
+
+// Middleware wraps an OrderProcessor with behavior before and after it
+type Middleware func(next OrderProcessor) OrderProcessor
+
+// Chain composes middlewares into one. The first is the outermost: it sees
+// the order first and the result last.
+func Chain(middlewares ...Middleware) Middleware {
+	return func(next OrderProcessor) OrderProcessor {
+		for _, mw := range slices.Backward(middlewares) {
+			next = mw(next)
+		}
+		return next
+	}
+}
+
+// Logging logs every order as it starts and how it ended
+func Logging(logf func(format string, args ...any)) Middleware {
+	return func(next OrderProcessor) OrderProcessor {
+		return func(ctx context.Context, o Order) error {
+			logf("▶️  order %d (%s)", o.ID, o.Dish)
+			startTime := clk.Now()
+			err := next(ctx, o)
+			if err != nil {
+				logf("❌ order %d failed after %v: %v", o.ID, clk.Since(startTime).Round(time.Millisecond), err)
+				return err
+			}
+			logf("✅ order %d done in %v", o.ID, clk.Since(startTime).Round(time.Millisecond))
+			return nil
+		}
+	}
+}
+
+// Metrics counts what passes through a Recording middleware. The counters
+// are atomic, so one Metrics can sit behind any number of workers.
+type Metrics struct {
+	calls, failed atomic.Int64
+	nanos         atomic.Int64 // Total time spent in next
+}
+
+// MetricsSnapshot is a consistent-enough read of Metrics for reporting
+type MetricsSnapshot struct {
+	Calls, Failed int64
+	Avg           time.Duration
+}
+
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	s := MetricsSnapshot{Calls: m.calls.Load(), Failed: m.failed.Load()}
+	if s.Calls > 0 {
+		s.Avg = time.Duration(m.nanos.Load() / s.Calls)
+	}
+	return s
+}
+
+// Recording counts every call into next, its failures and its duration in m
+func Recording(m *Metrics) Middleware {
+	return func(next OrderProcessor) OrderProcessor {
+		return func(ctx context.Context, o Order) error {
+			startTime := clk.Now()
+			err := next(ctx, o)
+			m.nanos.Add(int64(clk.Since(startTime)))
+			m.calls.Add(1)
+			if err != nil {
+				m.failed.Add(1)
+			}
+			return err
+		}
+	}
+}
+
+// Retry calls next up to attempts times, waiting backoff, then twice that,
+// and so on between tries. It stops early if ctx is done.
+func Retry(attempts int, backoff time.Duration) Middleware {
+	return func(next OrderProcessor) OrderProcessor {
+		return func(ctx context.Context, o Order) error {
+			var err error
+			for attempt := 1; attempt <= attempts; attempt++ {
+				if err = next(ctx, o); err == nil {
+					return nil
+				}
+				if attempt == attempts {
+					break
+				}
+				select {
+				case <-clk.After(backoff << (attempt - 1)):
+				case <-ctx.Done():
+					return errors.Join(err, ctx.Err())
+				}
+			}
+			return err
+		}
+	}
+}
+
+var ErrBurnt = errors.New("burnt")
+
+// flakyKitchen burns the first failFirst attempts at every order in burns.
+// It is safe for concurrent use, like everything behind a shared chain.
+type flakyKitchen struct {
+	failFirst int
+	burns     map[int]bool // Read-only after construction
+
+	mu       sync.Mutex
+	attempts map[int]int
+}
+
+func newFlakyKitchen(failFirst int, burns ...int) *flakyKitchen {
+	k := &flakyKitchen{failFirst: failFirst, burns: make(map[int]bool), attempts: make(map[int]int)}
+	for _, id := range burns {
+		k.burns[id] = true
+	}
+	return k
+}
+
+func (k *flakyKitchen) cook(ctx context.Context, o Order) error {
+	k.mu.Lock()
+	k.attempts[o.ID]++
+	attempt := k.attempts[o.ID]
+	k.mu.Unlock()
+
+	select {
+	case <-clk.After(o.PrepTime):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if k.burns[o.ID] && attempt <= k.failFirst {
+		return fmt.Errorf("order %d, attempt %d: %w", o.ID, attempt, ErrBurnt)
+	}
+	return nil
+}
+
+// runWorkers sends every order through process on the given number of workers
+func runWorkers(process OrderProcessor, orders []Order, workers int) []error {
+	jobs := make(chan int)
+	errs := make([]error, len(orders))
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				errs[i] = process(context.Background(), orders[i]) // Each index has one writer
+			}
+		}()
+	}
+	for i := range orders {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return errs
+}
+
+func lunchOrders() []Order {
+	return []Order{
+		{ID: 1, Dish: "Ramen", PrepTime: 30 * time.Millisecond},
+		{ID: 2, Dish: "Tacos", PrepTime: 20 * time.Millisecond},
+		{ID: 3, Dish: "Pizza", PrepTime: 40 * time.Millisecond},
+	}
+}
+
+// Logging, metrics and retry wrapped around one kitchen, step by step
+func composedChain() {
+	out.Printf("\n=== 1. LOGGING → METRICS → RETRY ===\n\n")
+
+	logger := log.New(out, "   ", log.Lmsgprefix)
+	var m Metrics
+	kitchen := newFlakyKitchen(1, 2) // Order 2 burns once
+	process := Chain(Logging(logger.Printf), Recording(&m), Retry(3, 10*time.Millisecond))(kitchen.cook)
+
+	for _, o := range lunchOrders() {
+		process(context.Background(), o)
+	}
+	s := m.Snapshot()
+	out.Printf("\n📊 Metrics: %d orders, %d failed, avg %v (order 2's retry is inside the timing)\n",
+		s.Calls, s.Failed, s.Avg.Round(time.Millisecond))
+}
+
+// The same three middlewares, with metrics moved inside the retry
+func orderMatters() {
+	out.Printf("\n=== 2. ORDER MATTERS ===\n\n")
+
+	run := func(name string, chain func(m *Metrics) Middleware) {
+		var m Metrics
+		process := chain(&m)(newFlakyKitchen(2, 1, 3).cook) // Orders 1 and 3 burn twice
+		for _, o := range lunchOrders() {
+			process(context.Background(), o)
+		}
+		s := m.Snapshot()
+		out.Printf("%-24s %d calls recorded, %d failed\n", name, s.Calls, s.Failed)
+	}
+	run("Metrics outside retry:", func(m *Metrics) Middleware { return Chain(Recording(m), Retry(3, time.Millisecond)) })
+	run("Metrics inside retry:", func(m *Metrics) Middleware { return Chain(Retry(3, time.Millisecond), Recording(m)) })
+	out.Printf("\n💡 Outside, metrics count orders. Inside, they count attempts.\n")
+}
+
+// One chain, built once, shared by eight workers
+func sharedAcrossWorkers() {
+	out.Printf("\n=== 3. ONE CHAIN, EIGHT WORKERS ===\n\n")
+
+	var m Metrics
+	var logged atomic.Int64
+	countLog := func(string, ...any) { logged.Add(1) }
+
+	burns := []int{}
+	orders := make([]Order, 400)
+	for i := range orders {
+		orders[i] = Order{ID: i + 1, Dish: "Dumplings", PrepTime: time.Millisecond}
+		if i%10 == 0 {
+			burns = append(burns, i+1)
+		}
+	}
+	process := Chain(Logging(countLog), Recording(&m), Retry(3, time.Millisecond))(newFlakyKitchen(1, burns...).cook)
+
+	startTime := clk.Now()
+	errs := runWorkers(process, orders, 8)
+	failed := 0
+	for _, err := range errs {
+		if err != nil {
+			failed++
+		}
+	}
+	s := m.Snapshot()
+	out.Printf("👨‍🍳 %d orders on 8 workers in %v\n", len(orders), clk.Since(startTime).Round(time.Millisecond))
+	out.Printf("📊 Metrics: %d orders, %d failed | %d log lines | %d burnt once and retried\n", s.Calls, s.Failed, logged.Load(), len(burns))
+	out.Printf("❌ Orders that failed for good: %d\n", failed)
+}
+
+// Composition order, identity, retry limits and shared counts, checked directly
+func middlewareChecks() {
+	out.Printf("\n=== 4. MIDDLEWARE CHECKS ===\n\n")
+
+	check := func(name string, ok bool, detail string) {
+		status := "✅"
+		if !ok {
+			status = "❌"
+		}
+		out.Printf("%s %-44s %s\n", status, name, detail)
+	}
+
+	// Each tracer records when it's entered and left
+	var trace []string
+	tracer := func(name string) Middleware {
+		return func(next OrderProcessor) OrderProcessor {
+			return func(ctx context.Context, o Order) error {
+				trace = append(trace, name+">")
+				err := next(ctx, o)
+				trace = append(trace, "<"+name)
+				return err
+			}
+		}
+	}
+	handler := func(context.Context, Order) error { trace = append(trace, "cook"); return nil }
+	Chain(tracer("A"), tracer("B"), tracer("C"))(handler)(context.Background(), Order{ID: 1})
+	want := []string{"A>", "B>", "C>", "cook", "<C", "<B", "<A"}
+	check("First middleware is outermost:", slices.Equal(trace, want), strings.Join(trace, " "))
+
+	trace = nil
+	Chain()(handler)(context.Background(), Order{ID: 1})
+	check("Empty chain calls the handler as is:", slices.Equal(trace, []string{"cook"}), strings.Join(trace, " "))
+
+	calls := 0
+	alwaysBurnt := func(context.Context, Order) error { calls++; return ErrBurnt }
+	err := Retry(3, time.Millisecond)(alwaysBurnt)(context.Background(), Order{ID: 1})
+	check("Retry gives up after 3 attempts:", calls == 3 && errors.Is(err, ErrBurnt), fmt.Sprintf("%d calls, %v", calls, err))
+
+	calls = 0
+	ctx, cancel := clk.WithTimeout(context.Background(), 15*time.Millisecond)
+	err = Retry(10, 10*time.Millisecond)(alwaysBurnt)(ctx, Order{ID: 1})
+	cancel()
+	check("Retry stops when the context ends:", calls < 10 && errors.Is(err, context.DeadlineExceeded),
+		fmt.Sprintf("%d calls, %v", calls, strings.ReplaceAll(err.Error(), "\n", " + ")))
+
+	// 1000 orders through one shared chain on 16 workers: every count exact
+	var m Metrics
+	var logged atomic.Int64
+	countLog := func(string, ...any) { logged.Add(1) }
+	orders := make([]Order, 1000)
+	burns := []int{}
+	for i := range orders {
+		orders[i] = Order{ID: i + 1}
+		if i%4 == 0 {
+			burns = append(burns, i+1)
+		}
+	}
+	process := Chain(Logging(countLog), Recording(&m), Retry(2, 0))(newFlakyKitchen(1, burns...).cook)
+	errs := runWorkers(process, orders, 16)
+	s := m.Snapshot()
+	check("Shared chain, 16 workers: counts exact:", s.Calls == 1000 && s.Failed == 0 && logged.Load() == 2000 && !slices.ContainsFunc(errs, func(e error) bool { return e != nil }),
+		fmt.Sprintf("%d calls, %d failed, %d log lines", s.Calls, s.Failed, logged.Load()))
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Middleware Chains")
+	out.Println("==========================================")
+
+	composedChain()
+	orderMatters()
+	sharedAcrossWorkers()
+	middlewareChecks()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ A middleware is a func that takes a processor and returns a wrapped one")
+	out.Println("✅ Chain applies them so the first listed is the outermost")
+	out.Println("✅ Where a middleware sits changes what it sees: orders or attempts")
+	out.Println("✅ Middleware built once can be shared by every worker if its state is safe for concurrent use")
+	out.Println("✅ Retry should back off and give up when the context does")
+	return nil
+}
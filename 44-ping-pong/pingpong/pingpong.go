@@ -0,0 +1,275 @@
+// Package pingpong is lesson 44: Ping-Pong Handoff.
+package pingpong
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+	SentAt   time.Time // Stamped just before each handoff
+}
+
+// Dish is a plated order travelling back to the waiter
+type Dish struct {
+	OrderID int
+	SentAt  time.Time
+}
+
+// Strict alternation: the waiter can't hand over ticket N+1 until dish N comes back
+func waiterAndChef() {
+	out.Printf("\n=== 1. WAITER ⇄ CHEF (UNBUFFERED HANDOFF) ===\n\n")
+
+	tickets := make(chan Order) // Unbuffered: a send completes only when the chef receives
+	dishes := make(chan Dish)
+	var ticketLatency, dishLatency time.Duration
+
+	go func() {
+		clk.Sleep(context.Background(), 50*time.Millisecond) // Chef is still washing up when the first ticket arrives
+		for order := range tickets {
+			took := clk.Since(order.SentAt)
+			ticketLatency += took
+			out.Printf("👨‍🍳 Chef got ticket %2d after %v\n", order.ID, took.Round(time.Microsecond))
+			clk.Sleep(context.Background(), order.PrepTime)
+			dishes <- Dish{OrderID: order.ID, SentAt: clk.Now()}
+		}
+		close(dishes)
+	}()
+
+	for i := 1; i <= 10; i++ {
+		tickets <- Order{ID: i, PrepTime: 10 * time.Millisecond, SentAt: clk.Now()}
+		dish := <-dishes
+		took := clk.Since(dish.SentAt)
+		dishLatency += took
+		out.Printf("🤵 Waiter got dish   %2d after %v\n", dish.OrderID, took.Round(time.Microsecond))
+	}
+	close(tickets)
+	<-dishes // Wait for the chef to finish (closed channel)
+
+	// Ticket 1 waited for the chef to finish washing up: a send blocks until there's a receiver
+	out.Printf("\n📊 Average ticket handoff: %v (includes the 50ms wait for ticket 1)\n", (ticketLatency / 10).Round(time.Microsecond))
+	out.Printf("📊 Average dish handoff:   %v\n", (dishLatency / 10).Round(time.Microsecond))
+}
+
+// tracker records what each party in a ring is doing and counts handoffs,
+// so a watchdog can tell when the ring has stopped moving and where it's stuck
+type tracker struct {
+	mu       sync.Mutex
+	doing    map[string]string
+	handoffs atomic.Int64
+}
+
+func newTracker() *tracker {
+	return &tracker{doing: map[string]string{}}
+}
+
+func (t *tracker) set(name, what string) {
+	t.mu.Lock()
+	t.doing[name] = what
+	t.mu.Unlock()
+}
+
+// snapshot returns each party's last recorded activity, sorted by name
+func (t *tracker) snapshot() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	lines := make([]string, 0, len(t.doing))
+	for name, what := range t.doing {
+		lines = append(lines, fmt.Sprintf("%-11s %s", name+":", what))
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+// watchdog calls stuck if no handoff happens for timeout, then returns.
+// It also returns when ctx is cancelled (the ring finished normally).
+func watchdog(ctx context.Context, t *tracker, timeout time.Duration, stuck func()) {
+	last := t.handoffs.Load()
+	ticker := clk.NewTicker(timeout)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			now := t.handoffs.Load()
+			if now == last {
+				stuck()
+				return
+			}
+			last = now
+		}
+	}
+}
+
+// handoff sends v unless ctx is cancelled first, and counts the handoff
+func handoff[T any](ctx context.Context, t *tracker, ch chan<- T, v T) bool {
+	select {
+	case ch <- v:
+		t.handoffs.Add(1)
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// take receives from ch unless ctx is cancelled first
+func take[T any](ctx context.Context, ch <-chan T) (T, bool) {
+	select {
+	case v, ok := <-ch:
+		return v, ok
+	case <-ctx.Done():
+		var zero T
+		return zero, false
+	}
+}
+
+// runRing passes orders waiter → chef → expeditor → waiter. The expeditor
+// checks expeditorOrders tickets; fewer than orders means one receive is
+// missing and the ring jams. It reports whether every order came back.
+func runRing(orders, expeditorOrders int, verbose bool) bool {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	t := newTracker()
+	toChef := make(chan Order)
+	toExpeditor := make(chan Order)
+	toWaiter := make(chan Order)
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+
+	go func() {
+		defer wg.Done()
+		for {
+			t.set("chef", "waiting for a ticket")
+			order, ok := take(ctx, toChef)
+			if !ok {
+				return
+			}
+			clk.Sleep(context.Background(), order.PrepTime)
+			t.set("chef", fmt.Sprintf("handing order %d to the expeditor", order.ID))
+			if !handoff(ctx, t, toExpeditor, order) {
+				return
+			}
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < expeditorOrders; i++ {
+			t.set("expeditor", "waiting for a plate")
+			order, ok := take(ctx, toExpeditor)
+			if !ok {
+				return
+			}
+			t.set("expeditor", fmt.Sprintf("handing order %d to the waiter", order.ID))
+			if !handoff(ctx, t, toWaiter, order) {
+				return
+			}
+		}
+		t.set("expeditor", "gone home")
+	}()
+
+	delivered := 0
+	go func() {
+		defer wg.Done()
+		defer close(toChef)
+		for i := 1; i <= orders; i++ {
+			t.set("waiter", fmt.Sprintf("handing ticket %d to the chef", i))
+			if !handoff(ctx, t, toChef, Order{ID: i, PrepTime: 10 * time.Millisecond}) {
+				return
+			}
+			t.set("waiter", fmt.Sprintf("waiting for order %d", i))
+			order, ok := take(ctx, toWaiter)
+			if !ok {
+				return
+			}
+			delivered++
+			if verbose {
+				out.Printf("🔁 Order %d went round the ring\n", order.ID)
+			}
+		}
+		t.set("waiter", "done")
+		cancel() // Ring finished: stop the watchdog
+	}()
+
+	jammed := false
+	watchdog(ctx, t, 200*time.Millisecond, func() {
+		jammed = true
+		out.Printf("\n🐕 Watchdog: no handoff for 200ms - the ring is deadlocked\n")
+		for _, line := range t.snapshot() {
+			out.Printf("   %s\n", line)
+		}
+		cancel() // Unblock every party so the goroutines don't leak
+	})
+	wg.Wait()
+
+	if verbose || jammed {
+		out.Printf("\n📦 Delivered %d of %d orders\n", delivered, orders)
+	}
+	return !jammed && delivered == orders
+}
+
+// Three parties in a ring: every handoff is a rendezvous
+func threePartyRing() {
+	out.Printf("\n=== 2. THREE-PARTY RING (WAITER → CHEF → EXPEDITOR → WAITER) ===\n\n")
+
+	if runRing(5, 5, true) {
+		out.Println("🏁 Every order made it back to the waiter")
+	} else {
+		out.Println("🐕 The ring did not complete")
+	}
+}
+
+// One missing receive is enough to jam the whole ring
+func missingReceive() {
+	out.Printf("\n=== 3. ONE MISSING RECEIVE DEADLOCKS THE RING ===\n\n")
+
+	out.Println("🐛 The expeditor only checks 4 of 5 plates (off-by-one)")
+	if runRing(5, 4, false) {
+		out.Println("🏁 The ring completed anyway")
+	} else {
+		out.Println("🔓 Deadlock detected and every goroutine released")
+	}
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Ping-Pong Handoff")
+	out.Println("==========================================")
+
+	waiterAndChef()
+	threePartyRing()
+	missingReceive()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ An unbuffered send completes only when someone receives it")
+	out.Println("✅ Two unbuffered channels force strict turn-taking")
+	out.Println("✅ In a ring, one missing receive stalls every party")
+	out.Println("✅ A progress watchdog catches deadlocks the runtime can't see")
+	out.Println("✅ A channel handoff costs well under a microsecond")
+	return nil
+}
@@ -0,0 +1,24 @@
+package pingpong
+
+import "testing"
+
+// BenchmarkHandoff measures one round trip of an unbuffered ping-pong: a send
+// on ping that a partner goroutine answers on pong. ns/handoff is half of it.
+func BenchmarkHandoff(b *testing.B) {
+	b.Run("round-trip", func(b *testing.B) {
+		ping := make(chan int)
+		pong := make(chan int)
+		go func() {
+			for v := range ping {
+				pong <- v
+			}
+		}()
+		defer close(ping)
+
+		for i := 0; b.Loop(); i++ {
+			ping <- i
+			<-pong
+		}
+		b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(2*b.N), "ns/handoff")
+	})
+}
@@ -0,0 +1,78 @@
+package pingpong
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// onFakeClock points the lesson at a self-advancing fake clock and a
+// printer into the returned buffer. Flush out before reading it.
+func onFakeClock(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	savedClk := clk
+	clk, out = testutil.FakeClock(t), display.NewPrinter(&buf)
+	t.Cleanup(func() {
+		out.Close()
+		clk = savedClk
+	})
+	return &buf
+}
+
+// Every order goes round the ring and the watchdog stays quiet
+func TestRingCompletes(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	buf := onFakeClock(t)
+
+	if !runRing(5, 5, true) {
+		t.Error("runRing(5, 5) = false, want every order back")
+	}
+	out.Flush()
+	printed := buf.String()
+	if n := strings.Count(printed, "went round the ring"); n != 5 {
+		t.Errorf("%d orders went round the ring, want 5", n)
+	}
+	if strings.Contains(printed, "Watchdog") {
+		t.Errorf("the watchdog fired on a ring that completes:\n%s", printed)
+	}
+}
+
+// The expeditor misses the fifth plate: the watchdog reports the jam, four
+// orders are delivered, and every goroutine in the ring returns
+func TestRingMissingReceive(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	buf := onFakeClock(t)
+
+	if runRing(5, 4, false) {
+		t.Error("runRing(5, 4) = true, want the ring to jam")
+	}
+	out.Flush()
+	printed := buf.String()
+	for _, want := range []string{
+		"🐕 Watchdog: no handoff for 200ms - the ring is deadlocked",
+		"chef:       handing order 5 to the expeditor",
+		"expeditor:  gone home",
+		"waiter:     waiting for order 5",
+		"📦 Delivered 4 of 5 orders",
+	} {
+		if !strings.Contains(printed, want) {
+			t.Errorf("output missing %q in:\n%s", want, printed)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	got := testutil.RunLesson(t, Run)
+	for _, want := range []string{
+		"🏁 Every order made it back to the waiter",
+		"🔓 Deadlock detected and every goroutine released",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+}
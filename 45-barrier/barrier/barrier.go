@@ -0,0 +1,353 @@
+// Package barrier is lesson 45: Barrier.
+package barrier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/conc"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+// prepTime gives each station a different amount of work per course
+func prepTime(station, course int) time.Duration {
+	return time.Duration((station*3+course*5)%7+1) * 30 * time.Millisecond
+}
+
+// Four stations prep at their own pace but start serving each course together
+func dinnerRush() {
+	out.Printf("\n=== 1. DINNER RUSH: 4 STATIONS, 3 COURSES ===\n\n")
+
+	stations := []string{"grill", "fryer", "salad", "pastry"}
+	courses := []string{"starters", "mains", "desserts"}
+	barrier := conc.NewBarrier(len(stations))
+	start := clk.Now()
+
+	var mu sync.Mutex
+	lastReady := make([]time.Duration, len(courses))
+	firstServe := make([]time.Duration, len(courses))
+	for i := range firstServe {
+		firstServe[i] = time.Hour
+	}
+
+	var wg sync.WaitGroup
+	for s, name := range stations {
+		wg.Add(1)
+		go func(station int, name string) {
+			defer wg.Done()
+			for c, course := range courses {
+				clk.Sleep(context.Background(), prepTime(station, c))
+				ready := clk.Since(start)
+				out.Printf("🔪 [+%3dms] %-6s ready for %s\n", ready.Milliseconds(), name, course)
+
+				if err := barrier.Wait(context.Background()); err != nil {
+					out.Printf("❌ %s: %v\n", name, err)
+					return
+				}
+
+				served := clk.Since(start)
+				mu.Lock()
+				lastReady[c] = max(lastReady[c], ready)
+				firstServe[c] = min(firstServe[c], served)
+				mu.Unlock()
+			}
+		}(s, name)
+	}
+	wg.Wait()
+
+	out.Println()
+	for c, course := range courses {
+		status := "✅"
+		if firstServe[c] < lastReady[c] {
+			status = "❌"
+		}
+		out.Printf("%s %-8s served from +%3dms, after the last station was ready at +%3dms\n",
+			status, course, firstServe[c].Milliseconds(), lastReady[c].Milliseconds())
+	}
+}
+
+// The same barrier trips round after round, and nobody runs ahead
+func reuseAcrossGenerations() {
+	out.Printf("\n=== 2. REUSE ACROSS GENERATIONS ===\n\n")
+
+	const participants, rounds = 5, 200
+	barrier := conc.NewBarrier(participants)
+	var finished [rounds]atomic.Int64 // How many participants finished each round
+	var behind atomic.Int64           // Times someone entered round r+1 before round r was complete
+
+	var wg sync.WaitGroup
+	for p := 0; p < participants; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for r := 0; r < rounds; r++ {
+				if r > 0 && finished[r-1].Load() != participants {
+					behind.Add(1)
+				}
+				finished[r].Add(1)
+				barrier.Wait(context.Background())
+			}
+		}()
+	}
+	wg.Wait()
+
+	status := "✅"
+	if behind.Load() != 0 {
+		status = "❌"
+	}
+	out.Printf("%s %d participants, %d rounds: %d times a participant ran ahead of a round\n", status, participants, rounds, behind.Load())
+}
+
+// One participant giving up releases everyone else with an error instead of hanging them
+func cancellationPropagation() {
+	out.Printf("\n=== 3. CANCELLATION BREAKS THE GENERATION ===\n\n")
+
+	barrier := conc.NewBarrier(4) // Only 3 will show up
+	ctx, cancel := context.WithCancel(context.Background())
+
+	errs := make(chan error, 3)
+	for i := 0; i < 3; i++ {
+		waitCtx := context.Background()
+		if i == 0 {
+			waitCtx = ctx // Only the first station can be called away
+		}
+		go func(ctx context.Context) {
+			errs <- barrier.Wait(ctx)
+		}(waitCtx)
+	}
+
+	clk.Sleep(context.Background(), 50*time.Millisecond)
+	start := clk.Now()
+	cancel()
+
+	broken := 0
+	for i := 0; i < 3; i++ {
+		err := <-errs
+		if errors.Is(err, conc.ErrBroken) && errors.Is(err, context.Canceled) {
+			broken++
+		}
+		out.Printf("📣 Waiter released: %v\n", err)
+	}
+	status := "✅"
+	if broken != 3 {
+		status = "❌"
+	}
+	out.Printf("%s All 3 waiters got ErrBroken within %v of the cancel\n", status, clk.Since(start).Round(time.Microsecond))
+
+	// The barrier starts a fresh generation and can be used again
+	var wg sync.WaitGroup
+	var ok atomic.Int64
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if barrier.Wait(context.Background()) == nil {
+				ok.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	status = "✅"
+	if ok.Load() != 4 {
+		status = "❌"
+	}
+	out.Printf("%s Next generation of 4 passed normally: %d released\n", status, ok.Load())
+}
+
+// Extra callers don't slip through: they become the next generation
+func moreCallersThanN() {
+	out.Printf("\n=== 4. MORE CALLERS THAN N ===\n\n")
+
+	tests := []struct {
+		name     string
+		n        int
+		callers  int
+		released int // Expected to pass before the 200ms timeout
+	}{
+		{"6 callers, n=3 (two full generations)", 3, 6, 6},
+		{"5 callers, n=3 (one full, one partial)", 3, 5, 3},
+		{"1 caller, n=1 (trips immediately)", 1, 1, 1},
+	}
+
+	for _, tt := range tests {
+		barrier := conc.NewBarrier(tt.n)
+		ctx, cancel := clk.WithTimeout(context.Background(), 200*time.Millisecond)
+
+		var released, timedOut atomic.Int64
+		var wg sync.WaitGroup
+		for i := 0; i < tt.callers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := barrier.Wait(ctx); err != nil {
+					timedOut.Add(1)
+				} else {
+					released.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+		cancel()
+
+		status := "✅"
+		if released.Load() != int64(tt.released) {
+			status = "❌"
+		}
+		out.Printf("%s %-40s released %d, timed out %d\n", status, tt.name+":", released.Load(), timedOut.Load())
+	}
+}
+
+// Five cooks move through prep, cooking and plating in lockstep
+func phasedKitchen() {
+	out.Printf("\n=== 5. PHASED KITCHEN: PREP → COOK → PLATE ===\n\n")
+
+	const cooks = 5
+	phases := []string{"prep", "cook", "plate"}
+	barrier := conc.NewBarrier(cooks)
+	start := clk.Now()
+
+	var mu sync.Mutex
+	finished := make([]time.Duration, len(phases)) // When the last cook finished each phase
+	started := make([]time.Duration, len(phases))  // When the first cook started each phase
+	for i := range started {
+		started[i] = time.Hour
+	}
+
+	var wg sync.WaitGroup
+	for cook := 0; cook < cooks; cook++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range phases {
+				mu.Lock()
+				started[p] = min(started[p], clk.Since(start))
+				mu.Unlock()
+
+				clk.Sleep(context.Background(), prepTime(cook, p))
+
+				mu.Lock()
+				finished[p] = max(finished[p], clk.Since(start))
+				mu.Unlock()
+				barrier.Await() // Nobody moves to the next phase until every cook is done with this one
+			}
+		}()
+	}
+	wg.Wait()
+
+	for p, phase := range phases {
+		status := "✅"
+		if p > 0 && started[p] < finished[p-1] {
+			status = "❌"
+		}
+		out.Printf("%s %-5s first cook started +%3dms, last cook finished +%3dms\n",
+			status, phase, started[p].Milliseconds(), finished[p].Milliseconds())
+	}
+	out.Printf("\n👨‍🍳 Each phase starts after the previous one's last cook finished\n")
+}
+
+// Arrivals block, the last one releases all, and the barrier is ready again, checked directly
+func barrierChecks() {
+	out.Printf("\n=== 6. BARRIER CHECKS ===\n\n")
+
+	check := func(name string, ok bool, detail string) {
+		status := "✅"
+		if !ok {
+			status = "❌"
+		}
+		out.Printf("%s %-46s %s\n", status, name, detail)
+	}
+
+	// 5 goroutines: the first 4 wait at the barrier until the 5th arrives
+	const n = 5
+	barrier := conc.NewBarrier(n)
+	var arrived, passed atomic.Int64
+	var wg sync.WaitGroup
+	launch := func() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			arrived.Add(1)
+			barrier.Await()
+			passed.Add(1)
+		}()
+	}
+	for range n - 1 {
+		launch()
+	}
+	for arrived.Load() < n-1 {
+		clk.Sleep(context.Background(), time.Millisecond)
+	}
+	clk.Sleep(context.Background(), 50*time.Millisecond) // Plenty of time for anyone to slip past
+	early := passed.Load()
+	check("4 of 5 arrived: nobody passes:", early == 0, fmt.Sprintf("%d passed after 50ms", early))
+
+	launch()
+	wg.Wait()
+	check("5th arrival releases all 5:", passed.Load() == n, fmt.Sprintf("%d passed", passed.Load()))
+
+	// The same barrier works for the next phase without being rebuilt
+	passed.Store(0)
+	for range n {
+		launch()
+	}
+	wg.Wait()
+	check("Cyclic: the next phase uses the same barrier:", passed.Load() == n, fmt.Sprintf("%d passed", passed.Load()))
+
+	// Three phases, five workers: nobody starts phase p+1 before all finish phase p
+	var done [3]atomic.Int64
+	var ranAhead atomic.Int64
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range done {
+				if p > 0 && done[p-1].Load() != n {
+					ranAhead.Add(1)
+				}
+				done[p].Add(1)
+				barrier.Await()
+			}
+		}()
+	}
+	wg.Wait()
+	check("3 phases, 5 workers: nobody runs ahead:", ranAhead.Load() == 0, fmt.Sprintf("%d early start(s)", ranAhead.Load()))
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Barrier")
+	out.Println("==========================================")
+
+	dinnerRush()
+	reuseAcrossGenerations()
+	cancellationPropagation()
+	moreCallersThanN()
+	phasedKitchen()
+	barrierChecks()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ A barrier releases a group only when every participant has arrived")
+	out.Println("✅ Generations make the barrier reusable round after round")
+	out.Println("✅ Closing a channel wakes every waiter of a generation at once")
+	out.Println("✅ A cancelled participant breaks the round instead of hanging the rest")
+	out.Println("✅ Extra callers wait for the next generation rather than slipping through")
+	out.Println("✅ One cyclic barrier can hold a whole team at every phase change")
+	return nil
+}
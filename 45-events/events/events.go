@@ -0,0 +1,387 @@
+// Package events is lesson 45: Order Events.
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+// OrderEvent is anything that happened to an order
+type OrderEvent interface {
+	OrderID() int
+}
+
+type OrderCreatedEvent struct {
+	ID   int
+	Dish string
+}
+
+type OrderStartedEvent struct {
+	ID   int
+	Chef int
+}
+
+type OrderCompletedEvent struct {
+	ID       int
+	Took     time.Duration
+	Replayed bool
+}
+
+type OrderFailedEvent struct {
+	ID   int
+	Dish string
+	Err  error
+}
+
+func (e OrderCreatedEvent) OrderID() int   { return e.ID }
+func (e OrderStartedEvent) OrderID() int   { return e.ID }
+func (e OrderCompletedEvent) OrderID() int { return e.ID }
+func (e OrderFailedEvent) OrderID() int    { return e.ID }
+
+// stage ranks how far an order got. Handlers run concurrently, so an
+// order's events can be applied out of order; the board keeps the highest.
+type stage int
+
+const (
+	stageCreated stage = iota + 1
+	stageStarted
+	stageFailed
+	stageCompleted // Above failed, so a replayed success replaces the failure
+)
+
+// Board is the status of every order, as the handlers report it
+type Board struct {
+	mu     sync.Mutex
+	stages map[int]stage
+	status map[int]string
+}
+
+func NewBoard() *Board {
+	return &Board{stages: make(map[int]stage), status: make(map[int]string)}
+}
+
+// set records status for the order unless it already reached a later stage
+func (b *Board) set(id int, s stage, status string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if s > b.stages[id] {
+		b.stages[id], b.status[id] = s, status
+	}
+}
+
+// Status returns the order's status and how far it got
+func (b *Board) Status(id int) (string, stage) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.status[id], b.stages[id]
+}
+
+// IDs returns every order on the board, sorted
+func (b *Board) IDs() []int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ids := make([]int, 0, len(b.stages))
+	for id := range b.stages {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// DeadLetters keeps failed events so they can be replayed later
+type DeadLetters struct {
+	mu     sync.Mutex
+	events []OrderFailedEvent
+}
+
+func (d *DeadLetters) add(e OrderFailedEvent) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.events = append(d.events, e)
+}
+
+// Events returns a copy of the failed events so far
+func (d *DeadLetters) Events() []OrderFailedEvent {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return slices.Clone(d.events)
+}
+
+// PipelineStats counts what the router sent to each handler
+type PipelineStats struct {
+	Created, Started, Completed, Failed, Unrouted atomic.Int64
+}
+
+// runPipeline routes every event from in to a handler goroutine for its type,
+// over a typed channel per handler. It returns a channel that closes once in
+// is closed and every handler has finished.
+func runPipeline(in <-chan OrderEvent, board *Board, dead *DeadLetters, stats *PipelineStats) <-chan struct{} {
+	created := make(chan OrderCreatedEvent)
+	started := make(chan OrderStartedEvent)
+	completed := make(chan OrderCompletedEvent)
+	failed := make(chan OrderFailedEvent)
+
+	// Router: the only stage that knows every event type
+	go func() {
+		defer close(created)
+		defer close(started)
+		defer close(completed)
+		defer close(failed)
+		for ev := range in {
+			switch e := ev.(type) {
+			case OrderCreatedEvent:
+				created <- e
+			case OrderStartedEvent:
+				started <- e
+			case OrderCompletedEvent:
+				completed <- e
+			case OrderFailedEvent:
+				failed <- e
+			default:
+				stats.Unrouted.Add(1) // A type this pipeline doesn't know; skip it
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	handle := func(fn func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn()
+		}()
+	}
+	handle(func() {
+		for e := range created {
+			stats.Created.Add(1)
+			board.set(e.ID, stageCreated, "🧾 queued: "+e.Dish)
+		}
+	})
+	handle(func() {
+		for e := range started {
+			stats.Started.Add(1)
+			board.set(e.ID, stageStarted, fmt.Sprintf("🔥 cooking (chef %d)", e.Chef))
+		}
+	})
+	handle(func() {
+		for e := range completed {
+			stats.Completed.Add(1)
+			status := fmt.Sprintf("✅ ready in %v", e.Took)
+			if e.Replayed {
+				status += " (replayed)"
+			}
+			board.set(e.ID, stageCompleted, status)
+		}
+	})
+	handle(func() {
+		for e := range failed {
+			stats.Failed.Add(1)
+			dead.add(e)
+			board.set(e.ID, stageFailed, "❌ failed: "+e.Err.Error())
+		}
+	})
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	return done
+}
+
+var ErrOvenFault = errors.New("oven fault")
+
+// kitchen emits created, started and then completed or failed for each dish,
+// pausing gap between orders. Orders whose ID is in broken fail.
+func kitchen(firstID int, dishes []string, gap time.Duration, broken map[int]bool) <-chan OrderEvent {
+	events := make(chan OrderEvent)
+	go func() {
+		defer close(events)
+		for i, dish := range dishes {
+			id := firstID + i
+			events <- OrderCreatedEvent{ID: id, Dish: dish}
+			events <- OrderStartedEvent{ID: id, Chef: i%2 + 1}
+			clk.Sleep(context.Background(), gap)
+			if broken[id] {
+				events <- OrderFailedEvent{ID: id, Dish: dish, Err: ErrOvenFault}
+				continue
+			}
+			events <- OrderCompletedEvent{ID: id, Took: gap}
+		}
+	}()
+	return events
+}
+
+// Replay cooks every failed event again on its own goroutine and feeds the
+// outcome through a separate pipeline. It shares the board, so a success
+// shows up there, but not the main pipeline's channels or dead letters.
+func Replay(failed []OrderFailedEvent, recook func(OrderFailedEvent) OrderEvent, board *Board) (*DeadLetters, <-chan struct{}) {
+	events := make(chan OrderEvent)
+	go func() {
+		defer close(events)
+		for _, e := range failed {
+			events <- recook(e)
+		}
+	}()
+	dead := &DeadLetters{}
+	return dead, runPipeline(events, board, dead, &PipelineStats{})
+}
+
+// printBoard prints every order's status
+func printBoard(board *Board) {
+	for _, id := range board.IDs() {
+		status, _ := board.Status(id)
+		out.Printf("   Order %2d: %s\n", id, status)
+	}
+}
+
+var lunchMenu = []string{"Ramen", "Tacos", "Pho", "Curry", "Burger", "Salad"}
+
+// Six orders through the pipeline; two hit an oven fault
+func lifecycle() {
+	out.Printf("\n=== 1. ORDER LIFECYCLE EVENTS ===\n\n")
+
+	board, dead, stats := NewBoard(), &DeadLetters{}, &PipelineStats{}
+	<-runPipeline(kitchen(1, lunchMenu, 10*time.Millisecond, map[int]bool{2: true, 5: true}), board, dead, stats)
+
+	printBoard(board)
+	out.Printf("\n📊 Routed: %d created, %d started, %d completed, %d failed | 📮 %d dead letters\n",
+		stats.Created.Load(), stats.Started.Load(), stats.Completed.Load(), stats.Failed.Load(), len(dead.Events()))
+}
+
+// Replay yesterday's failures while the dinner rush flows through the main pipeline
+func replayFailures() {
+	out.Printf("\n=== 2. REPLAYING FAILURES BESIDE THE MAIN PIPELINE ===\n\n")
+
+	board, dead := NewBoard(), &DeadLetters{}
+	<-runPipeline(kitchen(1, lunchMenu, 5*time.Millisecond, map[int]bool{2: true, 5: true}), board, dead, &PipelineStats{})
+	failed := dead.Events()
+	out.Printf("📮 %d failed orders to replay: %d and %d\n", len(failed), failed[0].ID, failed[1].ID)
+
+	startTime := clk.Now()
+	slowRecook := func(e OrderFailedEvent) OrderEvent {
+		clk.Sleep(context.Background(), 100*time.Millisecond) // The spare oven is slow
+		return OrderCompletedEvent{ID: e.ID, Took: 100 * time.Millisecond, Replayed: true}
+	}
+	_, replayDone := Replay(failed, slowRecook, board)
+
+	dinner := []string{"Steak", "Risotto", "Salmon", "Lasagna", "Gnocchi", "Paella"}
+	mainDone := runPipeline(kitchen(7, dinner, 5*time.Millisecond, nil), board, dead, &PipelineStats{})
+	<-mainDone
+	out.Printf("🍽️  Dinner batch through the main pipeline after %v\n", clk.Since(startTime).Round(10*time.Millisecond))
+	<-replayDone
+	out.Printf("🔁 Replay finished after %v\n\n", clk.Since(startTime).Round(10*time.Millisecond))
+
+	printBoard(board)
+}
+
+// unknownEvent is an OrderEvent the router has no handler for
+type unknownEvent struct{ ID int }
+
+func (e unknownEvent) OrderID() int { return e.ID }
+
+// Routing counts, out-of-order handling, unknown events and replay isolation, checked directly
+func eventChecks() {
+	out.Printf("\n=== 3. EVENT PIPELINE CHECKS ===\n\n")
+
+	check := func(name string, ok bool, detail string) {
+		status := "✅"
+		if !ok {
+			status = "❌"
+		}
+		out.Printf("%s %-46s %s\n", status, name, detail)
+	}
+
+	// 100 orders, every 10th broken, no pause: handlers race each other
+	dishes := make([]string, 100)
+	broken := make(map[int]bool)
+	for i := range dishes {
+		dishes[i] = "Dumplings"
+		if i%10 == 0 {
+			broken[i+1] = true
+		}
+	}
+	board, dead, stats := NewBoard(), &DeadLetters{}, &PipelineStats{}
+	<-runPipeline(kitchen(1, dishes, 0, broken), board, dead, stats)
+	check("Each type reaches its own handler:",
+		stats.Created.Load() == 100 && stats.Started.Load() == 100 && stats.Completed.Load() == 90 && stats.Failed.Load() == 10,
+		fmt.Sprintf("%d/%d/%d/%d", stats.Created.Load(), stats.Started.Load(), stats.Completed.Load(), stats.Failed.Load()))
+
+	final := true
+	for id := 1; id <= 100; id++ {
+		_, s := board.Status(id)
+		final = final && (s == stageFailed) == broken[id] && (s == stageCompleted) == !broken[id]
+	}
+	check("Racing handlers never move an order back:", final, "100 orders at their final stage")
+
+	in := make(chan OrderEvent, 3)
+	in <- OrderCreatedEvent{ID: 1, Dish: "Soup"}
+	in <- unknownEvent{ID: 1}
+	in <- OrderCompletedEvent{ID: 1}
+	close(in)
+	stats = &PipelineStats{}
+	board2 := NewBoard()
+	select {
+	case <-runPipeline(in, board2, &DeadLetters{}, stats):
+		_, s := board2.Status(1)
+		check("Unknown event skipped, pipeline keeps going:", stats.Unrouted.Load() == 1 && s == stageCompleted,
+			fmt.Sprintf("%d unrouted", stats.Unrouted.Load()))
+	case <-clk.After(time.Second):
+		check("Unknown event skipped, pipeline keeps going:", false, "pipeline stalled")
+	}
+
+	// Replay: order 11 recovers, order 21 fails again
+	failed := dead.Events()[:3] // Orders 1, 11, 21: one kitchen and one failed handler keep them in order
+	recook := func(e OrderFailedEvent) OrderEvent {
+		if e.ID == 21 {
+			return OrderFailedEvent{ID: e.ID, Dish: e.Dish, Err: ErrOvenFault}
+		}
+		return OrderCompletedEvent{ID: e.ID, Replayed: true}
+	}
+	replayDead, done := Replay(failed, recook, board)
+	<-done
+	_, s1 := board.Status(failed[0].ID)
+	_, s21 := board.Status(21)
+	check("Replayed successes replace the failure:", s1 == stageCompleted && s21 == stageFailed,
+		fmt.Sprintf("orders %d %d %d replayed", failed[0].ID, failed[1].ID, failed[2].ID))
+	check("Replay failures don't touch the main queue:", len(replayDead.Events()) == 1 && len(dead.Events()) == 10,
+		fmt.Sprintf("%d in replay's dead letters, main still has %d", len(replayDead.Events()), len(dead.Events())))
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Order Events")
+	out.Println("==========================================")
+
+	lifecycle()
+	replayFailures()
+	eventChecks()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ An interface with OrderID() lets one channel carry every kind of event")
+	out.Println("✅ A type switch in one router stage sends each kind to its own typed channel")
+	out.Println("✅ Handlers in separate goroutines can apply an order's events out of order; rank the stages")
+	out.Println("✅ Keep failed events as dead letters and replay them through a pipeline of their own")
+	out.Println("✅ Unknown event types are counted and skipped, never allowed to stall the router")
+	return nil
+}
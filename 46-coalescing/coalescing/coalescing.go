@@ -0,0 +1,282 @@
+// Package coalescing is lesson 46: Request Coalescing.
+package coalescing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+	"golang.org/x/sync/singleflight"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+var ErrUnknownOrder = errors.New("unknown order")
+
+// KitchenAPI is the slow upstream that knows every order's status
+type KitchenAPI struct {
+	latency  time.Duration
+	statuses map[int]string // Read-only after construction
+	fetches  atomic.Int64   // Upstream calls actually made
+}
+
+func NewKitchenAPI(latency time.Duration) *KitchenAPI {
+	return &KitchenAPI{
+		latency:  latency,
+		statuses: map[int]string{1: "cooking", 2: "plating", 3: "ready for pickup"},
+	}
+}
+
+// FetchStatus takes latency per call. The fetch number in the status shows
+// which callers got their answer from the same fetch.
+func (k *KitchenAPI) FetchStatus(ctx context.Context, orderID int) (string, error) {
+	n := k.fetches.Add(1)
+	select {
+	case <-clk.After(k.latency):
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	status, ok := k.statuses[orderID]
+	if !ok {
+		return "", fmt.Errorf("order %d: %w", orderID, ErrUnknownOrder)
+	}
+	return fmt.Sprintf("%s (fetch #%d)", status, n), nil
+}
+
+// StatusCoalescer sits in front of the kitchen API. However many callers ask
+// about one order at the same moment, the kitchen is asked once: a
+// singleflight.Group keyed by order ID merges them.
+type StatusCoalescer struct {
+	api      *KitchenAPI
+	group    singleflight.Group
+	fetching atomic.Int64 // Shared fetches running
+	waiting  atomic.Int64 // Callers waiting on one
+}
+
+func NewStatusCoalescer(api *KitchenAPI) *StatusCoalescer {
+	return &StatusCoalescer{api: api}
+}
+
+// GetStatus returns the order's status, joining any fetch for the same order
+// already in flight. ctx bounds how long this caller waits, not the fetch:
+// the fetch runs without the first caller's cancellation, so one caller
+// giving up doesn't fail everyone who joined it.
+func (s *StatusCoalescer) GetStatus(ctx context.Context, orderID int) (string, error) {
+	ch := s.group.DoChan(strconv.Itoa(orderID), func() (any, error) {
+		s.fetching.Add(1)
+		defer s.fetching.Add(-1)
+		return s.api.FetchStatus(context.WithoutCancel(ctx), orderID)
+	})
+	s.waiting.Add(1)
+	defer s.waiting.Add(-1)
+	select {
+	case r := <-ch:
+		if r.Err != nil {
+			return "", r.Err
+		}
+		return r.Val.(string), nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// inFlight returns how many order IDs have a fetch running
+func (s *StatusCoalescer) inFlight() int {
+	return int(s.fetching.Load())
+}
+
+// waiters returns how many GetStatus callers have joined a fetch and not
+// yet been answered
+func (s *StatusCoalescer) waiters() int {
+	return int(s.waiting.Load())
+}
+
+// askAll has callers goroutines ask about orderID at once, and returns every
+// answer and error, in caller order
+func askAll(s *StatusCoalescer, ctx context.Context, orderID, callers int) ([]string, []error) {
+	statuses := make([]string, callers)
+	errs := make([]error, callers)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start                                          // Line everyone up, then release them together
+			statuses[i], errs[i] = s.GetStatus(ctx, orderID) // Each index has one writer
+		}()
+	}
+	close(start)
+	wg.Wait()
+	return statuses, errs
+}
+
+// Fifty customers refresh the tracking page for order 1 at the same moment
+func fiftyCallers() {
+	out.Printf("\n=== 1. 50 CALLERS, ONE FETCH ===\n\n")
+
+	api := NewKitchenAPI(100 * time.Millisecond)
+	s := NewStatusCoalescer(api)
+	startTime := clk.Now()
+	statuses, _ := askAll(s, context.Background(), 1, 50)
+
+	out.Printf("📱 50 callers asked about order 1 in %v\n", clk.Since(startTime).Round(10*time.Millisecond))
+	out.Printf("🍳 Kitchen fetches: %d\n", api.fetches.Load())
+	out.Printf("📦 Caller 1 got %q, caller 50 got %q\n", statuses[0], statuses[49])
+}
+
+// Three orders, ten callers each: one fetch per order, all in parallel
+func perOrder() {
+	out.Printf("\n=== 2. ONE FETCH PER ORDER, NOT ONE FOR ALL ===\n\n")
+
+	api := NewKitchenAPI(100 * time.Millisecond)
+	s := NewStatusCoalescer(api)
+	startTime := clk.Now()
+	var mu sync.Mutex
+	answers := map[int]string{}
+	var wg sync.WaitGroup
+	for id := 1; id <= 3; id++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statuses, _ := askAll(s, context.Background(), id, 10)
+			mu.Lock()
+			answers[id] = statuses[0]
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	for id := 1; id <= 3; id++ {
+		out.Printf("📦 Order %d: %s\n", id, strings.Split(answers[id], " (")[0])
+	}
+	out.Printf("\n🍳 30 callers, %d kitchen fetches, %v (fetches for different orders run side by side)\n",
+		api.fetches.Load(), clk.Since(startTime).Round(10*time.Millisecond))
+}
+
+// The first caller gives up after 20ms; the fetch it started still answers the rest
+func impatientCaller() {
+	out.Printf("\n=== 3. THE FIRST CALLER GIVES UP ===\n\n")
+
+	api := NewKitchenAPI(100 * time.Millisecond)
+	s := NewStatusCoalescer(api)
+
+	ctx, cancel := clk.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	first := make(chan error, 1)
+	go func() {
+		_, err := s.GetStatus(ctx, 2) // Starts the fetch
+		first <- err
+	}()
+	for s.inFlight() == 0 {
+		clk.Sleep(context.Background(), time.Millisecond)
+	}
+	statuses, errs := askAll(s, context.Background(), 2, 9)
+
+	out.Printf("⌛ Caller 1 (20ms timeout): %v\n", <-first)
+	out.Printf("📦 Callers 2-10: %q, errors: %d\n", statuses[0], len(slices.DeleteFunc(errs, func(e error) bool { return e == nil })))
+	out.Printf("🍳 Kitchen fetches: %d\n", api.fetches.Load())
+}
+
+// One fetch, shared results and errors, no caching and independent callers, checked directly
+func coalescingChecks() {
+	out.Printf("\n=== 4. COALESCING CHECKS ===\n\n")
+
+	check := func(name string, ok bool, detail string) {
+		status := "✅"
+		if !ok {
+			status = "❌"
+		}
+		out.Printf("%s %-44s %s\n", status, name, detail)
+	}
+
+	// 50 concurrent requests for order 1: one fetch, one answer
+	api := NewKitchenAPI(100 * time.Millisecond)
+	s := NewStatusCoalescer(api)
+	statuses, errs := askAll(s, context.Background(), 1, 50)
+	same := !slices.ContainsFunc(statuses, func(st string) bool { return st != statuses[0] })
+	check("50 concurrent requests, one fetch:", api.fetches.Load() == 1, fmt.Sprintf("%d fetch(es)", api.fetches.Load()))
+	check("Every caller gets the same status:", same && errors.Join(errs...) == nil, fmt.Sprintf("50 × %q", statuses[0]))
+
+	// The result arrives Shared for everyone who joined
+	var g singleflight.Group
+	release := make(chan struct{})
+	leader := g.DoChan("k", func() (any, error) { <-release; return "v", nil })
+	joiner := g.DoChan("k", func() (any, error) { return "other", nil })
+	close(release)
+	r1, r2 := <-leader, <-joiner
+	check("Joiners share the leader's result:", r1.Val == "v" && r2.Val == "v" && r1.Shared && r2.Shared,
+		fmt.Sprintf("leader %v, joiner %v, shared %t/%t", r1.Val, r2.Val, r1.Shared, r2.Shared))
+
+	// An unknown order: the one error goes to every caller
+	api = NewKitchenAPI(20 * time.Millisecond)
+	s = NewStatusCoalescer(api)
+	_, errs = askAll(s, context.Background(), 99, 10)
+	allFailed := !slices.ContainsFunc(errs, func(e error) bool { return !errors.Is(e, ErrUnknownOrder) })
+	check("An error is shared like a status:", allFailed && api.fetches.Load() == 1, fmt.Sprintf("%d fetch, %v", api.fetches.Load(), errs[0]))
+
+	// Coalescing is not caching: asking again after the fetch returns fetches again
+	api = NewKitchenAPI(5 * time.Millisecond)
+	s = NewStatusCoalescer(api)
+	a, _ := s.GetStatus(context.Background(), 3)
+	b, _ := s.GetStatus(context.Background(), 3)
+	check("Back-to-back calls fetch twice:", api.fetches.Load() == 2 && a != b, fmt.Sprintf("%q then %q", a, b))
+
+	// The caller that started the fetch times out; the others still get the status
+	api = NewKitchenAPI(50 * time.Millisecond)
+	s = NewStatusCoalescer(api)
+	ctx, cancel := clk.WithTimeout(context.Background(), 10*time.Millisecond)
+	first := make(chan error, 1)
+	go func() {
+		_, err := s.GetStatus(ctx, 1)
+		first <- err
+	}()
+	for s.inFlight() == 0 {
+		clk.Sleep(context.Background(), time.Millisecond)
+	}
+	statuses, errs = askAll(s, context.Background(), 1, 5)
+	err := <-first
+	cancel()
+	check("Starter's timeout is its own:", errors.Is(err, context.DeadlineExceeded), fmt.Sprint(err))
+	check("Others still get the status from its fetch:", errors.Join(errs...) == nil && api.fetches.Load() == 1,
+		fmt.Sprintf("%q, %d fetch", statuses[0], api.fetches.Load()))
+	check("Nothing left in flight:", s.inFlight() == 0 && s.waiters() == 0,
+		fmt.Sprintf("%d fetch(es), %d waiter(s)", s.inFlight(), s.waiters()))
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Request Coalescing")
+	out.Println("==========================================")
+
+	fiftyCallers()
+	perOrder()
+	impatientCaller()
+	coalescingChecks()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ Concurrent requests for the same key can share one upstream call")
+	out.Println("✅ Every caller gets the same value or the same error")
+	out.Println("✅ DoChan plus select lets each caller wait with its own context")
+	out.Println("✅ Run the shared fetch without the first caller's cancellation")
+	out.Println("✅ Coalescing merges calls in flight; it is not a cache")
+	return nil
+}
@@ -0,0 +1,187 @@
+package coalescing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// onManualClock points the lesson at a fake clock that only moves when the
+// test advances it, and a printer that discards. Fetches never finish on
+// their own, so every caller a test starts has joined before it advances.
+func onManualClock(t *testing.T) *clock.FakeClock {
+	fake := clock.NewFake(testutil.Epoch)
+	savedClk := clk
+	clk, out = fake, display.NewPrinter(io.Discard)
+	t.Cleanup(func() {
+		out.Close()
+		clk = savedClk
+	})
+	return fake
+}
+
+func waitForWaiters(s *StatusCoalescer, n int) {
+	for s.waiters() < n {
+		runtime.Gosched()
+	}
+}
+
+type answers struct {
+	statuses []string
+	errs     []error
+}
+
+// askAllAsync is askAll in the background, once every caller has joined
+func askAllAsync(s *StatusCoalescer, ctx context.Context, orderID, callers int) <-chan answers {
+	done := make(chan answers, 1)
+	base := s.waiters()
+	go func() {
+		statuses, errs := askAll(s, ctx, orderID, callers)
+		done <- answers{statuses, errs}
+	}()
+	waitForWaiters(s, base+callers)
+	return done
+}
+
+func TestFiftyRequestsOneFetch(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := onManualClock(t)
+	api := NewKitchenAPI(100 * time.Millisecond)
+	s := NewStatusCoalescer(api)
+
+	done := askAllAsync(s, context.Background(), 1, 50)
+	fake.Advance(100 * time.Millisecond)
+	got := <-done
+
+	if n := api.fetches.Load(); n != 1 {
+		t.Errorf("%d kitchen fetches for 50 requests, want 1", n)
+	}
+	for i, status := range got.statuses {
+		if status != "cooking (fetch #1)" || got.errs[i] != nil {
+			t.Errorf("caller %d got %q, %v; want the one fetch's status", i+1, status, got.errs[i])
+		}
+	}
+	if s.inFlight() != 0 || s.waiters() != 0 {
+		t.Errorf("%d fetches and %d waiters left after everyone was answered", s.inFlight(), s.waiters())
+	}
+}
+
+func TestOneFetchPerOrder(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := onManualClock(t)
+	api := NewKitchenAPI(100 * time.Millisecond)
+	s := NewStatusCoalescer(api)
+
+	var pending []<-chan answers
+	for id := 1; id <= 3; id++ {
+		pending = append(pending, askAllAsync(s, context.Background(), id, 10))
+	}
+	if n := s.inFlight(); n != 3 {
+		t.Errorf("%d fetches in flight, want one per order", n)
+	}
+	fake.Advance(100 * time.Millisecond)
+
+	want := []string{"cooking", "plating", "ready for pickup"}
+	for i, done := range pending {
+		got := <-done
+		for _, status := range got.statuses {
+			if !strings.HasPrefix(status, want[i]) {
+				t.Errorf("order %d: got %q, want %s", i+1, status, want[i])
+			}
+		}
+	}
+	if n := api.fetches.Load(); n != 3 {
+		t.Errorf("%d kitchen fetches for 3 orders, want 3", n)
+	}
+}
+
+func TestErrorIsShared(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := onManualClock(t)
+	api := NewKitchenAPI(20 * time.Millisecond)
+	s := NewStatusCoalescer(api)
+
+	done := askAllAsync(s, context.Background(), 99, 10)
+	fake.Advance(20 * time.Millisecond)
+	for _, err := range (<-done).errs {
+		if !errors.Is(err, ErrUnknownOrder) {
+			t.Errorf("err = %v, want ErrUnknownOrder", err)
+		}
+	}
+	if n := api.fetches.Load(); n != 1 {
+		t.Errorf("%d kitchen fetches, want 1", n)
+	}
+}
+
+// Coalescing merges calls in flight; once a fetch returns, the next call
+// fetches again
+func TestNotACache(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := onManualClock(t)
+	api := NewKitchenAPI(5 * time.Millisecond)
+	s := NewStatusCoalescer(api)
+
+	var got []string
+	for range 2 {
+		done := askAllAsync(s, context.Background(), 3, 1)
+		fake.Advance(5 * time.Millisecond)
+		got = append(got, (<-done).statuses[0])
+	}
+	if want := []string{"ready for pickup (fetch #1)", "ready for pickup (fetch #2)"}; !slices.Equal(got, want) {
+		t.Errorf("back to back: %q, want %q", got, want)
+	}
+}
+
+// The caller that started the fetch gives up; the fetch carries on and
+// answers everyone who joined it
+func TestStarterTimeoutIsItsOwn(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := onManualClock(t)
+	api := NewKitchenAPI(50 * time.Millisecond)
+	s := NewStatusCoalescer(api)
+
+	ctx, cancel := clk.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	first := make(chan error, 1)
+	go func() {
+		_, err := s.GetStatus(ctx, 1)
+		first <- err
+	}()
+	waitForWaiters(s, 1)
+	others := askAllAsync(s, context.Background(), 1, 5)
+
+	fake.Advance(10 * time.Millisecond)
+	if err := <-first; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("starter got %v, want its own deadline", err)
+	}
+	fake.Advance(40 * time.Millisecond)
+	got := <-others
+	if errors.Join(got.errs...) != nil || slices.ContainsFunc(got.statuses, func(st string) bool { return st != "cooking (fetch #1)" }) {
+		t.Errorf("others got %q, %v; want the starter's fetch", got.statuses, got.errs)
+	}
+}
+
+func TestRun(t *testing.T) {
+	got := testutil.RunLesson(t, Run)
+	for _, want := range []string{
+		"🍳 Kitchen fetches: 1",
+		`📦 Caller 1 got "cooking (fetch #1)", caller 50 got "cooking (fetch #1)"`,
+		"⌛ Caller 1 (20ms timeout): context deadline exceeded",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "❌") {
+		t.Errorf("a coalescing check failed:\n%s", got)
+	}
+}
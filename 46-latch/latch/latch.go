@@ -0,0 +1,197 @@
+// Package latch is lesson 46: Countdown Latch.
+package latch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/conc"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID         int
+	Components map[string]time.Duration // Dish component → prep time
+}
+
+// prepare cooks each component in its own goroutine and counts the latch down as it finishes
+func prepare(order Order, latch *conc.Latch, start time.Time) {
+	for name, prep := range order.Components {
+		go func(name string, prep time.Duration) {
+			clk.Sleep(context.Background(), prep)
+			latch.CountDown()
+			out.Printf("🍳 [+%4dms] %s ready (%d to go)\n", clk.Since(start).Milliseconds(), name, latch.Count())
+		}(name, prep)
+	}
+}
+
+// The expeditor and the photographer both wait on the same latch
+func serveWhenComplete() {
+	out.Printf("\n=== 1. SERVE WHEN THE LAST SIDE IS DONE ===\n\n")
+
+	order := Order{ID: 1, Components: map[string]time.Duration{
+		"burger": 250 * time.Millisecond,
+		"fries":  100 * time.Millisecond,
+		"sauce":  150 * time.Millisecond,
+	}}
+	latch := conc.NewLatch(len(order.Components))
+	start := clk.Now()
+
+	var wg sync.WaitGroup
+	for _, who := range []string{"🧑‍🍳 Expeditor", "📸 Photographer"} {
+		wg.Add(1)
+		go func(who string) {
+			defer wg.Done()
+			if err := latch.Wait(context.Background()); err != nil {
+				out.Printf("%s gave up: %v\n", who, err)
+				return
+			}
+			out.Printf("%s [+%4dms] order %d complete\n", who, clk.Since(start).Milliseconds(), order.ID)
+		}(who)
+	}
+
+	prepare(order, latch, start)
+	wg.Wait()
+
+	// A customer who shows up after everything is done doesn't wait at all
+	clk.Sleep(context.Background(), 100*time.Millisecond)
+	ctx, cancel := clk.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	waitStart := clk.Now()
+	err := latch.Wait(ctx)
+	out.Printf("🧍 Late customer (1s patience): %v after %v\n", errOrOK(err), clk.Since(waitStart).Round(time.Microsecond))
+}
+
+// A slow dessert makes the customer's 1 second timeout expire first
+func customerTimeout() {
+	out.Printf("\n=== 2. CUSTOMER TIMES OUT ===\n\n")
+
+	order := Order{ID: 2, Components: map[string]time.Duration{
+		"steak":   300 * time.Millisecond,
+		"salad":   100 * time.Millisecond,
+		"souffle": 1500 * time.Millisecond, // Can't be rushed
+	}}
+	latch := conc.NewLatch(len(order.Components))
+	start := clk.Now()
+	prepare(order, latch, start)
+
+	ctx, cancel := clk.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err := latch.Wait(ctx)
+	out.Printf("🧍 [+%4dms] Customer: %v (still waiting on %d component)\n", clk.Since(start).Milliseconds(), err, latch.Count())
+
+	latch.Wait(context.Background()) // The kitchen finishes anyway
+}
+
+func errOrOK(err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return "served"
+}
+
+// The latch contract: release together, time out, open stays open
+func latchChecks() {
+	out.Printf("\n=== 3. LATCH CHECKS ===\n\n")
+
+	check := func(name string, ok bool) {
+		status := "✅"
+		if !ok {
+			status = "❌"
+		}
+		out.Printf("%s %s\n", status, name)
+	}
+
+	// Many waiters are released together by the last CountDown
+	{
+		latch := conc.NewLatch(2)
+		released := make(chan time.Time, 5)
+		for i := 0; i < 5; i++ {
+			go func() {
+				latch.Wait(context.Background())
+				released <- clk.Now()
+			}()
+		}
+		clk.Sleep(context.Background(), 20*time.Millisecond)
+		latch.CountDown()
+		early := len(released)
+		clk.Sleep(context.Background(), 20*time.Millisecond)
+		opened := clk.Now()
+		latch.CountDown()
+
+		allAfter := true
+		for i := 0; i < 5; i++ {
+			if (<-released).Before(opened) {
+				allAfter = false
+			}
+		}
+		check("5 waiters released together by the last CountDown", early == 0 && allAfter)
+	}
+
+	// Timeout before the count reaches zero
+	{
+		latch := conc.NewLatch(1)
+		ctx, cancel := clk.WithTimeout(context.Background(), 50*time.Millisecond)
+		err := latch.Wait(ctx)
+		cancel()
+		check("Wait times out before completion with context.DeadlineExceeded",
+			errors.Is(err, context.DeadlineExceeded) && latch.Count() == 1)
+	}
+
+	// Wait after the latch has already opened
+	{
+		latch := conc.NewLatch(1)
+		latch.CountDown()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel() // Even an already-cancelled context: an open latch wins
+		err := latch.Wait(ctx)
+		check("Wait after the latch hit zero returns nil immediately", err == nil)
+	}
+
+	// Counting down past zero is a no-op
+	{
+		latch := conc.NewLatch(1)
+		latch.CountDown()
+		latch.CountDown()
+		latch.CountDown()
+		check("CountDown below zero is a no-op (count stays 0)", latch.Count() == 0)
+	}
+
+	// A zero latch is open from the start
+	{
+		latch := conc.NewLatch(0)
+		check("NewLatch(0) is already open", latch.Wait(context.Background()) == nil)
+	}
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Countdown Latch")
+	out.Println("==========================================")
+
+	serveWhenComplete()
+	customerTimeout()
+	latchChecks()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ A latch opens once, when the count reaches zero, and stays open")
+	out.Println("✅ Closing a channel releases every waiter at the same moment")
+	out.Println("✅ Each waiter can bring its own context and give up independently")
+	out.Println("✅ Unlike sync.WaitGroup, waiting is cancellable and the count is visible")
+	return nil
+}
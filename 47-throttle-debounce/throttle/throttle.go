@@ -0,0 +1,132 @@
+// Package throttle is lesson 47: Throttle & Debounce.
+package throttle
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/conc"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+// StatusEvent is one change shown on the wall display
+type StatusEvent struct {
+	OrderID int
+	Status  string
+}
+
+func (e StatusEvent) String() string {
+	return fmt.Sprintf("#%d %s", e.OrderID, e.Status)
+}
+
+// scriptedBursts is when each status event reaches the display, relative to the start
+var scriptedBursts = []struct {
+	at    time.Duration
+	event StatusEvent
+}{
+	// Burst A: one order races through the kitchen
+	{0, StatusEvent{1, "received"}},
+	{50 * time.Millisecond, StatusEvent{1, "cooking"}},
+	{100 * time.Millisecond, StatusEvent{2, "received"}},
+	{150 * time.Millisecond, StatusEvent{1, "plating"}},
+	{200 * time.Millisecond, StatusEvent{1, "ready"}},
+	// Burst B
+	{900 * time.Millisecond, StatusEvent{2, "cooking"}},
+	{950 * time.Millisecond, StatusEvent{3, "received"}},
+	// A lone update
+	{1600 * time.Millisecond, StatusEvent{2, "ready"}},
+	// Burst C: steady trickle, 100ms apart
+	{2000 * time.Millisecond, StatusEvent{3, "cooking"}},
+	{2100 * time.Millisecond, StatusEvent{4, "received"}},
+	{2200 * time.Millisecond, StatusEvent{3, "plating"}},
+	{2300 * time.Millisecond, StatusEvent{4, "cooking"}},
+	{2400 * time.Millisecond, StatusEvent{3, "ready"}},
+}
+
+type delivery struct {
+	at    time.Duration
+	event StatusEvent
+}
+
+// Feed the same scripted bursts to a throttled and a debounced display
+func displayBoard() {
+	out.Printf("\n=== 1. THE WALL DISPLAY: THROTTLE VS DEBOUNCE ===\n\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	raw := make(chan StatusEvent, len(scriptedBursts))
+	forThrottle := make(chan StatusEvent, len(scriptedBursts))
+	forDebounce := make(chan StatusEvent, len(scriptedBursts))
+
+	start := clk.Now()
+	go func() {
+		defer close(raw)
+		defer close(forThrottle)
+		defer close(forDebounce)
+		for _, s := range scriptedBursts {
+			clk.Sleep(context.Background(), start.Add(s.at).Sub(clk.Now()))
+			raw <- s.event
+			forThrottle <- s.event
+			forDebounce <- s.event
+		}
+	}()
+
+	collect := func(ch <-chan StatusEvent, into *[]delivery, wg *sync.WaitGroup) {
+		defer wg.Done()
+		for e := range ch {
+			*into = append(*into, delivery{clk.Since(start), e})
+		}
+	}
+
+	var rawSeen, throttled, debounced []delivery
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go collect(raw, &rawSeen, &wg)
+	go collect(conc.Throttle(ctx, clk, forThrottle, 500*time.Millisecond), &throttled, &wg)
+	go collect(conc.Debounce(ctx, clk, forDebounce, 300*time.Millisecond), &debounced, &wg)
+	wg.Wait()
+
+	show := func(title string, ds []delivery) {
+		out.Printf("%s (%d events)\n", title, len(ds))
+		for _, d := range ds {
+			out.Printf("   [+%4dms] %s\n", d.at.Round(10*time.Millisecond).Milliseconds(), d.event)
+		}
+	}
+	show("📨 Raw events", rawSeen)
+	out.Println()
+	show("⏱️  Throttle (at most once per 500ms)", throttled)
+	out.Println()
+	show("🤫 Debounce (after 300ms of quiet)", debounced)
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Throttle & Debounce")
+	out.Println("==========================================")
+
+	displayBoard()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ Throttle caps the rate but still shows the latest state")
+	out.Println("✅ Debounce waits for a burst to finish and shows only its last value")
+	out.Println("✅ One goroutine with a select loop owns the timer and the pending value")
+	out.Println("✅ Flush on close so the final status is never lost")
+	out.Println("✅ A fake clock makes timing logic testable without sleeping")
+	return nil
+}
@@ -0,0 +1,147 @@
+// Package batching is lesson 48: Batching.
+package batching
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/conc"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID      int
+	Address string
+}
+
+// BatchCollector is the push-style version of conc.Batch: callers Add orders
+// instead of owning a channel, and a goroutine inside flushes them to
+// Batches() on the same size-or-time rule.
+type BatchCollector struct {
+	in   chan Order
+	out  <-chan []Order
+	once sync.Once
+}
+
+// NewBatchCollector starts a collector that flushes at maxSize orders, or
+// maxWait after the first order of the current batch
+func NewBatchCollector(maxSize int, maxWait time.Duration) *BatchCollector {
+	return newBatchCollector(maxSize, maxWait, clk)
+}
+
+func newBatchCollector(maxSize int, maxWait time.Duration, clock clock.Clock) *BatchCollector {
+	in := make(chan Order)
+	return &BatchCollector{in: in, out: conc.Batch(context.Background(), clock, in, maxSize, maxWait)}
+}
+
+// Add hands an order to the collector. It returns once the collector has the
+// order, and blocks while a full batch waits for a reader. Don't call it after Close.
+func (c *BatchCollector) Add(order Order) { c.in <- order }
+
+// Batches returns the flushed batches. It closes after Close, once the last
+// partial batch has been sent.
+func (c *BatchCollector) Batches() <-chan []Order { return c.out }
+
+// Close flushes any partial batch and closes Batches. It is safe to call twice.
+func (c *BatchCollector) Close() { c.once.Do(func() { close(c.in) }) }
+
+func ids(batch []Order) []int {
+	out := make([]int, len(batch))
+	for i, o := range batch {
+		out[i] = o.ID
+	}
+	return out
+}
+
+// The driver leaves with 4 orders, or with whatever is ready after 2 seconds
+func deliveryDriver() {
+	out.Printf("\n=== 1. DELIVERY DRIVER: UP TO 4 ORDERS OR 2 SECONDS ===\n\n")
+
+	arrivals := []time.Duration{ // When each order is packed and ready
+		0, 100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, // Lunch rush
+		400 * time.Millisecond, 500 * time.Millisecond,
+		1500 * time.Millisecond,
+		4500 * time.Millisecond, 4800 * time.Millisecond, // Kitchen closes at 5s
+	}
+
+	ready := make(chan Order)
+	start := clk.Now()
+	go func() {
+		defer close(ready)
+		for i, at := range arrivals {
+			clk.Sleep(context.Background(), start.Add(at).Sub(clk.Now()))
+			out.Printf("📦 [+%4dms] Order %d packed\n", clk.Since(start).Milliseconds(), i+1)
+			ready <- Order{ID: i + 1, Address: fmt.Sprintf("%d Main St", 100+i)}
+		}
+		clk.Sleep(context.Background(), start.Add(5*time.Second).Sub(clk.Now()))
+		out.Printf("🔒 [+%4dms] Kitchen closed\n", clk.Since(start).Milliseconds())
+	}()
+
+	trip := 0
+	for b := range conc.Batch(context.Background(), clk, ready, 4, 2*time.Second) {
+		trip++
+		out.Printf("🚗 [+%4dms] Trip %d leaves with orders %v\n", clk.Since(start).Milliseconds(), trip, ids(b))
+	}
+}
+
+// Three tablets take orders at once; the grill gets them in tickets of up to 3
+func ticketPrinter() {
+	out.Printf("\n=== 2. BatchCollector: GRILL TICKETS OF UP TO 3 ORDERS OR 300ms ===\n\n")
+
+	collector := NewBatchCollector(3, 300*time.Millisecond)
+	start := clk.Now()
+
+	var wg sync.WaitGroup
+	for tablet := 1; tablet <= 3; tablet++ {
+		wg.Add(1)
+		go func(tablet int) {
+			defer wg.Done()
+			for i := 0; i < 3; i++ {
+				clk.Sleep(context.Background(), time.Duration(tablet*70+i*150)*time.Millisecond)
+				collector.Add(Order{ID: tablet*100 + i + 1})
+			}
+		}(tablet)
+	}
+	go func() {
+		wg.Wait()
+		collector.Close()
+	}()
+
+	ticket := 0
+	for b := range collector.Batches() {
+		ticket++
+		out.Printf("🧾 [+%4dms] Ticket %d: orders %v\n", clk.Since(start).Milliseconds(), ticket, ids(b))
+	}
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Batching")
+	out.Println("==========================================")
+
+	deliveryDriver()
+	ticketPrinter()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ Batch on size or time, whichever comes first")
+	out.Println("✅ Start the timer on a batch's first item, stop it when the batch goes out")
+	out.Println("✅ Flush the partial batch when the input closes; cancelling drops it")
+	out.Println("✅ Hand each batch off as a fresh slice the consumer owns")
+	out.Println("✅ A collector with Add hides the input channel from producers")
+	return nil
+}
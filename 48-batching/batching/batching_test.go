@@ -0,0 +1,89 @@
+package batching
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// next returns the next batch, "closed", or "nothing" if none arrives
+func next(c *BatchCollector) string {
+	select {
+	case b, ok := <-c.Batches():
+		if !ok {
+			return "closed"
+		}
+		return fmt.Sprint(ids(b))
+	case <-time.After(time.Second): // Guard only; a correct run never waits here
+		return "nothing"
+	}
+}
+
+// Both flush triggers on a collector with maxSize 3 and maxWait 300ms
+func TestBatchCollector(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	tests := []struct {
+		name string
+		run  func(c *BatchCollector, fake *clock.FakeClock) []string
+		want []string
+	}{
+		{"size flush before maxWait", func(c *BatchCollector, fake *clock.FakeClock) []string {
+			for id := 1; id <= 3; id++ {
+				c.Add(Order{ID: id})
+			}
+			got := []string{next(c)} // No time has passed on the fake clock
+			c.Close()
+			return append(got, next(c))
+		}, []string{"[1 2 3]", "closed"}},
+
+		{"time flush below maxSize", func(c *BatchCollector, fake *clock.FakeClock) []string {
+			c.Add(Order{ID: 1})
+			fake.BlockUntil(1) // The batch's timer is armed
+			fake.Advance(200 * time.Millisecond)
+			c.Add(Order{ID: 2}) // Doesn't restart the 300ms
+			fake.Advance(100 * time.Millisecond)
+			got := []string{next(c)}
+			c.Close()
+			return append(got, next(c))
+		}, []string{"[1 2]", "closed"}},
+
+		{"first item starts the clock", func(c *BatchCollector, fake *clock.FakeClock) []string {
+			fake.Advance(time.Second) // Idle time doesn't count
+			c.Add(Order{ID: 1})
+			fake.BlockUntil(1) // The batch's timer is armed
+			fake.Advance(300 * time.Millisecond)
+			got := []string{next(c)}
+			c.Close()
+			c.Close()
+			return append(got, next(c))
+		}, []string{"[1]", "closed"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := clock.NewFake(testutil.Epoch)
+			got := tt.run(newBatchCollector(3, 300*time.Millisecond, fake), fake)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	got := testutil.RunLesson(t, Run)
+	for _, want := range []string{
+		"🚗 [+2400ms] Trip 2 leaves with orders [5 6 7]",
+		"Ticket 4: orders [303]",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+}
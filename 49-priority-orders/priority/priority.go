@@ -0,0 +1,185 @@
+// Package priority is lesson 49: Priority Orders.
+package priority
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/conc"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+const (
+	Normal = 0
+	VIP    = 1
+)
+
+type Order struct {
+	ID       int
+	Priority int // Higher is served first
+	PrepTime time.Duration
+}
+
+// byPriority serves VIPs first, then everyone else in arrival order
+func byPriority(a, b Order) bool { return a.Priority > b.Priority }
+
+// orderPriority is the priority an aging queue starts each order at
+func orderPriority(o Order) int { return o.Priority }
+
+func label(o Order) string {
+	if o.Priority == VIP {
+		return fmt.Sprintf("%d⭐", o.ID)
+	}
+	return fmt.Sprint(o.ID)
+}
+
+// 20 orders, every 5th is VIP; 2 chefs pull from a priority queue
+func vipsJumpTheLine() {
+	out.Printf("\n=== 1. VIP ORDERS JUMP THE LINE (2 chefs, every 5th order is VIP) ===\n\n")
+
+	queue := conc.NewPriorityQueue(byPriority)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var started []string
+	startTime := clk.Now()
+
+	for chef := 1; chef <= 2; chef++ {
+		wg.Add(1)
+		go func(chef int) {
+			defer wg.Done()
+			for {
+				order, err := queue.Pop(context.Background())
+				if err != nil {
+					out.Printf("👨‍🍳 Chef %d: %v, going home\n", chef, err)
+					return
+				}
+				mu.Lock()
+				started = append(started, label(order))
+				mu.Unlock()
+				out.Printf("🔥 [+%4dms] Chef %d starts order %s (%d waiting)\n",
+					clk.Since(startTime).Milliseconds(), chef, label(order), queue.Len())
+				clk.Sleep(context.Background(), order.PrepTime)
+			}
+		}(chef)
+	}
+
+	for id := 1; id <= 20; id++ {
+		order := Order{ID: id, Priority: Normal, PrepTime: 100 * time.Millisecond}
+		if id%5 == 0 {
+			order.Priority = VIP
+		}
+		queue.Push(order)
+		clk.Sleep(context.Background(), 25*time.Millisecond) // Orders arrive faster than 2 chefs can cook
+	}
+
+	// Closed queues still hand out what's queued, then report conc.ErrQueueClosed
+	queue.Close()
+	wg.Wait()
+
+	out.Printf("\n📋 Submitted: [1 2 3 4 5⭐ 6 ... 20⭐]\n")
+	out.Printf("📋 Started:   %v\n", started)
+}
+
+// vipStream has one chef cook from q while VIPs arrive every vipEvery for
+// streamFor, faster than one chef can cook them. A normal order is queued
+// right behind the first VIP. It returns how long the normal order waited to
+// start, how many VIPs started ahead of it, and whether VIPs were still
+// arriving when it did.
+func vipStream(q *conc.PriorityQueue[Order], vipEvery, prep, streamFor time.Duration) (waited time.Duration, vipsFirst int, duringStream bool) {
+	q.Push(Order{ID: 2, Priority: VIP, PrepTime: prep})
+	q.Push(Order{ID: 1, Priority: Normal, PrepTime: prep})
+	startTime := clk.Now()
+
+	streaming := make(chan struct{})
+	go func() {
+		defer close(streaming)
+		ticker := clk.NewTicker(vipEvery)
+		defer ticker.Stop()
+		deadline := clk.After(streamFor)
+		for id := 3; ; id++ {
+			select {
+			case <-ticker.C():
+			case <-deadline:
+				return
+			}
+			if q.Push(Order{ID: id, Priority: VIP, PrepTime: prep}) != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		o, err := q.Pop(context.Background())
+		if err != nil {
+			break
+		}
+		if o.ID == 1 {
+			waited = clk.Since(startTime)
+			select {
+			case <-streaming:
+			default:
+				duringStream = true
+			}
+			break
+		}
+		vipsFirst++
+		clk.Sleep(context.Background(), o.PrepTime)
+	}
+	q.Close() // The producer's next Push fails, and it returns
+	<-streaming
+	return waited, vipsFirst, duringStream
+}
+
+// A VIP every 10ms for 500ms, each 20ms to cook: without aging, the one
+// normal order waits until the VIPs stop and the backlog clears
+func agingVsStarvation() {
+	out.Printf("\n=== 2. AGING STOPS STARVATION (1 chef, a VIP every 10ms for 500ms) ===\n\n")
+
+	show := func(name string, q *conc.PriorityQueue[Order]) {
+		waited, vips, during := vipStream(q, 10*time.Millisecond, 20*time.Millisecond, 500*time.Millisecond)
+		when := "after the VIPs stopped"
+		if during {
+			when = "while VIPs kept coming"
+		}
+		out.Printf("%-26s order 1 started after %6v, %2d VIPs first, %s\n", name, waited.Round(10*time.Millisecond), vips, when)
+	}
+	show("No aging:", conc.NewPriorityQueue(byPriority))
+	for _, age := range []time.Duration{200 * time.Millisecond, 100 * time.Millisecond, 50 * time.Millisecond} {
+		show(fmt.Sprintf("+1 level per %v:", age), conc.NewAgingQueue(clk, orderPriority, age))
+	}
+	out.Printf("\n💡 A VIP is one level up, so order 1 overtakes every VIP that arrives more than one agePerLevel after it\n")
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Priority Orders")
+	out.Println("==========================================")
+
+	vipsJumpTheLine()
+	agingVsStarvation()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ A channel is FIFO; a heap behind a mutex serves by priority")
+	out.Println("✅ sync.Cond lets Pop sleep until a Push or Close wakes it")
+	out.Println("✅ context.AfterFunc turns ctx cancellation into a Cond broadcast")
+	out.Println("✅ A sequence number keeps equal priorities in arrival order")
+	out.Println("✅ Close hands out what's queued, then tells workers to stop")
+	out.Println("✅ Aging raises a waiting order's priority over time, so a VIP stream can't starve it")
+	out.Println("✅ When everything ages at the same rate, a score fixed at Push keeps the heap valid")
+	return nil
+}
@@ -0,0 +1,101 @@
+// Package elastic is lesson 51: Elastic Worker Pool.
+package elastic
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+	"github.com/Ajay2521/go-concurrency/pkg/pool"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+}
+
+// A manager watches the backlog and sizes the kitchen between 2 and 8 chefs
+func lunchRush() {
+	out.Printf("\n=== 1. LUNCH RUSH: 2 → 8 → 2 CHEFS ===\n\n")
+
+	kitchen := pool.NewElastic(200, func(o Order) { clk.Sleep(context.Background(), o.PrepTime) })
+	kitchen.SetWorkers(2)
+	startTime := clk.Now()
+
+	// The manager: one chef per 3 waiting orders, between 2 and 8
+	stop := make(chan struct{})
+	managerDone := make(chan struct{})
+	go func() {
+		defer close(managerDone)
+		ticker := clk.NewTicker(250 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C():
+				s := kitchen.Stats()
+				target := min(max((s.Queued+2)/3, 2), 8)
+				if target != s.Target {
+					kitchen.SetWorkers(target)
+				}
+				out.Printf("⏱️  [+%4dms] queued %3d | chefs %d → %d | cooked %3d\n",
+					clk.Since(startTime).Milliseconds(), s.Queued, s.Active, target, s.Processed)
+			}
+		}
+	}()
+
+	// Arrivals: quiet, a rush, then quiet again. Each order takes 100ms.
+	phases := []struct {
+		name  string
+		lasts time.Duration
+		every time.Duration
+	}{
+		{"☕ Quiet morning", 750 * time.Millisecond, 60 * time.Millisecond},
+		{"🔥 Lunch rush", 1250 * time.Millisecond, 10 * time.Millisecond},
+		{"🌙 Afternoon lull", 1500 * time.Millisecond, 80 * time.Millisecond},
+	}
+	id := 0
+	for _, phase := range phases {
+		out.Printf("%s: an order every %v\n", phase.name, phase.every)
+		end := clk.Now().Add(phase.lasts)
+		for clk.Now().Before(end) {
+			id++
+			kitchen.Submit(Order{ID: id, PrepTime: 100 * time.Millisecond})
+			clk.Sleep(context.Background(), phase.every)
+		}
+	}
+
+	close(stop)
+	<-managerDone
+	kitchen.Close()
+	out.Printf("\n📦 %d orders submitted, %d cooked\n", id, kitchen.Stats().Processed)
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Elastic Worker Pool")
+	out.Println("==========================================")
+
+	lunchRush()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ A quit channel per worker lets the pool retire exactly the workers it wants")
+	out.Println("✅ Workers check for retirement only between orders, so nothing is dropped")
+	out.Println("✅ A shared jobs channel hands each order to exactly one worker")
+	out.Println("✅ Track running goroutines separately from the target while workers retire")
+	return nil
+}
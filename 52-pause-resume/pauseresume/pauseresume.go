@@ -0,0 +1,110 @@
+// Package pauseresume is lesson 52: Pause & Resume.
+package pauseresume
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+	"github.com/Ajay2521/go-concurrency/pkg/pool"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+}
+
+// A health inspector stops the line mid-rush; the queue grows, then the kitchen catches up
+func healthInspection() {
+	out.Printf("\n=== 1. HEALTH INSPECTION MID-RUSH (3 chefs) ===\n\n")
+
+	startTime := clk.Now()
+	since := func() int64 { return clk.Since(startTime).Milliseconds() }
+
+	var mu sync.Mutex
+	cooked := 0
+	kitchen := pool.NewPausable(clk, 3, 100, func(o Order, _ time.Time) {
+		clk.Sleep(context.Background(), o.PrepTime)
+		mu.Lock()
+		cooked++
+		mu.Unlock()
+	})
+
+	// A manager glances at the queue every 250ms
+	stop := make(chan struct{})
+	watched := make(chan struct{})
+	go func() {
+		defer close(watched)
+		ticker := clk.NewTicker(250 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C():
+				state := "🟢 cooking"
+				if kitchen.Paused() {
+					state = "⏸️  paused "
+				}
+				mu.Lock()
+				out.Printf("📋 [+%4dms] %s | queued %2d | cooked %2d\n", since(), state, kitchen.Queued(), cooked)
+				mu.Unlock()
+			}
+		}
+	}()
+
+	// The inspector arrives at 800ms and leaves at 1800ms
+	go func() {
+		clk.Sleep(context.Background(), 800*time.Millisecond)
+		kitchen.Pause()
+		out.Printf("🕵️  [+%4dms] Health inspector arrives: no new orders start\n", since())
+		clk.Sleep(context.Background(), time.Second)
+		out.Printf("👍 [+%4dms] Inspection passed: resume cooking\n", since())
+		kitchen.Resume()
+	}()
+
+	// An order every 40ms for 2s; each takes 120ms, so 3 chefs just keep up
+	id := 0
+	for since() < 2000 {
+		id++
+		kitchen.Submit(Order{ID: id, PrepTime: 120 * time.Millisecond})
+		clk.Sleep(context.Background(), 40*time.Millisecond)
+	}
+	for kitchen.Queued() > 0 {
+		clk.Sleep(context.Background(), 10*time.Millisecond)
+	}
+	close(stop)
+	<-watched
+
+	kitchen.Shutdown()
+	out.Printf("\n📦 %d orders submitted, %d cooked at +%dms\n", id, cooked, since())
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Pause & Resume")
+	out.Println("==========================================")
+
+	healthInspection()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ A paused flag plus sync.Cond makes a gate workers wait at")
+	out.Println("✅ Check the gate between orders, so in-flight work finishes")
+	out.Println("✅ Broadcast on Resume so every waiting worker wakes, not just one")
+	out.Println("✅ Lift the pause on Shutdown, or queued orders never drain")
+	return nil
+}
@@ -0,0 +1,116 @@
+// Package scheduled is lesson 53: Scheduled Orders.
+package scheduled
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+	"github.com/Ajay2521/go-concurrency/pkg/sched"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	Customer string
+	PrepTime time.Duration
+}
+
+// Customers pre-order lunch; the kitchen starts each order at its scheduled moment
+func lunchPreorders() {
+	out.Printf("\n=== 1. LUNCH PRE-ORDERS ===\n\n")
+
+	kitchen := sched.NewScheduler(clk)
+	startTime := clk.Now()
+	since := func() int64 { return clk.Since(startTime).Milliseconds() }
+
+	var mu sync.Mutex
+	startsAt := map[int]time.Time{}
+	var cooked sync.WaitGroup
+
+	cook := func(o Order) func(ctx context.Context) {
+		return func(ctx context.Context) {
+			defer cooked.Done()
+			mu.Lock()
+			drift := clk.Since(startsAt[o.ID])
+			mu.Unlock()
+			out.Printf("🔥 [+%4dms] Order %d for %s: start cooking (drift %v)\n",
+				since(), o.ID, o.Customer, drift.Round(100*time.Microsecond))
+			select {
+			case <-clk.After(o.PrepTime):
+				out.Printf("🍱 [+%4dms] Order %d for %s: ready for pickup\n", since(), o.ID, o.Customer)
+			case <-ctx.Done():
+				out.Printf("🛑 [+%4dms] Order %d for %s: kitchen closed mid-cook\n", since(), o.ID, o.Customer)
+			}
+		}
+	}
+
+	// Placed in any order; the heap sorts them by start time
+	preorders := []struct {
+		order Order
+		start time.Duration
+	}{
+		{Order{ID: 1, Customer: "Asha", PrepTime: 150 * time.Millisecond}, 900 * time.Millisecond},
+		{Order{ID: 2, Customer: "Ben", PrepTime: 100 * time.Millisecond}, 300 * time.Millisecond},
+		{Order{ID: 3, Customer: "Chen", PrepTime: 200 * time.Millisecond}, 600 * time.Millisecond},
+		{Order{ID: 4, Customer: "Dara", PrepTime: 100 * time.Millisecond}, 300 * time.Millisecond},
+		{Order{ID: 5, Customer: "Eli", PrepTime: 100 * time.Millisecond}, 1200 * time.Millisecond},
+	}
+	ids := map[int]int{}
+	for _, p := range preorders {
+		at := startTime.Add(p.start)
+		mu.Lock()
+		startsAt[p.order.ID] = at
+		mu.Unlock()
+		cooked.Add(1)
+		ids[p.order.ID] = kitchen.Schedule(at, cook(p.order))
+		out.Printf("📅 Order %d for %s: start at +%v\n", p.order.ID, p.order.Customer, p.start)
+	}
+	out.Println()
+
+	// Before anything cooks, Chen cancels and Eli says they'll be early
+	clk.Sleep(context.Background(), 100*time.Millisecond)
+	if kitchen.Cancel(ids[3]) {
+		cooked.Done()
+		out.Printf("❌ [+%4dms] Chen cancelled order 3\n", since())
+	}
+	earlier := startTime.Add(450 * time.Millisecond)
+	mu.Lock()
+	startsAt[5] = earlier
+	mu.Unlock()
+	if kitchen.Reschedule(ids[5], earlier) {
+		out.Printf("🔁 [+%4dms] Eli moved order 5 to +450ms\n", since())
+	}
+
+	cooked.Wait()
+	out.Printf("\n📦 Pending after lunch: %d, dropped at close: %d\n", kitchen.Pending(), kitchen.Shutdown())
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Scheduled Orders")
+	out.Println("==========================================")
+
+	lunchPreorders()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ One goroutine and one timer serve any number of delayed jobs")
+	out.Println("✅ A min-heap keeps the earliest job on top; track indexes to Fix or Remove")
+	out.Println("✅ Re-arm the timer whenever the head of the heap changes")
+	out.Println("✅ Cancel under the same lock the loop pops under, so a cancelled job can't fire")
+	out.Println("✅ Inject a clock to test timing without sleeping")
+	return nil
+}
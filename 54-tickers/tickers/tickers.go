@@ -0,0 +1,181 @@
+// Package tickers is lesson 54: Tickers.
+package tickers
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+	"github.com/Ajay2521/go-concurrency/pkg/sched"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+}
+
+// The kitchen cooks orders nonstop while restocking and cleaning run on their own schedules
+func kitchenDay() {
+	out.Printf("\n=== 1. A KITCHEN DAY: ORDERS + RESTOCK EVERY 2s + CLEANING EVERY 5s ===\n\n")
+
+	ctx, cancel := clk.WithTimeout(context.Background(), 10500*time.Millisecond)
+	defer cancel()
+	startTime := clk.Now()
+	since := func() float64 { return clk.Since(startTime).Seconds() }
+
+	var cooked atomic.Int64
+	restock := sched.Every(ctx, clk, 2*time.Second, func(context.Context) {
+		out.Printf("📦 [%4.1fs] Restock check: %d orders cooked so far, shelves topped up\n", since(), cooked.Load())
+	})
+	cleaning := sched.Every(ctx, clk, 5*time.Second, func(ctx context.Context) {
+		out.Printf("🧽 [%4.1fs] Cleaning started\n", since())
+		select {
+		case <-clk.After(time.Second):
+			out.Printf("✨ [%4.1fs] Cleaning done\n", since())
+		case <-ctx.Done():
+			out.Printf("🛑 [%4.1fs] Cleaning cut short: kitchen closing\n", since())
+		}
+	})
+
+	// Two chefs take orders as they come until closing time
+	orders := make(chan Order)
+	var chefs sync.WaitGroup
+	for c := 0; c < 2; c++ {
+		chefs.Add(1)
+		go func() {
+			defer chefs.Done()
+			for o := range orders {
+				clk.Sleep(context.Background(), o.PrepTime)
+				cooked.Add(1)
+			}
+		}()
+	}
+	go func() {
+		defer close(orders)
+		for id := 1; ; id++ {
+			select {
+			case orders <- Order{ID: id, PrepTime: 300 * time.Millisecond}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	<-restock.Done()
+	<-cleaning.Done()
+	chefs.Wait()
+	out.Printf("\n🔒 [%4.1fs] Closed: %d orders cooked, %d restocks, %d cleanings, both tickers stopped\n",
+		since(), cooked.Load(), restock.Runs(), cleaning.Runs())
+}
+
+// time.Tick has no Stop, so a loop ranging over it never ends; NewTicker + ctx does
+func tickVsTicker() {
+	out.Printf("\n=== 2. time.Tick VS time.NewTicker ===\n\n")
+
+	base := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// time.Tick: fine for a loop that lives as long as the program, nothing else
+	go func() {
+		for range time.Tick(50 * time.Millisecond) {
+		}
+	}()
+
+	// NewTicker: stopped by defer when ctx ends
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := clk.NewTicker(50 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	clk.Sleep(context.Background(), 200*time.Millisecond)
+	out.Printf("🔁 Both loops running: %d extra goroutines\n", runtime.NumGoroutine()-base)
+	cancel()
+	<-stopped
+	clk.Sleep(context.Background(), 100*time.Millisecond)
+	out.Printf("🛑 After cancel: %d extra goroutine (the time.Tick loop, which nothing can stop)\n", runtime.NumGoroutine()-base)
+}
+
+// A 250ms handler on a 100ms ticker: inline, skip and concurrent compared over 1s
+func slowHandlers() {
+	out.Printf("\n=== 3. SLOW HANDLERS: 250ms WORK ON A 100ms TICKER, FOR 1s ===\n\n")
+
+	work := 250 * time.Millisecond
+
+	// Inline: the handler runs in the ticker loop. time.Ticker buffers one
+	// tick and drops the rest, so missed ticks are neither queued nor counted.
+	inline := 0
+	ctx, cancel := clk.WithTimeout(context.Background(), time.Second)
+	ticker := clk.NewTicker(100 * time.Millisecond)
+	for loop := true; loop; {
+		select {
+		case <-ticker.C():
+			inline++
+			clk.Sleep(context.Background(), work)
+		case <-ctx.Done():
+			loop = false
+		}
+	}
+	ticker.Stop()
+	cancel()
+	out.Printf("🐢 Inline handler:     %2d runs, missed ticks silently dropped\n", inline)
+
+	for _, policy := range []struct {
+		name   string
+		option sched.OverlapPolicy
+	}{{"OverlapSkip", sched.OverlapSkip}, {"OverlapConcurrent", sched.OverlapConcurrent}} {
+		var inFlight, peak atomic.Int64
+		ctx, cancel := clk.WithTimeout(context.Background(), time.Second)
+		p := sched.Every(ctx, clk, 100*time.Millisecond, func(context.Context) {
+			now := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for cur := peak.Load(); now > cur && !peak.CompareAndSwap(cur, now); cur = peak.Load() {
+			}
+			clk.Sleep(context.Background(), work)
+		}, sched.WithOverlap(policy.option))
+		<-p.Done()
+		cancel()
+		out.Printf("⚙️  %-18s %2d runs, %d skipped, at most %d at once\n", policy.name+":", p.Runs(), p.Skipped(), peak.Load())
+	}
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Tickers")
+	out.Println("==========================================")
+
+	kitchenDay()
+	tickVsTicker()
+	slowHandlers()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ time.NewTicker + defer Stop + ctx.Done() is the stoppable periodic loop")
+	out.Println("✅ time.Tick can't be stopped; keep it for loops that live as long as the program")
+	out.Println("✅ A Ticker buffers one tick and drops the rest, so slow handlers miss ticks silently")
+	out.Println("✅ Choose an overlap policy on purpose: skip and count, or run concurrently")
+	return nil
+}
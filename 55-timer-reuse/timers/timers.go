@@ -0,0 +1,182 @@
+// Package timers is lesson 55: Timer Reuse.
+package timers
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/conc"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+}
+
+// naiveRecv is the obvious version: a fresh timer and channel on every call
+func naiveRecv[T any](ch <-chan T, d time.Duration) (T, bool) {
+	select {
+	case v, ok := <-ch:
+		return v, ok
+	case <-clk.After(d):
+		var zero T
+		return zero, false
+	}
+}
+
+// feed sends n orders on a buffered channel and closes it
+func feed(n int) <-chan Order {
+	ch := make(chan Order, 1024)
+	go func() {
+		defer close(ch)
+		for id := 1; id <= n; id++ {
+			ch <- Order{ID: id}
+		}
+	}()
+	return ch
+}
+
+// 100k events through each loop: what the per-iteration timer costs
+func hotLoop() {
+	out.Printf("\n=== 1. 100,000 ORDER EVENTS WITH A 1-MINUTE IDLE TIMEOUT ===\n\n")
+
+	const events = 100_000
+	measure := func(loop func(ch <-chan Order) int) (received int, mallocs, bytes uint64, retained int64, elapsed time.Duration) {
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		startTime := clk.Now()
+		received = loop(feed(events))
+		elapsed = clk.Since(startTime)
+		runtime.ReadMemStats(&after)
+		mallocs, bytes = after.Mallocs-before.Mallocs, after.TotalAlloc-before.TotalAlloc
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		retained = int64(after.HeapAlloc) - int64(before.HeapAlloc)
+		return
+	}
+
+	naive := func(ch <-chan Order) int {
+		n := 0
+		for {
+			if _, ok := naiveRecv(ch, time.Minute); !ok {
+				return n
+			}
+			n++
+		}
+	}
+	reused := func(ch <-chan Order) int {
+		t := clk.NewTimer(time.Minute)
+		defer t.Stop()
+		n := 0
+		for {
+			if _, ok := conc.RecvTimeout(ch, t, time.Minute); !ok {
+				return n
+			}
+			n++
+		}
+	}
+
+	out.Printf("%-18s %9s %10s %12s %16s %10s\n", "", "received", "mallocs", "allocated", "kept after GC", "time")
+	for _, run := range []struct {
+		name string
+		loop func(ch <-chan Order) int
+	}{{"time.After", naive}, {"reused Timer", reused}} {
+		received, mallocs, bytes, retained, elapsed := measure(run.loop)
+		out.Printf("%-18s %9d %10d %9d KB %13d KB %10v\n", run.name, received, mallocs, bytes/1024, max(retained, 0)/1024, elapsed.Round(time.Millisecond))
+	}
+	out.Println("\n💡 Each time.After builds a timer and a channel that live until they fire or,")
+	out.Println("   since Go 1.23, until the GC finds them unreferenced. Before 1.23, all 100k")
+	out.Println("   one-minute timers stayed in memory for the full minute.")
+}
+
+// One receive per op, with and without a fresh timer
+func recvBenchmark() {
+	out.Printf("\n=== 2. PER-RECEIVE COST (testing.Benchmark) ===\n\n")
+
+	ready := make(chan Order, 1)
+	naive := testing.Benchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			ready <- Order{ID: i}
+			naiveRecv(ready, time.Minute)
+		}
+	})
+	reused := testing.Benchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		t := clk.NewTimer(time.Minute)
+		defer t.Stop()
+		for i := 0; i < b.N; i++ {
+			ready <- Order{ID: i}
+			conc.RecvTimeout(ready, t, time.Minute)
+		}
+	})
+
+	out.Printf("%-14s %10s %8s %10s\n", "", "ns/op", "B/op", "allocs/op")
+	out.Printf("%-14s %10d %8d %10d\n", "time.After", naive.NsPerOp(), naive.AllocedBytesPerOp(), naive.AllocsPerOp())
+	out.Printf("%-14s %10d %8d %10d\n", "RecvTimeout", reused.NsPerOp(), reused.AllocedBytesPerOp(), reused.AllocsPerOp())
+}
+
+// A supplier that sometimes goes quiet: the loop notices each gap with one timer
+func quietSupplier() {
+	out.Printf("\n=== 3. NOTICING A QUIET SUPPLIER ===\n\n")
+
+	orders := make(chan Order)
+	go func() {
+		defer close(orders)
+		gaps := []time.Duration{20, 20, 150, 20, 260, 20}
+		for i, gap := range gaps {
+			clk.Sleep(context.Background(), gap*time.Millisecond)
+			orders <- Order{ID: i + 1}
+		}
+	}()
+
+	startTime := clk.Now()
+	idle := 100 * time.Millisecond
+	t := clk.NewTimer(idle)
+	defer t.Stop()
+	for received := 0; received < 6; {
+		o, ok := conc.RecvTimeout(orders, t, idle)
+		elapsed := clk.Since(startTime).Milliseconds()
+		if !ok {
+			out.Printf("⏰ [+%3dms] No order for %v, pinging the supplier\n", elapsed, idle)
+			continue
+		}
+		received++
+		out.Printf("📦 [+%3dms] Order %d received\n", elapsed, o.ID)
+	}
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Timer Reuse")
+	out.Println("==========================================")
+
+	hotLoop()
+	recvBenchmark()
+	quietSupplier()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ time.After in a hot loop allocates a timer and a channel every iteration")
+	out.Println("✅ One time.Timer, stopped and reset each time, does the same job with no allocations")
+	out.Println("✅ If Stop returns false, drain t.C without blocking: the tick may already be gone")
+	out.Println("✅ Since Go 1.23, Reset discards a stale tick, but the non-blocking drain is safe everywhere")
+	out.Println("✅ Stop the timer when the loop is done")
+	return nil
+}
@@ -0,0 +1,117 @@
+// Package cancelorders is lesson 56: Cancelling Orders.
+package cancelorders
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+	"github.com/Ajay2521/go-concurrency/pkg/order"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+// Ten orders, four chefs, three customers who change their minds
+func lunchWithCancellations() {
+	out.Printf("\n=== 1. TEN ORDERS, THREE CANCELLATIONS ===\n\n")
+
+	dishes := []string{"Ramen", "Tacos", "Curry", "Pho", "Burger", "Salad", "Risotto", "Pad Thai", "Bibimbap", "Paella"}
+	r := rand.New(rand.NewSource(5))
+	startTime := clk.Now()
+	since := func() int64 { return clk.Since(startTime).Milliseconds() }
+
+	tickets := make([]*order.Ticket, len(dishes))
+	queue := make(chan *order.Ticket, len(dishes))
+	for i, dish := range dishes {
+		prep := time.Duration(200+r.Intn(400)) * time.Millisecond
+		tickets[i] = order.Place(context.Background(), order.Order{ID: i + 1, Dish: dish, PrepTime: prep})
+		queue <- tickets[i]
+	}
+	close(queue)
+
+	var chefs sync.WaitGroup
+	for c := 0; c < 4; c++ {
+		chefs.Add(1)
+		go func() {
+			defer chefs.Done()
+			for t := range queue {
+				t.Cook(clk, 10)
+			}
+		}()
+	}
+
+	// Three random customers cancel somewhere between 10% and 90% of the way through cooking
+	var customers sync.WaitGroup
+	for _, i := range r.Perm(len(tickets))[:3] {
+		t, fraction := tickets[i], 0.1+0.8*r.Float64()
+		customers.Add(1)
+		go func() {
+			defer customers.Done()
+			<-t.Started()
+			clk.Sleep(context.Background(), time.Duration(fraction*float64(t.PrepTime)))
+			err := t.Cancel()
+			out.Printf("🙅 [+%3dms] Customer cancels order %d (%s): %v\n", since(), t.ID, t.Dish, errOr(err, "ok"))
+		}()
+	}
+
+	customers.Wait()
+	chefs.Wait()
+
+	out.Printf("\n%-6s %-10s %8s  %s\n", "Order", "Dish", "Prep", "Result")
+	var pickedUp *order.Ticket
+	completed, cancelled := 0, 0
+	for _, t := range tickets {
+		err := t.Wait()
+		var ce *order.CancelledError
+		switch {
+		case errors.As(err, &ce):
+			cancelled++
+			out.Printf("%-6d %-10s %8v  ❌ cancelled at %.0f%%\n", t.ID, t.Dish, t.PrepTime, ce.Progress*100)
+		default:
+			completed++
+			pickedUp = t
+			out.Printf("%-6d %-10s %8v  ✅ completed\n", t.ID, t.Dish, t.PrepTime)
+		}
+	}
+	out.Printf("\n📊 %d completed, %d cancelled in %dms\n", completed, cancelled, since())
+
+	out.Printf("🙅 Customer tries to cancel order %d after pickup: %v\n", pickedUp.ID, errOr(pickedUp.Cancel(), "ok"))
+}
+
+// errOr returns ok in place of a nil error
+func errOr(err error, ok string) string {
+	if err == nil {
+		return ok
+	}
+	return err.Error()
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Cancelling Orders")
+	out.Println("==========================================")
+
+	lunchWithCancellations()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ Each order gets its own context.WithCancel, so one cancel stops one order")
+	out.Println("✅ Cook in steps and select on ctx.Done() in each, so a cancel lands promptly")
+	out.Println("✅ A typed error (CancelledError) carries how far cooking got")
+	out.Println("✅ Guard the state with a mutex so cancel and completion can't both win")
+	out.Println("✅ Cancelling a finished order is a no-op that reports too late")
+	return nil
+}
@@ -0,0 +1,99 @@
+// Package timeouts is lesson 57: Per-Order Timeouts.
+package timeouts
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+	"github.com/Ajay2521/go-concurrency/pkg/order"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+// PricedOrder is an order with what the customer paid, so a stopped one can be refunded
+type PricedOrder struct {
+	order.Order
+	Price float64
+}
+
+// refund returns the uncooked share of the price for an order that was stopped
+func refund(o PricedOrder, r order.Result) float64 {
+	if r.Err == nil {
+		return 0
+	}
+	cooked := min(float64(r.Latency())/float64(o.PrepTime), 1)
+	return o.Price * (1 - cooked)
+}
+
+func sampleOrders() []PricedOrder {
+	return []PricedOrder{
+		{Order: order.Order{ID: 1, PrepTime: 2 * time.Second}, Price: 12.00},
+		{Order: order.Order{ID: 2, PrepTime: 3 * time.Second}, Price: 18.00},
+		{Order: order.Order{ID: 3, PrepTime: 1 * time.Second}, Price: 8.00},
+		{Order: order.Order{ID: 4, PrepTime: 4 * time.Second}, Price: 24.00},
+		{Order: order.Order{ID: 5, PrepTime: 2 * time.Second}, Price: 12.00},
+	}
+}
+
+// The standard batch with a 2.5s SLA: the 3s and 4s orders are stopped and partly refunded
+func slaBatch() {
+	out.Printf("\n=== 1. THE STANDARD BATCH WITH A 2.5s SLA ===\n\n")
+
+	const sla = 2500 * time.Millisecond
+	orders := sampleOrders()
+	results := make([]order.Result, len(orders))
+
+	startTime := clk.Now()
+	var wg sync.WaitGroup
+	for i, o := range orders {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = order.ProcessWithTimeout(context.Background(), clk, o.Order, sla) // The error is also in Err
+		}()
+	}
+	wg.Wait()
+
+	out.Printf("%-6s %6s %9s %8s  %-33s %7s\n", "Order", "Prep", "Elapsed", "Price", "Result", "Refund")
+	var refunds float64
+	for i, o := range orders {
+		r, status := results[i], "✅ ready"
+		if r.Err != nil {
+			status = "⏰ " + r.Err.Error()
+		}
+		amount := refund(o, r)
+		refunds += amount
+		out.Printf("%-6d %6v %9v %8.2f  %-33s %7.2f\n", o.ID, o.PrepTime, r.Latency().Round(10*time.Millisecond), o.Price, status, amount)
+	}
+	out.Printf("\n💸 Refunds: $%.2f | ⏱️  Batch took %v, not the 4s the slowest order needs\n",
+		refunds, clk.Since(startTime).Round(10*time.Millisecond))
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Per-Order Timeouts")
+	out.Println("==========================================")
+
+	slaBatch()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ context.WithTimeout per order gives each one its own SLA")
+	out.Println("✅ The cooking goroutine must watch the same context, or it keeps cooking after the timeout")
+	out.Println("✅ Waiting for the goroutine to return before ProcessWithTimeout does means nothing leaks")
+	out.Println("✅ Record elapsed time even on failure: refunds and metrics need it")
+	out.Println("✅ Tell your own timeout apart from the caller's cancellation")
+	return nil
+}
@@ -0,0 +1,211 @@
+// Package errhandling is lesson 58: Error Handling.
+package errhandling
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+	"github.com/Ajay2521/go-concurrency/pkg/order"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+// Order is the shared order type
+type Order = order.Order
+
+// KitchenOption customizes NewKitchen
+type KitchenOption func(*Kitchen)
+
+// WithFailures injects failures as described by fc
+func WithFailures(fc order.FailureConfig) KitchenOption {
+	return func(k *Kitchen) {
+		k.failures = fc
+	}
+}
+
+// Kitchen cooks orders and remembers how many times each has been attempted
+type Kitchen struct {
+	failures order.FailureConfig
+
+	mu       sync.Mutex
+	attempts map[int]int // Order ID → attempts so far
+}
+
+// NewKitchen returns a kitchen that never fails unless WithFailures says so
+func NewKitchen(opts ...KitchenOption) *Kitchen {
+	k := &Kitchen{attempts: make(map[int]int)}
+	for _, opt := range opts {
+		opt(k)
+	}
+	return k
+}
+
+// Process makes one attempt at the order. A failed attempt returns an
+// *order.OrderError; calling Process again for the same order is the next attempt.
+func (k *Kitchen) Process(ctx context.Context, o Order) error {
+	k.mu.Lock()
+	k.attempts[o.ID]++
+	attempt := k.attempts[o.ID]
+	k.mu.Unlock()
+
+	select {
+	case <-clk.After(o.PrepTime):
+	case <-ctx.Done():
+		return &order.OrderError{OrderID: o.ID, Attempt: attempt, Err: ctx.Err()}
+	}
+	return k.failures.Failure(o.ID, attempt)
+}
+
+// Outcome is how one order ended up after every attempt
+type Outcome struct {
+	OrderID  int
+	Attempts int
+	Err      error // nil if the order was served
+}
+
+// processWithRetry retries retryable failures up to maxAttempts, backing off
+// between attempts, and gives up at once on a terminal one
+func processWithRetry(ctx context.Context, k *Kitchen, o Order, maxAttempts int, backoff time.Duration) Outcome {
+	out := Outcome{OrderID: o.ID}
+	for out.Attempts < maxAttempts {
+		out.Attempts++
+		out.Err = k.Process(ctx, o)
+		if out.Err == nil || !order.Retryable(out.Err) {
+			return out
+		}
+		if out.Attempts < maxAttempts {
+			clk.Sleep(context.Background(), backoff<<(out.Attempts-1))
+		}
+	}
+	return out
+}
+
+// runPool sends every order through workers goroutines and collects the outcomes by order ID
+func runPool(k *Kitchen, orders []Order, workers, maxAttempts int) map[int]Outcome {
+	jobs := make(chan Order)
+	outcomes := make(map[int]Outcome)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for o := range jobs {
+				out := processWithRetry(context.Background(), k, o, maxAttempts, 5*time.Millisecond)
+				mu.Lock()
+				outcomes[o.ID] = out
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, o := range orders {
+		jobs <- o
+	}
+	close(jobs)
+	wg.Wait()
+	return outcomes
+}
+
+// failedIDs returns the sorted IDs of orders that weren't served
+func failedIDs(outcomes map[int]Outcome) []int {
+	var ids []int
+	for id, out := range outcomes {
+		if out.Err != nil {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// dinnerOrders returns n orders cycling through the menu
+func dinnerOrders(n int) []Order {
+	menu := []string{"Steak", "Risotto", "Salmon", "Soufflé", "Lasagna"}
+	orders := make([]Order, n)
+	for i := range orders {
+		orders[i] = Order{ID: i + 1, Dish: menu[i%len(menu)], PrepTime: 10 * time.Millisecond}
+	}
+	return orders
+}
+
+var dinnerFailures = order.FailureConfig{Rate: 0.4, Kinds: []error{order.ErrBurnt, order.ErrOvenBusy, order.ErrOutOfStock}, Seed: 14}
+
+// The same seed fails the same orders, however the workers interleave
+func injectedFailures() {
+	out.Printf("\n=== 1. INJECTED FAILURES, ONE ATTEMPT EACH ===\n\n")
+
+	orders := dinnerOrders(20)
+	for _, workers := range []int{1, 4, 8} {
+		outcomes := runPool(NewKitchen(WithFailures(dinnerFailures)), orders, workers, 1)
+		out.Printf("👨‍🍳 %d chef(s): failed orders %v\n", workers, failedIDs(outcomes))
+	}
+
+	out.Println()
+	outcomes := runPool(NewKitchen(WithFailures(dinnerFailures)), orders, 4, 1)
+	for _, id := range failedIDs(outcomes) {
+		out.Printf("❌ %v\n", outcomes[id].Err)
+	}
+}
+
+// Retry what can succeed, give up at once on what can't, and report the totals
+func classifyAndRetry() {
+	out.Printf("\n=== 2. CLASSIFY, RETRY, REPORT ===\n\n")
+
+	orders := dinnerOrders(20)
+	outcomes := runPool(NewKitchen(WithFailures(dinnerFailures)), orders, 4, 3)
+
+	var firstTry, afterRetry, terminal, exhausted int
+	for _, o := range orders {
+		oc := outcomes[o.ID]
+		switch {
+		case oc.Err == nil && oc.Attempts == 1:
+			firstTry++
+		case oc.Err == nil:
+			afterRetry++
+			out.Printf("🔁 Order %2d (%s) served on attempt %d\n", o.ID, o.Dish, oc.Attempts)
+		case order.Retryable(oc.Err):
+			exhausted++
+			out.Printf("💀 Order %2d (%s) still failing after %d attempts: %v\n", o.ID, o.Dish, oc.Attempts, oc.Err)
+		default:
+			terminal++
+			out.Printf("🚫 Order %2d (%s) stopped, not retryable: %v\n", o.ID, o.Dish, oc.Err)
+		}
+	}
+
+	out.Printf("\n📊 %d orders\n", len(orders))
+	out.Printf("   ✅ Served first time:        %2d\n", firstTry)
+	out.Printf("   🔁 Served after a retry:     %2d\n", afterRetry)
+	out.Printf("   🚫 Terminal (out of stock):  %2d\n", terminal)
+	out.Printf("   💀 Retries exhausted:        %2d\n", exhausted)
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Error Handling")
+	out.Println("==========================================")
+
+	injectedFailures()
+	classifyAndRetry()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ Injected failures make error paths as visible as the happy path")
+	out.Println("✅ Deriving failures from seed, ID and attempt keeps them deterministic under concurrency")
+	out.Println("✅ A typed error that unwraps to a sentinel carries context and still classifies with errors.Is")
+	out.Println("✅ Retry only what can succeed next time; fail fast on the rest")
+	out.Println("✅ A final breakdown tells apart recovered, terminal and exhausted failures")
+	return nil
+}
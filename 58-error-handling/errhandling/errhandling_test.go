@@ -0,0 +1,102 @@
+package errhandling
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/order"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// Seed 14 through 4 chefs with 3 attempts each: retryable failures are tried
+// again until they're served or run out, and out of stock stops at once
+func TestRetryRouting(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	clk = clock.Real()
+	orders := dinnerOrders(20)
+	outcomes := runPool(NewKitchen(WithFailures(dinnerFailures)), orders, 4, 3)
+
+	var retried, stopped, exhausted []int
+	for _, o := range orders {
+		oc := outcomes[o.ID]
+		var oe *order.OrderError
+		if oc.Err != nil && !errors.As(oc.Err, &oe) {
+			t.Fatalf("order %d: %v is not an *order.OrderError", o.ID, oc.Err)
+		}
+		switch {
+		case errors.Is(oc.Err, order.ErrOutOfStock):
+			stopped = append(stopped, o.ID)
+			if oc.Attempts != oe.Attempt {
+				t.Errorf("order %d: attempted %d times after going out of stock on attempt %d", o.ID, oc.Attempts, oe.Attempt)
+			}
+		case oc.Err != nil:
+			exhausted = append(exhausted, o.ID)
+			if oc.Attempts != 3 {
+				t.Errorf("order %d: gave up on a retryable %v after %d attempts, want 3", o.ID, oc.Err, oc.Attempts)
+			}
+		case oc.Attempts > 1:
+			retried = append(retried, o.ID)
+		}
+	}
+	if want := []int{1, 4, 8, 9, 13, 15}; !slices.Equal(retried, want) {
+		t.Errorf("served after a retry: %v, want %v", retried, want)
+	}
+	if want := []int{5, 6, 11, 12}; !slices.Equal(stopped, want) {
+		t.Errorf("stopped out of stock: %v, want %v", stopped, want)
+	}
+	if want := []int{2}; !slices.Equal(exhausted, want) {
+		t.Errorf("retries exhausted: %v, want %v", exhausted, want)
+	}
+}
+
+// The same seed fails the same orders however many chefs race for them
+func TestFailedIDsIgnoreWorkers(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	clk = clock.Real()
+	want := []int{1, 2, 4, 5, 6, 8, 9, 11, 12, 13, 15}
+	for _, workers := range []int{1, 4, 8} {
+		got := failedIDs(runPool(NewKitchen(WithFailures(dinnerFailures)), dinnerOrders(20), workers, 1))
+		if !slices.Equal(got, want) {
+			t.Errorf("%d chefs: failed %v, want %v", workers, got, want)
+		}
+	}
+	if got := failedIDs(runPool(NewKitchen(), dinnerOrders(20), 4, 1)); len(got) != 0 {
+		t.Errorf("no FailureConfig: failed %v, want none", got)
+	}
+}
+
+func TestProcessWithRetry(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	clk = clock.Real()
+	o := Order{ID: 1, PrepTime: time.Millisecond}
+	testutil.RunParallel(t, []testutil.TestCase{
+		{Name: "out of stock is attempted once", Input: order.FailureConfig{Rate: 1, Kinds: []error{order.ErrOutOfStock}, Seed: 1},
+			Want: Outcome{OrderID: 1, Attempts: 1, Err: order.ErrOutOfStock}},
+		{Name: "always burnt uses every attempt", Input: order.FailureConfig{Rate: 1, Seed: 1},
+			Want: Outcome{OrderID: 1, Attempts: 3, Err: order.ErrBurnt}},
+		{Name: "no failures, served first time", Input: order.FailureConfig{},
+			Want: Outcome{OrderID: 1, Attempts: 1}},
+	}, func(t *testing.T, tc testutil.TestCase) {
+		want := tc.Want.(Outcome)
+		got := processWithRetry(context.Background(), NewKitchen(WithFailures(tc.Input.(order.FailureConfig))), o, 3, time.Millisecond)
+		if got.Attempts != want.Attempts || !errors.Is(got.Err, want.Err) || (want.Err == nil) != (got.Err == nil) {
+			t.Errorf("processWithRetry = %+v, want %+v", got, want)
+		}
+	})
+}
+
+// A cancelled context ends the attempt with the context's error, which isn't retried
+func TestProcessCancelled(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	clk = clock.Real()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	oc := processWithRetry(ctx, NewKitchen(), Order{ID: 1, PrepTime: time.Hour}, 3, time.Millisecond)
+	if !errors.Is(oc.Err, context.Canceled) || oc.Attempts != 1 {
+		t.Errorf("processWithRetry = %+v, want Canceled after one attempt", oc)
+	}
+}
@@ -0,0 +1,131 @@
+// Package watchdog is lesson 59: Watchdog.
+package watchdog
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	Dish     string
+	PrepTime time.Duration
+}
+
+// Watchdog warns about orders that are taking too long. Each watched order
+// gets its own time.AfterFunc timer; nothing polls.
+type Watchdog struct {
+	threshold time.Duration
+	logf      func(format string, args ...any)
+	inFlight  atomic.Int64
+}
+
+// NewWatchdog warns through logf about any order still in flight after threshold
+func NewWatchdog(threshold time.Duration, logf func(format string, args ...any)) *Watchdog {
+	return &Watchdog{threshold: threshold, logf: logf}
+}
+
+// Watch arms the timer for one order and returns the func to call when it
+// finishes. done stops the timer, so an order that finishes in time never
+// logs. It reports whether the order had already been reported stuck, and
+// is safe to call more than once.
+func (w *Watchdog) Watch(orderID int) (done func() (stuck bool)) {
+	w.inFlight.Add(1)
+	timer := clk.AfterFunc(w.threshold, func() {
+		w.logf("order %d stuck for %v", orderID, w.threshold)
+	})
+
+	var once sync.Once
+	var stuck bool
+	return func() bool {
+		once.Do(func() {
+			// Stop reports false only if the warning already started
+			stuck = !timer.Stop()
+			w.inFlight.Add(-1)
+		})
+		return stuck
+	}
+}
+
+// InFlight returns how many watched orders haven't called done yet
+func (w *Watchdog) InFlight() int {
+	return int(w.inFlight.Load())
+}
+
+// cookAll cooks every order at once under the watchdog
+func cookAll(w *Watchdog, orders []Order, report func(o Order, took time.Duration, stuck bool)) {
+	var wg sync.WaitGroup
+	for _, o := range orders {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			done := w.Watch(o.ID)
+			start := clk.Now()
+			clk.Sleep(context.Background(), o.PrepTime)
+			stuck := done()
+			if report != nil {
+				report(o, clk.Since(start), stuck)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// One soufflé collapses and has to be restarted; the watchdog notices
+func dinnerService() {
+	out.Printf("\n=== 1. WATCHDOG AT 300ms ===\n\n")
+
+	logger := log.New(out, "⚠️  ", log.Lmsgprefix)
+	w := NewWatchdog(300*time.Millisecond, logger.Printf)
+	orders := []Order{
+		{ID: 1, Dish: "Salad", PrepTime: 100 * time.Millisecond},
+		{ID: 2, Dish: "Soup", PrepTime: 150 * time.Millisecond},
+		{ID: 3, Dish: "Soufflé", PrepTime: 700 * time.Millisecond}, // Collapsed, started over
+		{ID: 4, Dish: "Pasta", PrepTime: 250 * time.Millisecond},
+	}
+
+	var mu sync.Mutex
+	cookAll(w, orders, func(o Order, took time.Duration, stuck bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		if stuck {
+			out.Printf("🐢 Order %d (%s) finally done after %v\n", o.ID, o.Dish, took.Round(10*time.Millisecond))
+			return
+		}
+		out.Printf("✅ Order %d (%s) done in %v\n", o.ID, o.Dish, took.Round(10*time.Millisecond))
+	})
+	out.Printf("\n📊 Orders still watched: %d\n", w.InFlight())
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Watchdog")
+	out.Println("==========================================")
+
+	dinnerService()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ time.AfterFunc arms a per-order alarm without a goroutine per order")
+	out.Println("✅ Stop the timer when the order finishes, or it warns about finished work")
+	out.Println("✅ Stop's return value tells you whether the warning already fired")
+	out.Println("✅ sync.Once makes the finish func safe to call twice")
+	return nil
+}
@@ -0,0 +1,132 @@
+package watchdog
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// onManualClock points the lesson at a fake clock that only moves when the
+// test advances it
+func onManualClock(t *testing.T) *clock.FakeClock {
+	saved := clk
+	fake := clock.NewFake(testutil.Epoch)
+	clk = fake
+	t.Cleanup(func() { clk = saved })
+	return fake
+}
+
+// warnings returns a logf that sends each warning on the returned channel.
+// AfterFunc logs from its own goroutine, so tests receive instead of polling.
+func warnings() (func(format string, args ...any), chan string) {
+	lines := make(chan string, 10)
+	return func(format string, args ...any) { lines <- fmt.Sprintf(format, args...) }, lines
+}
+
+// Orders 1 and 3 finish inside the 50ms threshold and order 2 doesn't:
+// exactly one warning, and only order 2's done reports it stuck
+func TestWatchdogOneStuckOrder(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := onManualClock(t)
+	logf, lines := warnings()
+	w := NewWatchdog(50*time.Millisecond, logf)
+
+	done1, done2, done3 := w.Watch(1), w.Watch(2), w.Watch(3)
+	fake.Advance(10 * time.Millisecond)
+	stuck1 := done1()
+	fake.Advance(10 * time.Millisecond)
+	stuck3 := done3()
+	fake.Advance(30 * time.Millisecond) // Order 2's threshold
+	if got := <-lines; got != "order 2 stuck for 50ms" {
+		t.Errorf("warning %q, want order 2 stuck for 50ms", got)
+	}
+	fake.Advance(70 * time.Millisecond)
+	stuck2 := done2()
+
+	if stuck1 || !stuck2 || stuck3 {
+		t.Errorf("done reported stuck 1:%v 2:%v 3:%v, want only order 2", stuck1, stuck2, stuck3)
+	}
+	if n := fake.Waiters(); n != 0 {
+		t.Errorf("%d timers still armed", n)
+	}
+	if len(lines) != 0 {
+		t.Errorf("extra warning %q", <-lines)
+	}
+}
+
+// 1000 orders that finish in time log nothing and leave no timer armed
+func TestWatchdogTimelyOrders(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := onManualClock(t)
+	logf, lines := warnings()
+	w := NewWatchdog(30*time.Millisecond, logf)
+
+	for id := 1; id <= 1000; id++ {
+		done := w.Watch(id)
+		fake.Advance(time.Millisecond)
+		if done() {
+			t.Fatalf("order %d reported stuck after 1ms", id)
+		}
+	}
+	fake.Advance(time.Hour)
+	if n := fake.Waiters(); n != 0 || w.InFlight() != 0 || len(lines) != 0 {
+		t.Errorf("%d timers armed, %d in flight, %d warnings; want none", n, w.InFlight(), len(lines))
+	}
+}
+
+func TestWatchdogDoneTwice(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := onManualClock(t)
+	logf, lines := warnings()
+	w := NewWatchdog(20*time.Millisecond, logf)
+
+	done := w.Watch(7)
+	if first, second := done(), done(); first || second {
+		t.Errorf("done() = %v then %v, want false both times", first, second)
+	}
+	fake.Advance(40 * time.Millisecond)
+	if w.InFlight() != 0 || len(lines) != 0 {
+		t.Errorf("%d in flight, %d warnings; want none", w.InFlight(), len(lines))
+	}
+}
+
+// An order that never finishes is reported once, not every threshold
+func TestWatchdogWarnsOnce(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := onManualClock(t)
+	logf, lines := warnings()
+	w := NewWatchdog(20*time.Millisecond, logf)
+
+	w.Watch(8) // Never finishes
+	fake.Advance(20 * time.Millisecond)
+	if got := <-lines; got != "order 8 stuck for 20ms" {
+		t.Errorf("warning %q, want order 8 stuck for 20ms", got)
+	}
+	fake.Advance(60 * time.Millisecond)
+	if n := fake.Waiters(); n != 0 || len(lines) != 0 {
+		t.Errorf("%d timers re-armed, %d more warnings; want none", n, len(lines))
+	}
+	if w.InFlight() != 1 {
+		t.Errorf("%d in flight, want the unfinished order", w.InFlight())
+	}
+}
+
+func TestRun(t *testing.T) {
+	got := testutil.RunLesson(t, Run)
+	for _, want := range []string{
+		"⚠️  order 3 stuck for 300ms",
+		"🐢 Order 3 (Soufflé) finally done after 700ms",
+		"📊 Orders still watched: 0",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	if n := strings.Count(got, "stuck for"); n != 1 {
+		t.Errorf("%d stuck warnings, want 1:\n%s", n, got)
+	}
+}
@@ -0,0 +1,351 @@
+// Package roundrobin is lesson 60: Round-Robin Dispatch.
+package roundrobin
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// clk is the clock the lesson sleeps on and reads the time from. Run sets
+// it from its options; tests swap in a fake.
+var clk clock.Clock = clock.Real()
+
+// out is where the lesson prints. Run starts it; every goroutine's lines
+// go through it, so they come out whole.
+var out *display.Printer
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+}
+
+// Dispatcher gives every worker its own input channel and deals incoming
+// orders to them in turn: worker 0, 1, 2, 0, 1, 2, ... Every worker gets
+// its share, however fast the others are. The price is that an order waits
+// behind whatever its worker already has, even while other workers sit idle.
+type Dispatcher struct {
+	queues  []chan Order
+	cook    func(worker int, o Order)
+	handled []atomic.Int64 // Orders each worker has cooked
+	stolen  []atomic.Int64 // How many of those came from another worker's channel
+	steal   bool
+	wg      sync.WaitGroup
+}
+
+// stealPoll is how often an idle stealing worker looks at the other queues again
+const stealPoll = time.Millisecond
+
+// NewDispatcher starts workers goroutines, each reading its own channel with
+// room for queueSize orders
+func NewDispatcher(workers, queueSize int, cook func(worker int, o Order)) *Dispatcher {
+	return newDispatcher(workers, queueSize, cook, false)
+}
+
+// NewStealingDispatcher deals orders the same way, but a worker whose own
+// channel is empty takes the next order from the busiest worker's channel
+func NewStealingDispatcher(workers, queueSize int, cook func(worker int, o Order)) *Dispatcher {
+	return newDispatcher(workers, queueSize, cook, true)
+}
+
+func newDispatcher(workers, queueSize int, cook func(worker int, o Order), steal bool) *Dispatcher {
+	d := &Dispatcher{
+		queues:  make([]chan Order, workers),
+		cook:    cook,
+		handled: make([]atomic.Int64, workers),
+		stolen:  make([]atomic.Int64, workers),
+		steal:   steal,
+	}
+	for w := range d.queues {
+		d.queues[w] = make(chan Order, queueSize)
+	}
+	for w := range d.queues {
+		d.wg.Add(1)
+		if steal {
+			go d.stealingWorker(w)
+		} else {
+			go d.worker(w)
+		}
+	}
+	return d
+}
+
+func (d *Dispatcher) worker(w int) {
+	defer d.wg.Done()
+	for o := range d.queues[w] {
+		d.handle(w, o)
+	}
+}
+
+// stealingWorker cooks from its own channel first. When that is empty it
+// steals, and when there is nothing to steal it waits on its own channel
+// for up to stealPoll before looking again. Once its own channel is closed,
+// every channel is, so it steals until they are all empty and then stops.
+func (d *Dispatcher) stealingWorker(w int) {
+	defer d.wg.Done()
+	own := d.queues[w]
+	closed := false
+	for {
+		select {
+		case o, ok := <-own:
+			if ok {
+				d.handle(w, o)
+				continue
+			}
+			closed = true
+		default:
+		}
+		if o, ok := d.stealFor(w); ok {
+			d.handle(w, o)
+			d.stolen[w].Add(1)
+			continue
+		}
+		if closed {
+			if slices.ContainsFunc(d.queues, func(q chan Order) bool { return len(q) > 0 }) {
+				continue // Another worker took the order we aimed at; look again
+			}
+			return
+		}
+		select {
+		case o, ok := <-own:
+			if !ok {
+				closed = true
+				continue
+			}
+			d.handle(w, o)
+		case <-clk.After(stealPoll):
+		}
+	}
+}
+
+func (d *Dispatcher) handle(w int, o Order) {
+	d.cook(w, o)
+	d.handled[w].Add(1)
+}
+
+// stealFor takes one order from the longest channel other than w's. The
+// receive is the synchronization: a channel hands each value to exactly one
+// receiver, so the owner and any number of thieves can reach for the same
+// order and only one gets it. The others' non-blocking receives just fail.
+func (d *Dispatcher) stealFor(w int) (Order, bool) {
+	victim, longest := -1, 0
+	for v, q := range d.queues {
+		if v != w && len(q) > longest {
+			victim, longest = v, len(q)
+		}
+	}
+	if victim < 0 {
+		return Order{}, false
+	}
+	select {
+	case o, ok := <-d.queues[victim]:
+		return o, ok
+	default:
+		return Order{}, false
+	}
+}
+
+// Dispatch deals every order from incoming round-robin until incoming is
+// closed, then closes the worker channels. A full worker channel blocks the
+// dispatcher: the next order is that worker's, however long it takes.
+func (d *Dispatcher) Dispatch(incoming <-chan Order) {
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		next := 0
+		for o := range incoming {
+			d.queues[next] <- o
+			next = (next + 1) % len(d.queues)
+		}
+		for _, q := range d.queues {
+			close(q)
+		}
+	}()
+}
+
+// Wait blocks until the dispatcher and every worker have finished
+func (d *Dispatcher) Wait() {
+	d.wg.Wait()
+}
+
+// queueDepths returns how many orders wait in each worker's channel
+func (d *Dispatcher) queueDepths() []int {
+	depths := make([]int, len(d.queues))
+	for w, q := range d.queues {
+		depths[w] = len(q)
+	}
+	return depths
+}
+
+// Handled returns how many orders each worker has cooked so far
+func (d *Dispatcher) Handled() []int {
+	counts := make([]int, len(d.handled))
+	for w := range d.handled {
+		counts[w] = int(d.handled[w].Load())
+	}
+	return counts
+}
+
+// Stolen returns how many orders each worker has taken from another's channel
+func (d *Dispatcher) Stolen() []int {
+	counts := make([]int, len(d.stolen))
+	for w := range d.stolen {
+		counts[w] = int(d.stolen[w].Load())
+	}
+	return counts
+}
+
+// feed returns a closed channel holding every order
+func feed(orders []Order) <-chan Order {
+	ch := make(chan Order, len(orders))
+	for _, o := range orders {
+		ch <- o
+	}
+	close(ch)
+	return ch
+}
+
+// runSharedQueue is the pool from lesson 04: every worker reads one shared channel
+func runSharedQueue(workers int, orders []Order, cook func(worker int, o Order)) []int {
+	queue := feed(orders)
+	counts := make([]int, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for o := range queue {
+				cook(w, o)
+				counts[w]++ // Each worker owns its own slot
+			}
+		}()
+	}
+	wg.Wait()
+	return counts
+}
+
+func cook(_ int, o Order) { clk.Sleep(context.Background(), o.PrepTime) }
+
+// makeOrders returns n orders; every slowEvery-th one (order 1, 1+slowEvery, ...) takes slow, the rest fast
+func makeOrders(n, slowEvery int, slow, fast time.Duration) []Order {
+	orders := make([]Order, n)
+	for i := range orders {
+		prep := fast
+		if slowEvery > 0 && i%slowEvery == 0 {
+			prep = slow
+		}
+		orders[i] = Order{ID: i + 1, PrepTime: prep}
+	}
+	return orders
+}
+
+// Twelve orders, three chefs: each chef gets every third order
+func dealing() {
+	out.Printf("\n=== 1. DEALING 12 ORDERS TO 3 CHEFS ===\n\n")
+
+	var mu sync.Mutex
+	cooked := make([][]int, 3)
+	d := NewDispatcher(3, 4, func(w int, o Order) {
+		clk.Sleep(context.Background(), o.PrepTime)
+		mu.Lock()
+		cooked[w] = append(cooked[w], o.ID)
+		mu.Unlock()
+	})
+	d.Dispatch(feed(makeOrders(12, 0, 0, 20*time.Millisecond)))
+	d.Wait()
+
+	for w, ids := range cooked {
+		out.Printf("👨‍🍳 Chef %d cooked %v\n", w, ids)
+	}
+	out.Printf("\n📊 Orders per chef: %v\n", d.Handled())
+}
+
+// Chef 0 gets every slow order; the shared queue lets the others pick up the slack
+func roundRobinVsShared() {
+	out.Printf("\n=== 2. ROUND-ROBIN VS A SHARED QUEUE ===\n\n")
+
+	const workers = 3
+	orders := makeOrders(24, workers, 80*time.Millisecond, 10*time.Millisecond)
+
+	d := NewDispatcher(workers, len(orders), cook)
+	startTime := clk.Now()
+	d.Dispatch(feed(orders))
+	clk.Sleep(context.Background(), 50*time.Millisecond)
+	depths := d.queueDepths()
+	d.Wait()
+	roundRobin := clk.Since(startTime)
+	rrCounts := d.Handled()
+
+	startTime = clk.Now()
+	sharedCounts := runSharedQueue(workers, orders, cook)
+	shared := clk.Since(startTime)
+
+	out.Printf("📥 Backlog after 50ms, per chef: %v (chef 0's slow orders pile up)\n\n", depths)
+	out.Printf("%-14s %9s  %s\n", "Queue", "Wall", "Orders per chef")
+	out.Printf("%-14s %9v  %v\n", "Round-robin", roundRobin.Round(10*time.Millisecond), rrCounts)
+	out.Printf("%-14s %9v  %v\n", "Shared", shared.Round(10*time.Millisecond), sharedCounts)
+	out.Printf("\n⚖️  Round-robin splits the orders evenly, not the work. The shared queue splits the work.\n")
+}
+
+// giantFirst returns n orders of prep each, except order 1, which takes giant.
+// Round-robin deals order 1 to worker 0.
+func giantFirst(n int, giant, prep time.Duration) []Order {
+	orders := makeOrders(n, 0, 0, prep)
+	orders[0].PrepTime = giant
+	return orders
+}
+
+// runDispatcher cooks orders on a dispatcher and returns how long it took
+func runDispatcher(d *Dispatcher, orders []Order) time.Duration {
+	startTime := clk.Now()
+	d.Dispatch(feed(orders))
+	d.Wait()
+	return clk.Since(startTime)
+}
+
+// One giant order on chef 0, 59 small ones dealt round-robin behind it
+func stealing() {
+	out.Printf("\n=== 3. ONE GIANT ORDER: WITH AND WITHOUT STEALING ===\n\n")
+
+	const workers = 3
+	orders := giantFirst(60, 300*time.Millisecond, 10*time.Millisecond)
+
+	plain := NewDispatcher(workers, len(orders), cook)
+	plainWall := runDispatcher(plain, orders)
+	thief := NewStealingDispatcher(workers, len(orders), cook)
+	thiefWall := runDispatcher(thief, orders)
+
+	out.Printf("%-14s %9s  %-18s %s\n", "Dispatcher", "Wall", "Orders per chef", "Stolen")
+	out.Printf("%-14s %9v  %-18v %v\n", "Round-robin", plainWall.Round(10*time.Millisecond), fmt.Sprint(plain.Handled()), plain.Stolen())
+	out.Printf("%-14s %9v  %-18v %v\n", "Stealing", thiefWall.Round(10*time.Millisecond), fmt.Sprint(thief.Handled()), thief.Stolen())
+	out.Printf("\n🥷 While chef 0 cooks order 1, chefs 1 and 2 take the small orders dealt to chef 0.\n")
+}
+
+func Run(ctx context.Context, opts lesson.Options) error {
+	clk = opts.ClockOrReal()
+	out = opts.NewPrinter()
+	defer out.Close()
+	out.Println("==========================================")
+	out.Println("🏪 Go Concurrency: Round-Robin Dispatch")
+	out.Println("==========================================")
+
+	dealing()
+	roundRobinVsShared()
+	stealing()
+
+	out.Println("\n📝 Key Learnings:")
+	out.Println("✅ A channel per worker plus a dispatcher deals orders out evenly")
+	out.Println("✅ Round-robin guarantees every worker a share; none starves")
+	out.Println("✅ Each worker sees its orders in arrival order, without sharing a queue")
+	out.Println("✅ Even counts aren't even work: a slow order holds up everything behind it")
+	out.Println("✅ len() on each channel shows where the backlog is building")
+	out.Println("✅ An idle worker can steal from the longest channel; the receive itself makes it safe")
+	return nil
+}
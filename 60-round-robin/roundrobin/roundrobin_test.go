@@ -0,0 +1,171 @@
+package roundrobin
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// Each worker gets N/W orders, the first N mod W one more, and cooks them
+// in the order they were dealt: worker w gets w+1, w+1+W, w+1+2W, ...
+func TestDispatcherEvenShares(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	tests := []struct {
+		name    string
+		workers int
+		orders  int
+		want    []int
+	}{
+		{"1000 orders to 4 workers", 4, 1000, []int{250, 250, 250, 250}},
+		{"10 orders to 3 workers", 3, 10, []int{4, 3, 3}},
+		{"fewer orders than workers", 3, 2, []int{1, 1, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen := make([][]int, tt.workers) // Each worker appends only to its own slot
+			d := NewDispatcher(tt.workers, 16, func(w int, o Order) {
+				seen[w] = append(seen[w], o.ID)
+			})
+			d.Dispatch(feed(makeOrders(tt.orders, 0, 0, 0)))
+			d.Wait()
+
+			if got := d.Handled(); !slices.Equal(got, tt.want) {
+				t.Errorf("orders per worker %v, want %v", got, tt.want)
+			}
+			for w, ids := range seen {
+				for i, id := range ids {
+					if want := w + 1 + i*tt.workers; id != want {
+						t.Errorf("worker %d's order %d is %d, want %d", w, i+1, id, want)
+						break
+					}
+				}
+			}
+			if depths := d.queueDepths(); slices.ContainsFunc(depths, func(n int) bool { return n > 0 }) {
+				t.Errorf("queue depths %v after Wait, want all empty", depths)
+			}
+		})
+	}
+}
+
+// Worker 0 stalls on order 1: its other orders wait in its own channel while
+// workers 1 and 2 finish theirs, and once it recovers nothing is left behind
+func TestDispatcherStalledWorker(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	stuck, release := make(chan struct{}, 3), make(chan struct{})
+	d := NewDispatcher(3, 4, func(w int, o Order) {
+		if w == 0 {
+			stuck <- struct{}{}
+			<-release
+		}
+	})
+	d.Dispatch(feed(makeOrders(9, 0, 0, 0)))
+	<-stuck // Worker 0 holds order 1
+
+	deadline := time.Now().Add(time.Second)
+	for !slices.Equal(d.Handled()[1:], []int{3, 3}) {
+		if time.Now().After(deadline) {
+			close(release)
+			t.Fatalf("workers 1 and 2 cooked %v while worker 0 was stuck, want [3 3]", d.Handled()[1:])
+		}
+		time.Sleep(time.Millisecond)
+	}
+	depths := d.queueDepths()
+	close(release)
+	d.Wait()
+
+	if !slices.Equal(depths, []int{2, 0, 0}) {
+		t.Errorf("depths %v while worker 0 was stuck, want [2 0 0]", depths)
+	}
+	if got := d.Handled(); !slices.Equal(got, []int{3, 3, 3}) {
+		t.Errorf("orders per worker %v after recovery, want [3 3 3]", got)
+	}
+}
+
+// One giant order on worker 0 and thirty small ones: the idle workers steal
+// worker 0's small orders and the whole batch finishes sooner
+func TestStealingDispatcherSharesTheGiant(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	saved := clk
+	clk = testutil.FakeClock(t)
+	t.Cleanup(func() { clk = saved })
+
+	orders := giantFirst(31, 100*time.Millisecond, 5*time.Millisecond)
+	plain := NewDispatcher(3, len(orders), cook)
+	plainWall := runDispatcher(plain, orders)
+	thief := NewStealingDispatcher(3, len(orders), cook)
+	thiefWall := runDispatcher(thief, orders)
+
+	if stolen := plain.Stolen(); !slices.Equal(stolen, []int{0, 0, 0}) {
+		t.Errorf("plain dispatcher stole %v", stolen)
+	}
+	if stolen := thief.Stolen(); stolen[1] == 0 || stolen[2] == 0 {
+		t.Errorf("stolen %v, want workers 1 and 2 to steal from worker 0", stolen)
+	}
+	if thiefWall >= plainWall {
+		t.Errorf("stealing took %v, plain round-robin %v; want stealing faster", thiefWall, plainWall)
+	}
+	total := 0
+	for _, n := range thief.Handled() {
+		total += n
+	}
+	if total != len(orders) {
+		t.Errorf("stealing dispatcher cooked %d of %d orders", total, len(orders))
+	}
+}
+
+// Eight workers race for the same channels: a stolen order is cooked by
+// exactly one of them, and none is lost
+func TestStealingCooksEachOrderOnce(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	saved := clk
+	clk = clock.Real() // Worker 0's sleeps must pass; a TestRun before this leaves clk on a stopped fake
+	t.Cleanup(func() { clk = saved })
+
+	const n = 10000
+	cooked := make([]atomic.Int32, n)
+	d := NewStealingDispatcher(8, 64, func(w int, o Order) {
+		if w == 0 {
+			clk.Sleep(context.Background(), 10*time.Microsecond) // Keep worker 0 behind so there is always something to steal
+		}
+		cooked[o.ID-1].Add(1)
+	})
+	d.Dispatch(feed(makeOrders(n, 0, 0, 0)))
+	d.Wait()
+
+	for i := range cooked {
+		if got := cooked[i].Load(); got != 1 {
+			t.Errorf("order %d cooked %d times, want once", i+1, got)
+		}
+	}
+	if depths := d.queueDepths(); slices.ContainsFunc(depths, func(n int) bool { return n > 0 }) {
+		t.Errorf("queue depths %v after Wait, want all empty", depths)
+	}
+	stolen := 0
+	for _, s := range d.Stolen() {
+		stolen += s
+	}
+	if stolen == 0 {
+		t.Error("no order was stolen")
+	}
+}
+
+func TestRun(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	got := testutil.RunLesson(t, Run)
+	for _, want := range []string{
+		"=== 3. ONE GIANT ORDER: WITH AND WITHOUT STEALING ===",
+		"Stealing",
+		"📊 Orders per chef: [4 4 4]",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+}
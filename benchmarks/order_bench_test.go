@@ -0,0 +1,99 @@
+// Package benchmarks measures the three ways the lessons cook a batch of
+// orders: one after another, a goroutine per order, and a fixed worker pool.
+package benchmarks
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+type Order struct {
+	ID       int
+	PrepTime time.Duration
+}
+
+const (
+	ordersPerOp = 1000
+	prepTime    = time.Millisecond
+	poolWorkers = 32
+)
+
+// makeOrders builds the batch every benchmark op cooks
+func makeOrders() []Order {
+	orders := make([]Order, ordersPerOp)
+	for i := range orders {
+		orders[i] = Order{ID: i + 1, PrepTime: prepTime}
+	}
+	return orders
+}
+
+func processOrder(order Order) {
+	time.Sleep(order.PrepTime)
+}
+
+// atEachProcs runs cook as a sub-benchmark at GOMAXPROCS 1, 2, 4 and 8,
+// reporting orders/sec and allocations, and restores GOMAXPROCS after
+func atEachProcs(b *testing.B, cook func([]Order)) {
+	orders := makeOrders()
+	original := runtime.GOMAXPROCS(0)
+	defer runtime.GOMAXPROCS(original)
+	for _, procs := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("procs=%d", procs), func(b *testing.B) {
+			runtime.GOMAXPROCS(procs)
+			b.ReportAllocs()
+			for b.Loop() {
+				cook(orders)
+			}
+			b.ReportMetric(float64(ordersPerOp*b.N)/b.Elapsed().Seconds(), "orders/sec")
+		})
+	}
+}
+
+// BenchmarkSequential cooks the batch one order at a time
+func BenchmarkSequential(b *testing.B) {
+	atEachProcs(b, func(orders []Order) {
+		for _, o := range orders {
+			processOrder(o)
+		}
+	})
+}
+
+// BenchmarkGoroutinePerTask starts a goroutine for every order
+func BenchmarkGoroutinePerTask(b *testing.B) {
+	atEachProcs(b, func(orders []Order) {
+		var wg sync.WaitGroup
+		for _, o := range orders {
+			wg.Add(1)
+			go func(o Order) {
+				defer wg.Done()
+				processOrder(o)
+			}(o)
+		}
+		wg.Wait()
+	})
+}
+
+// BenchmarkWorkerPool feeds the batch to a fixed set of workers through a channel
+func BenchmarkWorkerPool(b *testing.B) {
+	atEachProcs(b, func(orders []Order) {
+		jobs := make(chan Order, len(orders))
+		var wg sync.WaitGroup
+		for w := 0; w < poolWorkers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for o := range jobs {
+					processOrder(o)
+				}
+			}()
+		}
+		for _, o := range orders {
+			jobs <- o
+		}
+		close(jobs)
+		wg.Wait()
+	})
+}
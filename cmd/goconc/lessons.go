@@ -0,0 +1,139 @@
+package main
+
+import (
+	"github.com/Ajay2521/go-concurrency/01-sequential-synchronous/sequential"
+	"github.com/Ajay2521/go-concurrency/02-goroutines-and-waitgroups/waitgroups"
+	"github.com/Ajay2521/go-concurrency/03-buffered-channels/buffered"
+	"github.com/Ajay2521/go-concurrency/04-worker-pools/workerpool"
+	"github.com/Ajay2521/go-concurrency/05-fan-in/fanin"
+	"github.com/Ajay2521/go-concurrency/06-select/dispatch"
+	"github.com/Ajay2521/go-concurrency/08-sync-map/syncmap"
+	"github.com/Ajay2521/go-concurrency/09-sync-once/once"
+	"github.com/Ajay2521/go-concurrency/10-context/cancellation"
+	"github.com/Ajay2521/go-concurrency/11-atomic/atomics"
+	"github.com/Ajay2521/go-concurrency/12-semaphore/semaphore"
+	"github.com/Ajay2521/go-concurrency/13-sync-pool/syncpool"
+	"github.com/Ajay2521/go-concurrency/14-pub-sub/pubsub"
+	"github.com/Ajay2521/go-concurrency/15-rate-limiter/ratelimit"
+	"github.com/Ajay2521/go-concurrency/26-cache/cache"
+	"github.com/Ajay2521/go-concurrency/27-dynamic-pool/dynamicpool"
+	"github.com/Ajay2521/go-concurrency/28-scheduler/scheduler"
+	"github.com/Ajay2521/go-concurrency/29-aggregation/aggregation"
+	"github.com/Ajay2521/go-concurrency/30-state-machine/statemachine"
+	"github.com/Ajay2521/go-concurrency/31-long-poll/longpoll"
+	"github.com/Ajay2521/go-concurrency/32-work-stealing/worksteal"
+	"github.com/Ajay2521/go-concurrency/33-map-reduce/mapreduce"
+	"github.com/Ajay2521/go-concurrency/33-singleflight/singleflight"
+	"github.com/Ajay2521/go-concurrency/34-actors/actors"
+	"github.com/Ajay2521/go-concurrency/34-circuit-breaker/breaker"
+	"github.com/Ajay2521/go-concurrency/35-retries/retries"
+	"github.com/Ajay2521/go-concurrency/35-signal-handling/signals"
+	"github.com/Ajay2521/go-concurrency/36-parallel-map/parallelmap"
+	"github.com/Ajay2521/go-concurrency/36-resource-pool/resourcepool"
+	"github.com/Ajay2521/go-concurrency/37-dedup/dedup"
+	"github.com/Ajay2521/go-concurrency/37-ordered-results/ordered"
+	"github.com/Ajay2521/go-concurrency/38-scatter-gather/scatter"
+	"github.com/Ajay2521/go-concurrency/39-backpressure/backpressure"
+	"github.com/Ajay2521/go-concurrency/39-futures/futures"
+	"github.com/Ajay2521/go-concurrency/40-actor/actor"
+	"github.com/Ajay2521/go-concurrency/40-graph/graph"
+	"github.com/Ajay2521/go-concurrency/41-bulkhead/bulkhead"
+	"github.com/Ajay2521/go-concurrency/41-supervisor/supervisor"
+	"github.com/Ajay2521/go-concurrency/42-ingestion/ingestion"
+	"github.com/Ajay2521/go-concurrency/42-panics/panics"
+	"github.com/Ajay2521/go-concurrency/43-export/export"
+	"github.com/Ajay2521/go-concurrency/43-parallelism/parallelism"
+	"github.com/Ajay2521/go-concurrency/44-middleware/middleware"
+	"github.com/Ajay2521/go-concurrency/44-ping-pong/pingpong"
+	"github.com/Ajay2521/go-concurrency/45-barrier/barrier"
+	"github.com/Ajay2521/go-concurrency/45-events/events"
+	"github.com/Ajay2521/go-concurrency/46-coalescing/coalescing"
+	"github.com/Ajay2521/go-concurrency/46-latch/latch"
+	"github.com/Ajay2521/go-concurrency/47-throttle-debounce/throttle"
+	"github.com/Ajay2521/go-concurrency/48-batching/batching"
+	"github.com/Ajay2521/go-concurrency/49-priority-orders/priority"
+	"github.com/Ajay2521/go-concurrency/50-dynamic-select/dynselect"
+	"github.com/Ajay2521/go-concurrency/51-elastic-pool/elastic"
+	"github.com/Ajay2521/go-concurrency/52-pause-resume/pauseresume"
+	"github.com/Ajay2521/go-concurrency/53-scheduled-orders/scheduled"
+	"github.com/Ajay2521/go-concurrency/54-tickers/tickers"
+	"github.com/Ajay2521/go-concurrency/55-timer-reuse/timers"
+	"github.com/Ajay2521/go-concurrency/56-cancel-orders/cancelorders"
+	"github.com/Ajay2521/go-concurrency/57-order-timeouts/timeouts"
+	"github.com/Ajay2521/go-concurrency/58-error-handling/errhandling"
+	"github.com/Ajay2521/go-concurrency/59-watchdog/watchdog"
+	"github.com/Ajay2521/go-concurrency/60-round-robin/roundrobin"
+)
+
+// lessons is every lesson in course order, with the first heading of its
+// README as its title. main_test.go checks it against the directories.
+var lessons = []Lesson{
+	{"01-sequential-synchronous", "Sequential Synchronous Order Processing System", sequential.Run},
+	{"02-goroutines-and-waitgroups", "Goroutines and WaitGroup", waitgroups.Run},
+	{"03-buffered-channels", "Buffered Channels & Backpressure", buffered.Run},
+	{"04-worker-pools", "Worker Pools", workerpool.Run},
+	{"05-fan-in", "Fan-In", fanin.Run},
+	{"06-select", "Select Statement", dispatch.Run},
+	{"08-sync-map", "sync.Map & Deduplication", syncmap.Run},
+	{"09-sync-once", "sync.Once Lazy Kitchen", once.Run},
+	{"10-context", "Context Cancellation & Deadlines", cancellation.Run},
+	{"11-atomic", "Atomic Operations", atomics.Run},
+	{"12-semaphore", "Semaphores", semaphore.Run},
+	{"13-sync-pool", "sync.Pool", syncpool.Run},
+	{"14-pub-sub", "Pub/Sub", pubsub.Run},
+	{"15-rate-limiter", "Rate Limiting", ratelimit.Run},
+	{"26-cache", "Stale-While-Revalidate Cache", cache.Run},
+	{"27-dynamic-pool", "Dynamic Worker Pool", dynamicpool.Run},
+	{"28-scheduler", "Scheduled Order Processing", scheduler.Run},
+	{"29-aggregation", "Result Aggregation", aggregation.Run},
+	{"30-state-machine", "State Machines", statemachine.Run},
+	{"31-long-poll", "Long Polling", longpoll.Run},
+	{"32-work-stealing", "Work Stealing", worksteal.Run},
+	{"33-map-reduce", "Map-Reduce", mapreduce.Run},
+	{"33-singleflight", "Singleflight", singleflight.Run},
+	{"34-actors", "Order Actors", actors.Run},
+	{"34-circuit-breaker", "Circuit Breaker", breaker.Run},
+	{"35-retries", "Retries with Backoff", retries.Run},
+	{"35-signal-handling", "Signal Handling", signals.Run},
+	{"36-parallel-map", "Bounded Parallel Map", parallelmap.Run},
+	{"36-resource-pool", "Resource Pool", resourcepool.Run},
+	{"37-dedup", "Deduplication", dedup.Run},
+	{"37-ordered-results", "Ordered Fan-In", ordered.Run},
+	{"38-scatter-gather", "Scatter-Gather", scatter.Run},
+	{"39-backpressure", "Backpressure", backpressure.Run},
+	{"39-futures", "Futures", futures.Run},
+	{"40-actor", "Actors & Confinement", actor.Run},
+	{"40-graph", "Graph Traversal", graph.Run},
+	{"41-bulkhead", "Bulkheads", bulkhead.Run},
+	{"41-supervisor", "Supervisors", supervisor.Run},
+	{"42-ingestion", "Streaming Ingestion", ingestion.Run},
+	{"42-panics", "Panics in Goroutines", panics.Run},
+	{"43-export", "CSV Export", export.Run},
+	{"43-parallelism", "Concurrency vs Parallelism", parallelism.Run},
+	{"44-middleware", "Middleware Chains", middleware.Run},
+	{"44-ping-pong", "Ping-Pong Handoff", pingpong.Run},
+	{"45-barrier", "Barrier", barrier.Run},
+	{"45-events", "Order Events", events.Run},
+	{"46-coalescing", "Request Coalescing", coalescing.Run},
+	{"46-latch", "Countdown Latch", latch.Run},
+	{"47-throttle-debounce", "Throttle & Debounce", throttle.Run},
+	{"48-batching", "Batching", batching.Run},
+	{"49-priority-orders", "Priority Orders", priority.Run},
+	{"50-dynamic-select", "Dynamic Select", dynselect.Run},
+	{"51-elastic-pool", "Elastic Worker Pool", elastic.Run},
+	{"52-pause-resume", "Pause & Resume", pauseresume.Run},
+	{"53-scheduled-orders", "Scheduled Orders", scheduled.Run},
+	{"54-tickers", "Tickers", tickers.Run},
+	{"55-timer-reuse", "Timer Reuse", timers.Run},
+	{"56-cancel-orders", "Cancelling Orders", cancelorders.Run},
+	{"57-order-timeouts", "Per-Order Timeouts", timeouts.Run},
+	{"58-error-handling", "Error Handling", errhandling.Run},
+	{"59-watchdog", "Watchdog", watchdog.Run},
+	{"60-round-robin", "Round-Robin Dispatch", roundrobin.Run},
+}
+
+// solo is the lessons run --all skips, with the reason. Each still runs by
+// name.
+var solo = map[string]string{
+	"35-signal-handling": "it sends SIGTERM to its own process",
+}
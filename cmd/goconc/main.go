@@ -0,0 +1,217 @@
+// Command goconc lists the lessons in this repository and runs any of them,
+// or all of them in sequence. Every lesson is compiled in, so it runs from
+// anywhere.
+//
+//	go run ./cmd/goconc list
+//	go run ./cmd/goconc run 02-waitgroups -speed=10
+//	go run ./cmd/goconc run --all -speed=10
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// Lesson is one lesson directory and the Run of the package inside it
+type Lesson struct {
+	Name  string // Directory name, e.g. "04-worker-pools"
+	Title string // First heading of its README
+	Run   lesson.Func
+}
+
+// resolve finds the lesson a name refers to. An exact directory name wins.
+// Otherwise every dash-separated word of name must be a word of the
+// directory name, so "02-waitgroups", "worker-pools" and "60" all work, as
+// long as exactly one lesson matches.
+func resolve(lessons []Lesson, name string) (Lesson, error) {
+	if i := slices.IndexFunc(lessons, func(l Lesson) bool { return l.Name == name }); i >= 0 {
+		return lessons[i], nil
+	}
+	want := strings.Split(strings.ToLower(name), "-")
+	var matches []Lesson
+	for _, l := range lessons {
+		words := strings.Split(l.Name, "-")
+		if !slices.ContainsFunc(want, func(w string) bool { return !slices.Contains(words, w) }) {
+			matches = append(matches, l)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return Lesson{}, fmt.Errorf("no lesson matches %q; see goconc list", name)
+	case 1:
+		return matches[0], nil
+	}
+	names := make([]string, len(matches))
+	for i, l := range matches {
+		names[i] = l.Name
+	}
+	return Lesson{}, fmt.Errorf("%q matches %d lessons: %s", name, len(matches), strings.Join(names, ", "))
+}
+
+// runLesson calls the lesson's Run in this process with opts, exactly as
+// its own main.go does. A panic in the lesson's goroutine becomes an error,
+// so runAll can carry on; one in a goroutine it started still crashes
+// goconc, as it crashes the lesson on its own.
+func runLesson(ctx context.Context, l Lesson, opts lesson.Options) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s: panic: %v", l.Name, r)
+		}
+	}()
+	if err := l.Run(ctx, opts); err != nil {
+		return fmt.Errorf("%s: %w", l.Name, err)
+	}
+	return nil
+}
+
+// runAll runs every lesson in order with a divider and its elapsed time,
+// written to progress. A lesson that fails doesn't stop the rest; the
+// failures are reported at the end. Once ctx is cancelled, no further lesson
+// starts. Every lesson gets opts, which carries -speed, -deterministic and
+// -output but no other arguments. Lessons in solo are skipped, and the
+// skips are listed at the end.
+func runAll(ctx context.Context, lessons []Lesson, opts lesson.Options, progress io.Writer) error {
+	var queued []Lesson
+	var skipped []string
+	for _, l := range lessons {
+		if why := solo[l.Name]; why != "" {
+			skipped = append(skipped, fmt.Sprintf("⏭️  Skipped %s: %s; run it by name", l.Name, why))
+			continue
+		}
+		queued = append(queued, l)
+	}
+	lessons = queued
+
+	startTime := time.Now()
+	var failed []string
+	for i, l := range lessons {
+		fmt.Fprintf(progress, "\n%s\n▶️  [%d/%d] %s", strings.Repeat("━", 60), i+1, len(lessons), l.Name)
+		if l.Title != "" {
+			fmt.Fprintf(progress, " - %s", l.Title)
+		}
+		fmt.Fprintf(progress, "\n%s\n", strings.Repeat("━", 60))
+
+		lessonStart := time.Now()
+		err := runLesson(ctx, l, opts)
+		took := time.Since(lessonStart).Round(100 * time.Millisecond)
+		if err != nil {
+			fmt.Fprintf(progress, "\n❌ %s failed after %v: %v\n", l.Name, took, err)
+			failed = append(failed, l.Name)
+		} else {
+			fmt.Fprintf(progress, "\n⏱️  %s took %v\n", l.Name, took)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+	fmt.Fprintf(progress, "\n🏁 %d lessons in %v, %d failed\n", len(lessons), time.Since(startTime).Round(time.Second), len(failed))
+	for _, line := range skipped {
+		fmt.Fprintln(progress, line)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+func list(lessons []Lesson, stdout io.Writer) {
+	width := 0
+	for _, l := range lessons {
+		width = max(width, len(l.Name))
+	}
+	for _, l := range lessons {
+		fmt.Fprintf(stdout, "%-*s  %s\n", width, l.Name, l.Title)
+	}
+}
+
+const usage = `Usage:
+  goconc list                  List every lesson with its title
+  goconc run <lesson> [args]   Run one lesson, passing args to it
+  goconc run --all             Run every lesson in order
+
+-speed=N anywhere after run makes the lesson, or every lesson, run N times
+faster; printed durations stay nominal. -deterministic, in the same places,
+makes the output the same from run to run. -output=json prints one JSON
+report per run of a lesson that reports results, and nothing else on stdout.
+
+--all skips 35-signal-handling, which sends SIGTERM to its own process;
+run it by name.
+
+A lesson can be named by its directory, or by any of the words in it:
+"02-waitgroups", "worker-pools" and "60" all work if only one lesson matches.
+`
+
+// run parses args and carries out one subcommand
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("goconc", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+
+	switch cmd, rest := fs.Arg(0), fs.Args()[1:]; cmd {
+	case "list":
+		list(lessons, stdout)
+		return nil
+	case "run":
+		opts, err := lesson.Parse(rest)
+		if err != nil {
+			return err
+		}
+		opts.Stdout = stdout
+		runFlags := flag.NewFlagSet("goconc run", flag.ContinueOnError)
+		runFlags.SetOutput(stderr)
+		all := runFlags.Bool("all", false, "run every lesson in order")
+		if err := runFlags.Parse(opts.Args); err != nil {
+			return err
+		}
+		switch {
+		case *all && runFlags.NArg() > 0:
+			return errors.New("run --all takes no lesson")
+		case *all:
+			opts.Args = nil
+			progress := stdout
+			if opts.JSON() {
+				progress = stderr // Keep stdout to one JSON report per line
+			}
+			return runAll(ctx, lessons, opts, progress)
+		case runFlags.NArg() == 0:
+			return errors.New("run needs a lesson name, or --all")
+		}
+		l, err := resolve(lessons, runFlags.Arg(0))
+		if err != nil {
+			return err
+		}
+		opts.Args = runFlags.Args()[1:]
+		return runLesson(ctx, l, opts)
+	default:
+		return fmt.Errorf("unknown command %q; want list or run", cmd)
+	}
+}
+
+func main() {
+	// Ctrl+C stops goconc and the lesson together, as it stops a lesson run on its own
+	if err := run(context.Background(), os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "goconc:", err)
+		}
+		os.Exit(2)
+	}
+}
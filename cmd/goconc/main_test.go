@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+	"github.com/Ajay2521/go-concurrency/pkg/report"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// lessonDirs returns every directory at the repository root whose main.go
+// hands a Run to lesson.Main, sorted by name
+func lessonDirs(t *testing.T) []string {
+	t.Helper()
+	mains, err := filepath.Glob("../../*/main.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dirs []string
+	for _, m := range mains {
+		src, err := os.ReadFile(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Contains(src, []byte("lesson.Main(")) {
+			dirs = append(dirs, filepath.Base(filepath.Dir(m)))
+		}
+	}
+	slices.Sort(dirs)
+	return dirs
+}
+
+func readmeTitle(t *testing.T, dir string) string {
+	t.Helper()
+	f, err := os.Open(filepath.Join("../..", dir, "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if title, ok := strings.CutPrefix(sc.Text(), "# "); ok {
+			return strings.TrimSpace(title)
+		}
+	}
+	return ""
+}
+
+func TestEveryLessonRegistered(t *testing.T) {
+	dirs := lessonDirs(t)
+	names := make([]string, len(lessons))
+	for i, l := range lessons {
+		names[i] = l.Name
+	}
+	if !slices.Equal(names, dirs) {
+		t.Errorf("registered lessons don't match the directories\n got %v\nwant %v", names, dirs)
+	}
+	for _, l := range lessons {
+		if l.Run == nil {
+			t.Errorf("%s has no Run", l.Name)
+		}
+		if want := readmeTitle(t, l.Name); l.Title != want {
+			t.Errorf("%s title = %q, README says %q", l.Name, l.Title, want)
+		}
+	}
+	for name := range solo {
+		if !slices.Contains(names, name) {
+			t.Errorf("solo lesson %s is not registered", name)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	type want struct {
+		name string
+		err  bool
+	}
+	testutil.RunParallel(t, []testutil.TestCase{
+		{Name: "04-worker-pools", Want: want{name: "04-worker-pools"}},
+		{Name: "02-waitgroups", Want: want{name: "02-goroutines-and-waitgroups"}},
+		{Name: "worker-pools", Want: want{name: "04-worker-pools"}},
+		{Name: "60", Want: want{name: "60-round-robin"}},
+		{Name: "45", Want: want{err: true}},
+		{Name: "no-such-lesson", Want: want{err: true}},
+	}, func(t *testing.T, tc testutil.TestCase) {
+		w := tc.Want.(want)
+		l, err := resolve(lessons, tc.Name)
+		switch {
+		case w.err && err == nil:
+			t.Errorf("resolve(%q) = %s, want an error", tc.Name, l.Name)
+		case !w.err && err != nil:
+			t.Errorf("resolve(%q): %v", tc.Name, err)
+		case l.Name != w.name:
+			t.Errorf("resolve(%q) = %s, want %s", tc.Name, l.Name, w.name)
+		}
+	})
+}
+
+func TestList(t *testing.T) {
+	var out bytes.Buffer
+	if err := run(t.Context(), []string{"list"}, &out, &out); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != len(lessons) {
+		t.Fatalf("list printed %d lines for %d lessons", len(lines), len(lessons))
+	}
+	if !strings.HasPrefix(lines[3], "04-worker-pools") || !strings.HasSuffix(lines[3], "Worker Pools") {
+		t.Errorf("line 4 = %q, want 04-worker-pools and its title", lines[3])
+	}
+}
+
+func TestRunRejectsBadSpeed(t *testing.T) {
+	var out bytes.Buffer
+	for _, args := range [][]string{
+		{"run", "01-sequential-synchronous", "-speed=0"},
+		{"run", "-speed=-2", "--all"},
+	} {
+		err := run(t.Context(), args, &out, &out)
+		if code := lesson.ExitCode(err); code != 2 {
+			t.Errorf("run %q = %v, exit code %d, want a usage error", args, err, code)
+		}
+	}
+	if out.Len() != 0 {
+		t.Errorf("a rejected speed ran a lesson:\n%s", out.String())
+	}
+}
+
+// With -output=json, stdout gets one report per lesson that reports results
+// and nothing else; the dividers and timings go to progress
+func TestRunAllJSON(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	var picked []Lesson
+	for _, name := range []string{"01-sequential-synchronous", "02-goroutines-and-waitgroups", "04-worker-pools"} {
+		l, err := resolve(lessons, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		picked = append(picked, l)
+	}
+	opts, err := lesson.Parse([]string{"-output=json"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stdout, progress bytes.Buffer
+	opts.Clock, opts.Stdout = testutil.FakeClock(t), &stdout
+	if err := runAll(t.Context(), picked, opts, &progress); err != nil {
+		t.Fatal(err)
+	}
+
+	runs, err := report.Decode(&stdout)
+	if err != nil || len(runs) != 2 {
+		t.Fatalf("Decode = %d runs, %v; want one each from lessons 02 and 04", len(runs), err)
+	}
+	if runs[0].Lesson != "02-goroutines-and-waitgroups" || runs[1].Lesson != "04-worker-pools" {
+		t.Errorf("reports from %q and %q", runs[0].Lesson, runs[1].Lesson)
+	}
+	if !strings.Contains(progress.String(), "[3/3] 04-worker-pools") || !strings.Contains(progress.String(), "0 failed") {
+		t.Errorf("progress missing dividers or totals:\n%s", progress.String())
+	}
+}
+
+// run --all leaves out the signal lesson, which would SIGTERM the test
+// binary, and says so after the totals
+func TestRunAllSkipsSolo(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	var picked []Lesson
+	for _, name := range []string{"30-state-machine", "35-signal-handling"} {
+		l, err := resolve(lessons, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		picked = append(picked, l)
+	}
+	opts, err := lesson.Parse(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	opts.Clock, opts.Stdout = testutil.FakeClock(t), &out
+	if err := runAll(t.Context(), picked, opts, &out); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"[1/1] 30-state-machine",
+		"🏁 1 lessons in",
+		"⏭️  Skipped 35-signal-handling: it sends SIGTERM to its own process; run it by name",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "Signal Handling") {
+		t.Errorf("the signal lesson ran:\n%s", out.String())
+	}
+}
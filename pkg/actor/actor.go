@@ -0,0 +1,99 @@
+// Package actor confines state to a single goroutine. An Actor handles the
+// messages on its mailbox one at a time, so whatever its handler touches
+// needs no locks; Call adds request/response on top with a reply channel
+// inside the message.
+package actor
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrStopped is returned when sending to an actor that has been stopped
+var ErrStopped = errors.New("actor stopped")
+
+// Actor runs handle for each message on its mailbox, one at a time, in a
+// single goroutine. Any state handle touches is confined to that goroutine,
+// so it needs no locks.
+type Actor[M any] struct {
+	mailbox chan M
+	handle  func(M)
+
+	mu      sync.RWMutex // Guards stopped so Send never hits a closed mailbox
+	stopped bool
+	done    chan struct{} // Closed when the actor goroutine exits
+}
+
+// New starts an actor with a mailbox that buffers up to size messages
+func New[M any](size int, handle func(M)) *Actor[M] {
+	a := &Actor[M]{
+		mailbox: make(chan M, size),
+		handle:  handle,
+		done:    make(chan struct{}),
+	}
+
+	go func() {
+		defer close(a.done)
+		for msg := range a.mailbox {
+			a.handle(msg)
+		}
+	}()
+
+	return a
+}
+
+// Send delivers msg to the mailbox, blocking while it is full. It returns
+// ErrStopped once Stop has been called.
+func (a *Actor[M]) Send(msg M) error {
+	return a.send(context.Background(), msg)
+}
+
+func (a *Actor[M]) send(ctx context.Context, msg M) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.stopped {
+		return ErrStopped
+	}
+
+	select {
+	case a.mailbox <- msg:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stop refuses new messages, lets the actor handle everything already in
+// its mailbox, and returns once the actor goroutine has exited. Calling it
+// again just waits for the same exit. It must not be called from handle.
+func (a *Actor[M]) Stop() {
+	a.mu.Lock()
+	if !a.stopped {
+		a.stopped = true
+		close(a.mailbox)
+	}
+	a.mu.Unlock()
+
+	<-a.done
+}
+
+// Call sends a request built around a fresh reply channel and waits for the
+// answer. The reply channel is buffered, so if ctx expires first the actor
+// can still answer without blocking on a caller who has gone away.
+func Call[M, R any](ctx context.Context, a *Actor[M], build func(reply chan<- R) M) (R, error) {
+	var zero R
+	reply := make(chan R, 1)
+
+	if err := a.send(ctx, build(reply)); err != nil {
+		return zero, err
+	}
+
+	select {
+	case r := <-reply:
+		return r, nil
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
@@ -0,0 +1,201 @@
+package actor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// counter is the confined state: a map with no lock, touched only by handle
+type counter struct {
+	actor  *Actor[counterMsg]
+	counts map[int]int
+}
+
+type counterMsg struct {
+	add   int // Key to increment, when reply is nil
+	get   int // Key to read, when reply is set
+	reply chan<- int
+}
+
+func newCounter(size int) *counter {
+	c := &counter{counts: make(map[int]int)}
+	c.actor = New(size, func(m counterMsg) {
+		if m.reply != nil {
+			m.reply <- c.counts[m.get]
+			return
+		}
+		c.counts[m.add]++
+	})
+	return c
+}
+
+func (c *counter) get(ctx context.Context, key int) (int, error) {
+	return Call(ctx, c.actor, func(reply chan<- int) counterMsg {
+		return counterMsg{get: key, reply: reply}
+	})
+}
+
+func TestCall(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	c := newCounter(4)
+	defer c.actor.Stop()
+
+	c.actor.Send(counterMsg{add: 7})
+	c.actor.Send(counterMsg{add: 7})
+	// A Call is handled after every message sent before it
+	if n, err := c.get(context.Background(), 7); n != 2 || err != nil {
+		t.Errorf("get = %d, %v; want 2, nil", n, err)
+	}
+}
+
+// A Call that outlives its context returns ctx.Err(), and the actor's late
+// answer goes into the buffered reply instead of blocking it for good
+func TestCallTimesOut(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := clock.NewFake(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	release := make(chan struct{})
+	slow := New(1, func(reply chan<- int) {
+		<-release
+		reply <- 42
+	})
+
+	ctx, cancel := fake.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	errc := make(chan error)
+	go func() {
+		_, err := Call(ctx, slow, func(reply chan<- int) chan<- int { return reply })
+		errc <- err
+	}()
+
+	fake.BlockUntil(1)
+	fake.Advance(100 * time.Millisecond)
+	if err := <-errc; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Call = %v, want context.DeadlineExceeded", err)
+	}
+
+	close(release)
+	slow.Stop() // Returns only if the abandoned answer didn't block the actor
+}
+
+// A Call whose context ends while the mailbox is full gives up without sending
+func TestCallTimesOutOnFullMailbox(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	release := make(chan struct{})
+	busy := New(1, func(reply chan<- int) {
+		<-release
+		reply <- 0
+	})
+	busy.Send(make(chan int, 1)) // Being handled
+	busy.Send(make(chan int, 1)) // Fills the mailbox
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Call(ctx, busy, func(reply chan<- int) chan<- int { return reply }); !errors.Is(err, context.Canceled) {
+		t.Errorf("Call = %v, want context.Canceled", err)
+	}
+	close(release)
+	busy.Stop()
+}
+
+func TestStopDrainsQueuedMessages(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	release := make(chan struct{})
+	handled := 0
+	a := New(10, func(int) {
+		<-release
+		handled++ // Confined to the actor goroutine
+	})
+	for i := range 10 {
+		if err := a.Send(i); err != nil {
+			t.Fatalf("Send(%d) = %v", i, err)
+		}
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		a.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		t.Fatal("Stop returned with messages still queued")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	<-stopped
+	if handled != 10 {
+		t.Errorf("handled %d messages before Stop returned, want all 10", handled)
+	}
+	if err := a.Send(11); !errors.Is(err, ErrStopped) {
+		t.Errorf("Send after Stop = %v, want ErrStopped", err)
+	}
+	if _, err := Call(context.Background(), a, func(chan<- int) int { return 12 }); !errors.Is(err, ErrStopped) {
+		t.Errorf("Call after Stop = %v, want ErrStopped", err)
+	}
+	a.Stop() // A second Stop returns at once
+}
+
+// 1000 goroutines send at once while the map has no lock; run with -race
+func TestConcurrentSenders(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	c := newCounter(16)
+	defer c.actor.Stop()
+
+	const senders = 1000
+	var wg sync.WaitGroup
+	for i := range senders {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := c.actor.Send(counterMsg{add: i % 10}); err != nil {
+				t.Errorf("Send = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for key := range 10 {
+		if n, err := c.get(context.Background(), key); n != senders/10 || err != nil {
+			t.Errorf("key %d: get = %d, %v; want %d", key, n, err, senders/10)
+		}
+	}
+}
+
+// Stop racing with senders: every Send either lands and is handled, or is refused
+func TestStopWhileSending(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	var handled int
+	a := New(4, func(int) { handled++ })
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	accepted := 0
+	for i := range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := a.Send(i)
+			if err != nil && !errors.Is(err, ErrStopped) {
+				t.Errorf("Send = %v", err)
+			}
+			if err == nil {
+				mu.Lock()
+				accepted++
+				mu.Unlock()
+			}
+		}()
+	}
+	a.Stop()
+	wg.Wait()
+
+	if handled != accepted {
+		t.Errorf("handled %d of %d accepted messages", handled, accepted)
+	}
+}
@@ -0,0 +1,118 @@
+// Package clock lets code that sleeps, waits and ticks run on either the
+// real clock or a fake one that tests move forward by hand.
+package clock
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Clock is everything order processing needs from time
+type Clock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	// Sleep waits for d, or until ctx is done, whichever is first. It
+	// returns ctx.Err() if ctx ended the wait.
+	Sleep(ctx context.Context, d time.Duration) error
+	After(d time.Duration) <-chan time.Time
+	NewTicker(d time.Duration) Ticker
+	NewTimer(d time.Duration) Timer
+	// AfterFunc calls f in its own goroutine once d has passed. The Timer
+	// it returns has a nil C, as with time.AfterFunc.
+	AfterFunc(d time.Duration, f func()) Timer
+	// WithTimeout is context.WithTimeout with d measured on this clock
+	WithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc)
+}
+
+// Ticker is the part of time.Ticker a Clock can fake
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// Timer is the part of time.Timer a Clock can fake. As with time.Timer
+// since Go 1.23, no stale time is received from C after Stop or Reset.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+// Real returns the Clock backed by package time
+func Real() Clock { return realClock{} }
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                  { return time.Now() }
+func (realClock) Since(t time.Time) time.Duration { return time.Since(t) }
+
+func (realClock) Sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+func (realClock) NewTicker(d time.Duration) Ticker { return realTicker{time.NewTicker(d)} }
+
+func (realClock) NewTimer(d time.Duration) Timer { return realTimer{time.NewTimer(d)} }
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return realTimer{time.AfterFunc(d, f)}
+}
+
+func (realClock) WithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, d)
+}
+
+type realTicker struct{ t *time.Ticker }
+
+func (r realTicker) C() <-chan time.Time { return r.t.C }
+func (r realTicker) Stop()               { r.t.Stop() }
+
+type realTimer struct{ t *time.Timer }
+
+func (r realTimer) C() <-chan time.Time        { return r.t.C }
+func (r realTimer) Stop() bool                 { return r.t.Stop() }
+func (r realTimer) Reset(d time.Duration) bool { return r.t.Reset(d) }
+
+// timeoutCtx is a context whose deadline is on a clock other than the real
+// one. Done comes from the embedded context, which is cancelled with cause
+// DeadlineExceeded when the deadline passes; Err reports that as
+// DeadlineExceeded, as a context from context.WithTimeout would. Value goes
+// to parent, so contexts derived from this one learn of the timeout through
+// Err rather than from the embedded context directly.
+type timeoutCtx struct {
+	context.Context
+	parent   context.Context
+	deadline time.Time
+}
+
+func (c *timeoutCtx) Deadline() (time.Time, bool) { return c.deadline, true }
+func (c *timeoutCtx) Value(key any) any           { return c.parent.Value(key) }
+
+func (c *timeoutCtx) Err() error {
+	err := c.Context.Err()
+	if err != nil && errors.Is(context.Cause(c.Context), context.DeadlineExceeded) {
+		return context.DeadlineExceeded
+	}
+	return err
+}
+
+// withTimeout times ctx out when timer fires, where timer is made by
+// newTimer on whichever clock the deadline is on
+func withTimeout(ctx context.Context, deadline time.Time, newTimer func(f func()) Timer) (context.Context, context.CancelFunc) {
+	inner, cancel := context.WithCancelCause(ctx)
+	t := newTimer(func() { cancel(context.DeadlineExceeded) })
+	return &timeoutCtx{Context: inner, parent: ctx, deadline: deadline}, func() {
+		t.Stop()
+		cancel(context.Canceled)
+	}
+}
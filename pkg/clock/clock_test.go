@@ -0,0 +1,327 @@
+package clock
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+var epoch = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func TestFakeSleepersWakeInDeadlineOrder(t *testing.T) {
+	c := NewFake(epoch)
+	done := make(chan int, 3)
+	for id, prep := range []time.Duration{3 * time.Second, time.Second, 2 * time.Second} {
+		go func() {
+			c.Sleep(context.Background(), prep)
+			done <- id
+		}()
+	}
+
+	c.BlockUntil(3)
+	var got []int
+	for range 3 {
+		c.Advance(time.Second)
+		got = append(got, <-done)
+	}
+	if !slices.Equal(got, []int{1, 2, 0}) {
+		t.Errorf("woke in order %v, want [1 2 0]", got)
+	}
+	if n := c.Waiters(); n != 0 {
+		t.Errorf("%d waiters left", n)
+	}
+}
+
+func TestFakeAdvancePastSeveralDeadlines(t *testing.T) {
+	c := NewFake(epoch)
+	var mu sync.Mutex
+	var woke []time.Duration
+	var wg sync.WaitGroup
+	for _, d := range []time.Duration{300 * time.Millisecond, 100 * time.Millisecond, 200 * time.Millisecond, 5 * time.Second} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Sleep(context.Background(), d)
+			mu.Lock()
+			woke = append(woke, d)
+			mu.Unlock()
+		}()
+	}
+
+	c.BlockUntil(4)
+	c.Advance(time.Second) // Past three of the four deadlines at once
+	for c.Waiters() != 1 {
+		time.Sleep(time.Millisecond)
+	}
+	if got := c.Since(epoch); got != time.Second {
+		t.Errorf("Since(start) = %v after Advance(1s)", got)
+	}
+	c.Advance(4 * time.Second)
+	wg.Wait()
+	slices.Sort(woke)
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 5 * time.Second}
+	if !slices.Equal(woke, want) {
+		t.Errorf("woke %v, want %v", woke, want)
+	}
+}
+
+func TestFakeSleepCancelled(t *testing.T) {
+	c := NewFake(epoch)
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error)
+	go func() { errc <- c.Sleep(ctx, time.Hour) }()
+
+	c.BlockUntil(1)
+	cancel()
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Errorf("Sleep = %v, want context.Canceled", err)
+	}
+	if n := c.Waiters(); n != 0 {
+		t.Errorf("cancelled sleep left %d waiters", n)
+	}
+}
+
+func TestFakeSleepNonPositive(t *testing.T) {
+	c := NewFake(epoch)
+	if err := c.Sleep(context.Background(), 0); err != nil {
+		t.Errorf("Sleep(0) = %v", err)
+	}
+	select {
+	case <-c.After(-time.Second):
+	default:
+		t.Error("After(-1s) didn't fire at once")
+	}
+}
+
+func TestFakeTicker(t *testing.T) {
+	c := NewFake(epoch)
+	tk := c.NewTicker(100 * time.Millisecond)
+	defer tk.Stop()
+
+	c.Advance(100 * time.Millisecond)
+	if got := <-tk.C(); !got.Equal(epoch.Add(100 * time.Millisecond)) {
+		t.Errorf("first tick at %v", got.Sub(epoch))
+	}
+
+	// Nobody reads during five periods: like time.Ticker, one tick is kept
+	c.Advance(500 * time.Millisecond)
+	<-tk.C()
+	select {
+	case got := <-tk.C():
+		t.Errorf("extra tick at %v", got.Sub(epoch))
+	default:
+	}
+
+	tk.Stop()
+	c.Advance(time.Second)
+	select {
+	case <-tk.C():
+		t.Error("tick after Stop")
+	default:
+	}
+	if n := c.Waiters(); n != 0 {
+		t.Errorf("stopped ticker left %d waiters", n)
+	}
+}
+
+func TestFakeTimerStopAndReset(t *testing.T) {
+	c := NewFake(epoch)
+	tm := c.NewTimer(time.Second)
+	if !tm.Stop() {
+		t.Error("Stop on a pending timer = false")
+	}
+	if tm.Stop() {
+		t.Error("second Stop = true")
+	}
+
+	if tm.Reset(2 * time.Second) {
+		t.Error("Reset of a stopped timer = true")
+	}
+	c.Advance(time.Second)
+	select {
+	case <-tm.C():
+		t.Fatal("fired a second early")
+	default:
+	}
+	c.Advance(time.Second)
+	if got := <-tm.C(); !got.Equal(epoch.Add(2 * time.Second)) {
+		t.Errorf("fired at %v, want 2s", got.Sub(epoch))
+	}
+
+	// Fired but not received: Reset drops the stale time
+	tm.Reset(time.Second)
+	c.Advance(time.Second)
+	tm.Reset(time.Second)
+	select {
+	case <-tm.C():
+		t.Error("stale time received after Reset")
+	default:
+	}
+}
+
+func TestFakeAfterFunc(t *testing.T) {
+	c := NewFake(epoch)
+	called := make(chan time.Time, 1)
+	tm := c.AfterFunc(time.Second, func() { called <- c.Now() })
+	if tm.C() != nil {
+		t.Error("AfterFunc timer has a channel")
+	}
+	c.Advance(time.Second)
+	if got := <-called; !got.Equal(epoch.Add(time.Second)) {
+		t.Errorf("called at %v", got.Sub(epoch))
+	}
+
+	stopped := c.AfterFunc(time.Second, func() { t.Error("stopped AfterFunc called") })
+	stopped.Stop()
+	c.Advance(time.Hour)
+}
+
+func TestFakeWithTimeout(t *testing.T) {
+	c := NewFake(epoch)
+	ctx, cancel := c.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	child, cancelChild := context.WithCancel(ctx)
+	defer cancelChild()
+
+	if d, ok := ctx.Deadline(); !ok || !d.Equal(epoch.Add(time.Second)) {
+		t.Errorf("Deadline() = %v, %v", d, ok)
+	}
+	c.Advance(999 * time.Millisecond)
+	if err := ctx.Err(); err != nil {
+		t.Fatalf("done early: %v", err)
+	}
+	c.Advance(time.Millisecond)
+	<-ctx.Done()
+	<-child.Done()
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Errorf("Err() = %v, want DeadlineExceeded", ctx.Err())
+	}
+	if !errors.Is(child.Err(), context.DeadlineExceeded) {
+		t.Errorf("child Err() = %v, want DeadlineExceeded", child.Err())
+	}
+
+	ctx, cancel = c.WithTimeout(context.Background(), time.Second)
+	cancel()
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("cancelled Err() = %v, want Canceled", ctx.Err())
+	}
+	if n := c.Waiters(); n != 0 {
+		t.Errorf("cancel left %d waiters", n)
+	}
+}
+
+func TestFakeAdvanceWhenIdle(t *testing.T) {
+	c := NewFake(epoch)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go c.AdvanceWhenIdle(ctx, time.Millisecond)
+
+	// Three cooks in parallel, then one after them: wall time is the
+	// longest of the three plus the last
+	var wg sync.WaitGroup
+	for _, d := range []time.Duration{time.Second, 3 * time.Second, 2 * time.Second} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Sleep(context.Background(), d)
+		}()
+	}
+	wg.Wait()
+	c.Sleep(context.Background(), time.Second)
+	if got := c.Since(epoch); got != 4*time.Second {
+		t.Errorf("elapsed %v, want 4s", got)
+	}
+}
+
+// A context deadline cancels from an AfterFunc goroutine. The clock must not
+// move on to the next deadline while that callback is still running, or a
+// select on ctx.Done and a later After would see both ready.
+func TestFakeAdvanceWhenIdleWaitsForAfterFunc(t *testing.T) {
+	c := NewFake(epoch)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	release := make(chan struct{})
+	c.AfterFunc(time.Second, func() { <-release })
+	later := c.After(2 * time.Second)
+	go c.AdvanceWhenIdle(ctx, time.Millisecond)
+
+	time.Sleep(20 * time.Millisecond) // Many settle periods with the callback blocked
+	if got := c.Since(epoch); got != time.Second {
+		t.Errorf("clock at %v while the 1s callback ran, want 1s", got)
+	}
+	close(release)
+	<-later
+	if got := c.Since(epoch); got != 2*time.Second {
+		t.Errorf("clock at %v after the callback returned, want 2s", got)
+	}
+}
+
+func TestScaled(t *testing.T) {
+	base := NewFake(epoch)
+	c := Scaled(base, 10)
+	if got := c.Scale(2 * time.Second); got != 200*time.Millisecond {
+		t.Errorf("Scale(2s) = %v, want 200ms", got)
+	}
+
+	start := c.Now()
+	done := make(chan struct{})
+	go func() {
+		c.Sleep(context.Background(), 2*time.Second)
+		close(done)
+	}()
+	base.BlockUntil(1)
+	base.Advance(200 * time.Millisecond)
+	<-done
+	if got := c.Since(start); got != 2*time.Second {
+		t.Errorf("a 2s sleep at 10x measured %v, want the nominal 2s", got)
+	}
+	if got := base.Since(epoch); got != 200*time.Millisecond {
+		t.Errorf("base clock moved %v, want 200ms", got)
+	}
+
+	slow := Scaled(NewFake(epoch), 0.5)
+	if got := slow.Scale(time.Second); got != 2*time.Second {
+		t.Errorf("Scale(1s) at 0.5x = %v, want 2s", got)
+	}
+}
+
+func TestScaledWithTimeout(t *testing.T) {
+	base := NewFake(epoch)
+	c := Scaled(base, 4)
+	ctx, cancel := c.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if d, _ := ctx.Deadline(); !d.Equal(c.Now().Add(time.Second)) {
+		t.Errorf("Deadline is %v from now, want the nominal 1s", d.Sub(c.Now()))
+	}
+	base.Advance(250 * time.Millisecond)
+	<-ctx.Done()
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Errorf("Err() = %v, want DeadlineExceeded", ctx.Err())
+	}
+}
+
+func TestScaledRejectsBadSpeed(t *testing.T) {
+	for _, speed := range []float64{0, -1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Scaled(%v) didn't panic", speed)
+				}
+			}()
+			Scaled(Real(), speed)
+		}()
+	}
+}
+
+func TestRealWithTimeout(t *testing.T) {
+	ctx, cancel := Real().WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Errorf("Err() = %v, want DeadlineExceeded", ctx.Err())
+	}
+}
@@ -0,0 +1,284 @@
+package clock
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// FakeClock only moves when Advance is called. Sleepers, After channels and
+// tickers whose deadline Advance passes are released in deadline order, ties
+// in the order they started waiting, so a test sees the same sequence every
+// run. Nothing ever waits on the real clock.
+type FakeClock struct {
+	mu      sync.Mutex
+	changed *sync.Cond // Broadcast whenever waiters are added or removed
+	now     time.Time
+	waiters []*waiter // Sorted by at, then seq
+	seq     int
+	version int // Bumped on every change to waiters, for AdvanceWhenIdle
+	running int // AfterFunc callbacks that have fired and not yet returned
+}
+
+// waiter is one pending sleep, After channel, ticker, timer or AfterFunc
+type waiter struct {
+	at     time.Time
+	seq    int
+	ch     chan time.Time // Buffered: firing never blocks Advance
+	period time.Duration  // Non-zero for tickers
+	fn     func()         // Set for AfterFunc, which calls it instead of sending on ch
+}
+
+// NewFake returns a fake clock that reads start until advanced
+func NewFake(start time.Time) *FakeClock {
+	c := &FakeClock{now: start}
+	c.changed = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) Since(t time.Time) time.Duration { return c.Now().Sub(t) }
+
+// Sleep blocks until Advance moves the clock d past the time of the call, or
+// ctx is done
+func (c *FakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	w := c.add(d, 0, nil)
+	select {
+	case <-w.ch:
+		return nil
+	case <-ctx.Done():
+		c.remove(w)
+		return ctx.Err()
+	}
+}
+
+// After returns a channel that receives the fake time once Advance moves the
+// clock d past now
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	return c.add(d, 0, nil).ch
+}
+
+// NewTicker returns a ticker that ticks every d of fake time. Like
+// time.Ticker it drops ticks the reader hasn't kept up with, and d must be
+// positive.
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+	return &fakeTicker{clock: c, w: c.add(d, d, nil)}
+}
+
+type fakeTicker struct {
+	clock *FakeClock
+	w     *waiter
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.w.ch }
+func (t *fakeTicker) Stop()               { t.clock.remove(t.w) }
+
+// NewTimer returns a timer that fires once Advance moves the clock d past now
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	return &fakeTimer{clock: c, w: c.add(d, 0, nil)}
+}
+
+// AfterFunc calls f in its own goroutine once Advance moves the clock d past
+// now. The timer's C is nil.
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	return &fakeTimer{clock: c, w: c.add(d, 0, f)}
+}
+
+type fakeTimer struct {
+	clock *FakeClock
+	w     *waiter
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	if t.w.fn != nil {
+		return nil
+	}
+	return t.w.ch
+}
+
+// Stop reports whether it stopped the timer before it fired
+func (t *fakeTimer) Stop() bool {
+	stopped := t.clock.remove(t.w)
+	t.drain()
+	return stopped
+}
+
+// Reset reports whether the timer was still pending
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	pending := c.removeLocked(t.w)
+	t.drain()
+	t.w.at = c.now.Add(d)
+	c.seq++
+	t.w.seq = c.seq
+	c.insert(t.w)
+	c.version++
+	c.changed.Broadcast()
+	return pending
+}
+
+// drain drops a time fired but not yet received
+func (t *fakeTimer) drain() {
+	select {
+	case <-t.w.ch:
+	default:
+	}
+}
+
+// WithTimeout returns a context that is done with DeadlineExceeded once
+// Advance moves the clock d past now
+func (c *FakeClock) WithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, c.Now().Add(d), func(f func()) Timer { return c.AfterFunc(d, f) })
+}
+
+// Advance moves the clock forward by d, releasing every waiter whose
+// deadline it reaches. A ticker fires once for each period that passes, as
+// long as its channel has room.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.advanceTo(c.now.Add(d))
+}
+
+// advanceTo is Advance to an absolute time; c.mu must be held
+func (c *FakeClock) advanceTo(target time.Time) {
+	for len(c.waiters) > 0 && !c.waiters[0].at.After(target) {
+		w := c.waiters[0]
+		c.waiters = c.waiters[1:]
+		c.now = w.at
+		c.fire(w)
+		if w.period > 0 {
+			w.at = w.at.Add(w.period)
+			c.insert(w)
+		}
+	}
+	c.now = target
+	c.version++
+	c.changed.Broadcast()
+}
+
+// fire releases one waiter at c.now
+func (c *FakeClock) fire(w *waiter) {
+	if w.fn != nil {
+		c.running++
+		go func() {
+			w.fn()
+			c.mu.Lock()
+			c.running--
+			c.version++
+			c.mu.Unlock()
+		}()
+		return
+	}
+	select {
+	case w.ch <- c.now:
+	default: // A ticker nobody is reading; drop the tick
+	}
+}
+
+// AdvanceWhenIdle does what a test does with BlockUntil and Advance, for
+// code that can't say how many waiters to expect, such as a whole lesson
+// replayed on a fake clock. Whenever the pending waiters have stayed the
+// same for settle of real time, and no AfterFunc callback is still running,
+// it moves the clock to the earliest deadline among them. It returns when
+// ctx is done.
+//
+// settle has to be longer than any goroutine takes to get from waking to
+// its next wait, or the clock moves on without it.
+func (c *FakeClock) AdvanceWhenIdle(ctx context.Context, settle time.Duration) {
+	t := time.NewTicker(settle)
+	defer t.Stop()
+	c.mu.Lock()
+	seen := c.version
+	c.mu.Unlock()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+		c.mu.Lock()
+		if c.version == seen && len(c.waiters) > 0 && c.running == 0 {
+			c.advanceTo(c.waiters[0].at)
+		}
+		seen = c.version
+		c.mu.Unlock()
+	}
+}
+
+// Waiters returns how many sleeps, After channels and tickers are pending
+func (c *FakeClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+// BlockUntil waits until at least n waiters are pending. Call it before
+// Advance so the goroutines under test have started waiting; otherwise
+// Advance may run before they sleep and release nothing.
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.waiters) < n {
+		c.changed.Wait()
+	}
+}
+
+// add registers a waiter d from now. A non-positive d fires at once.
+func (c *FakeClock) add(d, period time.Duration, fn func()) *waiter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w := &waiter{at: c.now.Add(d), ch: make(chan time.Time, 1), period: period, fn: fn}
+	if d <= 0 && period == 0 {
+		c.fire(w)
+		return w
+	}
+	c.seq++
+	w.seq = c.seq
+	c.insert(w)
+	c.version++
+	c.changed.Broadcast()
+	return w
+}
+
+// insert keeps waiters sorted; c.mu must be held
+func (c *FakeClock) insert(w *waiter) {
+	i, _ := slices.BinarySearchFunc(c.waiters, w, func(a, b *waiter) int {
+		if cmp := a.at.Compare(b.at); cmp != 0 {
+			return cmp
+		}
+		return a.seq - b.seq
+	})
+	c.waiters = slices.Insert(c.waiters, i, w)
+}
+
+// remove drops a waiter that gave up or was stopped, and reports whether it
+// was still pending
+func (c *FakeClock) remove(w *waiter) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.removeLocked(w)
+}
+
+// removeLocked is remove with c.mu already held
+func (c *FakeClock) removeLocked(w *waiter) bool {
+	i := slices.Index(c.waiters, w)
+	if i < 0 {
+		return false
+	}
+	c.waiters = slices.Delete(c.waiters, i, i+1)
+	c.version++
+	c.changed.Broadcast()
+	return true
+}
@@ -0,0 +1,73 @@
+package clock
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// ScaledClock runs speed times faster than the clock it wraps. Every wait
+// takes 1/speed as long on the base clock, and Now scales the time since the
+// clock was made back up by speed, so a duration measured with it comes out
+// at its nominal length: a 2s sleep at speed 10 waits 200ms and Since says
+// 2s. A speed below 1 slows everything down.
+//
+// Times received from After, tickers and timers are the base clock's, so
+// measure with Now and Since rather than with them.
+type ScaledClock struct {
+	base   Clock
+	speed  float64
+	origin time.Time
+}
+
+// Scaled returns base sped up by speed, which must be positive and finite
+func Scaled(base Clock, speed float64) *ScaledClock {
+	if !(speed > 0) || math.IsInf(speed, 1) {
+		panic("clock: speed must be positive and finite")
+	}
+	return &ScaledClock{base: base, speed: speed, origin: base.Now()}
+}
+
+// Speed is how many times faster than its base c runs
+func (c *ScaledClock) Speed() float64 { return c.speed }
+
+// Scale converts a nominal duration to how long it takes on the base clock
+func (c *ScaledClock) Scale(d time.Duration) time.Duration {
+	return time.Duration(float64(d) / c.speed)
+}
+
+func (c *ScaledClock) Now() time.Time {
+	return c.origin.Add(time.Duration(float64(c.base.Now().Sub(c.origin)) * c.speed))
+}
+
+func (c *ScaledClock) Since(t time.Time) time.Duration { return c.Now().Sub(t) }
+
+func (c *ScaledClock) Sleep(ctx context.Context, d time.Duration) error {
+	return c.base.Sleep(ctx, c.Scale(d))
+}
+
+func (c *ScaledClock) After(d time.Duration) <-chan time.Time { return c.base.After(c.Scale(d)) }
+
+func (c *ScaledClock) NewTicker(d time.Duration) Ticker { return c.base.NewTicker(c.Scale(d)) }
+
+func (c *ScaledClock) NewTimer(d time.Duration) Timer {
+	return scaledTimer{Timer: c.base.NewTimer(c.Scale(d)), clock: c}
+}
+
+func (c *ScaledClock) AfterFunc(d time.Duration, f func()) Timer {
+	return scaledTimer{Timer: c.base.AfterFunc(c.Scale(d), f), clock: c}
+}
+
+// WithTimeout times out after d/speed on the base clock, and reports the
+// nominal deadline from Deadline so it can be compared with Now
+func (c *ScaledClock) WithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, c.Now().Add(d), func(f func()) Timer { return c.base.AfterFunc(c.Scale(d), f) })
+}
+
+// scaledTimer scales the duration given to Reset too
+type scaledTimer struct {
+	Timer
+	clock *ScaledClock
+}
+
+func (t scaledTimer) Reset(d time.Duration) bool { return t.Timer.Reset(t.clock.Scale(d)) }
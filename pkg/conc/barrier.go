@@ -0,0 +1,89 @@
+package conc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrBroken is returned to every waiter of a generation that was abandoned
+// because one participant's context ended before everyone arrived
+var ErrBroken = errors.New("barrier broken")
+
+// Barrier blocks participants until n of them have called Wait, then releases
+// them all together and resets for the next round (a new "generation").
+//
+// Calls are counted per generation: if more than n goroutines call Wait, the
+// first n are released together and the extra callers form the next
+// generation, waiting for it to fill up.
+type Barrier struct {
+	mu      sync.Mutex
+	n       int
+	arrived int
+	gen     *generation
+}
+
+// generation is one round of the barrier. done is closed when the round is
+// released; err is set first if the round was broken.
+type generation struct {
+	done chan struct{}
+	err  error
+}
+
+// NewBarrier creates a barrier for n participants
+func NewBarrier(n int) *Barrier {
+	if n < 1 {
+		panic("conc: barrier needs at least one participant")
+	}
+	return &Barrier{n: n, gen: &generation{done: make(chan struct{})}}
+}
+
+// Wait blocks until n participants have arrived or ctx ends. If any waiter's
+// ctx ends first, the whole generation is broken: every current waiter,
+// including the one that left, gets an error wrapping ErrBroken and the
+// context's error. The barrier then starts a fresh generation.
+func (b *Barrier) Wait(ctx context.Context) error {
+	b.mu.Lock()
+	if err := ctx.Err(); err != nil {
+		b.mu.Unlock()
+		return err // Never arrived, so nobody else is affected
+	}
+
+	g := b.gen
+	b.arrived++
+	if b.arrived == b.n {
+		b.next() // Last one in releases everyone
+		b.mu.Unlock()
+		return nil
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-g.done:
+		return g.err
+	case <-ctx.Done():
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if b.gen == g { // Still waiting: break this generation for everyone
+			g.err = fmt.Errorf("%w: participant left: %w", ErrBroken, ctx.Err())
+			b.next()
+		}
+		// If the generation tripped while we were cancelled, g.err says how
+		return g.err
+	}
+}
+
+// Await is Wait with no context: it blocks until all n participants have
+// arrived, however long that takes. Use it only when every participant is
+// sure to arrive; a group that can lose one should use Wait.
+func (b *Barrier) Await() {
+	b.Wait(context.Background()) // Can't fail: a background context never ends
+}
+
+// next releases the current generation and starts a new one. b.mu must be held.
+func (b *Barrier) next() {
+	close(b.gen.done)
+	b.gen = &generation{done: make(chan struct{})}
+	b.arrived = 0
+}
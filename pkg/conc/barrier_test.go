@@ -0,0 +1,176 @@
+package conc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// waiting reports how many participants have arrived in the current generation
+func (b *Barrier) waiting() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.arrived
+}
+
+func waitForArrivals(b *Barrier, n int) {
+	for b.waiting() < n {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestBarrierReleasesTogether(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	b := NewBarrier(3)
+	var released atomic.Int64
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b.Await()
+			released.Add(1)
+		}()
+	}
+	waitForArrivals(b, 2)
+	if n := released.Load(); n != 0 {
+		t.Fatalf("%d released with 2 of 3 arrived", n)
+	}
+
+	if err := b.Wait(context.Background()); err != nil {
+		t.Errorf("last arrival: %v", err)
+	}
+	wg.Wait()
+	if n := released.Load(); n != 2 {
+		t.Errorf("%d of 2 waiters released", n)
+	}
+}
+
+// The barrier resets after every generation, so the same participants can
+// meet at it round after round without anyone running a round ahead
+func TestBarrierReuse(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	const participants, rounds = 5, 20
+	b := NewBarrier(participants)
+	var mu sync.Mutex
+	round := make([]int, participants)
+
+	var wg sync.WaitGroup
+	for p := range participants {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for r := range rounds {
+				mu.Lock()
+				round[p] = r
+				for q, other := range round {
+					if other < r-1 || other > r+1 {
+						t.Errorf("round %d: participant %d is on round %d", r, q, other)
+					}
+				}
+				mu.Unlock()
+				b.Await()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestBarrierCancelBreaksGeneration(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	b := NewBarrier(4)
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 3)
+	for i := range 3 {
+		waitCtx := context.Background()
+		if i == 0 {
+			waitCtx = ctx // One participant gives up; the other two didn't
+		}
+		go func() { errs <- b.Wait(waitCtx) }()
+	}
+	waitForArrivals(b, 3)
+	cancel()
+
+	for range 3 {
+		if err := <-errs; !errors.Is(err, ErrBroken) || !errors.Is(err, context.Canceled) {
+			t.Errorf("Wait = %v, want ErrBroken wrapping context.Canceled", err)
+		}
+	}
+
+	// The next generation starts empty and works
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := b.Wait(context.Background()); err != nil {
+				t.Errorf("next generation: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestBarrierCancelledBeforeArriving(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	b := NewBarrier(2)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := b.Wait(ctx); !errors.Is(err, context.Canceled) || errors.Is(err, ErrBroken) {
+		t.Errorf("Wait with a done context = %v, want context.Canceled alone", err)
+	}
+	if n := b.waiting(); n != 0 {
+		t.Errorf("a caller that never arrived was counted: %d waiting", n)
+	}
+}
+
+// 7 callers at a barrier of 3: two full generations go, and the seventh
+// waits for a third
+func TestBarrierMoreCallersThanN(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	b := NewBarrier(3)
+	var released atomic.Int64
+	for range 7 {
+		go func() {
+			b.Await()
+			released.Add(1)
+		}()
+	}
+	for released.Load() < 6 {
+		time.Sleep(time.Millisecond)
+	}
+	waitForArrivals(b, 1)
+	if n := released.Load(); n != 6 {
+		t.Errorf("%d released, want 6", n)
+	}
+	go b.Await()
+	b.Await() // With the seventh, these two fill the third generation
+	for released.Load() < 7 {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestBarrierOfOne(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	b := NewBarrier(1)
+	for range 3 {
+		if err := b.Wait(context.Background()); err != nil {
+			t.Errorf("Wait = %v", err)
+		}
+	}
+}
+
+func TestNewBarrierRejectsZero(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	defer func() {
+		if recover() == nil {
+			t.Error("NewBarrier(0) didn't panic")
+		}
+	}()
+	NewBarrier(0)
+}
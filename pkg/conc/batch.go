@@ -0,0 +1,77 @@
+package conc
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+)
+
+// Batch groups values from in into slices. A batch is sent as soon as it
+// holds maxSize values, or maxWait after its first value arrived, timed on
+// clk, whichever comes first. When in closes, any partial batch is sent
+// before the output is closed, so consumers should read until it closes.
+// Cancelling ctx closes the output at once and drops the partial batch.
+// Empty batches are never sent.
+func Batch[T any](ctx context.Context, clk clock.Clock, in <-chan T, maxSize int, maxWait time.Duration) <-chan []T {
+	out := make(chan []T)
+
+	go func() {
+		defer close(out)
+
+		var pending []T
+		var timer clock.Timer
+		var deadline <-chan time.Time // nil until a batch has its first value
+
+		stopTimer := func() {
+			if timer != nil {
+				timer.Stop()
+				timer, deadline = nil, nil
+			}
+		}
+		defer stopTimer()
+
+		// flush sends the pending batch (if any) and resets for the next one.
+		// It reports false if ctx ended before the consumer took the batch.
+		flush := func() bool {
+			stopTimer()
+			if len(pending) == 0 {
+				return true
+			}
+			select {
+			case out <- pending:
+			case <-ctx.Done():
+				return false
+			}
+			pending = nil // A fresh slice: the consumer owns the one just sent
+			return true
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-in:
+				if !ok {
+					flush()
+					return
+				}
+				pending = append(pending, v)
+				if len(pending) == 1 {
+					timer = clk.NewTimer(maxWait) // The clock starts with the first value
+					deadline = timer.C()
+				}
+				if len(pending) == maxSize && !flush() {
+					return
+				}
+			case <-deadline:
+				timer, deadline = nil, nil // Already fired
+				if !flush() {
+					return
+				}
+			}
+		}
+	}()
+
+	return out
+}
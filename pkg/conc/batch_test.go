@@ -0,0 +1,142 @@
+package conc
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+func TestBatch(t *testing.T) {
+	ms := time.Millisecond
+	tests := []struct {
+		name string
+		run  func(s *stream[[]string])
+		want []timed
+	}{
+		{"size-triggered", func(s *stream[[]string]) {
+			s.send("a")
+			s.armed()
+			s.at(10 * ms)
+			s.send("b")
+			s.at(20 * ms)
+			s.send("c") // Full: sent at once, and its timer stopped
+			s.expect()
+			s.send("d")
+			s.armed()
+			s.send("e", "f")
+			s.expect()
+			s.at(3 * time.Second) // Neither stopped timer fires
+			close(s.in)
+			s.expect()
+		}, []timed{{20 * ms, "[a b c]"}, {20 * ms, "[d e f]"}, {3 * time.Second, "closed"}}},
+
+		{"time-triggered", func(s *stream[[]string]) {
+			s.send("a") // The clock starts with the first value
+			s.armed()
+			s.at(300 * ms)
+			s.send("b") // Doesn't restart it
+			s.at(time.Second)
+			s.expect()
+			s.at(1500 * ms)
+			s.send("c")
+			s.armed()
+			s.at(2500 * ms)
+			s.expect()
+			s.at(3 * time.Second) // Nothing pending: no empty batch
+			close(s.in)
+			s.expect()
+		}, []timed{{time.Second, "[a b]"}, {2500 * ms, "[c]"}, {3 * time.Second, "closed"}}},
+
+		{"close with a partial batch", func(s *stream[[]string]) {
+			s.send("a")
+			s.armed()
+			s.send("b", "c")
+			s.expect()
+			s.send("d")
+			s.armed()
+			s.at(400 * ms)
+			close(s.in)
+			s.expect()
+			s.expect()
+		}, []timed{{0, "[a b c]"}, {400 * ms, "[d]"}, {400 * ms, "closed"}}},
+
+		{"no values", func(s *stream[[]string]) {
+			s.at(5 * time.Second)
+			close(s.in)
+			s.expect()
+		}, []timed{{5 * time.Second, "closed"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutil.WaitForGoroutines(t)
+			s := newStream(t, func(clk clock.Clock, in <-chan string) <-chan []string {
+				return Batch(context.Background(), clk, in, 3, time.Second)
+			})
+			tt.run(s)
+			if !slices.Equal(s.got, tt.want) {
+				t.Errorf("batches %v, want %v", s.got, tt.want)
+			}
+		})
+	}
+}
+
+// Each batch is a new slice, so a consumer can keep it after reading the next
+func TestBatchHandsOffFreshSlices(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	in := make(chan int)
+	go func() {
+		defer close(in)
+		for i := range 6 {
+			in <- i
+		}
+	}()
+
+	var kept [][]int
+	for b := range Batch(context.Background(), clock.NewFake(epoch), in, 2, time.Second) {
+		kept = append(kept, b)
+	}
+	if want := fmt.Sprint([][]int{{0, 1}, {2, 3}, {4, 5}}); fmt.Sprint(kept) != want {
+		t.Errorf("kept %v, want %v", kept, want)
+	}
+}
+
+func TestBatchCancel(t *testing.T) {
+	t.Run("drops the partial batch", func(t *testing.T) {
+		testutil.WaitForGoroutines(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		in := make(chan string)
+		out := Batch(ctx, clock.NewFake(epoch), in, 3, time.Second) // Time never moves
+
+		in <- "x"
+		cancel()
+		if b, ok := <-out; ok {
+			t.Errorf("got %v after cancel, want the output closed", b)
+		}
+	})
+
+	// A full batch nobody reads doesn't strand the goroutine once ctx ends
+	t.Run("consumer stopped reading", func(t *testing.T) {
+		testutil.WaitForGoroutines(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		in := make(chan string)
+		Batch(ctx, clock.NewFake(epoch), in, 1, time.Second)
+		in <- "nobody reads this"
+		cancel()
+	})
+
+	t.Run("in closed and nobody reads the last batch", func(t *testing.T) {
+		testutil.WaitForGoroutines(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		in := make(chan string)
+		Batch(ctx, clock.NewFake(epoch), in, 3, time.Second)
+		in <- "x"
+		close(in) // The final flush blocks until ctx ends
+		cancel()
+	})
+}
@@ -0,0 +1,138 @@
+package conc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// QueuePolicy decides what BoundedQueue.Put does when the queue is full
+type QueuePolicy int
+
+const (
+	Block      QueuePolicy = iota // Producer waits for space (or for ctx to end)
+	DropNewest                    // Reject the incoming item
+	DropOldest                    // Evict the item that has waited longest, then enqueue
+)
+
+func (p QueuePolicy) String() string {
+	switch p {
+	case Block:
+		return "block"
+	case DropNewest:
+		return "drop-newest"
+	case DropOldest:
+		return "drop-oldest"
+	default:
+		return fmt.Sprintf("QueuePolicy(%d)", int(p))
+	}
+}
+
+var (
+	// ErrDropped is returned by Put under DropNewest when the queue is full
+	ErrDropped = errors.New("queue full, item dropped")
+	// ErrQueueClosed is returned by BoundedQueue.Get and PriorityQueue.Pop
+	// once the queue is closed and drained, and by PriorityQueue.Push after Close
+	ErrQueueClosed = errors.New("queue closed")
+)
+
+// BoundedQueue is a FIFO buffer with a fixed capacity and a policy for when it's full
+type BoundedQueue[T any] struct {
+	items  chan T
+	policy QueuePolicy
+	onDrop func(T)    // Called with each item evicted under DropOldest
+	putMu  sync.Mutex // Makes evict-then-enqueue atomic among producers under DropOldest
+}
+
+// NewBoundedQueue creates a queue holding at most capacity items. It panics
+// if capacity is below 1: with no room, DropOldest would have nothing to
+// evict and Put would spin forever. onDrop may be nil; it is only called
+// under DropOldest.
+func NewBoundedQueue[T any](capacity int, policy QueuePolicy, onDrop func(T)) *BoundedQueue[T] {
+	if capacity < 1 {
+		panic("conc: bounded queue needs a capacity of at least 1")
+	}
+	return &BoundedQueue[T]{
+		items:  make(chan T, capacity),
+		policy: policy,
+		onDrop: onDrop,
+	}
+}
+
+// Put adds item to the queue according to the queue's policy.
+// Block returns ctx.Err() if ctx ends while waiting; DropNewest returns ErrDropped
+// when full; DropOldest always succeeds by evicting the oldest item.
+// Put must not be called after Close.
+func (q *BoundedQueue[T]) Put(ctx context.Context, item T) error {
+	switch q.policy {
+	case DropNewest:
+		select {
+		case q.items <- item:
+			return nil
+		default:
+			return ErrDropped
+		}
+
+	case DropOldest:
+		q.putMu.Lock()
+		defer q.putMu.Unlock()
+		for {
+			select {
+			case q.items <- item:
+				return nil
+			default:
+			}
+			// Full: evict the head. A consumer may have taken it first,
+			// in which case there's now room and the next send succeeds.
+			select {
+			case oldest := <-q.items:
+				if q.onDrop != nil {
+					q.onDrop(oldest)
+				}
+			default:
+			}
+		}
+
+	default: // Block
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		select {
+		case q.items <- item:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Get removes and returns the oldest item, waiting until one is available.
+// It returns ErrQueueClosed once the queue is closed and empty, or ctx.Err() if ctx ends first.
+func (q *BoundedQueue[T]) Get(ctx context.Context) (T, error) {
+	var zero T
+	select {
+	case item, ok := <-q.items:
+		if !ok {
+			return zero, ErrQueueClosed
+		}
+		return item, nil
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+// Len returns the number of items waiting in the queue
+func (q *BoundedQueue[T]) Len() int {
+	return len(q.items)
+}
+
+// Cap returns the maximum number of items the queue can hold
+func (q *BoundedQueue[T]) Cap() int {
+	return cap(q.items)
+}
+
+// Close stops the queue; consumers drain what's left and then get ErrQueueClosed
+func (q *BoundedQueue[T]) Close() {
+	close(q.items)
+}
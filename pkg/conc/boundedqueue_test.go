@@ -0,0 +1,197 @@
+package conc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// fill puts 1..n into q and fails the test on any error
+func fill(t *testing.T, q *BoundedQueue[int], n int) {
+	t.Helper()
+	for i := 1; i <= n; i++ {
+		if err := q.Put(context.Background(), i); err != nil {
+			t.Fatalf("Put(%d) = %v", i, err)
+		}
+	}
+}
+
+// drainQueue closes q and returns everything left in it, in order
+func drainQueue(q *BoundedQueue[int]) []int {
+	q.Close()
+	var items []int
+	for {
+		item, err := q.Get(context.Background())
+		if errors.Is(err, ErrQueueClosed) {
+			return items
+		}
+		items = append(items, item)
+	}
+}
+
+func TestBoundedQueueBlock(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	q := NewBoundedQueue[int](2, Block, nil)
+	fill(t, q, 2)
+
+	put := make(chan error)
+	go func() { put <- q.Put(context.Background(), 3) }()
+	select {
+	case err := <-put:
+		t.Fatalf("Put on a full queue returned %v, want it to wait", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	if item, err := q.Get(context.Background()); item != 1 || err != nil {
+		t.Errorf("Get = %d, %v; want 1", item, err)
+	}
+	if err := <-put; err != nil {
+		t.Errorf("Put after a Get freed a slot = %v", err)
+	}
+	if got := drainQueue(q); !slices.Equal(got, []int{2, 3}) {
+		t.Errorf("left %v, want [2 3]", got)
+	}
+}
+
+func TestBoundedQueueBlockDeadline(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := runningFake(t)
+	q := NewBoundedQueue[int](2, Block, nil)
+	fill(t, q, 2)
+
+	ctx, cancel := fake.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := q.Put(ctx, 3); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Put = %v, want context.DeadlineExceeded", err)
+	}
+	if got := fake.Since(epoch); got != 100*time.Millisecond {
+		t.Errorf("gave up after %v, want 100ms", got)
+	}
+
+	// A context that has already ended is refused even when there is room
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	q.Get(context.Background())
+	if err := q.Put(ctx, 4); !errors.Is(err, context.Canceled) {
+		t.Errorf("Put with a cancelled ctx = %v, want context.Canceled", err)
+	}
+	if got := drainQueue(q); !slices.Equal(got, []int{2}) {
+		t.Errorf("left %v, want [2]", got)
+	}
+}
+
+func TestBoundedQueueDropNewest(t *testing.T) {
+	q := NewBoundedQueue[int](3, DropNewest, func(int) { t.Error("onDrop called under DropNewest") })
+	fill(t, q, 3)
+	for i := 4; i <= 5; i++ {
+		if err := q.Put(context.Background(), i); !errors.Is(err, ErrDropped) {
+			t.Errorf("Put(%d) on a full queue = %v, want ErrDropped", i, err)
+		}
+	}
+	if got := drainQueue(q); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("left %v, want the first three", got)
+	}
+}
+
+func TestBoundedQueueDropOldest(t *testing.T) {
+	var dropped []int
+	q := NewBoundedQueue(3, DropOldest, func(item int) { dropped = append(dropped, item) })
+	fill(t, q, 5)
+	if !slices.Equal(dropped, []int{1, 2}) {
+		t.Errorf("evicted %v, want [1 2]", dropped)
+	}
+	if got := drainQueue(q); !slices.Equal(got, []int{3, 4, 5}) {
+		t.Errorf("left %v, want the last three", got)
+	}
+}
+
+func TestBoundedQueueGet(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	q := NewBoundedQueue[int](1, Block, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.Get(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Get on an empty queue = %v, want context.DeadlineExceeded", err)
+	}
+
+	fill(t, q, 1)
+	q.Close()
+	if item, err := q.Get(context.Background()); item != 1 || err != nil {
+		t.Errorf("Get after Close = %d, %v; want the item still queued", item, err)
+	}
+	if _, err := q.Get(context.Background()); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Get on a closed, empty queue = %v, want ErrQueueClosed", err)
+	}
+}
+
+func TestNewBoundedQueueRejectsNoCapacity(t *testing.T) {
+	for _, capacity := range []int{0, -1} {
+		for _, policy := range []QueuePolicy{Block, DropNewest, DropOldest} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("NewBoundedQueue(%d, %v) didn't panic", capacity, policy)
+					}
+				}()
+				NewBoundedQueue[int](capacity, policy, nil)
+			}()
+		}
+	}
+}
+
+// Under every policy, each item put is either consumed or dropped, never lost
+func TestBoundedQueueConcurrent(t *testing.T) {
+	const producers, perProducer, consumers = 8, 500, 4
+
+	for _, policy := range []QueuePolicy{Block, DropNewest, DropOldest} {
+		t.Run(fmt.Sprint(policy), func(t *testing.T) {
+			testutil.WaitForGoroutines(t)
+			var consumed, dropped atomic.Int64
+			q := NewBoundedQueue(10, policy, func(int) { dropped.Add(1) })
+
+			var consumerWG sync.WaitGroup
+			for range consumers {
+				consumerWG.Add(1)
+				go func() {
+					defer consumerWG.Done()
+					for {
+						if _, err := q.Get(context.Background()); err != nil {
+							return
+						}
+						consumed.Add(1)
+					}
+				}()
+			}
+
+			var producerWG sync.WaitGroup
+			for p := range producers {
+				producerWG.Add(1)
+				go func() {
+					defer producerWG.Done()
+					for i := range perProducer {
+						if err := q.Put(context.Background(), p*perProducer+i); errors.Is(err, ErrDropped) {
+							dropped.Add(1)
+						}
+					}
+				}()
+			}
+			producerWG.Wait()
+			q.Close()
+			consumerWG.Wait()
+
+			if total := consumed.Load() + dropped.Load(); total != producers*perProducer {
+				t.Errorf("consumed %d + dropped %d = %d, want %d", consumed.Load(), dropped.Load(), total, producers*perProducer)
+			}
+			if policy == Block && dropped.Load() != 0 {
+				t.Errorf("Block dropped %d items", dropped.Load())
+			}
+		})
+	}
+}
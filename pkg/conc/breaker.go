@@ -0,0 +1,179 @@
+package conc
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// State is the current position of the circuit breaker
+type State int
+
+const (
+	StateClosed   State = iota // Calls flow through, failures are counted
+	StateOpen                  // Calls fail fast without touching the service
+	StateHalfOpen              // A limited number of probe calls test recovery
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "CLOSED"
+	case StateOpen:
+		return "OPEN"
+	case StateHalfOpen:
+		return "HALF-OPEN"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// ErrCircuitOpen is returned when the breaker rejects a call without running it
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// Settings configures a Breaker
+type Settings struct {
+	FailureThreshold int                  // Consecutive failures that trip the breaker
+	OpenDuration     time.Duration        // How long to fail fast before probing
+	HalfOpenProbes   int                  // Successful probes needed to close again
+	OnStateChange    func(from, to State) // Optional, called with the breaker locked
+	Now              func() time.Time     // Optional clock, defaults to time.Now
+}
+
+// Breaker protects a flaky dependency by failing fast once it keeps failing.
+// It is safe for concurrent use.
+type Breaker struct {
+	settings Settings
+
+	mu             sync.Mutex
+	state          State
+	failures       int       // Consecutive failures while closed
+	openedAt       time.Time // When the breaker last tripped
+	probesInFlight int       // Probe calls currently running while half-open
+	probeSuccesses int       // Successful probes since entering half-open
+	generation     uint64    // Bumped on every state change, so a call can tell it outlived its state
+}
+
+// NewBreaker creates a closed breaker, filling in defaults for zero settings
+func NewBreaker(settings Settings) *Breaker {
+	if settings.FailureThreshold <= 0 {
+		settings.FailureThreshold = 5
+	}
+	if settings.OpenDuration <= 0 {
+		settings.OpenDuration = 5 * time.Second
+	}
+	if settings.HalfOpenProbes <= 0 {
+		settings.HalfOpenProbes = 1
+	}
+	if settings.Now == nil {
+		settings.Now = time.Now
+	}
+	return &Breaker{settings: settings}
+}
+
+// State returns the current state, moving from Open to Half-Open if the open period has elapsed
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refresh()
+	return b.state
+}
+
+// Call runs fn if the breaker allows it and records the outcome.
+// It returns ErrCircuitOpen without calling fn when the breaker is open
+// or when all half-open probe slots are taken.
+func (b *Breaker) Call(fn func() error) error {
+	gen, err := b.before()
+	if err != nil {
+		return err
+	}
+
+	err = fn()
+	b.after(gen, err == nil)
+	return err
+}
+
+// before decides whether a call may proceed, and returns the generation it
+// was let through in
+func (b *Breaker) before() (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refresh()
+
+	switch b.state {
+	case StateOpen:
+		return 0, ErrCircuitOpen
+	case StateHalfOpen:
+		// Only let a limited number of probes test the service at once
+		if b.probesInFlight >= b.settings.HalfOpenProbes-b.probeSuccesses {
+			return 0, ErrCircuitOpen
+		}
+		b.probesInFlight++
+	}
+	return b.generation, nil
+}
+
+// after records the outcome of a call that was let through in generation
+// gen. A call that started before the last state change is ignored: a slow
+// failure from before the breaker tripped mustn't count against a half-open
+// probe, nor free a probe slot it never took.
+func (b *Breaker) after(gen uint64, success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if gen != b.generation {
+		return
+	}
+
+	switch b.state {
+	case StateClosed:
+		if success {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.settings.FailureThreshold {
+			b.trip()
+		}
+
+	case StateHalfOpen:
+		b.probesInFlight--
+		if !success {
+			b.trip() // The service is still broken - back to failing fast
+			return
+		}
+		b.probeSuccesses++
+		if b.probeSuccesses >= b.settings.HalfOpenProbes {
+			b.setState(StateClosed)
+		}
+	}
+}
+
+// refresh moves an expired open breaker to half-open (mu must be held)
+func (b *Breaker) refresh() {
+	if b.state == StateOpen && b.settings.Now().Sub(b.openedAt) >= b.settings.OpenDuration {
+		b.setState(StateHalfOpen)
+	}
+}
+
+// trip opens the breaker (mu must be held)
+func (b *Breaker) trip() {
+	b.openedAt = b.settings.Now()
+	b.setState(StateOpen)
+}
+
+// setState switches state and resets the counters for the new state (mu must be held)
+func (b *Breaker) setState(to State) {
+	from := b.state
+	b.state = to
+	b.generation++
+	b.failures = 0
+	b.probesInFlight = 0
+	b.probeSuccesses = 0
+
+	if from != to && b.settings.OnStateChange != nil {
+		b.settings.OnStateChange(from, to)
+	}
+}
@@ -0,0 +1,231 @@
+package conc
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+var epoch = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// manualClock is a Settings.Now that only moves when told to, and is safe to
+// read from many goroutines
+type manualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *manualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+var errTimeout = errors.New("gateway timeout")
+
+func failing() error { return errTimeout }
+func working() error { return nil }
+
+func TestBreakerTripsAndRecovers(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	clk := &manualClock{now: epoch}
+	b := NewBreaker(Settings{FailureThreshold: 3, OpenDuration: 30 * time.Second, HalfOpenProbes: 1, Now: clk.Now})
+
+	for range 2 {
+		b.Call(failing)
+	}
+	b.Call(working) // A success resets the count
+	for range 2 {
+		b.Call(failing)
+	}
+	if got := b.State(); got != StateClosed {
+		t.Fatalf("after 2 failures in a row: %v, want CLOSED", got)
+	}
+	b.Call(failing)
+	if got := b.State(); got != StateOpen {
+		t.Fatalf("after 3 failures in a row: %v, want OPEN", got)
+	}
+
+	called := false
+	if err := b.Call(func() error { called = true; return nil }); !errors.Is(err, ErrCircuitOpen) || called {
+		t.Errorf("open breaker: err %v, fn called %v; want ErrCircuitOpen without calling fn", err, called)
+	}
+
+	clk.Advance(29 * time.Second)
+	if got := b.State(); got != StateOpen {
+		t.Errorf("1s before the cooldown ends: %v, want OPEN", got)
+	}
+	clk.Advance(time.Second)
+	if got := b.State(); got != StateHalfOpen {
+		t.Errorf("once the cooldown has passed: %v, want HALF-OPEN", got)
+	}
+	if err := b.Call(working); err != nil || b.State() != StateClosed {
+		t.Errorf("successful probe: err %v, state %v; want CLOSED", err, b.State())
+	}
+
+	for range 3 {
+		b.Call(failing)
+	}
+	clk.Advance(30 * time.Second)
+	if err := b.Call(failing); !errors.Is(err, errTimeout) || b.State() != StateOpen {
+		t.Errorf("failed probe: err %v, state %v; want the probe's error and OPEN", err, b.State())
+	}
+}
+
+func TestBreakerLimitsProbes(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	clk := &manualClock{now: epoch}
+	b := NewBreaker(Settings{FailureThreshold: 1, OpenDuration: time.Second, HalfOpenProbes: 2, Now: clk.Now})
+	b.Call(failing)
+	clk.Advance(time.Second)
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b.Call(func() error { started <- struct{}{}; <-release; return nil })
+		}()
+	}
+	<-started
+	<-started
+	if err := b.Call(working); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("third probe while two are out: %v, want ErrCircuitOpen", err)
+	}
+	close(release)
+	wg.Wait()
+	if got := b.State(); got != StateClosed {
+		t.Errorf("after 2 successful probes: %v, want CLOSED", got)
+	}
+}
+
+// A call let through while closed that fails only after the breaker has
+// tripped and gone half-open belongs to a state that is gone. It mustn't
+// re-open the breaker, nor free the probe slot it never took.
+func TestBreakerIgnoresStaleResults(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	clk := &manualClock{now: epoch}
+	var changes []string
+	b := NewBreaker(Settings{
+		FailureThreshold: 2,
+		OpenDuration:     time.Second,
+		HalfOpenProbes:   1,
+		Now:              clk.Now,
+		OnStateChange:    func(from, to State) { changes = append(changes, from.String()+"→"+to.String()) },
+	})
+
+	slow := make(chan struct{})
+	inside := make(chan struct{})
+	stale := make(chan error)
+	go func() {
+		stale <- b.Call(func() error { close(inside); <-slow; return errTimeout })
+	}()
+	<-inside
+
+	b.Call(failing)
+	b.Call(failing)
+	clk.Advance(time.Second)
+	if got := b.State(); got != StateHalfOpen {
+		t.Fatalf("state %v, want HALF-OPEN", got)
+	}
+
+	probe := make(chan struct{})
+	probing := make(chan struct{})
+	probed := make(chan error)
+	go func() {
+		probed <- b.Call(func() error { close(probing); <-probe; return nil })
+	}()
+	<-probing
+
+	close(slow) // The stale failure lands while the probe is out
+	if err := <-stale; !errors.Is(err, errTimeout) {
+		t.Errorf("stale call returned %v, want its own error", err)
+	}
+	if got := b.State(); got != StateHalfOpen {
+		t.Errorf("stale failure moved the breaker to %v, want HALF-OPEN", got)
+	}
+	if err := b.Call(working); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("second probe with one out: %v, want ErrCircuitOpen (the stale call freed a slot)", err)
+	}
+
+	close(probe)
+	if err := <-probed; err != nil {
+		t.Errorf("probe = %v", err)
+	}
+	want := []string{"CLOSED→OPEN", "OPEN→HALF-OPEN", "HALF-OPEN→CLOSED"}
+	if !slices.Equal(changes, want) {
+		t.Errorf("transitions %v, want %v", changes, want)
+	}
+}
+
+// 100 goroutines call through one breaker while the clock moves and the
+// service flaps. Run with -race; the breaker's counters must stay in range
+// whatever order the calls land in.
+func TestBreakerHammer(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	clk := &manualClock{now: epoch}
+	b := NewBreaker(Settings{FailureThreshold: 3, OpenDuration: 10 * time.Millisecond, HalfOpenProbes: 2, Now: clk.Now})
+
+	var broken atomic.Bool
+	var wg sync.WaitGroup
+	var allowed, rejected atomic.Int64
+	for g := range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 200 {
+				if (g+i)%17 == 0 {
+					clk.Advance(time.Millisecond)
+				}
+				if (g+i)%50 == 0 {
+					broken.Store(!broken.Load())
+				}
+				err := b.Call(func() error {
+					if broken.Load() {
+						return errTimeout
+					}
+					return nil
+				})
+				if errors.Is(err, ErrCircuitOpen) {
+					rejected.Add(1)
+				} else {
+					allowed.Add(1)
+				}
+
+				b.mu.Lock()
+				inRange := b.probesInFlight >= 0 && b.probesInFlight <= b.settings.HalfOpenProbes &&
+					b.probeSuccesses >= 0 && b.probeSuccesses < b.settings.HalfOpenProbes &&
+					b.failures >= 0 && b.failures < b.settings.FailureThreshold
+				state := b.state
+				b.mu.Unlock()
+				if !inRange {
+					t.Errorf("counters out of range in %v", state)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if allowed.Load()+rejected.Load() != 100*200 {
+		t.Errorf("%d allowed + %d rejected, want %d calls", allowed.Load(), rejected.Load(), 100*200)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == StateHalfOpen && b.probesInFlight != 0 {
+		t.Errorf("every call returned but %d probes are still in flight", b.probesInFlight)
+	}
+}
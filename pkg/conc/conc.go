@@ -0,0 +1,3 @@
+// Package conc holds the concurrency primitives lessons build from scratch
+// and other code keeps using. Every type in it is safe for concurrent use.
+package conc
@@ -0,0 +1,54 @@
+package conc
+
+import "context"
+
+// ResultOf is the outcome of one scattered call
+type ResultOf[T any] struct {
+	Value    T
+	Err      error
+	TimedOut bool // No response before the gatherer gave up
+}
+
+// Gather calls every fn concurrently and collects whatever finishes before ctx is done.
+// Results are returned in the same order as fns. Calls that haven't responded by the
+// deadline are marked TimedOut and their context is cancelled so they can stop.
+func Gather[T any](ctx context.Context, fns ...func(ctx context.Context) (T, error)) []ResultOf[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() // Cancels stragglers once we stop waiting
+
+	type indexed struct {
+		i int
+		r ResultOf[T]
+	}
+
+	// Buffered so late responders can always send and exit - no goroutine leaks
+	responses := make(chan indexed, len(fns))
+
+	for i, fn := range fns {
+		go func(i int, fn func(ctx context.Context) (T, error)) {
+			v, err := fn(ctx)
+			responses <- indexed{i: i, r: ResultOf[T]{Value: v, Err: err}}
+		}(i, fn)
+	}
+
+	results := make([]ResultOf[T], len(fns))
+	received := make([]bool, len(fns))
+
+	for n := 0; n < len(fns); n++ {
+		select {
+		case resp := <-responses:
+			results[resp.i] = resp.r
+			received[resp.i] = true
+		case <-ctx.Done():
+			// Deadline hit - mark everyone who hasn't answered
+			for i := range results {
+				if !received[i] {
+					results[i] = ResultOf[T]{Err: ctx.Err(), TimedOut: true}
+				}
+			}
+			return results
+		}
+	}
+
+	return results
+}
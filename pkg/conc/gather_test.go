@@ -0,0 +1,121 @@
+package conc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// answerAfter returns a call that answers v after d on clk, or gives up when ctx ends
+func answerAfter(clk clock.Clock, v int, d time.Duration) func(context.Context) (int, error) {
+	return func(ctx context.Context) (int, error) {
+		select {
+		case <-clk.After(d):
+			return v, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+}
+
+func TestGatherKeepsOrder(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := runningFake(t)
+	errDown := errors.New("supplier down")
+
+	// Answers arrive 3, 2, 1; results still line up with the calls
+	results := Gather(context.Background(),
+		answerAfter(fake, 1, 300*time.Millisecond),
+		answerAfter(fake, 2, 200*time.Millisecond),
+		func(context.Context) (int, error) { return 0, errDown },
+	)
+
+	if len(results) != 3 {
+		t.Fatalf("%d results, want 3", len(results))
+	}
+	if r := results[0]; r.Value != 1 || r.Err != nil || r.TimedOut {
+		t.Errorf("results[0] = %+v, want value 1", r)
+	}
+	if r := results[1]; r.Value != 2 || r.Err != nil || r.TimedOut {
+		t.Errorf("results[1] = %+v, want value 2", r)
+	}
+	if r := results[2]; !errors.Is(r.Err, errDown) || r.TimedOut {
+		t.Errorf("results[2] = %+v, want the supplier's own error", r)
+	}
+}
+
+func TestGatherHonoursDeadline(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := runningFake(t)
+	ctx, cancel := fake.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	results := Gather(ctx,
+		answerAfter(fake, 1, 120*time.Millisecond),
+		answerAfter(fake, 2, 250*time.Millisecond),
+		answerAfter(fake, 3, 380*time.Millisecond),
+		answerAfter(fake, 4, 650*time.Millisecond),
+	)
+
+	if got := fake.Since(epoch); got != 300*time.Millisecond {
+		t.Errorf("Gather returned at %v, want at the 300ms deadline", got)
+	}
+	for i, r := range results {
+		inTime := i < 2
+		if inTime && (r.Value != i+1 || r.Err != nil || r.TimedOut) {
+			t.Errorf("results[%d] = %+v, want value %d in time", i, r, i+1)
+		}
+		if !inTime && (!r.TimedOut || !errors.Is(r.Err, context.DeadlineExceeded)) {
+			t.Errorf("results[%d] = %+v, want timed out with DeadlineExceeded", i, r)
+		}
+	}
+}
+
+func TestGatherCallerCancelled(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	block := func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}
+	for i, r := range Gather(ctx, block, block) {
+		if !r.TimedOut || !errors.Is(r.Err, context.Canceled) {
+			t.Errorf("results[%d] = %+v, want timed out with Canceled", i, r)
+		}
+	}
+}
+
+func TestGatherNoCalls(t *testing.T) {
+	if results := Gather[int](context.Background()); len(results) != 0 {
+		t.Errorf("Gather() = %v, want no results", results)
+	}
+}
+
+// Every straggler exits once Gather gives up: those watching ctx at once,
+// and one that ignores ctx as soon as it finishes, since its send can't block
+func TestGatherNoStragglersLeak(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	stubborn := make(chan struct{})
+	calls := []func(context.Context) (int, error){
+		answerAfter(clock.Real(), 1, time.Hour),
+		answerAfter(clock.Real(), 2, time.Hour),
+		func(context.Context) (int, error) {
+			<-stubborn
+			return 3, nil
+		},
+	}
+	for i, r := range Gather(ctx, calls...) {
+		if !r.TimedOut {
+			t.Errorf("results[%d] = %+v, want timed out", i, r)
+		}
+	}
+	close(stubborn) // Answers long after nobody is listening
+}
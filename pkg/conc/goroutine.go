@@ -0,0 +1,25 @@
+package conc
+
+import "runtime/debug"
+
+// Go runs fn in a new goroutine. If fn panics, the panic is recovered and
+// delivered as a *PanicError on the returned channel; otherwise nil is
+// delivered. The channel is buffered, so the goroutine never blocks even if
+// nobody reads the result, and it is closed after the single value.
+func Go(fn func()) <-chan error {
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(errc)
+		defer func() {
+			if r := recover(); r != nil {
+				errc <- &PanicError{Value: r, Stack: debug.Stack()}
+				return
+			}
+			errc <- nil
+		}()
+		fn()
+	}()
+
+	return errc
+}
@@ -0,0 +1,102 @@
+package conc
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+func TestGoReturns(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	ran := false
+	errc := Go(func() { ran = true })
+
+	if err := <-errc; err != nil || !ran {
+		t.Errorf("Go = %v with fn run %v, want nil after running fn", err, ran)
+	}
+	if _, open := <-errc; open {
+		t.Error("the channel wasn't closed after its value")
+	}
+}
+
+func TestGoPanics(t *testing.T) {
+	errOven := errors.New("oven exploded")
+	tests := []struct {
+		name  string
+		fn    func()
+		check func(t *testing.T, pe *PanicError)
+	}{
+		{"panic with a string", func() { panic("burnt the toast") }, func(t *testing.T, pe *PanicError) {
+			if pe.Value != "burnt the toast" {
+				t.Errorf("Value = %v, want the string passed to panic", pe.Value)
+			}
+		}},
+		{"panic with an error", func() { panic(errOven) }, func(t *testing.T, pe *PanicError) {
+			if !errors.Is(pe, errOven) {
+				t.Errorf("%v doesn't unwrap to the error passed to panic", pe)
+			}
+		}},
+		{"runtime error", func() {
+			var m map[string]int
+			m["oops"] = 1
+		}, func(t *testing.T, pe *PanicError) {
+			var re runtime.Error
+			if !errors.As(pe, &re) || !strings.Contains(re.Error(), "nil map") {
+				t.Errorf("Value = %v, want the runtime error for a nil map write", pe.Value)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutil.WaitForGoroutines(t)
+			errc := Go(tt.fn)
+			err := <-errc
+
+			var pe *PanicError
+			if !errors.As(err, &pe) {
+				t.Fatalf("Go = %v, want a *PanicError", err)
+			}
+			tt.check(t, pe)
+			// The stack is the panicking goroutine's, so it names fn
+			if !strings.Contains(string(pe.Stack), "conc.TestGoPanics.func") {
+				t.Errorf("stack doesn't mention the panicking function:\n%s", pe.Stack)
+			}
+			if _, open := <-errc; open {
+				t.Error("the channel wasn't closed after its value")
+			}
+		})
+	}
+}
+
+// The result channel is buffered, so nobody has to read it for the goroutine to exit
+func TestGoUnreadResultDoesNotLeak(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	for range 10 {
+		Go(func() { panic("nobody is listening") })
+		Go(func() {})
+	}
+}
+
+func TestGoManyAtOnce(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	results := make([]<-chan error, 100)
+	for i := range results {
+		results[i] = Go(func() {
+			if i%3 == 0 {
+				panic(i)
+			}
+		})
+	}
+
+	for i, errc := range results {
+		err := <-errc
+		var pe *PanicError
+		if panicked := errors.As(err, &pe); panicked != (i%3 == 0) || (panicked && pe.Value != i) {
+			t.Errorf("goroutine %d: Go = %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package conc
+
+import (
+	"errors"
+	"runtime/debug"
+	"sync"
+)
+
+// call is an in-flight or completed Do call for a single key
+type call[V any] struct {
+	wg   sync.WaitGroup // Released when the leader's fn returns
+	val  V
+	err  error
+	dups int // Number of callers that joined instead of running fn
+}
+
+// Group deduplicates concurrent calls that share the same key.
+// While a call for a key is in flight, every other caller for that key
+// waits for it and receives the same result instead of running fn again.
+// The zero Group is ready to use.
+type Group[K comparable, V any] struct {
+	mu    sync.Mutex     // Guards calls
+	calls map[K]*call[V] // Lazily initialized
+}
+
+// ErrGoexit is the error waiting callers get when the leader's fn calls
+// runtime.Goexit, as t.FailNow does
+var ErrGoexit = errors.New("singleflight: fn called runtime.Goexit")
+
+// Do runs fn once per key at a time and returns its result to every caller.
+// The shared flag reports whether the result was given to more than one caller.
+// If fn panics, waiting callers get a *PanicError and Do panics with it.
+func (g *Group[K, V]) Do(key K, fn func() (V, error)) (V, error, bool) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[K]*call[V])
+	}
+
+	// Someone is already fetching this key - wait for their result
+	if c, ok := g.calls[key]; ok {
+		c.dups++
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.val, c.err, true
+	}
+
+	// We are the leader for this key
+	c := new(call[V])
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	g.doCall(key, c, fn)
+	return c.val, c.err, c.dups > 0
+}
+
+// doCall runs fn for the leader and wakes every waiting duplicate, even if
+// fn panics: a waiter left blocked on wg would hang forever
+func (g *Group[K, V]) doCall(key K, c *call[V], fn func() (V, error)) {
+	returned := false
+	defer func() {
+		var panicked *PanicError
+		if !returned {
+			// recover is nil after runtime.Goexit, which can't be stopped
+			if r := recover(); r != nil {
+				panicked = &PanicError{Value: r, Stack: debug.Stack()}
+				c.err = panicked
+			} else {
+				c.err = ErrGoexit
+			}
+		}
+
+		g.mu.Lock()
+		// Forget may already have removed (or replaced) this entry
+		if g.calls[key] == c {
+			delete(g.calls, key)
+		}
+		g.mu.Unlock()
+		c.wg.Done()
+
+		if panicked != nil {
+			panic(panicked)
+		}
+	}()
+
+	c.val, c.err = fn()
+	returned = true
+}
+
+// Forget drops the in-flight call for key so the next Do starts a fresh one.
+// Callers already waiting on the old call still receive its result.
+func (g *Group[K, V]) Forget(key K) {
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+}
@@ -0,0 +1,232 @@
+package conc
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// waitForDups blocks until n callers have joined the in-flight call for key
+func waitForDups[K comparable, V any](g *Group[K, V], key K, n int) {
+	for {
+		g.mu.Lock()
+		c := g.calls[key]
+		joined := c != nil && c.dups >= n
+		g.mu.Unlock()
+		if joined {
+			return
+		}
+		runtime.Gosched()
+	}
+}
+
+func TestGroupDeduplicates(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	var g Group[string, int]
+	var calls atomic.Int64
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	type result struct {
+		val    int
+		err    error
+		shared bool
+	}
+	results := make(chan result, 50)
+	fn := func() (int, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+		}
+		<-release
+		return 42, nil
+	}
+
+	go func() {
+		v, err, shared := g.Do("margherita", fn)
+		results <- result{v, err, shared}
+	}()
+	<-started
+	for range 49 {
+		go func() {
+			v, err, shared := g.Do("margherita", fn)
+			results <- result{v, err, shared}
+		}()
+	}
+	waitForDups(&g, "margherita", 49)
+	close(release)
+
+	for range 50 {
+		r := <-results
+		if r.val != 42 || r.err != nil || !r.shared {
+			t.Errorf("Do = %d, %v, shared %v; want 42, nil, shared", r.val, r.err, r.shared)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("fn ran %d times for 50 callers, want 1", n)
+	}
+
+	// Once the call is done, the next Do runs fn again
+	if v, _, shared := g.Do("margherita", func() (int, error) { return 7, nil }); v != 7 || shared {
+		t.Errorf("Do after the call finished = %d, shared %v; want a fresh 7", v, shared)
+	}
+}
+
+func TestGroupKeysAreIndependent(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	var g Group[string, string]
+	release := make(chan struct{})
+	started := make(chan struct{})
+	done := make(chan string)
+	go func() {
+		v, _, _ := g.Do("slow", func() (string, error) { close(started); <-release; return "slow", nil })
+		done <- v
+	}()
+	<-started
+
+	if v, _, shared := g.Do("fast", func() (string, error) { return "fast", nil }); v != "fast" || shared {
+		t.Errorf("Do(fast) = %q, shared %v while slow is in flight", v, shared)
+	}
+	close(release)
+	if v := <-done; v != "slow" {
+		t.Errorf("Do(slow) = %q", v)
+	}
+}
+
+func TestGroupSharesErrors(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	var g Group[string, int]
+	errNotFound := errors.New("recipe not found")
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	errs := make(chan error, 10)
+	go func() {
+		_, err, _ := g.Do("hawaiian", func() (int, error) { close(started); <-release; return 0, errNotFound })
+		errs <- err
+	}()
+	<-started
+	for range 9 {
+		go func() {
+			_, err, _ := g.Do("hawaiian", func() (int, error) { t.Error("a waiter ran fn"); return 0, nil })
+			errs <- err
+		}()
+	}
+	waitForDups(&g, "hawaiian", 9)
+	close(release)
+
+	for range 10 {
+		if err := <-errs; !errors.Is(err, errNotFound) {
+			t.Errorf("Do = %v, want the leader's error", err)
+		}
+	}
+}
+
+func TestGroupForget(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	var g Group[string, int]
+	release := make(chan struct{})
+	started := make(chan struct{})
+	first := make(chan int)
+	go func() {
+		v, _, _ := g.Do("pepperoni", func() (int, error) { close(started); <-release; return 1, nil })
+		first <- v
+	}()
+	<-started
+
+	// A waiter that joined before Forget still gets the old call's result
+	joined := make(chan int)
+	go func() {
+		v, _, _ := g.Do("pepperoni", func() (int, error) { t.Error("joined caller ran fn"); return 0, nil })
+		joined <- v
+	}()
+	waitForDups(&g, "pepperoni", 1)
+
+	g.Forget("pepperoni")
+	v, _, shared := g.Do("pepperoni", func() (int, error) { return 2, nil })
+	if v != 2 || shared {
+		t.Errorf("Do after Forget = %d, shared %v; want a fresh 2", v, shared)
+	}
+
+	close(release)
+	if v := <-first; v != 1 {
+		t.Errorf("forgotten leader got %d, want 1", v)
+	}
+	if v := <-joined; v != 1 {
+		t.Errorf("caller that joined before Forget got %d, want 1", v)
+	}
+}
+
+// A panicking fn used to leave every waiter blocked on the call forever.
+// Now waiters get a *PanicError and the leader panics with it.
+func TestGroupPanic(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	var g Group[string, int]
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	leader := make(chan any)
+	go func() {
+		defer func() { leader <- recover() }()
+		g.Do("calzone", func() (int, error) { close(started); <-release; panic("oven on fire") })
+	}()
+	<-started
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err, _ := g.Do("calzone", func() (int, error) { return 0, nil })
+			errs <- err
+		}()
+	}
+	waitForDups(&g, "calzone", 5)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		var pe *PanicError
+		if !errors.As(err, &pe) || pe.Value != "oven on fire" {
+			t.Errorf("waiter got %v, want a *PanicError for the leader's panic", err)
+		}
+	}
+	if pe, ok := (<-leader).(*PanicError); !ok || pe.Value != "oven on fire" || len(pe.Stack) == 0 {
+		t.Errorf("leader panicked with %#v, want a *PanicError with a stack", pe)
+	}
+
+	// The key isn't stuck: the next Do runs fn
+	if v, err, _ := g.Do("calzone", func() (int, error) { return 3, nil }); v != 3 || err != nil {
+		t.Errorf("Do after the panic = %d, %v", v, err)
+	}
+}
+
+func TestGroupGoexit(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	var g Group[string, int]
+	release := make(chan struct{})
+	started := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		g.Do("calzone", func() (int, error) { close(started); <-release; runtime.Goexit(); return 0, nil })
+	}()
+	<-started
+
+	errc := make(chan error)
+	go func() {
+		_, err, _ := g.Do("calzone", func() (int, error) { return 0, nil })
+		errc <- err
+	}()
+	waitForDups(&g, "calzone", 1)
+	close(release)
+	if err := <-errc; !errors.Is(err, ErrGoexit) {
+		t.Errorf("waiter got %v, want ErrGoexit", err)
+	}
+	<-exited
+}
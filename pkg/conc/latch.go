@@ -0,0 +1,68 @@
+package conc
+
+import (
+	"context"
+	"sync"
+)
+
+// Latch is a one-shot countdown. Any number of goroutines can Wait for the
+// count to reach zero, each with its own context, and all are released
+// together when it does. Unlike sync.WaitGroup, waiters can give up, and
+// the count can be inspected.
+//
+// CountDown after the count has reached zero is a no-op: the latch stays
+// open and the count stays at zero.
+type Latch struct {
+	mu    sync.Mutex
+	count int
+	done  chan struct{} // Closed when count reaches zero
+}
+
+// NewLatch creates a latch that opens after count calls to CountDown.
+// A count of zero or less creates a latch that is already open.
+func NewLatch(count int) *Latch {
+	l := &Latch{count: max(count, 0), done: make(chan struct{})}
+	if l.count == 0 {
+		close(l.done)
+	}
+	return l
+}
+
+// CountDown decrements the count, opening the latch when it reaches zero
+func (l *Latch) CountDown() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.count == 0 {
+		return // Already open
+	}
+	l.count--
+	if l.count == 0 {
+		close(l.done)
+	}
+}
+
+// Count returns how many CountDown calls are still needed
+func (l *Latch) Count() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.count
+}
+
+// Wait blocks until the latch opens or ctx ends. It returns nil immediately
+// if the latch is already open, even if ctx is done.
+func (l *Latch) Wait(ctx context.Context) error {
+	// select picks randomly among ready cases, so check the open latch first
+	select {
+	case <-l.done:
+		return nil
+	default:
+	}
+
+	select {
+	case <-l.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
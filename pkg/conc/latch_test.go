@@ -0,0 +1,125 @@
+package conc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+func TestLatchOpensAtZero(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	l := NewLatch(3)
+	released := make(chan error, 2)
+	for range 2 {
+		go func() { released <- l.Wait(context.Background()) }()
+	}
+
+	l.CountDown()
+	l.CountDown()
+	if n := l.Count(); n != 1 {
+		t.Errorf("Count after 2 of 3 = %d, want 1", n)
+	}
+	select {
+	case err := <-released:
+		t.Fatalf("a waiter was released with 1 to go: %v", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	l.CountDown()
+	for range 2 {
+		if err := <-released; err != nil {
+			t.Errorf("Wait = %v", err)
+		}
+	}
+}
+
+func TestLatchCountDownPastZero(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	l := NewLatch(1)
+	l.CountDown()
+	l.CountDown() // Must not panic closing done twice
+	if n := l.Count(); n != 0 {
+		t.Errorf("Count = %d, want 0", n)
+	}
+	if err := l.Wait(context.Background()); err != nil {
+		t.Errorf("Wait on an open latch = %v", err)
+	}
+}
+
+func TestLatchAlreadyOpen(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	for _, count := range []int{0, -3} {
+		l := NewLatch(count)
+		if n := l.Count(); n != 0 {
+			t.Errorf("NewLatch(%d).Count() = %d, want 0", count, n)
+		}
+		if err := l.Wait(context.Background()); err != nil {
+			t.Errorf("NewLatch(%d).Wait = %v", count, err)
+		}
+	}
+}
+
+// An open latch wins over a done context, every time: select alone would
+// pick between them at random
+func TestLatchOpenBeatsDoneContext(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	l := NewLatch(1)
+	l.CountDown()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for range 100 {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatalf("Wait on an open latch with a done context = %v", err)
+		}
+	}
+}
+
+func TestLatchWaitTimesOut(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	l := NewLatch(1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait = %v, want context.DeadlineExceeded", err)
+	}
+
+	// Giving up doesn't affect the latch or other waiters
+	l.CountDown()
+	if err := l.Wait(context.Background()); err != nil {
+		t.Errorf("Wait after the count reached zero = %v", err)
+	}
+}
+
+// Many goroutines count down at once and many wait; run with -race
+func TestLatchConcurrent(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	const n = 200
+	l := NewLatch(n)
+	var waiters sync.WaitGroup
+	for range 50 {
+		waiters.Add(1)
+		go func() {
+			defer waiters.Done()
+			if err := l.Wait(context.Background()); err != nil {
+				t.Errorf("Wait = %v", err)
+			}
+			if c := l.Count(); c != 0 {
+				t.Errorf("released with Count %d", c)
+			}
+		}()
+	}
+	var counters sync.WaitGroup
+	for range n + 20 { // 20 too many: the extras must be no-ops
+		counters.Add(1)
+		go func() {
+			defer counters.Done()
+			l.CountDown()
+		}()
+	}
+	counters.Wait()
+	waiters.Wait()
+}
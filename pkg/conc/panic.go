@@ -0,0 +1,22 @@
+package conc
+
+import "fmt"
+
+// PanicError is a panic recovered in one goroutine and handed to another as
+// an error. Group gives it to the callers waiting on a leader that panicked,
+// Supervise restarts the function that panicked with it, and Go delivers it
+// on its result channel.
+type PanicError struct {
+	Value any    // What was passed to panic
+	Stack []byte // The panicking goroutine's stack, taken in the recover
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v\n\n%s", e.Value, e.Stack)
+}
+
+// Unwrap returns the panic value if it is an error
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
@@ -0,0 +1,113 @@
+package conc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Errors returned by Map
+var (
+	ErrInvalidLimit = errors.New("limit must be greater than zero")
+	ErrPanic        = errors.New("panic in map function")
+)
+
+// mapConfig holds the optional behavior of Map
+type mapConfig struct {
+	collectAll bool
+}
+
+// MapOption customizes Map
+type MapOption func(*mapConfig)
+
+// CollectErrors makes Map process every item and return all errors joined,
+// instead of stopping at the first failure.
+func CollectErrors() MapOption {
+	return func(c *mapConfig) {
+		c.collectAll = true
+	}
+}
+
+// Map applies fn to every item using at most limit goroutines at a time.
+// Results keep the order of items. By default Map stops launching new work
+// after the first error and returns that error; panics in fn are recovered
+// and reported as errors wrapping ErrPanic.
+func Map[T, R any](ctx context.Context, items []T, limit int, fn func(context.Context, T) (R, error), opts ...MapOption) ([]R, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("%w: got %d", ErrInvalidLimit, limit)
+	}
+
+	var cfg mapConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	results := make([]R, len(items))
+	if len(items) == 0 {
+		return results, nil
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, min(limit, len(items))) // Bounds concurrent goroutines
+		errs     = make([]error, len(items))                   // Indexed so each goroutine writes its own slot
+		firstErr error
+		errOnce  sync.Once
+	)
+
+launch:
+	for i, item := range items {
+		select {
+		case sem <- struct{}{}: // Acquire a slot
+		case <-ctx.Done():
+			break launch // First error or caller cancellation - stop launching
+		}
+		if ctx.Err() != nil {
+			<-sem
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, item T) {
+			defer wg.Done()
+			defer func() { <-sem }() // Release the slot
+
+			r, err := safeCall(ctx, fn, item)
+			if err != nil {
+				errs[i] = fmt.Errorf("item %d: %w", i, err)
+				errOnce.Do(func() { firstErr = errs[i] })
+				if !cfg.collectAll {
+					cancel() // Tell in-flight work and the launcher to stop
+				}
+				return
+			}
+			results[i] = r
+		}(i, item)
+	}
+
+	wg.Wait()
+
+	if cfg.collectAll {
+		if err := errors.Join(errs...); err != nil {
+			return results, err
+		}
+	} else if firstErr != nil {
+		return results, firstErr
+	}
+	return results, parent.Err()
+}
+
+// safeCall runs fn and converts a panic into an error
+func safeCall[T, R any](ctx context.Context, fn func(context.Context, T) (R, error), item T) (r R, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("%w: %v", ErrPanic, p)
+		}
+	}()
+	return fn(ctx, item)
+}
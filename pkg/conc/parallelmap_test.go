@@ -0,0 +1,183 @@
+package conc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+func square(_ context.Context, n int) (int, error) { return n * n, nil }
+
+func TestMapEdgeCases(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	tests := []struct {
+		name    string
+		items   []int
+		limit   int
+		want    []int
+		wantErr error
+	}{
+		{"empty input", []int{}, 5, []int{}, nil},
+		{"nil input", nil, 5, []int{}, nil},
+		{"limit larger than input", []int{1, 2, 3}, 100, []int{1, 4, 9}, nil},
+		{"limit of one", []int{1, 2, 3}, 1, []int{1, 4, 9}, nil},
+		{"limit zero", []int{1, 2, 3}, 0, nil, ErrInvalidLimit},
+		{"negative limit", []int{1, 2, 3}, -1, nil, ErrInvalidLimit},
+	}
+
+	for _, tt := range tests {
+		got, err := Map(context.Background(), tt.items, tt.limit, square)
+		if !errors.Is(err, tt.wantErr) || !slices.Equal(got, tt.want) || (got == nil) != (tt.want == nil) {
+			t.Errorf("%s: Map = %v, %v; want %v, %v", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+// Results line up with their items even when later items finish first
+func TestMapKeepsOrder(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	items := []int{0, 1, 2, 3, 4, 5, 6, 7}
+	got, err := Map(context.Background(), items, len(items), func(_ context.Context, n int) (string, error) {
+		time.Sleep(time.Duration(len(items)-n) * 2 * time.Millisecond) // Reverse finishing order
+		return fmt.Sprint("order-", n), nil
+	})
+	if err != nil {
+		t.Fatalf("Map: %v", err)
+	}
+	for i, s := range got {
+		if want := fmt.Sprint("order-", i); s != want {
+			t.Errorf("result %d = %q, want %q", i, s, want)
+		}
+	}
+}
+
+func TestMapBoundsConcurrency(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	const limit = 3
+	var running, peak atomic.Int64
+	_, err := Map(context.Background(), make([]int, 30), limit, func(context.Context, int) (int, error) {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+		return 0, nil
+	})
+	if err != nil {
+		t.Fatalf("Map: %v", err)
+	}
+	if p := peak.Load(); p > limit {
+		t.Errorf("%d calls ran at once, want at most %d", p, limit)
+	}
+}
+
+func TestMapPanic(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	_, err := Map(context.Background(), []int{1, 2, 3}, 2, func(_ context.Context, n int) (int, error) {
+		if n == 2 {
+			panic("routing service crashed")
+		}
+		return n, nil
+	})
+	if !errors.Is(err, ErrPanic) || err.Error() != "item 1: panic in map function: routing service crashed" {
+		t.Errorf("Map = %v, want item 1's panic wrapping ErrPanic", err)
+	}
+}
+
+func TestMapStopsAfterFirstError(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	errBad := errors.New("bad address")
+	var calls atomic.Int64
+
+	// With one slot, items run one after another: 0, 1, 2 fails, nothing after
+	results, err := Map(context.Background(), make([]int, 10), 1, func(_ context.Context, _ int) (int, error) {
+		if calls.Add(1) == 3 {
+			return 0, errBad
+		}
+		return 1, nil
+	})
+	if !errors.Is(err, errBad) || err.Error() != "item 2: bad address" {
+		t.Errorf("Map = %v, want item 2's error", err)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("fn called %d times, want 3: nothing should start after the error", n)
+	}
+	if want := []int{1, 1, 0, 0, 0, 0, 0, 0, 0, 0}; !slices.Equal(results, want) {
+		t.Errorf("results %v, want %v", results, want)
+	}
+}
+
+func TestMapCollectErrors(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	var calls atomic.Int64
+	results, err := Map(context.Background(), []int{1, 2, 3, 4, 5, 6}, 2, func(_ context.Context, n int) (int, error) {
+		calls.Add(1)
+		if n%3 == 0 {
+			return 0, fmt.Errorf("%d is a multiple of 3", n)
+		}
+		return n * 10, nil
+	}, CollectErrors())
+
+	if n := calls.Load(); n != 6 {
+		t.Errorf("fn called %d times, want every item", n)
+	}
+	if want := "item 2: 3 is a multiple of 3\nitem 5: 6 is a multiple of 3"; err == nil || err.Error() != want {
+		t.Errorf("Map = %v, want both errors joined in item order", err)
+	}
+	if want := []int{10, 20, 0, 40, 50, 0}; !slices.Equal(results, want) {
+		t.Errorf("results %v, want %v", results, want)
+	}
+}
+
+func TestMapCallerCancels(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int64
+	_, err := Map(ctx, make([]int, 10), 1, func(ctx context.Context, _ int) (int, error) {
+		if calls.Add(1) == 2 {
+			cancel()
+		}
+		return 0, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Map = %v, want context.Canceled", err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("fn called %d times after a cancel on the second, want 2", n)
+	}
+}
+
+func BenchmarkMap(b *testing.B) {
+	items := make([]int, 1000)
+	for i := range items {
+		items[i] = i
+	}
+	// Enough work per item that fanning out can pay for the goroutines
+	work := func(_ context.Context, n int) (int, error) {
+		sum := 0
+		for i := range 2000 {
+			sum += (n ^ i) % 7
+		}
+		return sum, nil
+	}
+
+	for _, limit := range []int{1, 4, 16, 1000} {
+		b.Run(fmt.Sprintf("limit=%d", limit), func(b *testing.B) {
+			for b.Loop() {
+				if _, err := Map(context.Background(), items, limit, work); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
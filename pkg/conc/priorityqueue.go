@@ -0,0 +1,160 @@
+package conc
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+)
+
+// item remembers insertion order so equal priorities stay first-in, first-out
+type item[T any] struct {
+	value T
+	seq   uint64
+	score float64 // Aging queues only: the effective priority, fixed at Push
+}
+
+// itemHeap implements heap.Interface; before reports whether a goes first.
+// An aging queue has no before and compares scores instead.
+type itemHeap[T any] struct {
+	items  []item[T]
+	before func(a, b T) bool
+}
+
+func (h *itemHeap[T]) Len() int { return len(h.items) }
+func (h *itemHeap[T]) Less(i, j int) bool {
+	a, b := h.items[i], h.items[j]
+	if h.before == nil {
+		if a.score != b.score {
+			return a.score > b.score
+		}
+		return a.seq < b.seq
+	}
+	if h.before(a.value, b.value) {
+		return true
+	}
+	if h.before(b.value, a.value) {
+		return false
+	}
+	return a.seq < b.seq
+}
+func (h *itemHeap[T]) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *itemHeap[T]) Push(x any)    { h.items = append(h.items, x.(item[T])) }
+func (h *itemHeap[T]) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
+
+// PriorityQueue is a blocking, concurrency-safe priority queue.
+// A mutex guards the heap and a sync.Cond wakes Pops waiting on an empty queue.
+type PriorityQueue[T any] struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	heap   itemHeap[T]
+	seq    uint64
+	closed bool
+
+	// Aging queues only
+	clock       clock.Clock
+	priority    func(T) int
+	agePerLevel time.Duration
+	epoch       time.Time
+}
+
+// NewPriorityQueue creates an empty queue where before(a, b) means a is served first
+func NewPriorityQueue[T any](before func(a, b T) bool) *PriorityQueue[T] {
+	q := &PriorityQueue[T]{heap: itemHeap[T]{before: before}}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// NewAgingQueue creates an empty queue that serves the highest effective
+// priority first: priority(v), plus one level for every agePerLevel v has
+// waited on clk. However many higher priorities keep arriving, a waiting
+// value overtakes each one pushed more than a level gap × agePerLevel after
+// it, so it can't starve. agePerLevel of 0 or less turns aging off.
+func NewAgingQueue[T any](clk clock.Clock, priority func(T) int, agePerLevel time.Duration) *PriorityQueue[T] {
+	q := &PriorityQueue[T]{clock: clk, priority: priority, agePerLevel: agePerLevel, epoch: clk.Now()}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// score is v's effective priority, fixed when it's pushed. Everything queued
+// ages at the same rate, so priority + waited/agePerLevel ranks values the
+// same way at every moment as priority - pushedAt/agePerLevel does at Push.
+// The heap never needs reordering as time passes.
+func (q *PriorityQueue[T]) score(v T) float64 {
+	s := float64(q.priority(v))
+	if q.agePerLevel > 0 {
+		s -= float64(q.clock.Since(q.epoch)) / float64(q.agePerLevel)
+	}
+	return s
+}
+
+// Push adds v and wakes one waiting Pop. It returns ErrQueueClosed after Close.
+func (q *PriorityQueue[T]) Push(v T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrQueueClosed
+	}
+	q.seq++
+	it := item[T]{value: v, seq: q.seq}
+	if q.priority != nil {
+		it.score = q.score(v)
+	}
+	heap.Push(&q.heap, it)
+	q.cond.Signal()
+	return nil
+}
+
+// Pop removes the highest-priority value, blocking while the queue is empty.
+// It returns ctx.Err() if ctx ends first, and ErrQueueClosed once the queue
+// is closed and empty. Values pushed before Close are still handed out.
+func (q *PriorityQueue[T]) Pop(ctx context.Context) (T, error) {
+	// sync.Cond can't select on ctx, so cancellation broadcasts to wake us
+	stop := context.AfterFunc(ctx, func() {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		q.cond.Broadcast()
+	})
+	defer stop()
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for q.heap.Len() == 0 && !q.closed && ctx.Err() == nil {
+		q.cond.Wait()
+	}
+
+	var zero T
+	if err := ctx.Err(); err != nil {
+		if q.heap.Len() > 0 {
+			q.cond.Signal() // We may have taken a Push's wakeup - pass it on
+		}
+		return zero, err
+	}
+	if q.heap.Len() == 0 {
+		return zero, ErrQueueClosed
+	}
+	return heap.Pop(&q.heap).(item[T]).value, nil
+}
+
+// Len returns the number of queued values
+func (q *PriorityQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.heap.Len()
+}
+
+// Close stops new Pushes and wakes every waiting Pop
+func (q *PriorityQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	q.cond.Broadcast()
+}
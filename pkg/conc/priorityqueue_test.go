@@ -0,0 +1,236 @@
+package conc
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// ticket is a queued order: a higher level is served first
+type ticket struct {
+	id    int
+	level int
+}
+
+func higherLevel(a, b ticket) bool { return a.level > b.level }
+func ticketLevel(t ticket) int     { return t.level }
+
+// popAll empties q without blocking and returns the ids in the order served
+func popAll(q *PriorityQueue[ticket]) []int {
+	var ids []int
+	for q.Len() > 0 {
+		t, _ := q.Pop(context.Background())
+		ids = append(ids, t.id)
+	}
+	return ids
+}
+
+func TestPriorityQueueOrder(t *testing.T) {
+	q := NewPriorityQueue(higherLevel)
+	for i, level := range []int{1, 3, 2, 3, 1, 2} {
+		q.Push(ticket{id: i + 1, level: level})
+	}
+	// By level, and within a level by arrival
+	if got, want := popAll(q), []int{2, 4, 3, 6, 1, 5}; !slices.Equal(got, want) {
+		t.Errorf("served %v, want %v", got, want)
+	}
+}
+
+func TestPriorityQueueConcurrentPushes(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	q := NewPriorityQueue(higherLevel)
+	var wg sync.WaitGroup
+	for g := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(int64(g)))
+			for i := range 100 {
+				q.Push(ticket{id: g*100 + i, level: rng.Intn(10)})
+			}
+		}()
+	}
+	wg.Wait()
+
+	served, last := 0, 10
+	for q.Len() > 0 {
+		tk, err := q.Pop(context.Background())
+		if err != nil || tk.level > last {
+			t.Fatalf("pop %d: got level %d after %d, err %v", served, tk.level, last, err)
+		}
+		last = tk.level
+		served++
+	}
+	if served != 1000 {
+		t.Errorf("served %d, want 1000", served)
+	}
+}
+
+func TestPriorityQueuePopBlocks(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	q := NewPriorityQueue(higherLevel)
+	got := make(chan ticket)
+	go func() {
+		tk, _ := q.Pop(context.Background())
+		got <- tk
+	}()
+
+	select {
+	case tk := <-got:
+		t.Fatalf("Pop on an empty queue returned %v", tk)
+	case <-time.After(20 * time.Millisecond):
+	}
+	q.Push(ticket{id: 7})
+	if tk := <-got; tk.id != 7 {
+		t.Errorf("Pop = %v, want the ticket just pushed", tk)
+	}
+}
+
+func TestPriorityQueuePopCancelled(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := runningFake(t)
+	q := NewPriorityQueue(higherLevel)
+	ctx, cancel := fake.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := q.Pop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Pop = %v, want context.DeadlineExceeded", err)
+	}
+	if got := fake.Since(epoch); got != 50*time.Millisecond {
+		t.Errorf("gave up after %v, want 50ms", got)
+	}
+}
+
+func TestPriorityQueueClose(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	q := NewPriorityQueue(higherLevel)
+	errs := make(chan error, 3)
+	for range 3 {
+		go func() {
+			_, err := q.Pop(context.Background())
+			errs <- err
+		}()
+	}
+	time.Sleep(20 * time.Millisecond) // Let all three wait
+	q.Close()
+	for range 3 {
+		if err := <-errs; !errors.Is(err, ErrQueueClosed) {
+			t.Errorf("a waiting Pop got %v, want ErrQueueClosed", err)
+		}
+	}
+	if err := q.Push(ticket{id: 8}); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Push after Close = %v, want ErrQueueClosed", err)
+	}
+
+	// What was queued before Close is still handed out
+	q = NewPriorityQueue(higherLevel)
+	q.Push(ticket{id: 1})
+	q.Push(ticket{id: 2, level: 1})
+	q.Close()
+	if got := popAll(q); !slices.Equal(got, []int{2, 1}) {
+		t.Errorf("served %v after Close, want [2 1]", got)
+	}
+	if _, err := q.Pop(context.Background()); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Pop on a closed, empty queue = %v, want ErrQueueClosed", err)
+	}
+}
+
+func TestAgingQueueOff(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	plain := NewPriorityQueue(higherLevel)
+	off := NewAgingQueue(clock.NewFake(epoch), ticketLevel, 0)
+	for id := range 1000 {
+		tk := ticket{id: id, level: rng.Intn(10)}
+		plain.Push(tk)
+		off.Push(tk)
+	}
+	if a, b := popAll(plain), popAll(off); !slices.Equal(a, b) {
+		t.Error("with aging off, the aging queue served a different order from NewPriorityQueue")
+	}
+}
+
+func TestAgingQueue(t *testing.T) {
+	tests := []struct {
+		name        string
+		agePerLevel time.Duration
+		want        []int
+	}{
+		{"waited three levels' worth: beats a new higher level", 10 * time.Millisecond, []int{1, 2}},
+		{"the same wait at a slower rate isn't enough", time.Second, []int{2, 1}},
+		{"a tie goes to the one that waited", 30 * time.Millisecond, []int{1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := clock.NewFake(epoch)
+			q := NewAgingQueue(fake, ticketLevel, tt.agePerLevel)
+			q.Push(ticket{id: 1, level: 0})
+			fake.Advance(30 * time.Millisecond)
+			q.Push(ticket{id: 2, level: 1})
+			if got := popAll(q); !slices.Equal(got, tt.want) {
+				t.Errorf("served %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	q := NewAgingQueue(clock.NewFake(epoch), ticketLevel, 10*time.Millisecond)
+	for id := 1; id <= 5; id++ {
+		q.Push(ticket{id: id})
+	}
+	if got := popAll(q); !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("equal levels served %v, want arrival order", got)
+	}
+}
+
+// One chef takes 10ms an order while a higher-level order arrives every 5ms
+// for 300ms, so the backlog only grows. A level-0 order is queued right
+// behind the first level-1 one. Returns when it started and how many
+// level-1 orders started before it, or -1 if it didn't start before the
+// stream stopped.
+func starvationRun(q *PriorityQueue[ticket], fake *clock.FakeClock) (time.Duration, int) {
+	const vipEvery, prep, streamFor = 5 * time.Millisecond, 10 * time.Millisecond, 300 * time.Millisecond
+	q.Push(ticket{id: 2, level: 1})
+	q.Push(ticket{id: 1, level: 0})
+	id := 3
+	served := 0
+	for at := time.Duration(0); at <= streamFor; at += vipEvery {
+		fake.Advance(epoch.Add(at).Sub(fake.Now()))
+		if at > 0 {
+			q.Push(ticket{id: id, level: 1})
+			id++
+		}
+		if at%prep != 0 {
+			continue // The chef is busy
+		}
+		tk, _ := q.Pop(context.Background())
+		if tk.id == 1 {
+			return at, served
+		}
+		served++
+	}
+	return -1, served
+}
+
+func TestAgingQueueStopsStarvation(t *testing.T) {
+	fake := clock.NewFake(epoch)
+	started, first := starvationRun(NewAgingQueue(fake, ticketLevel, 50*time.Millisecond), fake)
+
+	// Order 1 overtakes every level-1 order pushed 50ms or more after it, so
+	// only the one ahead of it and the nine of its first 45ms start first
+	if started != 100*time.Millisecond || first != 10 {
+		t.Errorf("with aging, started at %v after %d others; want 100ms after 10", started, first)
+	}
+
+	fake = clock.NewFake(epoch)
+	started, first = starvationRun(NewPriorityQueue(higherLevel), fake)
+	if started != -1 {
+		t.Errorf("without aging, started at %v after %d others; want it starved for the whole stream", started, first)
+	}
+}
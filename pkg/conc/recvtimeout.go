@@ -0,0 +1,33 @@
+package conc
+
+import (
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+)
+
+// RecvTimeout waits up to d for a value from ch, reusing t rather than
+// allocating a new timer on every call. It returns false on timeout or if ch
+// is closed. t is a clock.Timer, such as one from clock.Real().NewTimer for a
+// plain time.Timer. RecvTimeout leaves it running, so Stop it once the loop is
+// done, and don't share it between goroutines calling at the same time.
+func RecvTimeout[T any](ch <-chan T, t clock.Timer, d time.Duration) (T, bool) {
+	if !t.Stop() {
+		// The timer already fired. Before Go 1.23 its tick could still sit in
+		// t.C and end this wait early, so drain it. The drain must not block:
+		// an earlier call may have received the tick already.
+		select {
+		case <-t.C():
+		default:
+		}
+	}
+	t.Reset(d)
+
+	select {
+	case v, ok := <-ch:
+		return v, ok
+	case <-t.C():
+		var zero T
+		return zero, false
+	}
+}
@@ -0,0 +1,102 @@
+package conc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// sendAfter delivers v on a fresh channel after d on clk
+func sendAfter(clk clock.Clock, d time.Duration, v int) <-chan int {
+	ch := make(chan int, 1)
+	clk.AfterFunc(d, func() { ch <- v })
+	return ch
+}
+
+func TestRecvTimeout(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := runningFake(t)
+	timer := fake.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	// recv calls RecvTimeout and reports how long it took on the fake clock
+	recv := func(ch <-chan int, d time.Duration) (int, bool, time.Duration) {
+		start := fake.Now()
+		v, ok := RecvTimeout(ch, timer, d)
+		return v, ok, fake.Since(start)
+	}
+
+	if v, ok, took := recv(sendAfter(fake, 10*time.Millisecond, 1), 200*time.Millisecond); !ok || v != 1 || took != 10*time.Millisecond {
+		t.Errorf("value before the deadline: got %d, %v after %v; want 1, true after 10ms", v, ok, took)
+	}
+	if _, ok, took := recv(make(chan int), 30*time.Millisecond); ok || took != 30*time.Millisecond {
+		t.Errorf("nothing arrives: got %v after %v; want false after 30ms", ok, took)
+	}
+	// The timeout received the tick, so the timer has fired and its channel is empty
+	if v, ok, took := recv(sendAfter(fake, 10*time.Millisecond, 2), 200*time.Millisecond); !ok || v != 2 || took != 10*time.Millisecond {
+		t.Errorf("after a timeout: got %d, %v after %v; want 2, true after 10ms", v, ok, took)
+	}
+
+	closed := make(chan int)
+	close(closed)
+	if _, ok, took := recv(closed, time.Second); ok || took != 0 {
+		t.Errorf("closed channel: got %v after %v; want false at once", ok, took)
+	}
+}
+
+// A timer that fired with nobody reading its tick doesn't cut the next wait
+// short. This uses a real time.Timer, whose channel behaviour is what the
+// drain is for.
+func TestRecvTimeoutTimerAlreadyFired(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	wall := clock.Real()
+	timer := wall.NewTimer(time.Millisecond)
+	defer timer.Stop()
+	time.Sleep(20 * time.Millisecond) // Fires unread
+
+	v, ok := RecvTimeout(sendAfter(wall, 20*time.Millisecond, 3), timer, 5*time.Second)
+	if !ok || v != 3 {
+		t.Errorf("RecvTimeout = %d, %v; want 3, true", v, ok)
+	}
+}
+
+func TestRecvTimeoutAllocations(t *testing.T) {
+	timer := clock.Real().NewTimer(time.Minute)
+	defer timer.Stop()
+	ready := make(chan int, 1)
+	allocs := testing.AllocsPerRun(1000, func() {
+		ready <- 1
+		RecvTimeout(ready, timer, time.Minute)
+	})
+	if allocs != 0 {
+		t.Errorf("%.0f allocations per receive, want none", allocs)
+	}
+}
+
+// One receive per op: a reused timer against a fresh time.After
+func BenchmarkRecvTimeout(b *testing.B) {
+	ready := make(chan int, 1)
+
+	b.Run("RecvTimeout", func(b *testing.B) {
+		b.ReportAllocs()
+		timer := clock.Real().NewTimer(time.Minute)
+		defer timer.Stop()
+		for b.Loop() {
+			ready <- 1
+			RecvTimeout(ready, timer, time.Minute)
+		}
+	})
+
+	b.Run("time.After", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			ready <- 1
+			select {
+			case <-ready:
+			case <-time.After(time.Minute):
+			}
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package conc
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// ErrReorderBufferFull is reported when Reorder holds more out-of-order items than allowed
+var ErrReorderBufferFull = errors.New("reorder buffer full")
+
+// OverflowPolicy decides what Reorder does when its buffer is full
+type OverflowPolicy int
+
+const (
+	// OverflowPanic treats a full buffer as a bug and panics
+	OverflowPanic OverflowPolicy = iota
+	// OverflowSkipGap gives up on the missing keys, reports them, and
+	// continues from the smallest buffered key
+	OverflowSkipGap
+)
+
+// reorderConfig holds Reorder's options
+type reorderConfig struct {
+	first     int
+	maxBuffer int // 0 = unbounded
+	policy    OverflowPolicy
+	onSkip    func(error)
+}
+
+// ReorderOption configures Reorder
+type ReorderOption func(*reorderConfig)
+
+// WithFirstKey sets the first expected key (default 1)
+func WithFirstKey(key int) ReorderOption {
+	return func(c *reorderConfig) { c.first = key }
+}
+
+// WithMaxBuffer bounds how many out-of-order items may be held at once, and
+// what happens when one more arrives
+func WithMaxBuffer(n int, policy OverflowPolicy) ReorderOption {
+	return func(c *reorderConfig) { c.maxBuffer, c.policy = n, policy }
+}
+
+// WithOnSkip is called with an error wrapping ErrReorderBufferFull each time
+// OverflowSkipGap gives up on missing keys
+func WithOnSkip(fn func(error)) ReorderOption {
+	return func(c *reorderConfig) { c.onSkip = fn }
+}
+
+// Reorder emits the values from in strictly in key order, however they
+// arrive. Values that arrive early are buffered until every smaller key has
+// been emitted; a missing key holds back output until it arrives, unless the
+// buffer overflows under OverflowSkipGap. Keys below the next expected one,
+// such as duplicates or keys already skipped, are dropped. When in closes,
+// buffered values are flushed in key order, skipping any gaps. Read the
+// output until it closes.
+func Reorder[T any](in <-chan T, key func(T) int, opts ...ReorderOption) <-chan T {
+	cfg := reorderConfig{first: 1}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	out := make(chan T)
+	go func() {
+		defer close(out)
+
+		next := cfg.first
+		pending := make(map[int]T)
+
+		// emitReady sends every consecutive value starting at next
+		emitReady := func() {
+			for {
+				v, ok := pending[next]
+				if !ok {
+					return
+				}
+				delete(pending, next)
+				out <- v
+				next++
+			}
+		}
+
+		for v := range in {
+			k := key(v)
+			if k < next {
+				continue
+			}
+			pending[k] = v
+			emitReady()
+
+			if cfg.maxBuffer > 0 && len(pending) > cfg.maxBuffer {
+				if cfg.policy == OverflowPanic {
+					panic(fmt.Errorf("%w: waiting for key %d with %d items buffered", ErrReorderBufferFull, next, len(pending)))
+				}
+				// Skip the gap: jump to the smallest key we do have
+				smallest := slices.Min(slices.Collect(maps.Keys(pending)))
+				if cfg.onSkip != nil {
+					cfg.onSkip(fmt.Errorf("%w: gave up waiting for keys %d..%d", ErrReorderBufferFull, next, smallest-1))
+				}
+				next = smallest
+				emitReady()
+			}
+		}
+
+		// in closed: flush what's left in order
+		for _, k := range slices.Sorted(maps.Keys(pending)) {
+			out <- pending[k]
+		}
+	}()
+	return out
+}
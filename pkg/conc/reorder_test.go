@@ -0,0 +1,135 @@
+package conc
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+func identity(k int) int { return k }
+
+// reorderAll sends keys to Reorder in the given order, closes its input and
+// returns everything it emitted
+func reorderAll(keys []int, opts ...ReorderOption) []int {
+	in := make(chan int)
+	go func() {
+		defer close(in)
+		for _, k := range keys {
+			in <- k
+		}
+	}()
+	var got []int
+	for k := range Reorder(in, identity, opts...) {
+		got = append(got, k)
+	}
+	return got
+}
+
+func TestReorder(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []int
+		opts []ReorderOption
+		want []int
+	}{
+		{"already in order", []int{1, 2, 3}, nil, []int{1, 2, 3}},
+		{"reversed", []int{5, 4, 3, 2, 1}, nil, []int{1, 2, 3, 4, 5}},
+		{"shuffled", []int{3, 1, 2, 6, 4, 5}, nil, []int{1, 2, 3, 4, 5, 6}},
+		{"first key set", []int{2, 0, 1}, []ReorderOption{WithFirstKey(0)}, []int{0, 1, 2}},
+		{"duplicates and stale keys dropped", []int{1, 1, 0, 2, 1}, nil, []int{1, 2}},
+		{"gaps flushed in order on close", []int{2, 5, 1, 4}, nil, []int{1, 2, 4, 5}},
+		{"nothing", nil, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutil.WaitForGoroutines(t)
+			if got := reorderAll(tt.keys, tt.opts...); !slices.Equal(got, tt.want) {
+				t.Errorf("Reorder(%v) = %v, want %v", tt.keys, got, tt.want)
+			}
+		})
+	}
+}
+
+// Early values are held until the gap before them fills, then go out together
+func TestReorderHoldsBackUntilGapFills(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	in := make(chan int)
+	out := Reorder(in, identity)
+
+	in <- 2
+	in <- 3 // Taken, so 2 has been buffered rather than sent
+	select {
+	case k := <-out:
+		t.Fatalf("got %d while 1 was missing", k)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	in <- 1
+	for want := 1; want <= 3; want++ {
+		if k := <-out; k != want {
+			t.Fatalf("got %d, want %d", k, want)
+		}
+	}
+	close(in)
+	if k, ok := <-out; ok {
+		t.Errorf("got %d after in closed, want the output closed", k)
+	}
+}
+
+func TestReorderSkipGap(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	var skipped []error
+	// 3 never arrives in time; 4, 5 fit in the buffer and 6 overflows it.
+	// 3 then turns up late and is dropped.
+	got := reorderAll([]int{1, 2, 4, 5, 6, 3, 7},
+		WithMaxBuffer(2, OverflowSkipGap),
+		WithOnSkip(func(err error) { skipped = append(skipped, err) }),
+	)
+
+	if want := []int{1, 2, 4, 5, 6, 7}; !slices.Equal(got, want) {
+		t.Errorf("Reorder = %v, want %v", got, want)
+	}
+	if len(skipped) != 1 {
+		t.Fatalf("%d skips reported, want 1: %v", len(skipped), skipped)
+	}
+	if err := skipped[0]; !errors.Is(err, ErrReorderBufferFull) || err.Error() != "reorder buffer full: gave up waiting for keys 3..3" {
+		t.Errorf("skip reported as %q, want one wrapping ErrReorderBufferFull naming key 3", err)
+	}
+}
+
+// A buffer within its bound never skips, even with a gap at the end
+func TestReorderWithinMaxBuffer(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	got := reorderAll([]int{3, 2, 1, 5, 6}, WithMaxBuffer(2, OverflowSkipGap),
+		WithOnSkip(func(err error) { t.Errorf("unexpected skip: %v", err) }))
+	if want := []int{1, 2, 3, 5, 6}; !slices.Equal(got, want) {
+		t.Errorf("Reorder = %v, want %v", got, want)
+	}
+}
+
+// OverflowPanic crashes the program from Reorder's goroutine, so the test
+// runs itself in a child process and checks how the child died
+func TestReorderOverflowPanics(t *testing.T) {
+	if os.Getenv("REORDER_OVERFLOW_CHILD") == "1" {
+		reorderAll([]int{2, 3, 4}, WithMaxBuffer(2, OverflowPanic))
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestReorderOverflowPanics$")
+	cmd.Env = append(os.Environ(), "REORDER_OVERFLOW_CHILD=1")
+	output, err := cmd.CombinedOutput()
+	var exit *exec.ExitError
+	if !errors.As(err, &exit) {
+		t.Fatalf("child exited with %v, want a panic:\n%s", err, output)
+	}
+	if want := "reorder buffer full: waiting for key 1 with 3 items buffered"; !strings.Contains(string(output), want) {
+		t.Errorf("child output doesn't contain %q:\n%s", want, output)
+	}
+}
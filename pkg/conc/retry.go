@@ -0,0 +1,110 @@
+package conc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+)
+
+// RetryPolicy describes how often and how patiently an operation is retried
+type RetryPolicy struct {
+	MaxAttempts int           // Total attempts including the first one
+	BaseDelay   time.Duration // Backoff before the second attempt
+	Multiplier  float64       // Growth of each backoff over the previous one (0 = 2)
+	MaxDelay    time.Duration // Upper bound for a single backoff (0 = no cap)
+	Jitter      bool          // Randomize each backoff to avoid thundering herds
+
+	// Retryable reports whether err is worth another attempt (nil = retry everything)
+	Retryable func(err error) bool
+
+	// OnRetry is called after a failed attempt, before backing off
+	OnRetry func(attempt int, err error, delay time.Duration)
+
+	// Clock is what backoffs sleep on (nil = the real clock). Tests pass a
+	// fake so backoff can be asserted without waiting.
+	Clock clock.Clock
+}
+
+// backoff returns the delay before the attempt following the given one (1-based)
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+
+	// BaseDelay * Multiplier^(attempt-1), computed in float64 so it can't wrap around
+	nominal := float64(p.BaseDelay) * math.Pow(multiplier, float64(attempt-1))
+	delay := time.Duration(math.MaxInt64)
+	if nominal < float64(math.MaxInt64) {
+		delay = time.Duration(nominal)
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if p.Jitter && delay > 0 {
+		// "Equal jitter": keep half the delay, randomize the other half
+		half := delay / 2
+		delay = half + time.Duration(rand.Int63n(int64(half)+1))
+	}
+	return delay
+}
+
+// Retry calls fn until it succeeds, returns a non-retryable error, runs out of
+// attempts, or ctx is cancelled. The returned error wraps the last failure and
+// records how many attempts were made. A MaxAttempts below 1 means one attempt.
+func Retry(ctx context.Context, policy RetryPolicy, fn func(ctx context.Context) error) error {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 1
+	}
+	clk := policy.Clock
+	if clk == nil {
+		clk = clock.Real()
+	}
+
+	var err error
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		// Don't start an attempt if the caller already gave up
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			if err == nil {
+				err = ctxErr
+			}
+			return fmt.Errorf("gave up after %d attempt(s): %w", attempt-1, err)
+		}
+
+		err = fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if policy.Retryable != nil && !policy.Retryable(err) {
+			return fmt.Errorf("not retryable, failed after %d attempt(s): %w", attempt, err)
+		}
+		if attempt == policy.MaxAttempts {
+			break
+		}
+
+		delay := policy.backoff(attempt)
+		if policy.OnRetry != nil {
+			policy.OnRetry(attempt, err, delay)
+		}
+
+		// Backing off is also a cancellation point
+		if sleepErr := clk.Sleep(ctx, delay); sleepErr != nil {
+			return fmt.Errorf("cancelled during backoff after %d attempt(s): %w (last error: %w)", attempt, sleepErr, err)
+		}
+	}
+
+	return fmt.Errorf("failed after %d attempt(s): %w", policy.MaxAttempts, err)
+}
+
+// Do retries fn under the policy with no cancellation. It is the shorthand for
+// wrapping plain functions such as processOrder; use Retry when a ctx is at hand.
+func (p RetryPolicy) Do(fn func() error) error {
+	return Retry(context.Background(), p, func(context.Context) error {
+		return fn()
+	})
+}
@@ -0,0 +1,214 @@
+package conc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+var errBusy = errors.New("busy")
+
+// runningFake returns a fake clock that moves to the next deadline whenever
+// the code under test is waiting on it, until the test ends
+func runningFake(t *testing.T) *clock.FakeClock {
+	fake := clock.NewFake(epoch)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go fake.AdvanceWhenIdle(ctx, time.Millisecond)
+	return fake
+}
+
+func TestRetrySucceedsAfterTransientFailures(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := runningFake(t)
+
+	var attemptsAt, delays []time.Duration
+	var retried []int
+	policy := RetryPolicy{
+		MaxAttempts: 5,
+		BaseDelay:   100 * time.Millisecond,
+		Clock:       fake,
+		OnRetry: func(attempt int, err error, delay time.Duration) {
+			retried = append(retried, attempt)
+			delays = append(delays, delay)
+		},
+	}
+	err := Retry(context.Background(), policy, func(context.Context) error {
+		attemptsAt = append(attemptsAt, fake.Since(epoch))
+		if len(attemptsAt) < 3 {
+			return errBusy
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("Retry = %v, want success on the third attempt", err)
+	}
+	if want := []time.Duration{0, 100 * time.Millisecond, 300 * time.Millisecond}; !slices.Equal(attemptsAt, want) {
+		t.Errorf("attempts at %v, want %v", attemptsAt, want)
+	}
+	if want := []int{1, 2}; !slices.Equal(retried, want) {
+		t.Errorf("OnRetry attempts %v, want %v", retried, want)
+	}
+	if want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}; !slices.Equal(delays, want) {
+		t.Errorf("OnRetry delays %v, want %v", delays, want)
+	}
+}
+
+func TestRetryNotRetryable(t *testing.T) {
+	fake := clock.NewFake(epoch)
+	errUnknown := errors.New("unknown item")
+	calls := 0
+	policy := RetryPolicy{
+		MaxAttempts: 5,
+		BaseDelay:   100 * time.Millisecond,
+		Clock:       fake,
+		Retryable:   func(err error) bool { return errors.Is(err, errBusy) },
+	}
+	err := Retry(context.Background(), policy, func(context.Context) error {
+		calls++
+		return errUnknown
+	})
+
+	if !errors.Is(err, errUnknown) || !strings.Contains(err.Error(), "not retryable, failed after 1 attempt(s)") {
+		t.Errorf("Retry = %v, want the unknown item error after 1 attempt", err)
+	}
+	if calls != 1 || fake.Since(epoch) != 0 {
+		t.Errorf("%d call(s) over %v, want 1 and no backoff", calls, fake.Since(epoch))
+	}
+}
+
+func TestRetryRunsOutOfAttempts(t *testing.T) {
+	tests := []struct {
+		maxAttempts int
+		wantCalls   int
+		wantErr     string
+	}{
+		{3, 3, "failed after 3 attempt(s): busy"},
+		{1, 1, "failed after 1 attempt(s): busy"},
+		{0, 1, "failed after 1 attempt(s): busy"}, // Below 1 still makes one attempt
+		{-2, 1, "failed after 1 attempt(s): busy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("MaxAttempts %d", tt.maxAttempts), func(t *testing.T) {
+			testutil.WaitForGoroutines(t)
+			fake := runningFake(t)
+			calls := 0
+			policy := RetryPolicy{MaxAttempts: tt.maxAttempts, BaseDelay: time.Second, Clock: fake}
+			err := Retry(context.Background(), policy, func(context.Context) error {
+				calls++
+				return errBusy
+			})
+			if calls != tt.wantCalls || !errors.Is(err, errBusy) || err.Error() != tt.wantErr {
+				t.Errorf("%d call(s), err %q; want %d, %q", calls, err, tt.wantCalls, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRetryCancelledDuringBackoff(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := runningFake(t)
+	ctx, cancel := fake.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+
+	// Attempts at 0 and 100ms; the 200ms backoff after the second is cut short
+	calls := 0
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, Clock: fake}
+	err := Retry(ctx, policy, func(context.Context) error {
+		calls++
+		return errBusy
+	})
+
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, errBusy) {
+		t.Errorf("Retry = %v, want both the deadline and the last error", err)
+	}
+	if !strings.Contains(err.Error(), "cancelled during backoff after 2 attempt(s)") {
+		t.Errorf("Retry = %q, want it to count 2 attempts", err)
+	}
+	if calls != 2 {
+		t.Errorf("%d call(s), want 2", calls)
+	}
+	if got := fake.Since(epoch); got != 250*time.Millisecond {
+		t.Errorf("gave up at %v, want at the 250ms deadline", got)
+	}
+}
+
+func TestRetryCancelledBeforeStart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls := 0
+	err := Retry(ctx, RetryPolicy{MaxAttempts: 3, Clock: clock.NewFake(epoch)}, func(context.Context) error {
+		calls++
+		return nil
+	})
+	if calls != 0 || !errors.Is(err, context.Canceled) {
+		t.Errorf("%d call(s), err %v; want none and context.Canceled", calls, err)
+	}
+}
+
+// With no jitter, Do's total wait is exactly the backoff schedule
+func TestRetryPolicyDoSchedule(t *testing.T) {
+	ms := func(f float64) time.Duration { return time.Duration(f * float64(time.Millisecond)) }
+	tests := []struct {
+		name   string
+		policy RetryPolicy
+		want   []time.Duration
+	}{
+		{"base 100ms, ×2 (default), 4 attempts", RetryPolicy{MaxAttempts: 4, BaseDelay: ms(100)},
+			[]time.Duration{ms(100), ms(200), ms(400)}},
+		{"base 100ms, ×1.5, 5 attempts", RetryPolicy{MaxAttempts: 5, BaseDelay: ms(100), Multiplier: 1.5},
+			[]time.Duration{ms(100), ms(150), ms(225), ms(337.5)}},
+		{"base 100ms, ×3, capped at 500ms", RetryPolicy{MaxAttempts: 5, BaseDelay: ms(100), Multiplier: 3, MaxDelay: ms(500)},
+			[]time.Duration{ms(100), ms(300), ms(500), ms(500)}},
+		{"huge multiplier hits the cap", RetryPolicy{MaxAttempts: 4, BaseDelay: time.Second, Multiplier: 1e300, MaxDelay: time.Minute},
+			[]time.Duration{time.Second, time.Minute, time.Minute}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutil.WaitForGoroutines(t)
+			fake := runningFake(t)
+			var delays []time.Duration
+			tt.policy.Clock = fake
+			tt.policy.OnRetry = func(_ int, _ error, d time.Duration) { delays = append(delays, d) }
+
+			if err := tt.policy.Do(func() error { return errBusy }); !errors.Is(err, errBusy) {
+				t.Fatalf("Do = %v, want busy", err)
+			}
+			var want time.Duration
+			for _, d := range tt.want {
+				want += d
+			}
+			if !slices.Equal(delays, tt.want) || fake.Since(epoch) != want {
+				t.Errorf("waited %v in all, delays %v; want %v, %v", fake.Since(epoch), delays, want, tt.want)
+			}
+		})
+	}
+}
+
+// Jitter keeps each delay between half and all of its nominal value
+func TestRetryPolicyJitter(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, Multiplier: 2, MaxDelay: time.Second, Jitter: true}
+	varied := false
+	for range 200 {
+		for attempt, nominal := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second} {
+			d := p.backoff(attempt + 1)
+			if d < nominal/2 || d > nominal {
+				t.Fatalf("attempt %d: jittered delay %v outside [%v, %v]", attempt+1, d, nominal/2, nominal)
+			}
+			varied = varied || d != nominal
+		}
+	}
+	if !varied {
+		t.Error("200 rounds of jitter never moved a delay off its nominal value")
+	}
+}
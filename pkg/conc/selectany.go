@@ -0,0 +1,30 @@
+package conc
+
+import (
+	"context"
+	"reflect"
+)
+
+// SelectAny waits until one of chans can receive, or ctx is done.
+// It returns the index of the chosen channel, the value, and ok=false if that
+// channel is closed. When ctx ends first it returns -1 and ok=false.
+// Nil channels are never chosen, so callers can "remove" a closed channel by
+// setting its slot to nil.
+func SelectAny[T any](ctx context.Context, chans []<-chan T) (int, T, bool) {
+	cases := make([]reflect.SelectCase, len(chans)+1)
+	for i, ch := range chans {
+		cases[i] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ch)}
+	}
+	cases[len(chans)] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())}
+
+	chosen, value, ok := reflect.Select(cases)
+
+	var zero T
+	if chosen == len(chans) {
+		return -1, zero, false
+	}
+	if !ok {
+		return chosen, zero, false
+	}
+	return chosen, value.Interface().(T), true
+}
@@ -0,0 +1,152 @@
+package conc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+func TestSelectAnyClosedChannel(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	a, b, c := make(chan int), make(chan int), make(chan int)
+	close(b)
+	if i, v, ok := SelectAny(context.Background(), []<-chan int{a, b, c}); i != 1 || v != 0 || ok {
+		t.Errorf("SelectAny = (%d, %d, %v), want (1, 0, false) for the closed channel", i, v, ok)
+	}
+
+	// Its slot set to nil, the closed channel is never chosen again
+	go func() { c <- 42 }()
+	if i, v, ok := SelectAny(context.Background(), []<-chan int{a, nil, c}); i != 2 || v != 42 || !ok {
+		t.Errorf("SelectAny = (%d, %d, %v), want (2, 42, true)", i, v, ok)
+	}
+}
+
+func TestSelectAnyCancel(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := runningFake(t)
+	a, b := make(chan int), make(chan int)
+	ctx, cancel := fake.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	i, v, ok := SelectAny(ctx, []<-chan int{a, nil, b})
+	if i != -1 || v != 0 || ok {
+		t.Errorf("SelectAny = (%d, %d, %v), want (-1, 0, false)", i, v, ok)
+	}
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) || fake.Since(epoch) != 20*time.Millisecond {
+		t.Errorf("returned after %v with ctx.Err() = %v, want the 20ms deadline", fake.Since(epoch), ctx.Err())
+	}
+
+	// With nothing to receive from, only ctx can end the wait
+	if i, _, _ := SelectAny[int](ctx, nil); i != -1 {
+		t.Errorf("SelectAny over no channels = %d, want -1", i)
+	}
+}
+
+// 100 channels send 10 values each, their own index, then close. Every value
+// must come back at the index it was sent on, and each close exactly once.
+func TestSelectAnyManyChannels(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	const n, per = 100, 10
+	chans := make([]<-chan int, n)
+	for i := range chans {
+		ch := make(chan int)
+		chans[i] = ch
+		go func() {
+			defer close(ch)
+			for range per {
+				ch <- i
+			}
+		}()
+	}
+
+	counts := make([]int, n)
+	for open := n; open > 0; {
+		i, v, ok := SelectAny(context.Background(), chans)
+		if !ok {
+			if counts[i] != per {
+				t.Errorf("channel %d closed after %d values, want %d", i, counts[i], per)
+			}
+			chans[i] = nil
+			open--
+			continue
+		}
+		if v != i {
+			t.Fatalf("got %d at index %d", v, i)
+		}
+		counts[i]++
+	}
+}
+
+// busyChannels keeps n buffered channels full until ctx ends
+func busyChannels(ctx context.Context, n int) []<-chan int {
+	chans := make([]<-chan int, n)
+	for i := range chans {
+		ch := make(chan int, 16)
+		chans[i] = ch
+		go func() {
+			for {
+				select {
+				case ch <- i:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	return chans
+}
+
+// fanIn is the usual alternative to SelectAny: a goroutine per channel
+// forwarding into one output
+func fanIn[T any](ctx context.Context, chans []<-chan T) <-chan T {
+	out := make(chan T)
+	var wg sync.WaitGroup
+	for _, ch := range chans {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := range ch {
+				select {
+				case out <- v:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// One reflect.Select per value received costs O(N) and allocates; fan-in
+// pays for N goroutines once and then receives from a single channel
+func BenchmarkSelectAny(b *testing.B) {
+	for _, n := range []int{2, 7, 100} {
+		b.Run(fmt.Sprintf("reflect.Select/%d", n), func(b *testing.B) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			chans := busyChannels(ctx, n)
+			b.ReportAllocs()
+			for b.Loop() {
+				SelectAny(ctx, chans)
+			}
+		})
+		b.Run(fmt.Sprintf("fan-in/%d", n), func(b *testing.B) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			merged := fanIn(ctx, busyChannels(ctx, n))
+			b.ReportAllocs()
+			for b.Loop() {
+				<-merged
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package conc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+)
+
+// ErrRestartBudget is returned when fn crashes more often than the restart budget allows
+var ErrRestartBudget = errors.New("restart budget exhausted")
+
+// supervisor holds the settings for one supervised function
+type supervisor struct {
+	clock       clock.Clock
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	maxRestarts int           // Restarts allowed within window
+	window      time.Duration // Budget window (0 = count restarts for the whole lifetime)
+	onRestart   func(name string, restart int, err error, delay time.Duration)
+}
+
+// SuperviseOption configures Supervise
+type SuperviseOption func(*supervisor)
+
+// WithBackoff sets the first restart delay and the cap; each restart doubles the delay
+func WithBackoff(base, max time.Duration) SuperviseOption {
+	return func(s *supervisor) { s.baseDelay, s.maxDelay = base, max }
+}
+
+// WithRestartBudget allows at most n restarts within any window. A zero window counts all restarts.
+func WithRestartBudget(n int, window time.Duration) SuperviseOption {
+	return func(s *supervisor) { s.maxRestarts, s.window = n, window }
+}
+
+// WithOnRestart is called before each restart with the error that caused it
+// and the delay. A panic arrives as a *PanicError.
+func WithOnRestart(fn func(name string, restart int, err error, delay time.Duration)) SuperviseOption {
+	return func(s *supervisor) { s.onRestart = fn }
+}
+
+// Supervise runs fn in a goroutine and restarts it whenever it panics or
+// returns an error, waiting on clk with exponential backoff between attempts.
+// The returned channel receives the final outcome and is then closed: nil if
+// fn returned nil, ctx.Err() if ctx was cancelled, or an error wrapping
+// ErrRestartBudget and the last failure.
+//
+// By default the first restart waits 100ms, delays are capped at 5s, and 5
+// restarts are allowed in any minute.
+func Supervise(ctx context.Context, clk clock.Clock, name string, fn func(ctx context.Context) error, opts ...SuperviseOption) <-chan error {
+	s := &supervisor{
+		clock:       clk,
+		baseDelay:   100 * time.Millisecond,
+		maxDelay:    5 * time.Second,
+		maxRestarts: 5,
+		window:      time.Minute,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		defer close(done)
+		done <- s.run(ctx, name, fn)
+	}()
+	return done
+}
+
+func (s *supervisor) run(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	var restarts []time.Time // When each restart in the current window happened
+	total := 0
+
+	for {
+		err := runSafely(ctx, fn)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		// Forget restarts that have slid out of the budget window
+		now := s.clock.Now()
+		if s.window > 0 {
+			kept := restarts[:0]
+			for _, t := range restarts {
+				if now.Sub(t) < s.window {
+					kept = append(kept, t)
+				}
+			}
+			restarts = kept
+		}
+		if len(restarts) >= s.maxRestarts {
+			return fmt.Errorf("%s: %w (%d restarts): %w", name, ErrRestartBudget, total, err)
+		}
+
+		delay := s.backoff(len(restarts) + 1)
+		restarts = append(restarts, now)
+		total++
+		if s.onRestart != nil {
+			s.onRestart(name, total, err, delay)
+		}
+
+		if err := s.clock.Sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// backoff returns the delay before the n-th restart in the window (1-based)
+func (s *supervisor) backoff(n int) time.Duration {
+	delay := s.baseDelay << (n - 1)
+	if delay > s.maxDelay || delay <= 0 { // <= 0 guards against shift overflow
+		delay = s.maxDelay
+	}
+	return delay
+}
+
+// runSafely calls fn, turning a panic into a *PanicError
+func runSafely(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return fn(ctx)
+}
@@ -0,0 +1,188 @@
+package conc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// restartLog records every OnRestart call; the supervisor calls it from its own goroutine
+type restartLog struct {
+	mu     sync.Mutex
+	errs   []error
+	delays []time.Duration
+}
+
+func (l *restartLog) record(_ string, _ int, err error, delay time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errs = append(l.errs, err)
+	l.delays = append(l.delays, delay)
+}
+
+func (l *restartLog) count() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.delays)
+}
+
+func TestSupervisePanicsThenSucceeds(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := runningFake(t)
+	var log restartLog
+	attempts := 0
+	done := Supervise(context.Background(), fake, "chef", func(context.Context) error {
+		attempts++
+		if attempts <= 3 {
+			panic(fmt.Sprintf("pineapple #%d", attempts))
+		}
+		return nil
+	}, WithOnRestart(log.record))
+
+	if err := <-done; err != nil {
+		t.Fatalf("Supervise = %v, want nil after the fourth attempt", err)
+	}
+	if _, open := <-done; open {
+		t.Error("the result channel wasn't closed after its value")
+	}
+	if attempts != 4 || len(log.errs) != 3 {
+		t.Fatalf("%d attempts, %d restarts; want 4 and 3", attempts, len(log.errs))
+	}
+	for i, err := range log.errs {
+		var pe *PanicError
+		if !errors.As(err, &pe) || pe.Value != fmt.Sprintf("pineapple #%d", i+1) || len(pe.Stack) == 0 {
+			t.Errorf("restart %d after %v, want a *PanicError with the panic value and a stack", i+1, err)
+		}
+	}
+	if got := fake.Since(epoch); got != 700*time.Millisecond {
+		t.Errorf("took %v, want the default 100ms + 200ms + 400ms", got)
+	}
+}
+
+func TestSuperviseBackoffSchedule(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := runningFake(t)
+	var log restartLog
+	var attemptsAt []time.Duration
+	errBurnt := errors.New("burnt the sauce")
+	done := Supervise(context.Background(), fake, "chef", func(context.Context) error {
+		attemptsAt = append(attemptsAt, fake.Since(epoch))
+		if len(attemptsAt) <= 4 {
+			return errBurnt
+		}
+		return nil
+	}, WithBackoff(100*time.Millisecond, 250*time.Millisecond), WithRestartBudget(10, 0), WithOnRestart(log.record))
+
+	if err := <-done; err != nil {
+		t.Fatalf("Supervise = %v", err)
+	}
+	ms := time.Millisecond
+	if want := []time.Duration{100 * ms, 200 * ms, 250 * ms, 250 * ms}; !slices.Equal(log.delays, want) {
+		t.Errorf("delays %v, want %v: doubling, then capped", log.delays, want)
+	}
+	if want := []time.Duration{0, 100 * ms, 300 * ms, 550 * ms, 800 * ms}; !slices.Equal(attemptsAt, want) {
+		t.Errorf("attempts at %v, want %v", attemptsAt, want)
+	}
+	for _, err := range log.errs {
+		if !errors.Is(err, errBurnt) {
+			t.Errorf("OnRestart got %v, want fn's own error", err)
+		}
+	}
+}
+
+func TestSuperviseRestartBudget(t *testing.T) {
+	tests := []struct {
+		name     string
+		delay    time.Duration // Every restart waits this long
+		failures int           // fn fails this many times, then succeeds
+		wantErr  bool
+	}{
+		// 2 restarts a second: at 0 and 400ms both are still in the window at 800ms
+		{"restarts too close together", 400 * time.Millisecond, 3, true},
+		// At 1.2s the restart at 0 has slid out of the window, so a third is allowed
+		{"restarts spread out", 600 * time.Millisecond, 3, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutil.WaitForGoroutines(t)
+			fake := runningFake(t)
+			errFire := errors.New("oven on fire")
+			calls := 0
+			err := <-Supervise(context.Background(), fake, "chef", func(context.Context) error {
+				calls++
+				if calls <= tt.failures {
+					return errFire
+				}
+				return nil
+			}, WithBackoff(tt.delay, tt.delay), WithRestartBudget(2, time.Second))
+
+			if !tt.wantErr {
+				if err != nil || calls != tt.failures+1 {
+					t.Errorf("Supervise = %v after %d calls, want nil after %d", err, calls, tt.failures+1)
+				}
+				return
+			}
+			if !errors.Is(err, ErrRestartBudget) || !errors.Is(err, errFire) {
+				t.Errorf("Supervise = %v, want ErrRestartBudget wrapping the last failure", err)
+			}
+			if want := "chef: restart budget exhausted (2 restarts): oven on fire"; err.Error() != want {
+				t.Errorf("Supervise = %q, want %q", err, want)
+			}
+		})
+	}
+}
+
+func TestSuperviseCancellationStopsRestarts(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := runningFake(t)
+	ctx, cancel := fake.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+
+	// Attempts at 0 and 100ms; the 200ms backoff after the second outlasts the deadline
+	var log restartLog
+	calls := 0
+	err := <-Supervise(ctx, fake, "chef", func(context.Context) error {
+		calls++
+		return errors.New("dropped the tray")
+	}, WithRestartBudget(100, 0), WithOnRestart(log.record))
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Supervise = %v, want context.DeadlineExceeded", err)
+	}
+	if got := fake.Since(epoch); got != 250*time.Millisecond {
+		t.Errorf("stopped at %v, want at the 250ms deadline", got)
+	}
+	fake.Advance(time.Minute)
+	if calls != 2 || log.count() != 2 {
+		t.Errorf("%d calls and %d restarts, want 2 of each and none after the deadline", calls, log.count())
+	}
+}
+
+// A function that fails because its context ended isn't restarted
+func TestSuperviseCancelledWhileRunning(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	var log restartLog
+	started := make(chan struct{})
+	done := Supervise(ctx, runningFake(t), "chef", func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return errors.New("interrupted mid-order")
+	}, WithOnRestart(log.record))
+
+	<-started
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Supervise = %v, want context.Canceled", err)
+	}
+	if n := log.count(); n != 0 {
+		t.Errorf("%d restarts, want none", n)
+	}
+}
@@ -0,0 +1,137 @@
+package conc
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+)
+
+// Throttle passes on at most one value per d. The first value of a quiet
+// period goes out immediately and opens a window; values arriving inside the
+// window replace each other, and the latest is sent when the window ends.
+// When in closes, a pending value is still sent when its window ends, so the
+// rate limit holds to the last value. Windows are timed on clk.
+func Throttle[T any](ctx context.Context, clk clock.Clock, in <-chan T, d time.Duration) <-chan T {
+	out := make(chan T)
+
+	go func() {
+		defer close(out)
+
+		var window clock.Timer
+		var windowEnd <-chan time.Time // nil while no window is open
+		var pending T
+		hasPending := false
+
+		defer func() {
+			if window != nil {
+				window.Stop()
+			}
+		}()
+
+		// emit opens a new window, then sends v
+		emit := func(v T) bool {
+			window = clk.NewTimer(d)
+			windowEnd = window.C()
+			select {
+			case out <- v:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-in:
+				if !ok {
+					if hasPending {
+						select {
+						case <-windowEnd:
+							emit(pending)
+						case <-ctx.Done():
+						}
+					}
+					return
+				}
+				if windowEnd == nil {
+					if !emit(v) {
+						return
+					}
+					continue
+				}
+				pending, hasPending = v, true // Inside a window: keep only the latest
+			case <-windowEnd:
+				windowEnd = nil
+				if hasPending {
+					hasPending = false
+					if !emit(pending) {
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return out
+}
+
+// Debounce waits until values stop arriving for d on clk, then sends the
+// latest one. When in closes, the burst is over: a pending value is sent at once.
+func Debounce[T any](ctx context.Context, clk clock.Clock, in <-chan T, d time.Duration) <-chan T {
+	out := make(chan T)
+
+	go func() {
+		defer close(out)
+
+		var timer clock.Timer
+		var quiet <-chan time.Time // Fires once no value has arrived for d
+		var latest T
+		hasLatest := false
+
+		defer func() {
+			if timer != nil {
+				timer.Stop()
+			}
+		}()
+
+		send := func(v T) bool {
+			select {
+			case out <- v:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-in:
+				if !ok {
+					if hasLatest {
+						send(latest)
+					}
+					return
+				}
+				latest, hasLatest = v, true
+				if timer != nil {
+					timer.Stop() // Every value restarts the quiet period
+				}
+				timer = clk.NewTimer(d)
+				quiet = timer.C()
+			case <-quiet:
+				quiet = nil
+				hasLatest = false
+				if !send(latest) {
+					return
+				}
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,200 @@
+package conc
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// timed is an output and when it arrived, from the start of the test
+type timed struct {
+	at time.Duration
+	v  string
+}
+
+// timerClock is a fake clock that reports every timer it makes, so a test
+// can wait for the code under test to arm one before moving time past it
+type timerClock struct {
+	*clock.FakeClock
+	made chan struct{}
+}
+
+func (c *timerClock) NewTimer(d time.Duration) clock.Timer {
+	t := c.FakeClock.NewTimer(d)
+	c.made <- struct{}{}
+	return t
+}
+
+// stream drives a channel operator one step at a time. Only the test sends
+// values and moves the clock, and each output is recorded with its time.
+type stream[O any] struct {
+	t     *testing.T
+	clock *timerClock
+	in    chan string
+	out   <-chan O
+	got   []timed
+}
+
+func newStream[O any](t *testing.T, start func(clk clock.Clock, in <-chan string) <-chan O) *stream[O] {
+	s := &stream[O]{
+		t:     t,
+		clock: &timerClock{FakeClock: clock.NewFake(epoch), made: make(chan struct{}, 10)},
+		in:    make(chan string),
+	}
+	s.out = start(s.clock, s.in)
+	return s
+}
+
+// send hands over each value; in is unbuffered, so it returns once the operator has them
+func (s *stream[O]) send(values ...string) {
+	for _, v := range values {
+		s.in <- v
+	}
+}
+
+// armed waits until the operator has made a timer
+func (s *stream[O]) armed() {
+	select {
+	case <-s.clock.made:
+	case <-time.After(time.Second): // Guard only; a correct run never waits here
+		s.t.Fatal("no timer was armed")
+	}
+}
+
+// at moves the clock to at from the start of the test
+func (s *stream[O]) at(at time.Duration) { s.clock.Advance(epoch.Add(at).Sub(s.clock.Now())) }
+
+// expect records the next output, or "closed"
+func (s *stream[O]) expect() {
+	select {
+	case v, ok := <-s.out:
+		got := "closed"
+		if ok {
+			got = fmt.Sprint(v)
+		}
+		s.got = append(s.got, timed{s.clock.Since(epoch), got})
+	case <-time.After(time.Second): // Guard only
+		s.t.Fatalf("nothing arrived after %v", s.got)
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	ms := time.Millisecond
+	s := newStream(t, func(clk clock.Clock, in <-chan string) <-chan string {
+		return Throttle(context.Background(), clk, in, 500*ms)
+	})
+
+	s.send("a") // Leading edge: sent at once, and opens a window
+	s.armed()
+	s.expect()
+	s.at(100 * ms)
+	s.send("b")
+	s.at(200 * ms)
+	s.send("c")
+	s.at(500 * ms) // Window ends: only the latest of b and c
+	s.armed()
+	s.expect()
+	s.at(1200 * ms) // The window c opened ends at 1000ms with nothing pending
+	s.send("d")     // A new leading edge
+	s.armed()
+	s.expect()
+	s.at(1300 * ms)
+	s.send("e")
+	s.at(1400 * ms)
+	close(s.in)
+	s.at(1700 * ms) // Pending when in closed, and still held to its window
+	s.expect()
+	s.expect()
+
+	want := []timed{{0, "a"}, {500 * ms, "c"}, {1200 * ms, "d"}, {1700 * ms, "e"}, {1700 * ms, "closed"}}
+	if !slices.Equal(s.got, want) {
+		t.Errorf("throttled %v, want %v", s.got, want)
+	}
+}
+
+func TestDebounce(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	ms := time.Millisecond
+	s := newStream(t, func(clk clock.Clock, in <-chan string) <-chan string {
+		return Debounce(context.Background(), clk, in, 300*ms)
+	})
+
+	// sendAt sends v at the given time and waits for it to restart the quiet period
+	sendAt := func(at time.Duration, v string) {
+		s.at(at)
+		s.send(v)
+		s.armed()
+	}
+	sendAt(0, "a")
+	sendAt(100*ms, "b")
+	sendAt(200*ms, "c")
+	s.at(500 * ms) // 300ms after the last of the burst
+	s.expect()
+	sendAt(700*ms, "d")
+	sendAt(900*ms, "e") // Supersedes d and restarts its quiet period
+	s.at(1100 * ms)     // d's deadline passes: nothing
+	s.at(1200 * ms)
+	s.expect()
+	sendAt(1500*ms, "f")
+	s.at(1550 * ms)
+	close(s.in) // Flushed at once
+	s.expect()
+	s.expect()
+
+	want := []timed{{500 * ms, "c"}, {1200 * ms, "e"}, {1550 * ms, "f"}, {1550 * ms, "closed"}}
+	if !slices.Equal(s.got, want) {
+		t.Errorf("debounced %v, want %v", s.got, want)
+	}
+}
+
+// Cancelling closes the output without sending what's pending, even if
+// nobody is reading
+func TestThrottleDebounceCancel(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       func(context.Context, clock.Clock, <-chan string, time.Duration) <-chan string
+		leading bool // Sends the first value at once
+	}{
+		{"throttle", Throttle[string], true},
+		{"debounce", Debounce[string], false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutil.WaitForGoroutines(t)
+			ctx, cancel := context.WithCancel(context.Background())
+			in := make(chan string)
+			out := tt.f(ctx, clock.NewFake(epoch), in, 300*time.Millisecond) // Time never moves
+
+			in <- "x"
+			if tt.leading {
+				if v := <-out; v != "x" {
+					t.Fatalf("leading value = %q, want x", v)
+				}
+			}
+			in <- "y" // Pending when the context is cancelled
+			cancel()
+			if v, ok := <-out; ok {
+				t.Errorf("got %q after cancel, want the output closed", v)
+			}
+		})
+	}
+}
+
+// A consumer that stops reading doesn't strand the goroutine once ctx ends
+func TestThrottleStuckConsumer(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	fake := clock.NewFake(epoch)
+	in := make(chan string)
+	Throttle(ctx, fake, in, time.Second)
+	in <- "nobody reads this"
+	fake.BlockUntil(1) // The window timer is running and the send is blocked
+	cancel()
+}
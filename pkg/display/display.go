@@ -0,0 +1,184 @@
+// Package display is how lessons print. A Printer's goroutine is the only
+// code that writes to its io.Writer, so lines printed from many goroutines
+// at once come out whole.
+package display
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+)
+
+// ErrClosed is returned by Write after Close
+var ErrClosed = errors.New("display: printer closed")
+
+// Printer is the only goroutine that writes to its io.Writer. Printf formats
+// the line in the caller and hands it over a channel, so lines from many
+// goroutines arrive whole and in the order the printer received them.
+type Printer struct {
+	lines chan msg
+	done  chan struct{}
+
+	mu     sync.RWMutex // Held for reading while sending, so Close can't close lines under a sender
+	closed bool
+
+	deterministic bool // Set once by NewPrinter, read by Duration and Deterministic
+}
+
+// msg is a formatted line, or a Flush marker when flushed is set
+type msg struct {
+	text    string
+	flushed chan struct{}
+}
+
+// Option customizes NewPrinter
+type Option func(*config)
+
+type config struct {
+	timestamps    bool
+	deterministic bool
+	clock         clock.Clock
+}
+
+// WithTimestamps prefixes every line with a sequence number and the time
+// since the printer started
+func WithTimestamps() Option {
+	return func(c *config) {
+		c.timestamps = true
+	}
+}
+
+// WithClock measures timestamps on c instead of the real clock, so they
+// stay nominal under -speed and fixed on a fake clock
+func WithClock(c clock.Clock) Option {
+	return func(cfg *config) {
+		cfg.clock = c
+	}
+}
+
+// WithDeterministic is for output compared byte for byte, such as a golden
+// file: Duration prints coarse buckets, timestamps are left off, and
+// Deterministic reports true so callers leave out what the scheduler
+// decides, such as which worker took an order
+func WithDeterministic() Option {
+	return func(c *config) {
+		c.deterministic = true
+	}
+}
+
+// NewPrinter starts the goroutine that owns w
+func NewPrinter(w io.Writer, opts ...Option) *Printer {
+	cfg := config{clock: clock.Real()}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	cfg.timestamps = cfg.timestamps && !cfg.deterministic
+	p := &Printer{lines: make(chan msg, 64), done: make(chan struct{}), deterministic: cfg.deterministic}
+
+	go func() {
+		defer close(p.done)
+		start := cfg.clock.Now()
+		seq := 0
+		for m := range p.lines {
+			if m.flushed != nil {
+				close(m.flushed)
+				continue
+			}
+			text := m.text
+			if cfg.timestamps {
+				var b strings.Builder
+				for _, line := range strings.SplitAfter(text, "\n") {
+					if strings.TrimSpace(line) == "" {
+						b.WriteString(line)
+						continue
+					}
+					seq++
+					fmt.Fprintf(&b, "[%04d +%7.3fs] %s", seq, cfg.clock.Since(start).Seconds(), line)
+				}
+				text = b.String()
+			}
+			io.WriteString(w, text) // One write per message, so it can't be split
+		}
+	}()
+	return p
+}
+
+// Deterministic reports whether the printer was started WithDeterministic
+func (p *Printer) Deterministic() bool {
+	return p.deterministic
+}
+
+// Duration formats d for printing, rounded to the millisecond. A
+// deterministic printer cuts it down to whole 100ms steps below a second
+// and whole seconds above, as "~200ms" and "~2s": scheduling jitter only
+// ever adds a few milliseconds, so it rarely crosses a step.
+func (p *Printer) Duration(d time.Duration) string {
+	if !p.deterministic {
+		return d.Round(time.Millisecond).String()
+	}
+	if d < time.Second {
+		return fmt.Sprintf("~%dms", d.Truncate(100*time.Millisecond).Milliseconds())
+	}
+	return fmt.Sprintf("~%ds", int64(d.Truncate(time.Second).Seconds()))
+}
+
+// send queues m, and reports false if the printer is closed
+func (p *Printer) send(m msg) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return false
+	}
+	p.lines <- m
+	return true
+}
+
+// Printf formats a message and queues it for the printer. After Close the
+// message is dropped, so a goroutine a lesson leaves behind can't crash it.
+func (p *Printer) Printf(format string, args ...any) {
+	p.send(msg{text: fmt.Sprintf(format, args...)})
+}
+
+// Print is fmt.Print through the printer
+func (p *Printer) Print(args ...any) {
+	p.send(msg{text: fmt.Sprint(args...)})
+}
+
+// Println is fmt.Println through the printer
+func (p *Printer) Println(args ...any) {
+	p.send(msg{text: fmt.Sprintln(args...)})
+}
+
+// Write queues b as one message, so a log.Logger or fmt.Fprintf can print
+// through the printer too
+func (p *Printer) Write(b []byte) (int, error) {
+	if !p.send(msg{text: string(b)}) {
+		return 0, ErrClosed
+	}
+	return len(b), nil
+}
+
+// Flush blocks until every message queued before it has been written
+func (p *Printer) Flush() {
+	flushed := make(chan struct{})
+	if p.send(msg{flushed: flushed}) {
+		<-flushed
+	}
+}
+
+// Close writes any queued messages and stops the printer. Calling it again
+// does nothing.
+func (p *Printer) Close() {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.lines)
+	}
+	p.mu.Unlock()
+	<-p.done
+}
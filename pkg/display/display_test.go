@@ -0,0 +1,185 @@
+package display
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+)
+
+// 200 goroutines print at once; every line must arrive whole
+func TestPrinterHammer(t *testing.T) {
+	const goroutines, perGoroutine = 200, 50
+	// payload is long and multi-byte, the kind of line that shows a split
+	payload := strings.Repeat("🍜🍣🥟", 20)
+
+	var buf bytes.Buffer
+	p := NewPrinter(&buf)
+	var wg sync.WaitGroup
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perGoroutine {
+				switch i % 3 {
+				case 0:
+					p.Printf("goroutine %03d line %02d %s end\n", g, i, payload)
+				case 1:
+					p.Println(fmt.Sprintf("goroutine %03d line %02d", g, i), payload, "end")
+				default:
+					fmt.Fprintf(p, "goroutine %03d line %02d %s end\n", g, i, payload)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	p.Close()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != goroutines*perGoroutine {
+		t.Fatalf("got %d lines, want %d", len(lines), goroutines*perGoroutine)
+	}
+	next := make([]int, goroutines) // Next line number expected from each goroutine
+	for _, line := range lines {
+		var g, i int
+		var rest string
+		if n, _ := fmt.Sscanf(line, "goroutine %d line %d %s", &g, &i, &rest); n != 3 || rest != payload || !strings.HasSuffix(line, " end") {
+			t.Fatalf("split or mangled line: %q", line)
+		}
+		if i != next[g] {
+			t.Fatalf("goroutine %d: line %d came out before line %d", g, i, next[g])
+		}
+		next[g]++
+	}
+}
+
+func TestPrinterTimestamps(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	var buf bytes.Buffer
+	p := NewPrinter(&buf, WithTimestamps(), WithClock(fake))
+	p.Printf("\n=== HEADER ===\n\n")
+	p.Printf("first\n")
+	p.Flush()
+	fake.Advance(1500 * time.Millisecond)
+	p.Printf("second\nthird\n")
+	p.Close()
+
+	want := "\n[0001 +  0.000s] === HEADER ===\n\n" +
+		"[0002 +  0.000s] first\n" +
+		"[0003 +  1.500s] second\n" +
+		"[0004 +  1.500s] third\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPrinterDuration(t *testing.T) {
+	tests := []struct {
+		d             time.Duration
+		exact, coarse string
+	}{
+		{0, "0s", "~0ms"},
+		{1234567 * time.Nanosecond, "1ms", "~0ms"},
+		{250 * time.Millisecond, "250ms", "~200ms"},
+		{999 * time.Millisecond, "999ms", "~900ms"},
+		{time.Second, "1s", "~1s"},
+		{2*time.Second + 3*time.Millisecond, "2.003s", "~2s"},
+		{12500 * time.Millisecond, "12.5s", "~12s"},
+	}
+	exact, coarse := NewPrinter(&bytes.Buffer{}), NewPrinter(&bytes.Buffer{}, WithDeterministic())
+	defer exact.Close()
+	defer coarse.Close()
+	if exact.Deterministic() || !coarse.Deterministic() {
+		t.Errorf("Deterministic() = %v and %v, want false and true", exact.Deterministic(), coarse.Deterministic())
+	}
+	for _, tt := range tests {
+		if got := exact.Duration(tt.d); got != tt.exact {
+			t.Errorf("Duration(%v) = %q, want %q", tt.d, got, tt.exact)
+		}
+		if got := coarse.Duration(tt.d); got != tt.coarse {
+			t.Errorf("deterministic Duration(%v) = %q, want %q", tt.d, got, tt.coarse)
+		}
+	}
+}
+
+// Timestamps depend on when lines are printed, so a deterministic printer leaves them off
+func TestPrinterDeterministicDropsTimestamps(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinter(&buf, WithTimestamps(), WithDeterministic())
+	p.Printf("first\n")
+	p.Close()
+	if got := buf.String(); got != "first\n" {
+		t.Errorf("got %q, want the line without a timestamp", got)
+	}
+}
+
+func TestPrinterFlush(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinter(&buf)
+	defer p.Close()
+	p.Printf("one\n")
+	p.Print("two", "\n")
+	p.Flush()
+	// Safe to read: Flush waited for the writes
+	if got := buf.String(); got != "one\ntwo\n" {
+		t.Errorf("after Flush the buffer holds %q", got)
+	}
+}
+
+func TestPrinterAsLogOutput(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinter(&buf)
+	logger := log.New(p, "⚠️  ", log.Lmsgprefix)
+	logger.Printf("order %d is late", 7)
+	p.Close()
+	if got := buf.String(); got != "⚠️  order 7 is late\n" {
+		t.Errorf("logged %q", got)
+	}
+}
+
+func TestPrinterAfterClose(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinter(&buf)
+	p.Printf("kept\n")
+	p.Close()
+	p.Close()
+
+	p.Printf("dropped\n")
+	p.Flush()
+	if _, err := p.Write([]byte("dropped\n")); !errors.Is(err, ErrClosed) {
+		t.Errorf("Write after Close = %v, want ErrClosed", err)
+	}
+	if got := buf.String(); got != "kept\n" {
+		t.Errorf("wrote %q", got)
+	}
+}
+
+// Close while goroutines are still printing must neither panic nor lose a
+// line queued before it
+func TestPrinterCloseWhilePrinting(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinter(&buf)
+	var wg sync.WaitGroup
+	for g := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 100 {
+				p.Printf("%d-%d\n", g, i)
+			}
+		}()
+	}
+	p.Close()
+	wg.Wait()
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if slices.ContainsFunc(lines, func(l string) bool { return l != "" && !strings.Contains(l, "-") }) {
+		t.Errorf("mangled output:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,237 @@
+// Package lesson is what every lesson package has in common: the options
+// its Run takes, and Main, which turns a Run into a program. Each lesson
+// directory keeps a main.go that is only
+//
+//	func main() { lesson.Main(workerpool.Run) }
+//
+// so go run main.go still works from inside it, and goconc calls the same
+// Run in-process.
+package lesson
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+)
+
+// Options is everything a lesson is run with
+type Options struct {
+	Args       []string    // Command-line arguments after the program or lesson name
+	Clock      clock.Clock // What the lesson sleeps on and reads the time from; nil is the real clock
+	Stdout     io.Writer   // Where the lesson prints; nil is os.Stdout
+	Timestamps bool        // Number each printed line and add the time since the lesson started
+	// Deterministic makes the output the same every run: random values
+	// come from a fixed seed, durations print in coarse buckets, and
+	// whatever the scheduler decides, such as which worker took an order,
+	// is left out
+	Deterministic bool
+	// Output is "text" for the lesson's usual prose, or "json" for one
+	// machine-readable report per run, written to Stdout, and nothing else
+	Output string
+}
+
+// JSON reports whether the lesson should write a JSON report instead of text
+func (o Options) JSON() bool {
+	return o.Output == "json"
+}
+
+// Out returns o.Stdout, or os.Stdout if it isn't set. A lesson writes its
+// JSON report here; text goes through NewPrinter.
+func (o Options) Out() io.Writer {
+	if o.Stdout == nil {
+		return os.Stdout
+	}
+	return o.Stdout
+}
+
+// DeterministicSeed is the seed Seed picks in deterministic mode
+const DeterministicSeed = 1
+
+// Seed returns the seed a lesson should draw its random values from: asked
+// if it isn't 0, DeterministicSeed in deterministic mode, and a fresh seed
+// otherwise
+func (o Options) Seed(asked int64) int64 {
+	switch {
+	case asked != 0:
+		return asked
+	case o.Deterministic:
+		return DeterministicSeed
+	default:
+		return time.Now().UnixNano()
+	}
+}
+
+// ClockOrReal returns o.Clock, or clock.Real() if it isn't set
+func (o Options) ClockOrReal() clock.Clock {
+	if o.Clock == nil {
+		return clock.Real()
+	}
+	return o.Clock
+}
+
+// NewPrinter starts the printer a lesson prints through: to o.Stdout, with
+// timestamps on the lesson's clock if o.Timestamps is set, and coarse
+// durations and no timestamps if o.Deterministic is. With JSON output the
+// text is thrown away, so the report is all that reaches Stdout. Run closes
+// it before returning.
+func (o Options) NewPrinter() *display.Printer {
+	w := o.Out()
+	if o.JSON() {
+		w = io.Discard
+	}
+	opts := []display.Option{display.WithClock(o.ClockOrReal())}
+	if o.Timestamps {
+		opts = append(opts, display.WithTimestamps())
+	}
+	if o.Deterministic {
+		opts = append(opts, display.WithDeterministic())
+	}
+	return display.NewPrinter(w, opts...)
+}
+
+// Parse builds Options from a lesson's command line. Flags every lesson
+// takes are removed from args wherever they appear, and the rest are left
+// in Args, in order, for the lesson's own flag set:
+//
+//	-speed=N     Run N times faster, or slower below 1. Every sleep on the
+//	             lesson's clock is divided by N, and durations measured on
+//	             it are multiplied back, so printed times stay nominal.
+//	-timestamps  Number each printed line and add the time since the
+//	             lesson started.
+//	-deterministic
+//	             Print the same output every run, for comparing runs.
+//	-output=F    text, the default, or json: write one JSON report per
+//	             run, for lessons that report results, and no text.
+//
+// A bad value is a usage error.
+func Parse(args []string) (Options, error) {
+	opts := Options{Output: "text"}
+	speed := 1.0
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			opts.Args = append(opts.Args, args[i:]...)
+			break
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		switch {
+		case !strings.HasPrefix(arg, "-"):
+			opts.Args = append(opts.Args, arg)
+			continue
+		case name == "timestamps" || name == "deterministic":
+			on := true
+			if hasValue {
+				var err error
+				if on, err = strconv.ParseBool(value); err != nil {
+					return Options{}, Usage(fmt.Errorf("-%s must be true or false, not %q", name, value))
+				}
+			}
+			if name == "timestamps" {
+				opts.Timestamps = on
+			} else {
+				opts.Deterministic = on
+			}
+			continue
+		case name != "speed" && name != "output":
+			opts.Args = append(opts.Args, arg)
+			continue
+		}
+		if !hasValue {
+			if i+1 == len(args) {
+				return Options{}, Usage(fmt.Errorf("-%s needs a value", name))
+			}
+			i++
+			value = args[i]
+		}
+		if name == "output" {
+			if value != "text" && value != "json" {
+				return Options{}, Usage(fmt.Errorf("-output must be text or json, not %q", value))
+			}
+			opts.Output = value
+			continue
+		}
+		var err error
+		if speed, err = ParseSpeed(value); err != nil {
+			return Options{}, Usage(err)
+		}
+	}
+	if speed != 1 {
+		opts.Clock = clock.Scaled(clock.Real(), speed)
+	}
+	return opts, nil
+}
+
+// ParseSpeed reads a -speed value, which must be a positive, finite number
+func ParseSpeed(value string) (float64, error) {
+	speed, err := strconv.ParseFloat(value, 64)
+	if err != nil || !(speed > 0) || math.IsInf(speed, 0) {
+		return 0, fmt.Errorf("-speed must be a positive number, not %q", value)
+	}
+	return speed, nil
+}
+
+// Speed is how many times faster than real time the lesson's clock runs
+func (o Options) Speed() float64 {
+	if c, ok := o.Clock.(*clock.ScaledClock); ok {
+		return c.Speed()
+	}
+	return 1
+}
+
+// Func is the signature of every lesson's Run. It returns once the lesson
+// has finished printing, or with the error that stopped it.
+type Func func(ctx context.Context, opts Options) error
+
+// UsageError is an error in the arguments rather than in the run, such as
+// an unknown flag or a value out of range
+type UsageError struct {
+	Err error
+}
+
+func (e *UsageError) Error() string { return e.Err.Error() }
+func (e *UsageError) Unwrap() error { return e.Err }
+
+// Usage marks err as a usage error. A nil err stays nil.
+func Usage(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &UsageError{Err: err}
+}
+
+// ExitCode is the exit status a program should end with after err: 0 for
+// nil, 2 for a usage error or -h, and 1 for anything else
+func ExitCode(err error) int {
+	var usage *UsageError
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp), errors.As(err, &usage):
+		return 2
+	}
+	return 1
+}
+
+// Main runs run with the program's arguments, as Parse reads them, and
+// exits with ExitCode. The error is printed to stderr first, unless it is
+// flag.ErrHelp, whose usage text the flag set has already printed.
+func Main(run Func) {
+	opts, err := Parse(os.Args[1:])
+	if err == nil {
+		err = run(context.Background(), opts)
+	}
+	if err != nil && !errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	os.Exit(ExitCode(err))
+}
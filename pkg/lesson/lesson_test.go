@@ -0,0 +1,232 @@
+package lesson
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		wantArgs  []string
+		wantSpeed float64
+	}{
+		{"no args", nil, nil, 1},
+		{"lesson flags pass through", []string{"-orders=5", "-seed", "3"}, []string{"-orders=5", "-seed", "3"}, 1},
+		{"speed with =", []string{"-speed=10"}, nil, 10},
+		{"speed as next arg", []string{"-orders=5", "-speed", "10", "-seed=3"}, []string{"-orders=5", "-seed=3"}, 10},
+		{"double dash", []string{"--speed=2", "run"}, []string{"run"}, 2},
+		{"slower", []string{"-speed=0.5"}, nil, 0.5},
+		{"speed 1 keeps the real clock", []string{"-speed=1"}, nil, 1},
+		{"-- ends parsing", []string{"-speed=4", "--", "-speed=8"}, []string{"--", "-speed=8"}, 4},
+		{"not a flag", []string{"speed=3"}, []string{"speed=3"}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := Parse(tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(opts.Args, tt.wantArgs) {
+				t.Errorf("Args = %q, want %q", opts.Args, tt.wantArgs)
+			}
+			if got := opts.Speed(); got != tt.wantSpeed {
+				t.Errorf("Speed() = %v, want %v", got, tt.wantSpeed)
+			}
+			if tt.wantSpeed == 1 && opts.Clock != nil {
+				t.Errorf("Clock = %T, want nil at speed 1", opts.Clock)
+			}
+		})
+	}
+}
+
+func TestParseRejectsBadSpeed(t *testing.T) {
+	for _, args := range [][]string{
+		{"-speed=0"},
+		{"-speed=-1"},
+		{"-speed=NaN"},
+		{"-speed=Inf"},
+		{"-speed=fast"},
+		{"-speed"},
+		{"-timestamps=sometimes"},
+		{"-deterministic=maybe"},
+		{"-output=yaml"},
+		{"-output"},
+	} {
+		_, err := Parse(args)
+		var usage *UsageError
+		if !errors.As(err, &usage) {
+			t.Errorf("Parse(%q) = %v, want a usage error", args, err)
+		}
+		if code := ExitCode(err); code != 2 {
+			t.Errorf("Parse(%q): exit code %d, want 2", args, code)
+		}
+	}
+}
+
+func TestParseTimestamps(t *testing.T) {
+	tests := []struct {
+		args     []string
+		want     bool
+		wantArgs []string
+	}{
+		{nil, false, nil},
+		{[]string{"-timestamps"}, true, nil},
+		{[]string{"-orders=3", "--timestamps", "-seed=1"}, true, []string{"-orders=3", "-seed=1"}},
+		{[]string{"-timestamps=false"}, false, nil},
+		{[]string{"-timestamps", "-timestamps=0"}, false, nil},
+	}
+	for _, tt := range tests {
+		opts, err := Parse(tt.args)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.args, err)
+		}
+		if opts.Timestamps != tt.want || !slices.Equal(opts.Args, tt.wantArgs) {
+			t.Errorf("Parse(%q) = timestamps %v, args %q; want %v, %q", tt.args, opts.Timestamps, opts.Args, tt.want, tt.wantArgs)
+		}
+	}
+}
+
+func TestParseDeterministic(t *testing.T) {
+	tests := []struct {
+		args     []string
+		want     bool
+		wantArgs []string
+	}{
+		{nil, false, nil},
+		{[]string{"-deterministic"}, true, nil},
+		{[]string{"-orders=3", "--deterministic", "-seed=1"}, true, []string{"-orders=3", "-seed=1"}},
+		{[]string{"-deterministic=false"}, false, nil},
+	}
+	for _, tt := range tests {
+		opts, err := Parse(tt.args)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.args, err)
+		}
+		if opts.Deterministic != tt.want || !slices.Equal(opts.Args, tt.wantArgs) {
+			t.Errorf("Parse(%q) = deterministic %v, args %q; want %v, %q", tt.args, opts.Deterministic, opts.Args, tt.want, tt.wantArgs)
+		}
+	}
+}
+
+func TestParseOutput(t *testing.T) {
+	tests := []struct {
+		args     []string
+		want     string
+		wantArgs []string
+	}{
+		{nil, "text", nil},
+		{[]string{"-output=json"}, "json", nil},
+		{[]string{"-orders=3", "--output", "json", "-seed=1"}, "json", []string{"-orders=3", "-seed=1"}},
+		{[]string{"-output=json", "-output=text"}, "text", nil},
+	}
+	for _, tt := range tests {
+		opts, err := Parse(tt.args)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.args, err)
+		}
+		if opts.Output != tt.want || opts.JSON() != (tt.want == "json") || !slices.Equal(opts.Args, tt.wantArgs) {
+			t.Errorf("Parse(%q) = output %q, args %q; want %q, %q", tt.args, opts.Output, opts.Args, tt.want, tt.wantArgs)
+		}
+	}
+}
+
+func TestSeed(t *testing.T) {
+	if got := (Options{}).Seed(42); got != 42 {
+		t.Errorf("Seed(42) = %d, want the seed asked for", got)
+	}
+	if got := (Options{Deterministic: true}).Seed(42); got != 42 {
+		t.Errorf("deterministic Seed(42) = %d, want the seed asked for", got)
+	}
+	if got := (Options{Deterministic: true}).Seed(0); got != DeterministicSeed {
+		t.Errorf("deterministic Seed(0) = %d, want %d", got, DeterministicSeed)
+	}
+	if a, b := (Options{}).Seed(0), (Options{}).Seed(0); a == 0 || a == b {
+		t.Errorf("Seed(0) gave %d then %d, want two fresh seeds", a, b)
+	}
+}
+
+// A deterministic lesson prints coarse durations and no timestamps, even if they were asked for
+func TestNewPrinterDeterministic(t *testing.T) {
+	var buf bytes.Buffer
+	out := Options{Stdout: &buf, Timestamps: true, Deterministic: true}.NewPrinter()
+	out.Printf("took %s\n", out.Duration(2300*time.Millisecond))
+	out.Close()
+	if want := "took ~2s\n"; buf.String() != want {
+		t.Errorf("printed %q, want %q", buf.String(), want)
+	}
+}
+
+// With JSON output the printer's text is dropped, so only the report reaches Stdout
+func TestNewPrinterJSON(t *testing.T) {
+	var buf bytes.Buffer
+	opts := Options{Stdout: &buf, Output: "json"}
+	out := opts.NewPrinter()
+	out.Printf("🏪 open\n")
+	out.Close()
+	fmt.Fprintln(opts.Out(), `{"schema":"test"}`)
+	if want := "{\"schema\":\"test\"}\n"; buf.String() != want {
+		t.Errorf("printed %q, want %q", buf.String(), want)
+	}
+}
+
+func TestNewPrinter(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	var buf bytes.Buffer
+	out := Options{Clock: fake, Stdout: &buf, Timestamps: true}.NewPrinter()
+	out.Printf("🏪 open\n")
+	out.Flush()
+	fake.Advance(2 * time.Second)
+	out.Printf("🏁 closed\n")
+	out.Close()
+	if want := "[0001 +  0.000s] 🏪 open\n[0002 +  2.000s] 🏁 closed\n"; buf.String() != want {
+		t.Errorf("printed %q, want %q", buf.String(), want)
+	}
+}
+
+func TestSpeedKeepsPrintedDurationsNominal(t *testing.T) {
+	opts, err := Parse([]string{"-speed=100"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	clk := opts.ClockOrReal()
+	start, realStart := clk.Now(), time.Now()
+	if err := clk.Sleep(context.Background(), time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if got := clk.Since(start); got < time.Second || got > 2*time.Second {
+		t.Errorf("a 1s sleep at 100x measured %v, want about 1s", got)
+	}
+	if took := time.Since(realStart); took > 500*time.Millisecond {
+		t.Errorf("a 1s sleep at 100x took %v of real time", took)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, 0},
+		{flag.ErrHelp, 2},
+		{Usage(errors.New("-orders must not be negative")), 2},
+		{errors.New("oven on fire"), 1},
+	}
+	for _, tt := range tests {
+		if got := ExitCode(tt.err); got != tt.want {
+			t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+	if Usage(nil) != nil {
+		t.Error("Usage(nil) isn't nil")
+	}
+}
@@ -0,0 +1,68 @@
+package order
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kinds of kitchen failure. Burnt dishes can be cooked again and a busy oven
+// frees up; a missing ingredient won't appear by trying again.
+var (
+	ErrBurnt      = errors.New("burnt")        // Retryable
+	ErrOvenBusy   = errors.New("oven busy")    // Retryable
+	ErrOutOfStock = errors.New("out of stock") // Terminal
+)
+
+// OrderError is a failed attempt at one order. It unwraps to the kind of
+// failure, so callers classify it with errors.Is.
+type OrderError struct {
+	OrderID int
+	Attempt int
+	Err     error
+}
+
+func (e *OrderError) Error() string {
+	return fmt.Sprintf("order %d, attempt %d: %v", e.OrderID, e.Attempt, e.Err)
+}
+
+func (e *OrderError) Unwrap() error { return e.Err }
+
+// Retryable reports whether another attempt at the order could succeed
+func Retryable(err error) bool {
+	return errors.Is(err, ErrBurnt) || errors.Is(err, ErrOvenBusy)
+}
+
+// FailureConfig fails a fraction of attempts on purpose, so error paths get
+// exercised. Whether an attempt fails, and how, depends only on Seed, the
+// order ID and the attempt number, so the same seed fails the same orders
+// no matter how many workers race for them.
+type FailureConfig struct {
+	Rate  float64 // Fraction of attempts that fail, 0 to 1
+	Kinds []error // Failures to pick from (empty = ErrBurnt)
+	Seed  int64
+}
+
+// splitmix64 scrambles x into a well-mixed 64-bit value
+func splitmix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ x>>30) * 0xbf58476d1ce4e5b9
+	x = (x ^ x>>27) * 0x94d049bb133111eb
+	return x ^ x>>31
+}
+
+// Failure returns the *OrderError this attempt at the order fails with, or
+// nil if it succeeds. Attempts are numbered from 1.
+func (fc FailureConfig) Failure(orderID, attempt int) error {
+	if fc.Rate <= 0 {
+		return nil
+	}
+	h := splitmix64(uint64(fc.Seed) ^ splitmix64(uint64(orderID)<<16|uint64(attempt)))
+	if float64(h>>11)/(1<<53) >= fc.Rate {
+		return nil
+	}
+	kind := ErrBurnt
+	if len(fc.Kinds) > 0 {
+		kind = fc.Kinds[splitmix64(h)%uint64(len(fc.Kinds))]
+	}
+	return &OrderError{OrderID: orderID, Attempt: attempt, Err: kind}
+}
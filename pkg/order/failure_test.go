@@ -0,0 +1,117 @@
+package order
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+var dinnerFailures = FailureConfig{Rate: 0.4, Kinds: []error{ErrBurnt, ErrOvenBusy, ErrOutOfStock}, Seed: 14}
+
+// firstAttempts returns how the first attempt at each of orders 1..n fails,
+// by order ID, leaving out the ones that succeed
+func firstAttempts(fc FailureConfig, n int) map[int]error {
+	failed := make(map[int]error)
+	for id := 1; id <= n; id++ {
+		if err := fc.Failure(id, 1); err != nil {
+			failed[id] = err
+		}
+	}
+	return failed
+}
+
+// Seed 14 fails exactly these first attempts, each with this kind
+func TestFailurePinnedSeed(t *testing.T) {
+	want := map[int]error{
+		1: ErrBurnt, 2: ErrBurnt, 4: ErrOvenBusy, 5: ErrOutOfStock, 6: ErrBurnt, 8: ErrBurnt,
+		9: ErrOvenBusy, 11: ErrBurnt, 12: ErrOutOfStock, 13: ErrBurnt, 15: ErrOvenBusy,
+	}
+	got := firstAttempts(dinnerFailures, 20)
+	if ids := slices.Sorted(maps.Keys(got)); !slices.Equal(ids, slices.Sorted(maps.Keys(want))) {
+		t.Fatalf("failed orders %v, want %v", ids, slices.Sorted(maps.Keys(want)))
+	}
+	for id, err := range got {
+		var oe *OrderError
+		if !errors.As(err, &oe) || oe.OrderID != id || oe.Attempt != 1 || oe.Err != want[id] {
+			t.Errorf("order %d: %v, want order %d, attempt 1: %v", id, err, id, want[id])
+		}
+	}
+}
+
+// The failures depend on the seed, ID and attempt alone, not on which
+// goroutine asks or in what order
+func TestFailureSameFromEveryGoroutine(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	want := fmt.Sprint(firstAttempts(dinnerFailures, 200))
+	var wg sync.WaitGroup
+	got := make([]string, 8)
+	for g := range got {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got[g] = fmt.Sprint(firstAttempts(dinnerFailures, 200))
+		}()
+	}
+	wg.Wait()
+	for g, s := range got {
+		if s != want {
+			t.Errorf("goroutine %d saw different failures", g)
+		}
+	}
+}
+
+func TestFailureSeedAndAttemptMatter(t *testing.T) {
+	other := dinnerFailures
+	other.Seed = 7
+	if a, b := firstAttempts(dinnerFailures, 20), firstAttempts(other, 20); fmt.Sprint(a) == fmt.Sprint(b) {
+		t.Error("seeds 14 and 7 fail the same orders")
+	}
+	// Order 1 is burnt on its first attempt and cooks on its second
+	if err := dinnerFailures.Failure(1, 2); err != nil {
+		t.Errorf("order 1, attempt 2: %v, want it served", err)
+	}
+}
+
+func TestFailureRate(t *testing.T) {
+	testutil.RunParallel(t, []testutil.TestCase{
+		{Name: "rate 0 never fails", Input: FailureConfig{Seed: 1}, Want: 0},
+		{Name: "negative rate never fails", Input: FailureConfig{Rate: -1, Seed: 1}, Want: 0},
+		{Name: "rate 1 always fails", Input: FailureConfig{Rate: 1, Seed: 1}, Want: 10_000},
+		{Name: "rate 0.4 fails about 40%", Input: FailureConfig{Rate: 0.4, Seed: 1}, Want: 4_000},
+	}, func(t *testing.T, tc testutil.TestCase) {
+		got := len(firstAttempts(tc.Input.(FailureConfig), 10_000))
+		if want := tc.Want.(int); got < want-200 || got > want+200 {
+			t.Errorf("%d of 10000 failed, want about %d", got, want)
+		}
+	})
+}
+
+func TestFailureNoKindsBurns(t *testing.T) {
+	fc := FailureConfig{Rate: 1, Seed: 1}
+	for attempt := 1; attempt <= 3; attempt++ {
+		if err := fc.Failure(1, attempt); !errors.Is(err, ErrBurnt) {
+			t.Errorf("attempt %d: %v, want burnt", attempt, err)
+		}
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	testutil.RunParallel(t, []testutil.TestCase{
+		{Name: "burnt", Input: &OrderError{OrderID: 1, Attempt: 1, Err: ErrBurnt}, Want: true},
+		{Name: "oven busy", Input: &OrderError{OrderID: 1, Attempt: 1, Err: ErrOvenBusy}, Want: true},
+		{Name: "out of stock", Input: &OrderError{OrderID: 1, Attempt: 1, Err: ErrOutOfStock}, Want: false},
+		{Name: "wrapped again", Input: fmt.Errorf("table 4: %w", &OrderError{Err: ErrOvenBusy}), Want: true},
+		{Name: "some other error", Input: errors.New("no such dish"), Want: false},
+		{Name: "nil", Input: nil, Want: false},
+	}, func(t *testing.T, tc testutil.TestCase) {
+		err, _ := tc.Input.(error)
+		if got := Retryable(err); got != tc.Want.(bool) {
+			t.Errorf("Retryable(%v) = %v, want %v", err, got, tc.Want)
+		}
+	})
+}
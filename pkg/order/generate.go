@@ -0,0 +1,64 @@
+package order
+
+import (
+	"errors"
+	"flag"
+	"math/rand"
+	"time"
+)
+
+// Options sizes a load run: how many orders to generate, how their prep
+// times are drawn, and how many workers cook them
+type Options struct {
+	Orders  int
+	Seed    int64 // The same seed gives the same batch; lessons pick a fresh one for 0
+	Workers int
+	MaxPrep time.Duration
+}
+
+// AddFlags defines -orders, -seed, -workers and -maxprep on fs, writing
+// into o. o's values when AddFlags is called are the defaults.
+func (o *Options) AddFlags(fs *flag.FlagSet) {
+	fs.IntVar(&o.Orders, "orders", o.Orders, "number of orders to generate (0 is an empty batch)")
+	fs.Int64Var(&o.Seed, "seed", o.Seed, "random seed for prep times; the same seed gives the same orders (0 = random)")
+	fs.IntVar(&o.Workers, "workers", o.Workers, "number of chef goroutines")
+	fs.DurationVar(&o.MaxPrep, "maxprep", o.MaxPrep, "longest prep time; each order gets a random time up to this")
+}
+
+// Validate reports the first option out of range, naming its flag
+func (o Options) Validate() error {
+	if err := o.validateBatch(); err != nil {
+		return err
+	}
+	if o.Workers < 1 {
+		return errors.New("-workers must be at least 1")
+	}
+	return nil
+}
+
+// validateBatch checks the options Generate uses
+func (o Options) validateBatch() error {
+	switch {
+	case o.Orders < 0:
+		return errors.New("-orders must not be negative")
+	case o.MaxPrep <= 0:
+		return errors.New("-maxprep must be positive")
+	}
+	return nil
+}
+
+// Generate makes opts.Orders orders, numbered from 1, with prep times
+// between 1ns and opts.MaxPrep drawn from opts.Seed: the same options give
+// the same batch, and a smaller batch is a prefix of a bigger one. Workers
+// isn't used. Negative Orders or a MaxPrep that isn't positive is an error.
+func Generate(opts Options) ([]Order, error) {
+	if err := opts.validateBatch(); err != nil {
+		return nil, err
+	}
+	r := rand.New(rand.NewSource(opts.Seed))
+	orders := make([]Order, opts.Orders)
+	for i := range orders {
+		orders[i] = Order{ID: i + 1, PrepTime: time.Duration(r.Int63n(int64(opts.MaxPrep))) + 1}
+	}
+	return orders, nil
+}
@@ -0,0 +1,52 @@
+package order
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Metrics counts orders with atomics, so workers and whoever hands them out
+// update it without a lock. The zero value is ready to use.
+type Metrics struct {
+	submitted atomic.Int64
+	rejected  atomic.Int64
+	completed atomic.Int64
+	waitNanos atomic.Int64 // Total time orders spent queued
+	maxWait   atomic.Int64
+}
+
+// Submit counts an order accepted into the queue
+func (m *Metrics) Submit() { m.submitted.Add(1) }
+
+// Reject counts an order turned away
+func (m *Metrics) Reject() { m.rejected.Add(1) }
+
+// Complete counts a finished order that waited in the queue for wait, and
+// raises the longest wait if this one beats it
+func (m *Metrics) Complete(wait time.Duration) {
+	m.waitNanos.Add(int64(wait))
+	for cur := m.maxWait.Load(); int64(wait) > cur && !m.maxWait.CompareAndSwap(cur, int64(wait)); cur = m.maxWait.Load() {
+	}
+	m.completed.Add(1)
+}
+
+// MetricsSnapshot is a point-in-time copy of Metrics
+type MetricsSnapshot struct {
+	Submitted, Rejected, Completed int64
+	AvgWait, MaxWait               time.Duration
+}
+
+// Snapshot reads every counter. Counters are read one by one, so a snapshot
+// taken while orders flow can be off by the orders in flight.
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	s := MetricsSnapshot{
+		Submitted: m.submitted.Load(),
+		Rejected:  m.rejected.Load(),
+		Completed: m.completed.Load(),
+		MaxWait:   time.Duration(m.maxWait.Load()),
+	}
+	if s.Completed > 0 {
+		s.AvgWait = time.Duration(m.waitNanos.Load() / s.Completed)
+	}
+	return s
+}
@@ -0,0 +1,62 @@
+package order
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+func TestMetricsZeroValue(t *testing.T) {
+	var m Metrics
+	if s := m.Snapshot(); s != (MetricsSnapshot{}) {
+		t.Errorf("Snapshot of unused Metrics = %+v, want zero", s)
+	}
+}
+
+func TestMetricsAverageAndMax(t *testing.T) {
+	var m Metrics
+	for _, ms := range []int{10, 40, 25} {
+		m.Submit()
+		m.Complete(time.Duration(ms) * time.Millisecond)
+	}
+	m.Reject()
+	want := MetricsSnapshot{Submitted: 3, Rejected: 1, Completed: 3, AvgWait: 25 * time.Millisecond, MaxWait: 40 * time.Millisecond}
+	if s := m.Snapshot(); s != want {
+		t.Errorf("Snapshot = %+v, want %+v", s, want)
+	}
+}
+
+// 8 goroutines record 1000 orders each with no lock: every count is exact,
+// and the longest wait survives every CompareAndSwap that raced with it
+func TestMetricsConcurrentUpdates(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	var m Metrics
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				m.Submit()
+				if i%10 == 0 {
+					m.Reject()
+				}
+				m.Complete(time.Duration(g*1000+i) * time.Microsecond)
+			}
+		}()
+	}
+	wg.Wait()
+
+	s := m.Snapshot()
+	if s.Submitted != 8000 || s.Rejected != 800 || s.Completed != 8000 {
+		t.Errorf("counts %+v, want 8000 submitted, 800 rejected, 8000 completed", s)
+	}
+	if s.MaxWait != 7999*time.Microsecond {
+		t.Errorf("MaxWait = %v, want 7.999ms", s.MaxWait)
+	}
+	if want := 3999500 * time.Nanosecond; s.AvgWait != want { // Mean of 0..7999µs
+		t.Errorf("AvgWait = %v, want %v", s.AvgWait, want)
+	}
+}
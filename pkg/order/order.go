@@ -0,0 +1,166 @@
+// Package order is the order every lesson cooks. Generate makes a
+// reproducible batch of them for a load run, and a Ticket is an order a
+// customer can cancel while the kitchen cooks it: the customer's cancel
+// handle and the kitchen's work item. ProcessWithTimeout cooks one against
+// a time limit, FailureConfig fails orders on purpose, and Metrics counts
+// them with atomics.
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+)
+
+type Order struct {
+	ID       int
+	Dish     string
+	PrepTime time.Duration
+}
+
+// ErrInvalidOrder is wrapped by every Validate error
+var ErrInvalidOrder = errors.New("invalid order")
+
+// Validate rejects orders that can't be cooked. A zero-value Order fails
+// instead of finishing instantly as if it had been cooked.
+func (o Order) Validate() error {
+	switch {
+	case o.ID <= 0:
+		return fmt.Errorf("%w: ID %d, want a positive ID", ErrInvalidOrder, o.ID)
+	case o.PrepTime <= 0:
+		return fmt.Errorf("%w: order %d has prep time %v", ErrInvalidOrder, o.ID, o.PrepTime)
+	}
+	return nil
+}
+
+var (
+	ErrTooLate          = errors.New("too late: order already completed")
+	ErrAlreadyCancelled = errors.New("order already cancelled")
+)
+
+// CancelledError reports an order stopped by its customer and how far cooking got
+type CancelledError struct {
+	ID       int
+	Progress float64 // 0 = never started, 1 = every step done
+}
+
+func (e *CancelledError) Error() string {
+	return fmt.Sprintf("order %d cancelled at %.0f%%", e.ID, e.Progress*100)
+}
+
+type ticketState int
+
+const (
+	statePlaced ticketState = iota
+	stateCooking
+	stateDone
+	stateCancelled
+)
+
+// Ticket is a placed order. The customer holds it to Cancel; the kitchen
+// cooks it in steps and stops at the next step boundary, or mid-step, once
+// the ticket's context is cancelled.
+type Ticket struct {
+	Order
+	ctx     context.Context
+	cancel  context.CancelFunc
+	started chan struct{} // Closed when the first step begins
+	done    chan struct{} // Closed when cooking finishes or is abandoned
+
+	mu       sync.Mutex
+	state    ticketState
+	progress float64
+	err      error
+}
+
+// Place creates a ticket whose context derives from parent
+func Place(parent context.Context, o Order) *Ticket {
+	ctx, cancel := context.WithCancel(parent)
+	return &Ticket{Order: o, ctx: ctx, cancel: cancel, started: make(chan struct{}), done: make(chan struct{})}
+}
+
+// Cancel stops the order. It returns ErrTooLate if the order is already
+// complete, which leaves it untouched, and ErrAlreadyCancelled on a second call.
+func (t *Ticket) Cancel() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	switch t.state {
+	case stateDone:
+		return ErrTooLate
+	case stateCancelled:
+		return ErrAlreadyCancelled
+	}
+	t.state = stateCancelled
+	t.cancel()
+	return nil
+}
+
+// Wait blocks until the kitchen is finished with the ticket and returns nil
+// or a *CancelledError
+func (t *Ticket) Wait() error {
+	<-t.done
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.err
+}
+
+// Started returns a channel that closes when cooking begins. It never closes
+// for a ticket cancelled before it started.
+func (t *Ticket) Started() <-chan struct{} {
+	return t.started
+}
+
+// Progress returns the share of cooking steps done so far
+func (t *Ticket) Progress() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.progress
+}
+
+// Cook prepares the order in steps timed on clk, checking for cancellation
+// in each one. A ticket cancelled before it starts is abandoned at 0%.
+func (t *Ticket) Cook(clk clock.Clock, steps int) error {
+	defer close(t.done)
+
+	t.mu.Lock()
+	if t.state == stateCancelled {
+		t.err = &CancelledError{ID: t.ID}
+		t.mu.Unlock()
+		return t.err
+	}
+	t.state = stateCooking
+	t.mu.Unlock()
+	close(t.started)
+
+	stepTime := t.PrepTime / time.Duration(steps)
+	step := clk.NewTimer(stepTime)
+	defer step.Stop()
+	for i := 1; i <= steps; i++ {
+		select {
+		case <-step.C():
+			t.mu.Lock()
+			t.progress = float64(i) / float64(steps)
+			t.mu.Unlock()
+			step.Reset(stepTime)
+		case <-t.ctx.Done():
+			t.mu.Lock()
+			t.err = &CancelledError{ID: t.ID, Progress: t.progress}
+			t.mu.Unlock()
+			return t.err
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.state == stateCancelled { // Cancel won the race with the last step
+		t.err = &CancelledError{ID: t.ID, Progress: t.progress}
+		return t.err
+	}
+	t.state = stateDone
+	t.cancel() // Release the context's resources
+	return nil
+}
@@ -0,0 +1,261 @@
+package order
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+var ramen = Order{ID: 1, Dish: "Ramen", PrepTime: 200 * time.Millisecond}
+
+// cookSteps starts t cooking in 10 steps on a fake clock and lets n of them
+// finish
+func cookSteps(t *testing.T, tk *Ticket, n int) *clock.FakeClock {
+	t.Helper()
+	fake := clock.NewFake(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	go tk.Cook(fake, 10)
+	for range n {
+		fake.BlockUntil(1)
+		fake.Advance(tk.PrepTime / 10)
+	}
+	fake.BlockUntil(1) // The step after the last one is under way
+	return fake
+}
+
+func TestCancelBeforeStart(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	tk := Place(context.Background(), ramen)
+	if err := tk.Cancel(); err != nil {
+		t.Fatalf("Cancel = %v", err)
+	}
+	err := tk.Cook(clock.NewFake(time.Time{}), 10) // A fake clock nobody advances: any step would hang
+	var ce *CancelledError
+	if !errors.As(err, &ce) || ce.ID != 1 || ce.Progress != 0 {
+		t.Errorf("Cook = %v, want cancelled at 0%%", err)
+	}
+	select {
+	case <-tk.Started():
+		t.Error("Started closed for an order that never cooked")
+	default:
+	}
+}
+
+func TestCancelMidCook(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	tk := Place(context.Background(), ramen)
+	cookSteps(t, tk, 5)
+	if err := tk.Cancel(); err != nil {
+		t.Fatalf("Cancel = %v", err)
+	}
+	// Wait returns with the clock stopped mid-step: the chef didn't finish it
+	err := tk.Wait()
+	var ce *CancelledError
+	if !errors.As(err, &ce) || ce.Progress != 0.5 {
+		t.Errorf("Wait = %v, want cancelled at 50%%", err)
+	}
+	if err.Error() != "order 1 cancelled at 50%" {
+		t.Errorf("Error() = %q", err)
+	}
+}
+
+func TestCancelAfterDone(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	tk := Place(context.Background(), ramen)
+	cookSteps(t, tk, 9).Advance(ramen.PrepTime / 10)
+	if err := tk.Wait(); err != nil {
+		t.Fatalf("Wait = %v", err)
+	}
+	if err := tk.Cancel(); !errors.Is(err, ErrTooLate) {
+		t.Errorf("Cancel = %v, want ErrTooLate", err)
+	}
+	if err := tk.Wait(); err != nil || tk.Progress() != 1 {
+		t.Errorf("after a late cancel: Wait = %v, progress %v; want nil, 1", err, tk.Progress())
+	}
+}
+
+func TestDoubleCancel(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	tk := Place(context.Background(), ramen)
+	cookSteps(t, tk, 2)
+	first, second := tk.Cancel(), tk.Cancel()
+	if first != nil || !errors.Is(second, ErrAlreadyCancelled) {
+		t.Errorf("Cancel, Cancel = %v, %v; want nil, ErrAlreadyCancelled", first, second)
+	}
+	var ce *CancelledError
+	if err := tk.Wait(); !errors.As(err, &ce) || ce.Progress != 0.2 {
+		t.Errorf("Wait = %v, want cancelled at 20%%", err)
+	}
+}
+
+func TestParentCancel(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	parent, cancelAll := context.WithCancel(context.Background())
+	tk := Place(parent, ramen)
+	cookSteps(t, tk, 3)
+	cancelAll()
+	var ce *CancelledError
+	if err := tk.Wait(); !errors.As(err, &ce) || ce.Progress != 0.3 {
+		t.Errorf("Wait = %v, want cancelled at 30%%", err)
+	}
+}
+
+// Cancel racing the last step: the customer and the kitchen must agree on
+// who won
+func TestCancelRacesCompletion(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	for range 200 {
+		tk := Place(context.Background(), Order{ID: 1, PrepTime: 10 * time.Microsecond})
+		go tk.Cook(clock.Real(), 2)
+		<-tk.Started()
+		cancelErr := tk.Cancel()
+		err := tk.Wait()
+		switch {
+		case cancelErr == nil && err == nil:
+			t.Fatal("Cancel succeeded but the order completed")
+		case errors.Is(cancelErr, ErrTooLate) && err != nil:
+			t.Fatalf("Cancel said too late but the order ended with %v", err)
+		}
+	}
+}
+
+var batch = Options{Orders: 1000, Seed: 42, Workers: 4, MaxPrep: 100 * time.Millisecond}
+
+func TestGenerateSameSeedSameBatch(t *testing.T) {
+	a, err := Generate(batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := Generate(batch)
+	if !slices.Equal(a, b) {
+		t.Error("two batches from seed 42 differ")
+	}
+	other := batch
+	other.Seed = 43
+	if c, _ := Generate(other); slices.Equal(a, c) {
+		t.Error("seeds 42 and 43 gave the same batch")
+	}
+	for i, o := range a {
+		if o.ID != i+1 || o.PrepTime < 1 || o.PrepTime > batch.MaxPrep {
+			t.Fatalf("order %d = %+v, want ID %d and prep in (0, %v]", i, o, i+1, batch.MaxPrep)
+		}
+	}
+}
+
+func TestGenerateBoundaries(t *testing.T) {
+	full, _ := Generate(batch)
+	testutil.RunParallel(t, []testutil.TestCase{
+		{Name: "0 orders: empty batch", Input: 0, Want: full[:0]},
+		{Name: "1 order: prefix of the bigger batch", Input: 1, Want: full[:1]},
+		{Name: "negative orders rejected", Input: -1, Want: "-orders must not be negative"},
+	}, func(t *testing.T, tc testutil.TestCase) {
+		opts := batch
+		opts.Orders = tc.Input.(int)
+		got, err := Generate(opts)
+		if msg, ok := tc.Want.(string); ok {
+			if err == nil || err.Error() != msg || got != nil {
+				t.Errorf("Generate = %v, %v, want error %q", got, err, msg)
+			}
+			return
+		}
+		if err != nil || !slices.Equal(got, tc.Want.([]Order)) {
+			t.Errorf("Generate = %v, %v, want %v", got, err, tc.Want)
+		}
+	})
+}
+
+func TestOptionsFlags(t *testing.T) {
+	defaults := Options{Orders: 100, Workers: 4, MaxPrep: 100 * time.Millisecond}
+	testutil.RunParallel(t, []testutil.TestCase{
+		{Name: "no flags keep the defaults", Input: []string{}, Want: defaults},
+		{Name: "every flag", Input: []string{"-orders=200", "-seed=7", "-workers=8", "-maxprep=1s"},
+			Want: Options{Orders: 200, Seed: 7, Workers: 8, MaxPrep: time.Second}},
+		{Name: "zero orders accepted", Input: []string{"-orders=0"}, Want: Options{Workers: 4, MaxPrep: 100 * time.Millisecond}},
+		{Name: "negative orders rejected", Input: []string{"-orders=-1"}, Want: "-orders must not be negative"},
+		{Name: "zero workers rejected", Input: []string{"-workers=0"}, Want: "-workers must be at least 1"},
+		{Name: "zero prep rejected", Input: []string{"-maxprep=0s"}, Want: "-maxprep must be positive"},
+	}, func(t *testing.T, tc testutil.TestCase) {
+		opts := defaults
+		fs := flag.NewFlagSet("orders", flag.ContinueOnError)
+		opts.AddFlags(fs)
+		if err := fs.Parse(tc.Input.([]string)); err != nil {
+			t.Fatal(err)
+		}
+		err := opts.Validate()
+		if msg, ok := tc.Want.(string); ok {
+			if err == nil || err.Error() != msg {
+				t.Errorf("Validate = %v, want %q", err, msg)
+			}
+			return
+		}
+		if err != nil || opts != tc.Want {
+			t.Errorf("got %+v, %v, want %+v", opts, err, tc.Want)
+		}
+	})
+}
+
+// Three hand-built results with known latencies: 10ms, 30ms and a failed 20ms
+func TestSummarize(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(ms int) time.Time { return t0.Add(time.Duration(ms) * time.Millisecond) }
+	three := []Result{
+		{Order: Order{ID: 1}, StartedAt: at(0), FinishedAt: at(10)},
+		{Order: Order{ID: 2}, StartedAt: at(5), FinishedAt: at(35)},
+		{Order: Order{ID: 3}, StartedAt: at(10), FinishedAt: at(30), Err: errors.New("burnt")},
+	}
+
+	testutil.RunParallel(t, []testutil.TestCase{
+		{Name: "no results, zero summary", Input: []Result(nil), Want: Summary{}},
+		{Name: "one result", Input: three[:1],
+			Want: Summary{Orders: 1, Total: 10 * time.Millisecond, Max: 10 * time.Millisecond, Avg: 10 * time.Millisecond, Start: t0, Wall: 10 * time.Millisecond}},
+		{Name: "total, max, average and wall", Input: three,
+			Want: Summary{Orders: 3, Failed: 1, Total: 60 * time.Millisecond, Max: 30 * time.Millisecond, Avg: 20 * time.Millisecond, Start: t0, Wall: 35 * time.Millisecond}},
+		{Name: "input order doesn't matter", Input: []Result{three[2], three[1], three[0]},
+			Want: Summary{Orders: 3, Failed: 1, Total: 60 * time.Millisecond, Max: 30 * time.Millisecond, Avg: 20 * time.Millisecond, Start: t0, Wall: 35 * time.Millisecond}},
+	}, func(t *testing.T, tc testutil.TestCase) {
+		if got := Summarize(tc.Input.([]Result)); got != tc.Want.(Summary) {
+			t.Errorf("Summarize = %+v, want %+v", got, tc.Want)
+		}
+	})
+}
+
+func TestPrintSummary(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	results := []Result{ // In completion order, not ID order
+		{Order: Order{ID: 2, PrepTime: time.Second}, Worker: 1, StartedAt: t0, FinishedAt: t0.Add(time.Second)},
+		{Order: Order{ID: 1, PrepTime: 2 * time.Second}, Worker: 2, StartedAt: t0, FinishedAt: t0.Add(2 * time.Second)},
+		{Order: Order{ID: 3}, StartedAt: t0.Add(time.Second), FinishedAt: t0.Add(time.Second), Err: ErrInvalidOrder},
+	}
+
+	testutil.RunParallel(t, []testutil.TestCase{
+		{Name: "workers shown", Input: []display.Option(nil), Want: `Order   Worker        Prep    Started   Finished    Latency
+1       2               2s         0s         2s         2s
+2       1               1s         0s         1s         1s
+3       -               0s         1s         1s         0s  ❌ invalid order
+
+📦 3 order(s), 1 failed | Total 3s | Max 2s | Avg 1s | Wall 2s
+`},
+		{Name: "deterministic hides workers", Input: []display.Option{display.WithDeterministic()}, Want: `Order   Worker        Prep    Started   Finished    Latency
+1       -              ~2s       ~0ms        ~2s        ~2s
+2       -              ~1s       ~0ms        ~1s        ~1s
+3       -             ~0ms        ~1s        ~1s       ~0ms  ❌ invalid order
+
+📦 3 order(s), 1 failed | Total ~3s | Max ~2s | Avg ~1s | Wall ~2s
+`},
+	}, func(t *testing.T, tc testutil.TestCase) {
+		var buf bytes.Buffer
+		out := display.NewPrinter(&buf, tc.Input.([]display.Option)...)
+		PrintSummary(out, results)
+		out.Close()
+		if got := buf.String(); got != tc.Want.(string) {
+			t.Errorf("PrintSummary printed\n%s\nwant\n%s", got, tc.Want)
+		}
+	})
+}
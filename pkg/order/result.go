@@ -0,0 +1,85 @@
+package order
+
+import (
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/display"
+)
+
+// Result is what happened to one order. Workers return Results instead of
+// printing, and the caller prints them all once every worker is done.
+type Result struct {
+	Order      Order
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Worker     int // 0 when the order had a goroutine to itself
+	Err        error
+}
+
+// Latency is how long the order took from start to finish
+func (r Result) Latency() time.Duration {
+	return r.FinishedAt.Sub(r.StartedAt)
+}
+
+// Summary is the totals over a set of Results
+type Summary struct {
+	Orders, Failed int
+	Total          time.Duration // Sum of every order's latency
+	Max, Avg       time.Duration
+	Start          time.Time     // Earliest StartedAt
+	Wall           time.Duration // Earliest start to latest finish
+}
+
+// Summarize adds up results; an empty slice gives a zero Summary
+func Summarize(results []Result) Summary {
+	var s Summary
+	var end time.Time
+	for _, r := range results {
+		s.Orders++
+		if r.Err != nil {
+			s.Failed++
+		}
+		s.Total += r.Latency()
+		s.Max = max(s.Max, r.Latency())
+		if s.Start.IsZero() || r.StartedAt.Before(s.Start) {
+			s.Start = r.StartedAt
+		}
+		if r.FinishedAt.After(end) {
+			end = r.FinishedAt
+		}
+	}
+	if s.Orders > 0 {
+		s.Avg = s.Total / time.Duration(s.Orders)
+		s.Wall = end.Sub(s.Start)
+	}
+	return s
+}
+
+// PrintSummary prints results to out sorted by order ID, with each order's
+// latency and the totals. Start and finish times are relative to the
+// earliest start. A deterministic printer shows - for every worker, since
+// which worker takes which order is up to the scheduler.
+func PrintSummary(out *display.Printer, results []Result) {
+	sorted := slices.Clone(results)
+	slices.SortFunc(sorted, func(a, b Result) int { return a.Order.ID - b.Order.ID })
+	s := Summarize(sorted)
+
+	out.Printf("%-7s %-7s %10s %10s %10s %10s\n", "Order", "Worker", "Prep", "Started", "Finished", "Latency")
+	for _, r := range sorted {
+		worker := "-"
+		if r.Worker > 0 && !out.Deterministic() {
+			worker = strconv.Itoa(r.Worker)
+		}
+		failed := ""
+		if r.Err != nil {
+			failed = "  ❌ " + r.Err.Error()
+		}
+		out.Printf("%-7d %-7s %10s %10s %10s %10s%s\n", r.Order.ID, worker, out.Duration(r.Order.PrepTime),
+			out.Duration(r.StartedAt.Sub(s.Start)), out.Duration(r.FinishedAt.Sub(s.Start)),
+			out.Duration(r.Latency()), failed)
+	}
+	out.Printf("\n📦 %d order(s), %d failed | Total %s | Max %s | Avg %s | Wall %s\n", s.Orders, s.Failed,
+		out.Duration(s.Total), out.Duration(s.Max), out.Duration(s.Avg), out.Duration(s.Wall))
+}
@@ -0,0 +1,51 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+)
+
+// ErrTooSlow is returned by ProcessWithTimeout for an order that ran past its limit
+var ErrTooSlow = errors.New("order exceeded its time limit")
+
+// cook prepares o on clk, giving up as soon as ctx is done
+func cook(ctx context.Context, clk clock.Clock, o Order) error {
+	prep := clk.NewTimer(o.PrepTime)
+	defer prep.Stop()
+	select {
+	case <-prep.C():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ProcessWithTimeout cooks o on clk with its own deadline of limit. If
+// cooking runs past the limit it returns ErrTooSlow, and the cooking
+// goroutine has stopped by the time it returns. If ctx itself ends first,
+// ctx's error is returned instead. Either way the Result's start and finish
+// record how long the kitchen spent, and its Err is the error returned.
+func ProcessWithTimeout(ctx context.Context, clk clock.Clock, o Order, limit time.Duration) (Result, error) {
+	orderCtx, cancel := clk.WithTimeout(ctx, limit)
+	defer cancel()
+
+	res := Result{Order: o, StartedAt: clk.Now()}
+	done := make(chan error, 1) // Buffered: the cook never blocks on sending
+	go func() {
+		done <- cook(orderCtx, clk, o)
+	}()
+
+	err := <-done // The cook watches orderCtx, so this returns by the deadline
+	res.FinishedAt = clk.Now()
+	switch {
+	case err == nil:
+	case ctx.Err() != nil:
+		res.Err = ctx.Err() // The caller gave up, not the SLA
+	default:
+		res.Err = ErrTooSlow
+	}
+	return res, res.Err
+}
@@ -0,0 +1,111 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+type timeoutRun struct {
+	res Result
+	err error
+}
+
+// startWithTimeout runs ProcessWithTimeout in the background and waits
+// until waiters timers are pending on fake: the order's deadline, the
+// cooking, and any the caller's ctx added
+func startWithTimeout(ctx context.Context, fake *clock.FakeClock, o Order, limit time.Duration, waiters int) <-chan timeoutRun {
+	ch := make(chan timeoutRun, 1)
+	go func() {
+		res, err := ProcessWithTimeout(ctx, fake, o, limit)
+		ch <- timeoutRun{res, err}
+	}()
+	fake.BlockUntil(waiters)
+	return ch
+}
+
+func TestProcessWithTimeoutTooSlow(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := clock.NewFake(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	slow := Order{ID: 1, PrepTime: 300 * time.Millisecond}
+	run := startWithTimeout(context.Background(), fake, slow, 100*time.Millisecond, 2)
+
+	fake.Advance(100 * time.Millisecond)
+	r := <-run
+	if !errors.Is(r.err, ErrTooSlow) || !errors.Is(r.res.Err, ErrTooSlow) {
+		t.Errorf("err = %v, Result.Err = %v; want ErrTooSlow for both", r.err, r.res.Err)
+	}
+	if d := r.res.Latency(); d != 100*time.Millisecond {
+		t.Errorf("Latency = %v, want the 100ms limit", d)
+	}
+	if r.res.Order != slow {
+		t.Errorf("Result.Order = %+v, want %+v", r.res.Order, slow)
+	}
+	if n := fake.Waiters(); n != 0 {
+		t.Errorf("%d timers left after the timeout, want the cooking stopped", n)
+	}
+}
+
+func TestProcessWithTimeoutCompletes(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := clock.NewFake(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	fast := Order{ID: 2, PrepTime: 50 * time.Millisecond}
+	run := startWithTimeout(context.Background(), fake, fast, 100*time.Millisecond, 2)
+
+	fake.Advance(50 * time.Millisecond)
+	r := <-run
+	if r.err != nil || r.res.Err != nil {
+		t.Errorf("err = %v, Result.Err = %v; want nil", r.err, r.res.Err)
+	}
+	if d := r.res.Latency(); d != 50*time.Millisecond {
+		t.Errorf("Latency = %v, want its own 50ms", d)
+	}
+	if n := fake.Waiters(); n != 0 {
+		t.Errorf("%d timers left after completing, want the deadline released", n)
+	}
+}
+
+// The caller's own deadline ending first is not an SLA miss
+func TestProcessWithTimeoutCallerDeadline(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := clock.NewFake(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	ctx, cancel := fake.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	run := startWithTimeout(ctx, fake, Order{ID: 3, PrepTime: 300 * time.Millisecond}, time.Second, 3)
+
+	fake.Advance(30 * time.Millisecond)
+	r := <-run
+	if !errors.Is(r.err, context.DeadlineExceeded) || errors.Is(r.err, ErrTooSlow) {
+		t.Errorf("err = %v, want the caller's DeadlineExceeded and not ErrTooSlow", r.err)
+	}
+	if d := r.res.Latency(); d != 30*time.Millisecond {
+		t.Errorf("Latency = %v, want 30ms", d)
+	}
+}
+
+func TestProcessWithTimeoutCallerCancelled(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := clock.NewFake(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	res, err := ProcessWithTimeout(ctx, fake, Order{ID: 4, PrepTime: time.Second}, time.Second)
+	if !errors.Is(err, context.Canceled) || res.Latency() != 0 {
+		t.Errorf("ProcessWithTimeout = %v after %v, want Canceled at once", err, res.Latency())
+	}
+}
+
+// 50 timeouts in a row on the real clock; WaitForGoroutines fails the test
+// if any cooking goroutine is still running afterwards
+func TestProcessWithTimeoutLeavesNoGoroutines(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	for i := range 50 {
+		_, err := ProcessWithTimeout(context.Background(), clock.Real(), Order{ID: i + 1, PrepTime: time.Hour}, time.Millisecond)
+		if !errors.Is(err, ErrTooSlow) {
+			t.Fatalf("order %d: %v, want ErrTooSlow", i+1, err)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package pool
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// ElasticStats is a snapshot of an Elastic pool
+type ElasticStats struct {
+	Active    int   // Worker goroutines running, including any finishing their last job
+	Target    int   // Workers asked for by the last SetWorkers
+	Queued    int   // Jobs waiting for a worker
+	Processed int64 // Jobs finished since the pool was created
+}
+
+// Elastic is a worker pool whose size is set at runtime with SetWorkers.
+// Each worker has its own quit channel; retiring a worker closes it, and the
+// worker exits the next time it is between jobs, so a job is never
+// dropped halfway.
+type Elastic[T any] struct {
+	jobs    chan T
+	process func(T)
+
+	mu      sync.Mutex
+	quits   []chan struct{} // One per non-retired worker, newest last
+	running int             // Worker goroutines that haven't exited yet
+	wg      sync.WaitGroup
+
+	processed atomic.Int64
+}
+
+// NewElastic creates a pool with no workers and room for queueSize waiting jobs.
+// Call SetWorkers to start workers.
+func NewElastic[T any](queueSize int, process func(T)) *Elastic[T] {
+	return &Elastic[T]{
+		jobs:    make(chan T, queueSize),
+		process: process,
+	}
+}
+
+// SetWorkers grows or shrinks the pool to n workers. New workers start at
+// once; retired workers finish the job in hand first. Don't call it after Close.
+func (p *Elastic[T]) SetWorkers(n int) {
+	n = max(n, 0)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for len(p.quits) < n {
+		quit := make(chan struct{})
+		p.quits = append(p.quits, quit)
+		p.running++
+		p.wg.Add(1)
+		go p.worker(quit)
+	}
+	for len(p.quits) > n {
+		last := len(p.quits) - 1
+		close(p.quits[last]) // Newest worker retires first
+		p.quits = p.quits[:last]
+	}
+}
+
+// worker runs jobs until it is retired or the queue is closed and empty
+func (p *Elastic[T]) worker(quit <-chan struct{}) {
+	defer func() {
+		p.mu.Lock()
+		p.running--
+		p.mu.Unlock()
+		p.wg.Done()
+	}()
+
+	for {
+		// Retirement wins over a waiting job, so shrinking takes effect promptly
+		select {
+		case <-quit:
+			return
+		default:
+		}
+
+		select {
+		case <-quit:
+			return
+		case job, ok := <-p.jobs:
+			if !ok {
+				return
+			}
+			p.process(job)
+			p.processed.Add(1)
+		}
+	}
+}
+
+// Submit queues a job, blocking while the queue is full
+func (p *Elastic[T]) Submit(job T) {
+	p.jobs <- job
+}
+
+// Stats returns the current size, backlog and throughput
+func (p *Elastic[T]) Stats() ElasticStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return ElasticStats{
+		Active:    p.running,
+		Target:    len(p.quits),
+		Queued:    len(p.jobs),
+		Processed: p.processed.Load(),
+	}
+}
+
+// Close stops intake and waits for the queue to drain and every worker to exit.
+// At least one worker must be running, or the queued jobs never finish.
+func (p *Elastic[T]) Close() {
+	close(p.jobs)
+	p.wg.Wait()
+}
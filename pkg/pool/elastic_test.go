@@ -0,0 +1,171 @@
+package pool
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// eventually polls cond until it holds or a second passes
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("gave up waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// gate holds every job in process until it is opened, counting how many are waiting
+type gate struct {
+	open    chan struct{}
+	waiting atomic.Int32
+}
+
+func newGate() *gate { return &gate{open: make(chan struct{})} }
+
+func (g *gate) process(int) {
+	g.waiting.Add(1)
+	<-g.open
+	g.waiting.Add(-1)
+}
+
+func TestElasticScalesUp(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	g := newGate()
+	p := NewElastic(10, g.process)
+	for id := range 10 {
+		p.Submit(id)
+	}
+
+	for _, n := range []int{2, 5, 8} {
+		p.SetWorkers(n)
+		// Every new worker picks up a job at once
+		eventually(t, "workers to start", func() bool { return int(g.waiting.Load()) == n })
+		if s := p.Stats(); s.Active != n || s.Target != n || s.Queued != 10-n {
+			t.Errorf("SetWorkers(%d): stats %+v, want %d active, %d queued", n, s, n, 10-n)
+		}
+	}
+
+	close(g.open)
+	p.Close()
+	if s := p.Stats(); s.Processed != 10 || s.Active != 0 {
+		t.Errorf("after Close: stats %+v, want 10 processed and no workers", s)
+	}
+}
+
+// Retired workers finish the job in hand and only then exit
+func TestElasticScalesDown(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	g := newGate()
+	p := NewElastic(10, g.process)
+	p.SetWorkers(4)
+	for id := range 6 {
+		p.Submit(id)
+	}
+	eventually(t, "4 jobs in hand", func() bool { return g.waiting.Load() == 4 })
+
+	p.SetWorkers(1)
+	if s := p.Stats(); s.Target != 1 || s.Active != 4 || s.Processed != 0 {
+		t.Errorf("right after SetWorkers(1): stats %+v, want target 1 with all 4 still cooking", s)
+	}
+
+	close(g.open)
+	// The three retired workers finish their jobs and exit; the last one drains the queue
+	eventually(t, "the queue to drain", func() bool { return p.Stats().Processed == 6 })
+	eventually(t, "retired workers to exit", func() bool { return p.Stats().Active == 1 })
+	p.Close()
+}
+
+// With no workers nothing runs, but the jobs wait for the next SetWorkers
+func TestElasticZeroWorkers(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	var processed atomic.Int32
+	p := NewElastic(5, func(int) { processed.Add(1) })
+	p.SetWorkers(1)
+	p.SetWorkers(0)
+	eventually(t, "the worker to retire", func() bool { return p.Stats().Active == 0 })
+
+	for id := range 5 {
+		p.Submit(id)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if n := processed.Load(); n != 0 {
+		t.Fatalf("%d jobs ran with no workers", n)
+	}
+
+	p.SetWorkers(-3) // Treated as 0
+	p.SetWorkers(1)
+	p.Close()
+	if n := processed.Load(); n != 5 {
+		t.Errorf("processed %d, want 5", n)
+	}
+}
+
+// 1000 jobs while another goroutine keeps resizing the pool: none is lost or run twice
+func TestElasticResizeUnderLoad(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	const jobs = 1000
+	var mu sync.Mutex
+	seen := make([]int, jobs)
+	p := NewElastic(50, func(id int) {
+		time.Sleep(time.Duration(id%5) * 100 * time.Microsecond)
+		mu.Lock()
+		seen[id]++
+		mu.Unlock()
+	})
+	p.SetWorkers(4)
+
+	stop := make(chan struct{})
+	resized := make(chan int)
+	go func() {
+		for count, n := 0, 1; ; count, n = count+1, n%10+1 {
+			select {
+			case <-stop:
+				resized <- count
+				return
+			case <-time.After(200 * time.Microsecond):
+				p.SetWorkers(n) // Never 0, so the queue always drains
+			}
+		}
+	}()
+
+	for id := range jobs {
+		p.Submit(id)
+	}
+	close(stop)
+	resizes := <-resized
+	p.Close()
+
+	for id, n := range seen {
+		if n != 1 {
+			t.Errorf("job %d ran %d times", id, n)
+		}
+	}
+	if s := p.Stats(); s.Processed != jobs {
+		t.Errorf("Processed = %d, want %d", s.Processed, jobs)
+	}
+	if resizes < 10 {
+		t.Errorf("only %d resizes happened during the run", resizes)
+	}
+}
+
+// An idle pool retires workers at once, so its goroutines settle on the target
+func TestElasticGoroutinesTrackTarget(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	base := runtime.NumGoroutine()
+	p := NewElastic(10, func(int) {})
+	for _, target := range []int{3, 8, 1, 5, 0} {
+		p.SetWorkers(target)
+		eventually(t, "workers to settle", func() bool { return p.Stats().Active == target })
+		if got := runtime.NumGoroutine() - base; got != target {
+			t.Errorf("SetWorkers(%d): %d worker goroutines", target, got)
+		}
+	}
+}
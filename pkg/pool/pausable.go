@@ -0,0 +1,96 @@
+package pool
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+)
+
+// Pausable is a fixed worker pool that can be paused. While paused, workers
+// finish the job in hand but don't start anything new; jobs keep queueing.
+type Pausable[T any] struct {
+	clock   clock.Clock
+	jobs    chan T
+	process func(job T, started time.Time)
+	wg      sync.WaitGroup
+
+	mu     sync.Mutex
+	cond   *sync.Cond // Broadcast on Resume
+	paused bool
+}
+
+// NewPausable starts workers goroutines reading from a queue of queueSize
+// jobs. process is given each job with the time, on clk, that it started.
+func NewPausable[T any](clk clock.Clock, workers, queueSize int, process func(job T, started time.Time)) *Pausable[T] {
+	p := &Pausable[T]{
+		clock:   clk,
+		jobs:    make(chan T, queueSize),
+		process: process,
+	}
+	p.cond = sync.NewCond(&p.mu)
+
+	for range workers {
+		p.wg.Add(1)
+		go p.worker()
+	}
+	return p
+}
+
+// worker takes the next job, then waits at the gate before starting it.
+// The start is stamped under the same lock Pause takes, so no job can start
+// after Pause returns and before Resume is called.
+func (p *Pausable[T]) worker() {
+	defer p.wg.Done()
+
+	for job := range p.jobs {
+		p.mu.Lock()
+		for p.paused {
+			p.cond.Wait()
+		}
+		started := p.clock.Now()
+		p.mu.Unlock()
+
+		p.process(job, started)
+	}
+}
+
+// Submit queues a job, blocking while the queue is full
+func (p *Pausable[T]) Submit(job T) {
+	p.jobs <- job
+}
+
+// Pause stops workers from starting new jobs. In-flight jobs finish.
+// Pausing a paused pool does nothing.
+func (p *Pausable[T]) Pause() {
+	p.mu.Lock()
+	p.paused = true
+	p.mu.Unlock()
+}
+
+// Resume lets every waiting worker start again. Resuming a running pool does nothing.
+func (p *Pausable[T]) Resume() {
+	p.mu.Lock()
+	p.paused = false
+	p.mu.Unlock()
+	p.cond.Broadcast()
+}
+
+// Paused reports whether the pool is paused
+func (p *Pausable[T]) Paused() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.paused
+}
+
+// Queued returns how many jobs are waiting in the queue
+func (p *Pausable[T]) Queued() int {
+	return len(p.jobs)
+}
+
+// Shutdown stops intake, lifts any pause so the queue drains, and waits for every worker
+func (p *Pausable[T]) Shutdown() {
+	close(p.jobs)
+	p.Resume()
+	p.wg.Wait()
+}
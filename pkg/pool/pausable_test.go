@@ -0,0 +1,180 @@
+package pool
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// starts collects the start stamp of every job a pool runs
+type starts struct {
+	mu    sync.Mutex
+	times []time.Time
+}
+
+func (s *starts) record(_ int, started time.Time) {
+	s.mu.Lock()
+	s.times = append(s.times, started)
+	s.mu.Unlock()
+}
+
+func (s *starts) all() []time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]time.Time(nil), s.times...)
+}
+
+// 4 workers under a steady stream, paused in the middle. Each start is
+// stamped under the lock Pause takes, so none can fall inside the window
+// even on a real clock.
+func TestPausableNothingStartsWhilePaused(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	clk := clock.Real()
+	var s starts
+	p := NewPausable(clk, 4, 1000, func(id int, started time.Time) {
+		s.record(id, started)
+		time.Sleep(200 * time.Microsecond)
+	})
+
+	submitted := make(chan int)
+	go func() {
+		n := 0
+		for end := time.Now().Add(150 * time.Millisecond); time.Now().Before(end); n++ {
+			p.Submit(n)
+			time.Sleep(100 * time.Microsecond)
+		}
+		submitted <- n
+	}()
+
+	time.Sleep(40 * time.Millisecond)
+	p.Pause()
+	pausedAt := clk.Now()
+	time.Sleep(60 * time.Millisecond)
+	queued := p.Queued()
+	resumedAt := clk.Now()
+	p.Resume()
+
+	n := <-submitted
+	p.Shutdown()
+
+	got := s.all()
+	for _, at := range got {
+		if at.After(pausedAt) && at.Before(resumedAt) {
+			t.Errorf("a job started %v into the pause", at.Sub(pausedAt))
+		}
+	}
+	if queued == 0 {
+		t.Error("nothing queued up during the pause")
+	}
+	if len(got) != n {
+		t.Errorf("%d of %d jobs ran", len(got), n)
+	}
+}
+
+// Paused twice, one Resume still lets everything queued run, and only after it
+func TestPausablePauseIsIdempotent(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := clock.NewFake(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	var s starts
+	p := NewPausable(fake, 2, 10, s.record)
+
+	p.Pause()
+	p.Pause()
+	if !p.Paused() {
+		t.Fatal("Paused() = false after Pause")
+	}
+	for id := range 5 {
+		p.Submit(id)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if n := len(s.all()); n != 0 {
+		t.Fatalf("%d jobs started while paused", n)
+	}
+
+	fake.Advance(time.Second)
+	resumedAt := fake.Now()
+	p.Resume()
+	p.Resume() // Resuming a running pool does nothing
+	p.Shutdown()
+
+	got := s.all()
+	if len(got) != 5 {
+		t.Fatalf("%d of 5 jobs ran after Resume", len(got))
+	}
+	for _, at := range got {
+		if at.Before(resumedAt) {
+			t.Errorf("a job started %v before Resume", resumedAt.Sub(at))
+		}
+	}
+}
+
+// A job in hand when Pause is called runs to the end
+func TestPausableInFlightJobFinishes(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	started, release := make(chan struct{}), make(chan struct{})
+	var finished atomic.Bool
+	p := NewPausable(clock.Real(), 1, 10, func(int, time.Time) {
+		close(started)
+		<-release
+		finished.Store(true)
+	})
+	p.Submit(1)
+	<-started
+
+	p.Pause()
+	close(release)
+	eventually(t, "the job in hand to finish", finished.Load)
+
+	p.Resume()
+	p.Shutdown()
+}
+
+// Resume wakes every waiting worker at once, not one per finished job: each
+// job blocks until all 4 are running, so a single wakeup would never finish
+func TestPausableResumeWakesAll(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	var running atomic.Int32
+	allRunning := make(chan struct{})
+	p := NewPausable(clock.Real(), 4, 10, func(int, time.Time) {
+		if running.Add(1) == 4 {
+			close(allRunning)
+		}
+		<-allRunning
+	})
+
+	p.Pause()
+	for id := range 4 {
+		p.Submit(id)
+	}
+	time.Sleep(20 * time.Millisecond) // Let all 4 workers take a job and wait at the gate
+	p.Resume()
+
+	select {
+	case <-allRunning:
+	case <-time.After(time.Second):
+		t.Fatalf("only %d of 4 workers woke on Resume", running.Load())
+	}
+	p.Shutdown()
+}
+
+func TestPausableShutdownWhilePaused(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	var s starts
+	p := NewPausable(clock.Real(), 2, 20, s.record)
+	p.Pause()
+	for id := range 20 {
+		p.Submit(id)
+	}
+	p.Shutdown()
+
+	if n := len(s.all()); n != 20 {
+		t.Errorf("%d of 20 queued jobs ran after Shutdown", n)
+	}
+	if p.Paused() {
+		t.Error("still paused after Shutdown")
+	}
+}
@@ -0,0 +1,5 @@
+// Package pool holds the worker pools lessons grow beyond a fixed set of
+// chefs reading one channel: Elastic resizes at runtime, and Pausable can
+// stop starting new jobs for a while. Every type in it is safe for
+// concurrent use.
+package pool
@@ -0,0 +1,157 @@
+// Package report is the JSON shape of one lesson run: the options it ran
+// with, what happened to every order, and the totals. Each run is one JSON
+// object on one line, so a series of runs can be appended to a file and read
+// back with Decode.
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/order"
+)
+
+// Schema names this version of the format. It changes when a field is
+// renamed or removed, not when one is added.
+const Schema = "go-concurrency/report/v1"
+
+// Run is everything one lesson run reports. Durations are integer
+// nanoseconds, which is how encoding/json writes a time.Duration; the _ns
+// suffix on every name says so.
+type Run struct {
+	Schema  string  `json:"schema"`
+	Lesson  string  `json:"lesson"` // Directory name, e.g. "02-goroutines-and-waitgroups"
+	Options Options `json:"options"`
+	Orders  []Order `json:"orders"` // Sorted by ID
+	Stats   Stats   `json:"stats"`
+}
+
+// Options are the settings the run actually used. Seed is the seed drawn,
+// even when none was asked for, so the run can be repeated.
+type Options struct {
+	Orders  int           `json:"orders"`
+	Workers int           `json:"workers"`
+	Seed    int64         `json:"seed"`
+	MaxPrep time.Duration `json:"max_prep_ns"`
+	Speed   float64       `json:"speed"`
+}
+
+// Order is what happened to one order. Start and Finish are offsets from
+// the earliest start in the run, not wall-clock times, so two runs line up.
+type Order struct {
+	ID       int           `json:"id"`
+	Worker   int           `json:"worker"` // 0 when the order had a goroutine to itself
+	Prep     time.Duration `json:"prep_ns"`
+	Start    time.Duration `json:"start_ns"`
+	Finish   time.Duration `json:"finish_ns"`
+	Duration time.Duration `json:"duration_ns"` // Finish - Start
+	Error    string        `json:"error,omitempty"`
+}
+
+// Stats are the totals over a run's orders
+type Stats struct {
+	Orders int           `json:"orders"`
+	Failed int           `json:"failed"`
+	Wall   time.Duration `json:"wall_ns"` // Earliest start to latest finish
+	Max    time.Duration `json:"max_ns"`
+	Mean   time.Duration `json:"mean_ns"`
+	P95    time.Duration `json:"p95_ns"` // Nearest rank: 95% of orders took this long or less
+}
+
+// FromResults turns a lesson's results into a Run: orders sorted by ID and
+// timed from the earliest start, with Stats worked out by Summarize
+func FromResults(lesson string, opts Options, results []order.Result) Run {
+	sorted := slices.Clone(results)
+	slices.SortFunc(sorted, func(a, b order.Result) int { return a.Order.ID - b.Order.ID })
+	start := order.Summarize(sorted).Start
+
+	r := Run{Schema: Schema, Lesson: lesson, Options: opts, Orders: []Order{}}
+	for _, res := range sorted {
+		o := Order{ID: res.Order.ID, Worker: res.Worker, Prep: res.Order.PrepTime,
+			Start: res.StartedAt.Sub(start), Finish: res.FinishedAt.Sub(start), Duration: res.Latency()}
+		if res.Err != nil {
+			o.Error = res.Err.Error()
+		}
+		r.Orders = append(r.Orders, o)
+	}
+	r.Stats = Summarize(r.Orders)
+	return r
+}
+
+// Summarize works out Stats from orders. An empty slice gives zero Stats.
+func Summarize(orders []Order) Stats {
+	var s Stats
+	if len(orders) == 0 {
+		return s
+	}
+	durations := make([]time.Duration, len(orders))
+	var total, first, last time.Duration
+	for i, o := range orders {
+		durations[i] = o.Duration
+		total += o.Duration
+		if o.Error != "" {
+			s.Failed++
+		}
+		if i == 0 || o.Start < first {
+			first = o.Start
+		}
+		last = max(last, o.Finish)
+	}
+	slices.Sort(durations)
+	s.Orders = len(orders)
+	s.Wall = last - first
+	s.Max = durations[len(durations)-1]
+	s.Mean = total / time.Duration(len(orders))
+	s.P95 = Percentile(durations, 95)
+	return s
+}
+
+// Percentile returns the nearest-rank pth percentile of sorted: the smallest
+// value that at least p percent of the values are at or below. p is clamped
+// to 0..100; an empty slice gives 0.
+func Percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	p = min(max(p, 0), 100)
+	rank := (p*len(sorted) + 99) / 100 // ceil(p/100 × n)
+	return sorted[max(rank, 1)-1]
+}
+
+// Encode writes r as one line of JSON. Schema is filled in if it's empty.
+func Encode(w io.Writer, r Run) error {
+	if r.Schema == "" {
+		r.Schema = Schema
+	}
+	if r.Orders == nil {
+		r.Orders = []Order{} // [] rather than null, so readers can always range over it
+	}
+	return json.NewEncoder(w).Encode(r)
+}
+
+// ErrSchema is returned by Decode for a run written in another format
+var ErrSchema = errors.New("report: unknown schema")
+
+// Decode reads every run from r, one JSON object after another, until EOF
+func Decode(r io.Reader) ([]Run, error) {
+	dec := json.NewDecoder(r)
+	var runs []Run
+	for {
+		var run Run
+		err := dec.Decode(&run)
+		if errors.Is(err, io.EOF) {
+			return runs, nil
+		}
+		if err != nil {
+			return runs, err
+		}
+		if run.Schema != Schema {
+			return runs, fmt.Errorf("%w %q, want %q", ErrSchema, run.Schema, Schema)
+		}
+		runs = append(runs, run)
+	}
+}
@@ -0,0 +1,203 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/order"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+func sampleRun() Run {
+	orders := []Order{
+		{ID: 1, Worker: 1, Prep: 30 * time.Millisecond, Start: 0, Finish: 30 * time.Millisecond, Duration: 30 * time.Millisecond},
+		{ID: 2, Worker: 2, Prep: 10 * time.Millisecond, Start: 5 * time.Millisecond, Finish: 15 * time.Millisecond, Duration: 10 * time.Millisecond, Error: "invalid order"},
+	}
+	return Run{
+		Lesson:  "02-goroutines-and-waitgroups",
+		Options: Options{Orders: 2, Workers: 2, Seed: 7, MaxPrep: 50 * time.Millisecond, Speed: 1},
+		Orders:  orders,
+		Stats:   Summarize(orders),
+	}
+}
+
+// The JSON names are the schema; renaming one breaks every reader
+func TestEncodeFieldNames(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Encode(&buf, sampleRun()); err != nil {
+		t.Fatal(err)
+	}
+	var raw struct {
+		Options map[string]any   `json:"options"`
+		Orders  []map[string]any `json:"orders"`
+		Stats   map[string]any   `json:"stats"`
+	}
+	var top map[string]any
+	for _, v := range []any{&top, &raw} {
+		if err := json.Unmarshal(buf.Bytes(), v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, c := range []struct {
+		what string
+		got  map[string]any
+		want []string
+	}{
+		{"top level", top, []string{"lesson", "options", "orders", "schema", "stats"}},
+		{"options", raw.Options, []string{"max_prep_ns", "orders", "seed", "speed", "workers"}},
+		{"order", raw.Orders[0], []string{"duration_ns", "finish_ns", "id", "prep_ns", "start_ns", "worker"}},
+		{"failed order", raw.Orders[1], []string{"duration_ns", "error", "finish_ns", "id", "prep_ns", "start_ns", "worker"}},
+		{"stats", raw.Stats, []string{"failed", "max_ns", "mean_ns", "orders", "p95_ns", "wall_ns"}},
+	} {
+		keys := make([]string, 0, len(c.got))
+		for k := range c.got {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		if !slices.Equal(keys, c.want) {
+			t.Errorf("%s keys = %v, want %v", c.what, keys, c.want)
+		}
+	}
+	if got := raw.Options["max_prep_ns"]; got != float64(50*time.Millisecond) {
+		t.Errorf("max_prep_ns = %v, want integer nanoseconds", got)
+	}
+}
+
+func TestEncodeOneLine(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Encode(&buf, sampleRun()); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(buf.String(), "\n"); n != 1 || !strings.HasSuffix(buf.String(), "\n") {
+		t.Errorf("wrote %d newlines, want one line ending in one:\n%s", n, buf.String())
+	}
+}
+
+func TestEncodeFillsDefaults(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Encode(&buf, Run{Lesson: "empty"}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"schema":"`+Schema+`"`) {
+		t.Errorf("schema not filled in: %s", buf.String())
+	}
+	if !strings.Contains(buf.String(), `"orders":[]`) {
+		t.Errorf("nil orders not written as []: %s", buf.String())
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	first, second := sampleRun(), sampleRun()
+	second.Options.Seed = 8
+	var buf bytes.Buffer
+	for _, r := range []Run{first, second} {
+		if err := Encode(&buf, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	runs, err := Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first.Schema, second.Schema = Schema, Schema
+	if want := []Run{first, second}; !reflect.DeepEqual(runs, want) {
+		t.Errorf("read back\n%+v\nwant\n%+v", runs, want)
+	}
+}
+
+func TestDecodeEmpty(t *testing.T) {
+	runs, err := Decode(strings.NewReader(""))
+	if err != nil || len(runs) != 0 {
+		t.Errorf("Decode(\"\") = %v, %v", runs, err)
+	}
+}
+
+func TestDecodeWrongSchema(t *testing.T) {
+	var buf bytes.Buffer
+	Encode(&buf, sampleRun())
+	buf.WriteString(`{"schema":"go-concurrency/report/v0","orders":[]}` + "\n")
+
+	runs, err := Decode(&buf)
+	if !errors.Is(err, ErrSchema) {
+		t.Errorf("err = %v, want ErrSchema", err)
+	}
+	if len(runs) != 1 {
+		t.Errorf("got %d runs before the bad one, want 1", len(runs))
+	}
+}
+
+func TestDecodeBadJSON(t *testing.T) {
+	if _, err := Decode(strings.NewReader("{not json")); err == nil || errors.Is(err, ErrSchema) {
+		t.Errorf("err = %v, want a syntax error", err)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	if got := Summarize(nil); got != (Stats{}) {
+		t.Errorf("Summarize(nil) = %+v, want zero", got)
+	}
+
+	got := sampleRun().Stats
+	want := Stats{Orders: 2, Failed: 1, Wall: 30 * time.Millisecond, Max: 30 * time.Millisecond, Mean: 20 * time.Millisecond, P95: 30 * time.Millisecond}
+	if got != want {
+		t.Errorf("Summarize = %+v, want %+v", got, want)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	var sorted []time.Duration
+	for i := 1; i <= 20; i++ {
+		sorted = append(sorted, time.Duration(i)*time.Millisecond)
+	}
+	testutil.RunParallel(t, []testutil.TestCase{
+		{Name: "p95", Input: 95, Want: 19 * time.Millisecond},
+		{Name: "p50", Input: 50, Want: 10 * time.Millisecond},
+		{Name: "p100", Input: 100, Want: 20 * time.Millisecond},
+		{Name: "p0", Input: 0, Want: time.Millisecond},
+		{Name: "below 0", Input: -5, Want: time.Millisecond},
+		{Name: "above 100", Input: 150, Want: 20 * time.Millisecond},
+	}, func(t *testing.T, tc testutil.TestCase) {
+		if got := Percentile(sorted, tc.Input.(int)); got != tc.Want {
+			t.Errorf("Percentile(1..20ms, %d) = %v, want %v", tc.Input, got, tc.Want)
+		}
+	})
+	if got := Percentile(nil, 95); got != 0 {
+		t.Errorf("Percentile(nil) = %v, want 0", got)
+	}
+}
+
+// Results in completion order come out sorted by ID, timed from the earliest
+// start, with failed orders carrying their error
+func TestFromResults(t *testing.T) {
+	t0 := testutil.Epoch
+	at := func(ms int) time.Time { return t0.Add(time.Duration(ms) * time.Millisecond) }
+	results := []order.Result{
+		{Order: order.Order{ID: 2, PrepTime: 10 * time.Millisecond}, Worker: 2, StartedAt: at(105), FinishedAt: at(115)},
+		{Order: order.Order{ID: 1, PrepTime: 30 * time.Millisecond}, Worker: 1, StartedAt: at(100), FinishedAt: at(130)},
+		{Order: order.Order{ID: 3}, StartedAt: at(110), FinishedAt: at(110), Err: order.ErrInvalidOrder},
+	}
+	opts := Options{Orders: 3, Workers: 2, Seed: 7, MaxPrep: 50 * time.Millisecond, Speed: 1}
+	got := FromResults("04-worker-pools", opts, results)
+
+	want := Run{Schema: Schema, Lesson: "04-worker-pools", Options: opts, Orders: []Order{
+		{ID: 1, Worker: 1, Prep: 30 * time.Millisecond, Start: 0, Finish: 30 * time.Millisecond, Duration: 30 * time.Millisecond},
+		{ID: 2, Worker: 2, Prep: 10 * time.Millisecond, Start: 5 * time.Millisecond, Finish: 15 * time.Millisecond, Duration: 10 * time.Millisecond},
+		{ID: 3, Start: 10 * time.Millisecond, Finish: 10 * time.Millisecond, Error: order.ErrInvalidOrder.Error()},
+	}}
+	want.Stats = Stats{Orders: 3, Failed: 1, Wall: 30 * time.Millisecond, Max: 30 * time.Millisecond, Mean: 40 * time.Millisecond / 3, P95: 30 * time.Millisecond}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FromResults =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if empty := FromResults("04-worker-pools", opts, nil); empty.Orders == nil || len(empty.Orders) != 0 || empty.Stats != (Stats{}) {
+		t.Errorf("FromResults(nil) = %+v, want no orders and zero stats", empty)
+	}
+}
@@ -0,0 +1,98 @@
+package sched
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+)
+
+// OverlapPolicy decides what Every does when a tick arrives while the
+// previous run is still going
+type OverlapPolicy int
+
+const (
+	// OverlapSkip drops the tick; at most one run at a time
+	OverlapSkip OverlapPolicy = iota
+	// OverlapConcurrent starts another run alongside the slow one
+	OverlapConcurrent
+)
+
+// everyConfig holds Every's options
+type everyConfig struct {
+	overlap OverlapPolicy
+}
+
+// EveryOption customizes Every
+type EveryOption func(*everyConfig)
+
+// WithOverlap sets what happens when a run is still going at the next tick (default OverlapSkip)
+func WithOverlap(policy OverlapPolicy) EveryOption {
+	return func(c *everyConfig) {
+		c.overlap = policy
+	}
+}
+
+// Periodic is a running Every loop
+type Periodic struct {
+	done    chan struct{}
+	runs    atomic.Int64
+	skipped atomic.Int64
+	running atomic.Int64
+}
+
+// Done closes once ctx is cancelled, the ticker is stopped, and every run has returned
+func (p *Periodic) Done() <-chan struct{} { return p.done }
+
+// Runs returns how many times fn has been started
+func (p *Periodic) Runs() int64 { return p.runs.Load() }
+
+// Skipped returns how many ticks OverlapSkip dropped because a run was still going
+func (p *Periodic) Skipped() int64 { return p.skipped.Load() }
+
+// Running returns how many runs are in progress right now
+func (p *Periodic) Running() int64 { return p.running.Load() }
+
+// Every calls fn every d of clk's time until ctx is cancelled. Each run gets
+// its own goroutine, so the loop is always free to receive the next tick; the
+// overlap policy decides whether that tick starts a run while another is going.
+func Every(ctx context.Context, clk clock.Clock, d time.Duration, fn func(ctx context.Context), opts ...EveryOption) *Periodic {
+	cfg := everyConfig{overlap: OverlapSkip}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	p := &Periodic{done: make(chan struct{})}
+	ticker := clk.NewTicker(d)
+
+	go func() {
+		var runs sync.WaitGroup
+		defer close(p.done)
+		defer runs.Wait()   // Then wait for runs still going
+		defer ticker.Stop() // Stop ticking first
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C():
+				if cfg.overlap == OverlapSkip && p.running.Load() > 0 {
+					p.skipped.Add(1)
+					continue
+				}
+				p.runs.Add(1)
+				p.running.Add(1) // Counted before the goroutine starts, so the next tick sees it
+				runs.Add(1)
+				go func() {
+					defer runs.Done()
+					defer p.running.Add(-1)
+					fn(ctx)
+				}()
+			}
+		}
+	}()
+
+	return p
+}
@@ -0,0 +1,140 @@
+package sched
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+// eventually polls cond until it holds or a second passes. Every acts on a
+// tick in its own goroutine, just after the fake clock has delivered it.
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("gave up waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// tick moves the clock one period and waits for Every to act on the tick.
+// The fake ticker, like a real one, holds a single tick, so moving on before
+// Every takes it would drop the next.
+func tick(t *testing.T, fake *clock.FakeClock, p *Periodic) {
+	t.Helper()
+	seen := p.Runs() + p.Skipped()
+	fake.Advance(time.Second)
+	eventually(t, "the tick to be taken", func() bool { return p.Runs()+p.Skipped() == seen+1 })
+}
+
+func TestEveryTickCount(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := clock.NewFake(epoch)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var ran atomic.Int64
+	p := Every(ctx, fake, time.Second, func(context.Context) { ran.Add(1) })
+
+	fake.Advance(999 * time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	if n := p.Runs(); n != 0 {
+		t.Fatalf("%d runs before the first period", n)
+	}
+	fake.Advance(time.Millisecond)
+	eventually(t, "the first run", func() bool { return ran.Load() == 1 })
+	for range 9 {
+		eventually(t, "the run to finish", func() bool { return p.Running() == 0 })
+		tick(t, fake, p)
+	}
+
+	eventually(t, "10 runs", func() bool { return ran.Load() == 10 })
+	if p.Runs() != 10 || p.Skipped() != 0 {
+		t.Errorf("after 10s: %d runs, %d skipped; want 10 and 0", p.Runs(), p.Skipped())
+	}
+	cancel()
+	<-p.Done()
+}
+
+// A stuck run makes later ticks skip, and the first tick after it finishes runs
+func TestEveryOverlapSkip(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := clock.NewFake(epoch)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	release := make(chan struct{})
+	p := Every(ctx, fake, time.Second, func(context.Context) { <-release }, WithOverlap(OverlapSkip))
+
+	for range 4 { // Tick 1 starts a run; ticks 2-4 find it still going
+		tick(t, fake, p)
+	}
+	if p.Runs() != 1 || p.Skipped() != 3 || p.Running() != 1 {
+		t.Errorf("with a run stuck: %d runs, %d skipped, %d running; want 1, 3, 1", p.Runs(), p.Skipped(), p.Running())
+	}
+
+	close(release)
+	eventually(t, "the stuck run to finish", func() bool { return p.Running() == 0 })
+	tick(t, fake, p)
+	if p.Runs() != 2 || p.Skipped() != 3 {
+		t.Errorf("after it finished: %d runs, %d skipped; want 2 and 3", p.Runs(), p.Skipped())
+	}
+	cancel()
+	<-p.Done()
+}
+
+// Every tick starts a run, even with earlier ones stuck
+func TestEveryOverlapConcurrent(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := clock.NewFake(epoch)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	release := make(chan struct{})
+	p := Every(ctx, fake, time.Second, func(context.Context) { <-release }, WithOverlap(OverlapConcurrent))
+
+	for range 4 {
+		tick(t, fake, p)
+	}
+	eventually(t, "4 runs at once", func() bool { return p.Running() == 4 })
+	if p.Skipped() != 0 {
+		t.Errorf("%d ticks skipped, want 0", p.Skipped())
+	}
+	close(release)
+	cancel()
+	<-p.Done()
+}
+
+// Cancelling stops the ticker, the run in progress sees ctx end, and Done
+// waits for it. WaitForGoroutines checks nothing is left behind.
+func TestEveryCancel(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	fake := clock.NewFake(epoch)
+	ctx, cancel := context.WithCancel(context.Background())
+	sawCancel := make(chan struct{})
+	p := Every(ctx, fake, time.Second, func(ctx context.Context) {
+		<-ctx.Done()
+		close(sawCancel)
+	})
+	tick(t, fake, p)
+
+	cancel()
+	<-p.Done()
+	select {
+	case <-sawCancel:
+	default:
+		t.Error("Done closed before the running run returned")
+	}
+	if n := fake.Waiters(); n != 0 {
+		t.Errorf("%d tickers still pending after cancel", n)
+	}
+
+	fake.Advance(5 * time.Second)
+	time.Sleep(10 * time.Millisecond)
+	if n := p.Runs(); n != 1 {
+		t.Errorf("%d runs after cancel, want still 1", n)
+	}
+}
@@ -0,0 +1,5 @@
+// Package sched runs work at set times: Scheduler starts jobs at the
+// moments they were scheduled for, all from one goroutine and one timer,
+// and Every runs a function on a period with a policy for slow runs.
+// Everything in it takes a clock.Clock, so tests drive it with a fake.
+package sched
@@ -0,0 +1,218 @@
+package sched
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+)
+
+// entry is a job waiting for its time
+type entry struct {
+	id    int
+	at    time.Time
+	seq   int // Breaks ties between jobs due at the same instant: first scheduled runs first
+	fn    func(ctx context.Context)
+	index int // Position in the heap, kept up to date by Swap so heap.Fix and heap.Remove work
+}
+
+// entryHeap is a min-heap of entries ordered by due time (implements heap.Interface)
+type entryHeap []*entry
+
+func (h entryHeap) Len() int { return len(h) }
+func (h entryHeap) Less(i, j int) bool {
+	if h[i].at.Equal(h[j].at) {
+		return h[i].seq < h[j].seq
+	}
+	return h[i].at.Before(h[j].at)
+}
+func (h entryHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index, h[j].index = i, j
+}
+func (h *entryHeap) Push(x any) {
+	e := x.(*entry)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+func (h *entryHeap) Pop() any {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	*h = old[:n-1]
+	return e
+}
+
+// Scheduler runs functions at set times. Pending jobs live in a min-heap and
+// one loop goroutine keeps a single timer for the earliest of them, however
+// many jobs are waiting.
+type Scheduler struct {
+	clock clock.Clock
+
+	mu      sync.Mutex
+	jobs    entryHeap
+	byID    map[int]*entry
+	nextID  int
+	stopped bool
+
+	wake    chan struct{} // Nudges the loop when the earliest job may have changed
+	stop    chan struct{}
+	done    chan struct{}
+	ctx     context.Context // Passed to every job; cancelled by Shutdown
+	cancel  context.CancelFunc
+	running sync.WaitGroup
+}
+
+// NewScheduler starts a scheduler whose jobs run at times on clk
+func NewScheduler(clk clock.Clock) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Scheduler{
+		clock:  clk,
+		byID:   make(map[int]*entry),
+		wake:   make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	go s.loop()
+	return s
+}
+
+// Schedule runs fn in its own goroutine at time at, or at once if at has
+// passed. It returns an ID for Cancel and Reschedule. After Shutdown it
+// returns 0 and fn never runs.
+func (s *Scheduler) Schedule(at time.Time, fn func(ctx context.Context)) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		return 0
+	}
+	s.nextID++
+	e := &entry{id: s.nextID, at: at, seq: s.nextID, fn: fn}
+	heap.Push(&s.jobs, e)
+	s.byID[e.id] = e
+	if e.index == 0 {
+		s.nudge() // New earliest job: the loop's timer is now too late
+	}
+	return e.id
+}
+
+// Reschedule moves a pending job to a new time. It reports false if the job
+// already ran, was cancelled, or never existed.
+func (s *Scheduler) Reschedule(id int, at time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.byID[id]
+	if !ok {
+		return false
+	}
+	wasHead := e.index == 0
+	e.at = at
+	heap.Fix(&s.jobs, e.index)
+	if wasHead || e.index == 0 {
+		s.nudge() // The earliest deadline changed, so the timer must be reset
+	}
+	return true
+}
+
+// Cancel removes a pending job so it never runs. It reports false if the job
+// already started, was cancelled before, or never existed.
+func (s *Scheduler) Cancel(id int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.byID[id]
+	if !ok {
+		return false
+	}
+	wasHead := e.index == 0
+	heap.Remove(&s.jobs, e.index)
+	delete(s.byID, id)
+	if wasHead {
+		s.nudge()
+	}
+	return true
+}
+
+// Pending returns how many jobs are waiting for their time
+func (s *Scheduler) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.jobs.Len()
+}
+
+// Shutdown stops the scheduler. Pending jobs are dropped without running,
+// running jobs see their ctx cancelled, and Shutdown waits for them to return.
+// It returns how many pending jobs were dropped.
+func (s *Scheduler) Shutdown() int {
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return 0
+	}
+	s.stopped = true
+	dropped := s.jobs.Len()
+	s.jobs, s.byID = nil, nil
+	s.mu.Unlock()
+
+	close(s.stop)
+	<-s.done
+	s.cancel()
+	s.running.Wait()
+	return dropped
+}
+
+// nudge wakes the loop without blocking; one pending wake-up is enough.
+// Callers hold s.mu.
+func (s *Scheduler) nudge() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// loop starts due jobs, then sleeps on one timer until the earliest job is due
+// or the heap changes
+func (s *Scheduler) loop() {
+	defer close(s.done)
+
+	for {
+		var timer clock.Timer
+		var due <-chan time.Time // nil while nothing is pending
+
+		s.mu.Lock()
+		now := s.clock.Now()
+		for s.jobs.Len() > 0 && !s.jobs[0].at.After(now) {
+			e := heap.Pop(&s.jobs).(*entry)
+			delete(s.byID, e.id) // Started: Cancel and Reschedule now report false
+			s.running.Add(1)
+			go func() {
+				defer s.running.Done()
+				e.fn(s.ctx)
+			}()
+		}
+		if s.jobs.Len() > 0 {
+			timer = s.clock.NewTimer(s.jobs[0].at.Sub(now))
+			due = timer.C()
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-s.stop:
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		case <-due:
+		case <-s.wake:
+			if timer != nil {
+				timer.Stop() // Recompute from the new earliest job
+			}
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package sched
+
+import (
+	"context"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/testutil"
+)
+
+var epoch = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// armedClock is a fake clock that remembers when the last timer it made is
+// due. The scheduler re-arms asynchronously, so tests wait for the timer
+// they expect before moving time past it.
+type armedClock struct {
+	*clock.FakeClock
+	mu    sync.Mutex
+	armed *sync.Cond
+	last  time.Time
+}
+
+func newArmedClock() *armedClock {
+	c := &armedClock{FakeClock: clock.NewFake(epoch)}
+	c.armed = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *armedClock) NewTimer(d time.Duration) clock.Timer {
+	t := c.FakeClock.NewTimer(d)
+	c.mu.Lock()
+	c.last = c.Now().Add(d)
+	c.mu.Unlock()
+	c.armed.Broadcast()
+	return t
+}
+
+// waitForTimerAt blocks until the newest timer is due at epoch+d
+func (c *armedClock) waitForTimerAt(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for !c.last.Equal(epoch.Add(d)) {
+		c.armed.Wait()
+	}
+}
+
+// fired collects job names as they run
+type fired struct {
+	mu    sync.Mutex
+	names []string
+	ch    chan struct{}
+}
+
+func newFired() *fired { return &fired{ch: make(chan struct{}, 1000)} }
+
+func (f *fired) job(name string) func(context.Context) {
+	return func(context.Context) {
+		f.mu.Lock()
+		f.names = append(f.names, name)
+		f.mu.Unlock()
+		f.ch <- struct{}{}
+	}
+}
+
+// await waits for n more jobs to run and returns every name so far
+func (f *fired) await(t *testing.T, n int) []string {
+	t.Helper()
+	for range n {
+		select {
+		case <-f.ch:
+		case <-time.After(time.Second):
+			t.Fatalf("gave up waiting for a job to run")
+		}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.names)
+}
+
+// quiet reports whether nothing else runs for a short while
+func (f *fired) quiet() bool {
+	select {
+	case <-f.ch:
+		return false
+	case <-time.After(20 * time.Millisecond):
+		return true
+	}
+}
+
+func TestSchedulerRunsInTimeOrder(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	clk := newArmedClock()
+	s := NewScheduler(clk)
+	defer s.Shutdown()
+	f := newFired()
+
+	s.Schedule(epoch.Add(300*time.Millisecond), f.job("C@300"))
+	s.Schedule(epoch.Add(100*time.Millisecond), f.job("A@100"))
+	s.Schedule(epoch.Add(200*time.Millisecond), f.job("B@200"))
+	for i := 1; i <= 3; i++ {
+		clk.waitForTimerAt(time.Duration(i) * 100 * time.Millisecond)
+		clk.Advance(100 * time.Millisecond)
+		f.await(t, 1)
+	}
+	if got := f.await(t, 0); !slices.Equal(got, []string{"A@100", "B@200", "C@300"}) {
+		t.Errorf("ran %v, want time order", got)
+	}
+}
+
+func TestSchedulerPastTimeRunsAtOnce(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	clk := newArmedClock()
+	s := NewScheduler(clk)
+	defer s.Shutdown()
+	f := newFired()
+
+	s.Schedule(epoch.Add(-time.Hour), f.job("overdue"))
+	if got := f.await(t, 1); !slices.Equal(got, []string{"overdue"}) {
+		t.Errorf("ran %v, want the overdue job without advancing the clock", got)
+	}
+}
+
+func TestSchedulerReschedule(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	clk := newArmedClock()
+	s := NewScheduler(clk)
+	defer s.Shutdown()
+	f := newFired()
+
+	// Moving the head later re-arms the timer, so the old time passes quietly
+	id := s.Schedule(epoch.Add(100*time.Millisecond), f.job("head"))
+	clk.waitForTimerAt(100 * time.Millisecond)
+	if !s.Reschedule(id, epoch.Add(300*time.Millisecond)) {
+		t.Fatal("Reschedule of a pending job = false")
+	}
+	clk.waitForTimerAt(300 * time.Millisecond)
+	clk.Advance(100 * time.Millisecond)
+	if !f.quiet() {
+		t.Error("the head ran at its old time")
+	}
+	clk.Advance(200 * time.Millisecond)
+	f.await(t, 1)
+
+	// Moving a later job ahead of the head re-arms too
+	id = s.Schedule(epoch.Add(900*time.Millisecond), f.job("late"))
+	s.Schedule(epoch.Add(800*time.Millisecond), f.job("head2"))
+	clk.waitForTimerAt(800 * time.Millisecond)
+	s.Reschedule(id, epoch.Add(400*time.Millisecond))
+	clk.waitForTimerAt(400 * time.Millisecond)
+	clk.Advance(100 * time.Millisecond)
+	if got := f.await(t, 1); !slices.Equal(got, []string{"head", "late"}) {
+		t.Errorf("ran %v, want the rescheduled job first", got)
+	}
+	if s.Reschedule(id, epoch) {
+		t.Error("Reschedule of a job that already ran = true")
+	}
+}
+
+func TestSchedulerCancel(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	clk := newArmedClock()
+	s := NewScheduler(clk)
+	defer s.Shutdown()
+	f := newFired()
+
+	a := s.Schedule(epoch.Add(100*time.Millisecond), f.job("cancelled"))
+	b := s.Schedule(epoch.Add(200*time.Millisecond), f.job("kept"))
+	clk.waitForTimerAt(100 * time.Millisecond)
+	first, second := s.Cancel(a), s.Cancel(a)
+	clk.waitForTimerAt(200 * time.Millisecond)
+	clk.Advance(200 * time.Millisecond)
+
+	if got := f.await(t, 1); !slices.Equal(got, []string{"kept"}) || !f.quiet() {
+		t.Errorf("ran %v, want only the job that wasn't cancelled", got)
+	}
+	if !first || second {
+		t.Errorf("Cancel = %v, then %v; want true once, then false", first, second)
+	}
+	if s.Cancel(b) {
+		t.Error("Cancel of a job that already ran = true")
+	}
+	if s.Cancel(99) {
+		t.Error("Cancel of an unknown ID = true")
+	}
+}
+
+// 100 jobs due at the same instant all run on one tick, with only the loop
+// goroutine waiting for them
+func TestSchedulerManyAtOneInstant(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	clk := newArmedClock()
+	base := runtime.NumGoroutine()
+	s := NewScheduler(clk)
+	defer s.Shutdown()
+
+	var mu sync.Mutex
+	var order []int
+	done := make(chan struct{}, 100)
+	for i := range 100 {
+		s.Schedule(epoch.Add(time.Second), func(context.Context) {
+			mu.Lock()
+			order = append(order, i)
+			mu.Unlock()
+			done <- struct{}{}
+		})
+	}
+	clk.waitForTimerAt(time.Second)
+	if waiting := runtime.NumGoroutine() - base; waiting != 1 {
+		t.Errorf("%d goroutines wait for 100 jobs, want 1", waiting)
+	}
+	if n := s.Pending(); n != 100 {
+		t.Errorf("Pending = %d, want 100", n)
+	}
+
+	clk.Advance(time.Second)
+	for range 100 {
+		<-done
+	}
+	if n := s.Pending(); n != 0 {
+		t.Errorf("Pending = %d after the tick, want 0", n)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	slices.Sort(order)
+	for i, v := range order {
+		if v != i {
+			t.Fatalf("jobs ran %v, want each of 0..99 once", order)
+		}
+	}
+}
+
+func TestSchedulerShutdown(t *testing.T) {
+	testutil.WaitForGoroutines(t)
+	clk := newArmedClock()
+	s := NewScheduler(clk)
+	f := newFired()
+
+	sawCancel := make(chan struct{})
+	s.Schedule(epoch, func(ctx context.Context) {
+		f.ch <- struct{}{}
+		<-ctx.Done() // Only Shutdown ends this job
+		close(sawCancel)
+	})
+	for i := 1; i <= 3; i++ {
+		s.Schedule(epoch.Add(time.Duration(i)*time.Hour), f.job("later"))
+	}
+	f.await(t, 1) // The first job is running
+
+	if dropped := s.Shutdown(); dropped != 3 {
+		t.Errorf("Shutdown dropped %d pending jobs, want 3", dropped)
+	}
+	select {
+	case <-sawCancel: // Shutdown waited for the running job to see its ctx end
+	default:
+		t.Error("Shutdown returned before the running job saw its ctx cancelled")
+	}
+
+	clk.Advance(5 * time.Hour)
+	if id := s.Schedule(epoch, f.job("after shutdown")); id != 0 {
+		t.Errorf("Schedule after Shutdown = %d, want 0", id)
+	}
+	if !f.quiet() {
+		t.Error("a job ran after Shutdown")
+	}
+	if dropped := s.Shutdown(); dropped != 0 {
+		t.Errorf("a second Shutdown dropped %d, want 0", dropped)
+	}
+}
@@ -0,0 +1,155 @@
+package testutil
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// ChanParam is a bidirectional channel parameter that its function only
+// ever sends on or only ever receives from, so its type could say so
+type ChanParam struct {
+	Pos     token.Position
+	Func    string // Function name, or "func literal"
+	Param   string
+	Suggest string // The restricted type, e.g. "<-chan Order"
+}
+
+func (c ChanParam) String() string {
+	return fmt.Sprintf("%s: %s parameter %s could be %s", c.Pos, c.Func, c.Param, c.Suggest)
+}
+
+// chanUses counts how a function body uses one channel parameter
+type chanUses struct {
+	send, recv, close, neutral, total int
+}
+
+// FindUnrestrictedChanParams parses every .go file under dir and reports
+// bidirectional channel parameters that could be chan<- or <-chan. Uses are
+// matched by name, so a shadowing variable with the same name counts too. A
+// parameter passed on, assigned or stored anywhere is left alone, since the
+// other end may need both directions.
+func FindUnrestrictedChanParams(dir string) ([]ChanParam, error) {
+	fset := token.NewFileSet()
+	var found []ChanParam
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") {
+			return err
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch fn := n.(type) {
+			case *ast.FuncDecl:
+				found = append(found, checkFunc(fset, fn.Name.Name, fn.Type, fn.Body)...)
+			case *ast.FuncLit:
+				found = append(found, checkFunc(fset, "func literal", fn.Type, fn.Body)...)
+			}
+			return true
+		})
+		return nil
+	})
+	sort.Slice(found, func(i, j int) bool {
+		a, b := found[i].Pos, found[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Line < b.Line
+	})
+	return found, err
+}
+
+// CheckChannelDirections fails the test for every parameter
+// FindUnrestrictedChanParams reports under dir
+func CheckChannelDirections(t *testing.T, dir string) {
+	t.Helper()
+	found, err := FindUnrestrictedChanParams(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range found {
+		t.Error(c)
+	}
+}
+
+// checkFunc reports the bidirectional channel parameters of one function
+func checkFunc(fset *token.FileSet, name string, ft *ast.FuncType, body *ast.BlockStmt) []ChanParam {
+	if body == nil || ft.Params == nil {
+		return nil
+	}
+	var found []ChanParam
+	for _, field := range ft.Params.List {
+		ct, ok := field.Type.(*ast.ChanType)
+		if !ok || ct.Dir != ast.SEND|ast.RECV {
+			continue
+		}
+		elem := types.ExprString(ct.Value)
+		for _, id := range field.Names {
+			u := countUses(body, id.Name)
+			var suggest string
+			switch {
+			case u.send+u.recv+u.close+u.neutral < u.total:
+				continue // Escapes: passed on, assigned or stored
+			case u.recv > 0 && u.send == 0 && u.close == 0:
+				suggest = "<-chan " + elem
+			case u.recv == 0 && u.send+u.close > 0:
+				suggest = "chan<- " + elem
+			default:
+				continue // Used both ways, or not at all
+			}
+			found = append(found, ChanParam{Pos: fset.Position(id.Pos()), Func: name, Param: id.Name, Suggest: suggest})
+		}
+	}
+	return found
+}
+
+// countUses classifies every use of name in body
+func countUses(body *ast.BlockStmt, name string) chanUses {
+	var u chanUses
+	is := func(e ast.Expr) bool {
+		id, ok := ast.Unparen(e).(*ast.Ident)
+		return ok && id.Name == name
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Ident:
+			if n.Name == name {
+				u.total++
+			}
+		case *ast.SendStmt:
+			if is(n.Chan) {
+				u.send++
+			}
+		case *ast.UnaryExpr:
+			if n.Op == token.ARROW && is(n.X) {
+				u.recv++
+			}
+		case *ast.RangeStmt:
+			if is(n.X) {
+				u.recv++
+			}
+		case *ast.CallExpr:
+			fun, ok := n.Fun.(*ast.Ident)
+			if !ok || len(n.Args) != 1 || !is(n.Args[0]) {
+				break
+			}
+			switch fun.Name {
+			case "close":
+				u.close++
+			case "len", "cap":
+				u.neutral++
+			}
+		}
+		return true
+	})
+	return u
+}
@@ -0,0 +1,55 @@
+package testutil
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// The fixtures in testdata/chandir: kitchen.go is the README's example,
+// more.go has the other ways a parameter can be one-way, and clean.go has
+// parameters that must not be reported
+func TestFindUnrestrictedChanParams(t *testing.T) {
+	found, err := FindUnrestrictedChanParams("testdata/chandir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, c := range found {
+		c.Pos.Filename = filepath.Base(c.Pos.Filename)
+		got = append(got, c.String())
+	}
+	want := []string{
+		"kitchen.go:5:15: producer parameter out could be chan<- Order",
+		"kitchen.go:10:15: consumer parameter in could be <-chan Order",
+		"kitchen.go:22:17: func literal parameter done could be <-chan struct{}",
+		"more.go:4:13: finish parameter done could be chan<- struct{}",
+		"more.go:7:12: drain parameter q could be <-chan Order",
+		"more.go:16:12: relay parameter in could be <-chan Order",
+		"more.go:16:16: relay parameter out could be chan<- Order",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("found\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestFindUnrestrictedChanParamsBadSource(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "broken.go"), []byte("package broken\n\nfunc ("), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FindUnrestrictedChanParams(dir); err == nil {
+		t.Error("a file that doesn't parse gave no error")
+	}
+}
+
+// The lessons whose modules document the rule keep every channel parameter
+// typed by direction
+func TestLessonChannelDirections(t *testing.T) {
+	for _, dir := range []string{"03-buffered-channels", "04-worker-pools", "05-fan-in", "12-semaphore"} {
+		t.Run(dir, func(t *testing.T) {
+			CheckChannelDirections(t, filepath.Join("..", dir))
+		})
+	}
+}
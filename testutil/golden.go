@@ -0,0 +1,98 @@
+package testutil
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ajay2521/go-concurrency/pkg/clock"
+	"github.com/Ajay2521/go-concurrency/pkg/lesson"
+)
+
+// update is set by go test -update: Golden rewrites its file from the output
+// instead of comparing against it
+var update = flag.Bool("update", false, "rewrite golden files from the current output")
+
+// Golden compares got with the file at path, relative to the test's package
+// directory, and fails the test at the first line that differs. With
+// go test -update it writes got to path instead.
+func Golden(t *testing.T, path, got string) {
+	t.Helper()
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		t.Logf("rewrote %s (%d bytes)", path, len(got))
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test -update to create it)", err)
+	}
+	if diff := FirstDiff(got, string(want)); diff != "" {
+		t.Errorf("output doesn't match %s: %s (run go test -update if the change is intended)", path, diff)
+	}
+}
+
+// FirstDiff describes the first line where got and want differ, or returns
+// "" if they are the same
+func FirstDiff(got, want string) string {
+	g, w := strings.Split(got, "\n"), strings.Split(want, "\n")
+	for i := range max(len(g), len(w)) {
+		var gl, wl string
+		if i < len(g) {
+			gl = g[i]
+		}
+		if i < len(w) {
+			wl = w[i]
+		}
+		if gl != wl {
+			return fmt.Sprintf("line %d: got %q, want %q", i+1, gl, wl)
+		}
+	}
+	return ""
+}
+
+// Epoch is the time every fake clock here starts at
+var Epoch = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// RunLesson runs a lesson's Run with args on a fake clock and returns what
+// it printed. args are read with lesson.Parse, so -deterministic works as it
+// does on the command line; -speed is left with nothing to scale. The clock
+// moves to the next deadline whenever every goroutine the lesson started is
+// waiting on it, so prep times take no real time but still overlap as they
+// would for real. A Run error fails the test.
+func RunLesson(t *testing.T, run lesson.Func, args ...string) string {
+	t.Helper()
+	opts, err := lesson.Parse(args)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", args, err)
+	}
+	var buf bytes.Buffer
+	opts.Clock, opts.Stdout = FakeClock(t), &buf
+	if err := run(context.Background(), opts); err != nil {
+		t.Fatalf("Run(%q): %v", args, err)
+	}
+	return buf.String() // Run has closed its printer, so everything is written
+}
+
+// FakeClock returns a clock.FakeClock at Epoch that moves itself on, as
+// RunLesson's does: whenever its waiters have stayed put for a millisecond,
+// it jumps to the earliest deadline. It stops when the test ends. A test
+// that calls lesson functions directly sets the package's clk to it.
+func FakeClock(t testing.TB) *clock.FakeClock {
+	fake := clock.NewFake(Epoch)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go fake.AdvanceWhenIdle(ctx, time.Millisecond)
+	return fake
+}
@@ -0,0 +1,57 @@
+// Package testutil holds helpers for table-driven concurrency tests.
+package testutil
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+// TestCase is one row of a table-driven test. Input and Want hold whatever
+// the test needs; fn type-asserts them.
+type TestCase struct {
+	Name  string
+	Input any
+	Want  any
+}
+
+// RunParallel runs fn for every case as its own subtest, and marks each
+// subtest parallel so the cases run at the same time. Cases must not share
+// mutable state.
+func RunParallel(t *testing.T, cases []TestCase, fn func(*testing.T, TestCase)) {
+	t.Helper()
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			fn(t, tc)
+		})
+	}
+}
+
+// goroutineGrace is how long WaitForGoroutines lets goroutines wind down
+const goroutineGrace = 100 * time.Millisecond
+
+// WaitForGoroutines records the goroutine count now, and fails the test if
+// the count hasn't returned to that baseline within 100ms of the test
+// function returning. Call it first thing in the test. Goroutines started by
+// parallel tests running alongside count too, so don't combine it with
+// RunParallel in the same test.
+func WaitForGoroutines(t *testing.T) {
+	t.Helper()
+	baseline := runtime.NumGoroutine()
+	t.Cleanup(func() {
+		deadline := time.Now().Add(goroutineGrace)
+		for {
+			n := runtime.NumGoroutine()
+			if n <= baseline {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Errorf("%d goroutine(s) still running %v after the test (baseline %d, now %d)",
+					n-baseline, goroutineGrace, baseline, n)
+				return
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	})
+}
@@ -0,0 +1,27 @@
+package kitchen
+
+// Already restricted
+func cook(in <-chan Order, out chan<- Order) {
+	for o := range in {
+		out <- o
+	}
+}
+
+// Stored: the struct may need both directions
+type station struct{ orders chan Order }
+
+func newStation(orders chan Order) *station { return &station{orders: orders} }
+
+// Assigned and returned
+func keep(c chan int) chan int {
+	d := c
+	return d
+}
+
+// Unused, or only measured
+func ignore(c chan int) {}
+
+func depth(c chan int) int { return len(c) + cap(c) }
+
+// No body
+func external(c chan int)
@@ -0,0 +1,22 @@
+package kitchen
+
+type Order struct{}
+
+func producer(out chan Order) {
+	out <- Order{}
+	close(out)
+}
+
+func consumer(in chan Order) {
+	for o := range in {
+		_ = o
+	}
+}
+
+func both(c chan int) { c <- 1; <-c }
+
+func passes(c chan int) { produce(c) }
+
+func produce(c chan<- int) { c <- 1 }
+
+var wait = func(done chan struct{}) { <-done }
@@ -0,0 +1,20 @@
+package kitchen
+
+// Only closed: a sender's job
+func finish(done chan struct{}) { close(done) }
+
+// len alongside a receive is still receive-only
+func drain(q chan Order) int {
+	n := len(q)
+	for range n {
+		<-(q)
+	}
+	return n
+}
+
+// Two parameters in one field, each judged on its own
+func relay(in, out chan Order) {
+	for o := range in {
+		out <- o
+	}
+}